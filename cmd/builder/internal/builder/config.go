@@ -40,6 +40,7 @@ type Config struct {
 	Extensions   []Module     `mapstructure:"extensions"`
 	Receivers    []Module     `mapstructure:"receivers"`
 	Processors   []Module     `mapstructure:"processors"`
+	Connectors   []Module     `mapstructure:"connectors"`
 	Replaces     []string     `mapstructure:"replaces"`
 	Excludes     []string     `mapstructure:"excludes"`
 }
@@ -55,7 +56,7 @@ type Distribution struct {
 	Version        string `mapstructure:"version"`
 }
 
-// Module represents a receiver, exporter, processor or extension for the distribution
+// Module represents a receiver, exporter, processor, connector or extension for the distribution
 type Module struct {
 	Name   string `mapstructure:"name"`   // if not specified, this is package part of the go mod (last part of the path)
 	Import string `mapstructure:"import"` // if not specified, this is the path part of the go mods
@@ -125,6 +126,11 @@ func (c *Config) ParseModules() error {
 		return err
 	}
 
+	c.Connectors, err = parseModules(c.Connectors)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
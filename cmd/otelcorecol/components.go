@@ -4,14 +4,30 @@ package main
 
 import (
 	"go.opentelemetry.io/collector/component"
+	forwardconnector "go.opentelemetry.io/collector/connector/forwardconnector"
 	loggingexporter "go.opentelemetry.io/collector/exporter/loggingexporter"
 	otlpexporter "go.opentelemetry.io/collector/exporter/otlpexporter"
 	otlphttpexporter "go.opentelemetry.io/collector/exporter/otlphttpexporter"
 	ballastextension "go.opentelemetry.io/collector/extension/ballastextension"
 	zpagesextension "go.opentelemetry.io/collector/extension/zpagesextension"
 	batchprocessor "go.opentelemetry.io/collector/processor/batchprocessor"
+	groupbyattrsprocessor "go.opentelemetry.io/collector/processor/groupbyattrsprocessor"
+	logparserprocessor "go.opentelemetry.io/collector/processor/logparserprocessor"
 	memorylimiterprocessor "go.opentelemetry.io/collector/processor/memorylimiterprocessor"
+	schemaprocessor "go.opentelemetry.io/collector/processor/schemaprocessor"
+	filelogreceiver "go.opentelemetry.io/collector/receiver/filelogreceiver"
+	fluentforwardreceiver "go.opentelemetry.io/collector/receiver/fluentforwardreceiver"
+	hostmetricsreceiver "go.opentelemetry.io/collector/receiver/hostmetricsreceiver"
+	httpcheckreceiver "go.opentelemetry.io/collector/receiver/httpcheckreceiver"
+	jaegerreceiver "go.opentelemetry.io/collector/receiver/jaegerreceiver"
+	otlpfilereceiver "go.opentelemetry.io/collector/receiver/otlpfilereceiver"
 	otlpreceiver "go.opentelemetry.io/collector/receiver/otlpreceiver"
+	prometheusreceiver "go.opentelemetry.io/collector/receiver/prometheusreceiver"
+	prometheusremotewritereceiver "go.opentelemetry.io/collector/receiver/prometheusremotewritereceiver"
+	selftelemetryreceiver "go.opentelemetry.io/collector/receiver/selftelemetryreceiver"
+	statsdreceiver "go.opentelemetry.io/collector/receiver/statsdreceiver"
+	syslogreceiver "go.opentelemetry.io/collector/receiver/syslogreceiver"
+	zipkinreceiver "go.opentelemetry.io/collector/receiver/zipkinreceiver"
 )
 
 func components() (component.Factories, error) {
@@ -27,7 +43,19 @@ func components() (component.Factories, error) {
 	}
 
 	factories.Receivers, err = component.MakeReceiverFactoryMap(
+		filelogreceiver.NewFactory(),
+		fluentforwardreceiver.NewFactory(),
+		hostmetricsreceiver.NewFactory(),
+		httpcheckreceiver.NewFactory(),
+		jaegerreceiver.NewFactory(),
+		otlpfilereceiver.NewFactory(),
 		otlpreceiver.NewFactory(),
+		prometheusreceiver.NewFactory(),
+		prometheusremotewritereceiver.NewFactory(),
+		selftelemetryreceiver.NewFactory(),
+		statsdreceiver.NewFactory(),
+		syslogreceiver.NewFactory(),
+		zipkinreceiver.NewFactory(),
 	)
 	if err != nil {
 		return component.Factories{}, err
@@ -44,7 +72,17 @@ func components() (component.Factories, error) {
 
 	factories.Processors, err = component.MakeProcessorFactoryMap(
 		batchprocessor.NewFactory(),
+		groupbyattrsprocessor.NewFactory(),
+		logparserprocessor.NewFactory(),
 		memorylimiterprocessor.NewFactory(),
+		schemaprocessor.NewFactory(),
+	)
+	if err != nil {
+		return component.Factories{}, err
+	}
+
+	factories.Connectors, err = component.MakeConnectorFactoryMap(
+		forwardconnector.NewFactory(),
 	)
 	if err != nil {
 		return component.Factories{}, err
@@ -39,5 +39,9 @@ func NopFactories() (component.Factories, error) {
 		return component.Factories{}, err
 	}
 
+	if factories.Connectors, err = component.MakeConnectorFactoryMap(NewNopConnectorFactory()); err != nil {
+		return component.Factories{}, err
+	}
+
 	return factories, err
 }
@@ -0,0 +1,377 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component // import "go.opentelemetry.io/collector/component"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+// Connector sends the data exported by pipelines to other pipelines. It is used
+// as an exporter by the pipelines it consumes the data of, and as a receiver by
+// the pipelines it feeds. The data types of these pipelines may differ, for
+// example a connector may derive metrics from the spans of a traces pipeline and
+// feed them to a metrics pipeline.
+//
+// A connector must pass the data to its next consumer synchronously, as it is
+// shut down after the processors of the pipelines it feeds.
+type Connector interface {
+	Component
+}
+
+// TracesConnector is a Connector that consumes traces.
+type TracesConnector interface {
+	Connector
+	consumer.Traces
+}
+
+// MetricsConnector is a Connector that consumes metrics.
+type MetricsConnector interface {
+	Connector
+	consumer.Metrics
+}
+
+// LogsConnector is a Connector that consumes logs.
+type LogsConnector interface {
+	Connector
+	consumer.Logs
+}
+
+// ConnectorCreateSettings configures Connector creators.
+type ConnectorCreateSettings struct {
+	TelemetrySettings
+
+	// BuildInfo can be used by components for informational purposes.
+	BuildInfo BuildInfo
+}
+
+// ConnectorFactory is factory interface for connectors. A connector is created
+// for each pair of data types it consumes and produces.
+//
+// This interface cannot be directly implemented. Implementations must
+// use the NewConnectorFactory to implement it.
+type ConnectorFactory interface {
+	Factory
+
+	// CreateDefaultConfig creates the default configuration for the Connector.
+	// This method can be called multiple times depending on the pipeline
+	// configuration and should not cause side-effects that prevent the creation
+	// of multiple instances of the Connector.
+	// The object returned by this method needs to pass the checks implemented by
+	// 'configtest.CheckConfigStruct'. It is recommended to have these checks in the
+	// tests of any implementation of the Factory interface.
+	CreateDefaultConfig() config.Connector
+
+	// CreateTracesToTracesConnector creates a connector consuming traces and sending
+	// traces to nextConsumer. If the connector type does not support this pair of
+	// data types or if the config is not valid, an error will be returned instead.
+	CreateTracesToTracesConnector(ctx context.Context, set ConnectorCreateSettings,
+		cfg config.Connector, nextConsumer consumer.Traces) (TracesConnector, error)
+
+	// CreateTracesToMetricsConnector creates a connector consuming traces and sending
+	// metrics to nextConsumer. If the connector type does not support this pair of
+	// data types or if the config is not valid, an error will be returned instead.
+	CreateTracesToMetricsConnector(ctx context.Context, set ConnectorCreateSettings,
+		cfg config.Connector, nextConsumer consumer.Metrics) (TracesConnector, error)
+
+	// CreateTracesToLogsConnector creates a connector consuming traces and sending
+	// logs to nextConsumer. If the connector type does not support this pair of
+	// data types or if the config is not valid, an error will be returned instead.
+	CreateTracesToLogsConnector(ctx context.Context, set ConnectorCreateSettings,
+		cfg config.Connector, nextConsumer consumer.Logs) (TracesConnector, error)
+
+	// CreateMetricsToTracesConnector creates a connector consuming metrics and sending
+	// traces to nextConsumer. If the connector type does not support this pair of
+	// data types or if the config is not valid, an error will be returned instead.
+	CreateMetricsToTracesConnector(ctx context.Context, set ConnectorCreateSettings,
+		cfg config.Connector, nextConsumer consumer.Traces) (MetricsConnector, error)
+
+	// CreateMetricsToMetricsConnector creates a connector consuming metrics and sending
+	// metrics to nextConsumer. If the connector type does not support this pair of
+	// data types or if the config is not valid, an error will be returned instead.
+	CreateMetricsToMetricsConnector(ctx context.Context, set ConnectorCreateSettings,
+		cfg config.Connector, nextConsumer consumer.Metrics) (MetricsConnector, error)
+
+	// CreateMetricsToLogsConnector creates a connector consuming metrics and sending
+	// logs to nextConsumer. If the connector type does not support this pair of
+	// data types or if the config is not valid, an error will be returned instead.
+	CreateMetricsToLogsConnector(ctx context.Context, set ConnectorCreateSettings,
+		cfg config.Connector, nextConsumer consumer.Logs) (MetricsConnector, error)
+
+	// CreateLogsToTracesConnector creates a connector consuming logs and sending
+	// traces to nextConsumer. If the connector type does not support this pair of
+	// data types or if the config is not valid, an error will be returned instead.
+	CreateLogsToTracesConnector(ctx context.Context, set ConnectorCreateSettings,
+		cfg config.Connector, nextConsumer consumer.Traces) (LogsConnector, error)
+
+	// CreateLogsToMetricsConnector creates a connector consuming logs and sending
+	// metrics to nextConsumer. If the connector type does not support this pair of
+	// data types or if the config is not valid, an error will be returned instead.
+	CreateLogsToMetricsConnector(ctx context.Context, set ConnectorCreateSettings,
+		cfg config.Connector, nextConsumer consumer.Metrics) (LogsConnector, error)
+
+	// CreateLogsToLogsConnector creates a connector consuming logs and sending
+	// logs to nextConsumer. If the connector type does not support this pair of
+	// data types or if the config is not valid, an error will be returned instead.
+	CreateLogsToLogsConnector(ctx context.Context, set ConnectorCreateSettings,
+		cfg config.Connector, nextConsumer consumer.Logs) (LogsConnector, error)
+}
+
+// ConnectorFactoryOption apply changes to ConnectorOptions.
+type ConnectorFactoryOption func(o *connectorFactory)
+
+// ConnectorCreateDefaultConfigFunc is the equivalent of ConnectorFactory.CreateDefaultConfig().
+type ConnectorCreateDefaultConfigFunc func() config.Connector
+
+// CreateDefaultConfig implements ConnectorFactory.CreateDefaultConfig().
+func (f ConnectorCreateDefaultConfigFunc) CreateDefaultConfig() config.Connector {
+	return f()
+}
+
+// CreateTracesToTracesConnectorFunc is the equivalent of ConnectorFactory.CreateTracesToTracesConnector().
+type CreateTracesToTracesConnectorFunc func(context.Context, ConnectorCreateSettings, config.Connector, consumer.Traces) (TracesConnector, error)
+
+// CreateTracesToTracesConnector implements ConnectorFactory.CreateTracesToTracesConnector().
+func (f CreateTracesToTracesConnectorFunc) CreateTracesToTracesConnector(
+	ctx context.Context,
+	set ConnectorCreateSettings,
+	cfg config.Connector,
+	nextConsumer consumer.Traces,
+) (TracesConnector, error) {
+	if f == nil {
+		return nil, componenterror.ErrDataTypeIsNotSupported
+	}
+	return f(ctx, set, cfg, nextConsumer)
+}
+
+// CreateTracesToMetricsConnectorFunc is the equivalent of ConnectorFactory.CreateTracesToMetricsConnector().
+type CreateTracesToMetricsConnectorFunc func(context.Context, ConnectorCreateSettings, config.Connector, consumer.Metrics) (TracesConnector, error)
+
+// CreateTracesToMetricsConnector implements ConnectorFactory.CreateTracesToMetricsConnector().
+func (f CreateTracesToMetricsConnectorFunc) CreateTracesToMetricsConnector(
+	ctx context.Context,
+	set ConnectorCreateSettings,
+	cfg config.Connector,
+	nextConsumer consumer.Metrics,
+) (TracesConnector, error) {
+	if f == nil {
+		return nil, componenterror.ErrDataTypeIsNotSupported
+	}
+	return f(ctx, set, cfg, nextConsumer)
+}
+
+// CreateTracesToLogsConnectorFunc is the equivalent of ConnectorFactory.CreateTracesToLogsConnector().
+type CreateTracesToLogsConnectorFunc func(context.Context, ConnectorCreateSettings, config.Connector, consumer.Logs) (TracesConnector, error)
+
+// CreateTracesToLogsConnector implements ConnectorFactory.CreateTracesToLogsConnector().
+func (f CreateTracesToLogsConnectorFunc) CreateTracesToLogsConnector(
+	ctx context.Context,
+	set ConnectorCreateSettings,
+	cfg config.Connector,
+	nextConsumer consumer.Logs,
+) (TracesConnector, error) {
+	if f == nil {
+		return nil, componenterror.ErrDataTypeIsNotSupported
+	}
+	return f(ctx, set, cfg, nextConsumer)
+}
+
+// CreateMetricsToTracesConnectorFunc is the equivalent of ConnectorFactory.CreateMetricsToTracesConnector().
+type CreateMetricsToTracesConnectorFunc func(context.Context, ConnectorCreateSettings, config.Connector, consumer.Traces) (MetricsConnector, error)
+
+// CreateMetricsToTracesConnector implements ConnectorFactory.CreateMetricsToTracesConnector().
+func (f CreateMetricsToTracesConnectorFunc) CreateMetricsToTracesConnector(
+	ctx context.Context,
+	set ConnectorCreateSettings,
+	cfg config.Connector,
+	nextConsumer consumer.Traces,
+) (MetricsConnector, error) {
+	if f == nil {
+		return nil, componenterror.ErrDataTypeIsNotSupported
+	}
+	return f(ctx, set, cfg, nextConsumer)
+}
+
+// CreateMetricsToMetricsConnectorFunc is the equivalent of ConnectorFactory.CreateMetricsToMetricsConnector().
+type CreateMetricsToMetricsConnectorFunc func(context.Context, ConnectorCreateSettings, config.Connector, consumer.Metrics) (MetricsConnector, error)
+
+// CreateMetricsToMetricsConnector implements ConnectorFactory.CreateMetricsToMetricsConnector().
+func (f CreateMetricsToMetricsConnectorFunc) CreateMetricsToMetricsConnector(
+	ctx context.Context,
+	set ConnectorCreateSettings,
+	cfg config.Connector,
+	nextConsumer consumer.Metrics,
+) (MetricsConnector, error) {
+	if f == nil {
+		return nil, componenterror.ErrDataTypeIsNotSupported
+	}
+	return f(ctx, set, cfg, nextConsumer)
+}
+
+// CreateMetricsToLogsConnectorFunc is the equivalent of ConnectorFactory.CreateMetricsToLogsConnector().
+type CreateMetricsToLogsConnectorFunc func(context.Context, ConnectorCreateSettings, config.Connector, consumer.Logs) (MetricsConnector, error)
+
+// CreateMetricsToLogsConnector implements ConnectorFactory.CreateMetricsToLogsConnector().
+func (f CreateMetricsToLogsConnectorFunc) CreateMetricsToLogsConnector(
+	ctx context.Context,
+	set ConnectorCreateSettings,
+	cfg config.Connector,
+	nextConsumer consumer.Logs,
+) (MetricsConnector, error) {
+	if f == nil {
+		return nil, componenterror.ErrDataTypeIsNotSupported
+	}
+	return f(ctx, set, cfg, nextConsumer)
+}
+
+// CreateLogsToTracesConnectorFunc is the equivalent of ConnectorFactory.CreateLogsToTracesConnector().
+type CreateLogsToTracesConnectorFunc func(context.Context, ConnectorCreateSettings, config.Connector, consumer.Traces) (LogsConnector, error)
+
+// CreateLogsToTracesConnector implements ConnectorFactory.CreateLogsToTracesConnector().
+func (f CreateLogsToTracesConnectorFunc) CreateLogsToTracesConnector(
+	ctx context.Context,
+	set ConnectorCreateSettings,
+	cfg config.Connector,
+	nextConsumer consumer.Traces,
+) (LogsConnector, error) {
+	if f == nil {
+		return nil, componenterror.ErrDataTypeIsNotSupported
+	}
+	return f(ctx, set, cfg, nextConsumer)
+}
+
+// CreateLogsToMetricsConnectorFunc is the equivalent of ConnectorFactory.CreateLogsToMetricsConnector().
+type CreateLogsToMetricsConnectorFunc func(context.Context, ConnectorCreateSettings, config.Connector, consumer.Metrics) (LogsConnector, error)
+
+// CreateLogsToMetricsConnector implements ConnectorFactory.CreateLogsToMetricsConnector().
+func (f CreateLogsToMetricsConnectorFunc) CreateLogsToMetricsConnector(
+	ctx context.Context,
+	set ConnectorCreateSettings,
+	cfg config.Connector,
+	nextConsumer consumer.Metrics,
+) (LogsConnector, error) {
+	if f == nil {
+		return nil, componenterror.ErrDataTypeIsNotSupported
+	}
+	return f(ctx, set, cfg, nextConsumer)
+}
+
+// CreateLogsToLogsConnectorFunc is the equivalent of ConnectorFactory.CreateLogsToLogsConnector().
+type CreateLogsToLogsConnectorFunc func(context.Context, ConnectorCreateSettings, config.Connector, consumer.Logs) (LogsConnector, error)
+
+// CreateLogsToLogsConnector implements ConnectorFactory.CreateLogsToLogsConnector().
+func (f CreateLogsToLogsConnectorFunc) CreateLogsToLogsConnector(
+	ctx context.Context,
+	set ConnectorCreateSettings,
+	cfg config.Connector,
+	nextConsumer consumer.Logs,
+) (LogsConnector, error) {
+	if f == nil {
+		return nil, componenterror.ErrDataTypeIsNotSupported
+	}
+	return f(ctx, set, cfg, nextConsumer)
+}
+
+type connectorFactory struct {
+	baseFactory
+	ConnectorCreateDefaultConfigFunc
+	CreateTracesToTracesConnectorFunc
+	CreateTracesToMetricsConnectorFunc
+	CreateTracesToLogsConnectorFunc
+	CreateMetricsToTracesConnectorFunc
+	CreateMetricsToMetricsConnectorFunc
+	CreateMetricsToLogsConnectorFunc
+	CreateLogsToTracesConnectorFunc
+	CreateLogsToMetricsConnectorFunc
+	CreateLogsToLogsConnectorFunc
+}
+
+// WithTracesToTracesConnector overrides the default "error not supported" implementation for CreateTracesToTracesConnector.
+func WithTracesToTracesConnector(createTracesToTracesConnector CreateTracesToTracesConnectorFunc) ConnectorFactoryOption {
+	return func(o *connectorFactory) {
+		o.CreateTracesToTracesConnectorFunc = createTracesToTracesConnector
+	}
+}
+
+// WithTracesToMetricsConnector overrides the default "error not supported" implementation for CreateTracesToMetricsConnector.
+func WithTracesToMetricsConnector(createTracesToMetricsConnector CreateTracesToMetricsConnectorFunc) ConnectorFactoryOption {
+	return func(o *connectorFactory) {
+		o.CreateTracesToMetricsConnectorFunc = createTracesToMetricsConnector
+	}
+}
+
+// WithTracesToLogsConnector overrides the default "error not supported" implementation for CreateTracesToLogsConnector.
+func WithTracesToLogsConnector(createTracesToLogsConnector CreateTracesToLogsConnectorFunc) ConnectorFactoryOption {
+	return func(o *connectorFactory) {
+		o.CreateTracesToLogsConnectorFunc = createTracesToLogsConnector
+	}
+}
+
+// WithMetricsToTracesConnector overrides the default "error not supported" implementation for CreateMetricsToTracesConnector.
+func WithMetricsToTracesConnector(createMetricsToTracesConnector CreateMetricsToTracesConnectorFunc) ConnectorFactoryOption {
+	return func(o *connectorFactory) {
+		o.CreateMetricsToTracesConnectorFunc = createMetricsToTracesConnector
+	}
+}
+
+// WithMetricsToMetricsConnector overrides the default "error not supported" implementation for CreateMetricsToMetricsConnector.
+func WithMetricsToMetricsConnector(createMetricsToMetricsConnector CreateMetricsToMetricsConnectorFunc) ConnectorFactoryOption {
+	return func(o *connectorFactory) {
+		o.CreateMetricsToMetricsConnectorFunc = createMetricsToMetricsConnector
+	}
+}
+
+// WithMetricsToLogsConnector overrides the default "error not supported" implementation for CreateMetricsToLogsConnector.
+func WithMetricsToLogsConnector(createMetricsToLogsConnector CreateMetricsToLogsConnectorFunc) ConnectorFactoryOption {
+	return func(o *connectorFactory) {
+		o.CreateMetricsToLogsConnectorFunc = createMetricsToLogsConnector
+	}
+}
+
+// WithLogsToTracesConnector overrides the default "error not supported" implementation for CreateLogsToTracesConnector.
+func WithLogsToTracesConnector(createLogsToTracesConnector CreateLogsToTracesConnectorFunc) ConnectorFactoryOption {
+	return func(o *connectorFactory) {
+		o.CreateLogsToTracesConnectorFunc = createLogsToTracesConnector
+	}
+}
+
+// WithLogsToMetricsConnector overrides the default "error not supported" implementation for CreateLogsToMetricsConnector.
+func WithLogsToMetricsConnector(createLogsToMetricsConnector CreateLogsToMetricsConnectorFunc) ConnectorFactoryOption {
+	return func(o *connectorFactory) {
+		o.CreateLogsToMetricsConnectorFunc = createLogsToMetricsConnector
+	}
+}
+
+// WithLogsToLogsConnector overrides the default "error not supported" implementation for CreateLogsToLogsConnector.
+func WithLogsToLogsConnector(createLogsToLogsConnector CreateLogsToLogsConnectorFunc) ConnectorFactoryOption {
+	return func(o *connectorFactory) {
+		o.CreateLogsToLogsConnectorFunc = createLogsToLogsConnector
+	}
+}
+
+// NewConnectorFactory returns a ConnectorFactory.
+func NewConnectorFactory(cfgType config.Type, createDefaultConfig ConnectorCreateDefaultConfigFunc, options ...ConnectorFactoryOption) ConnectorFactory {
+	f := &connectorFactory{
+		baseFactory:                      baseFactory{cfgType: cfgType},
+		ConnectorCreateDefaultConfigFunc: createDefaultConfig,
+	}
+	for _, opt := range options {
+		opt(f)
+	}
+	return f
+}
@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+func TestNewConnectorFactory(t *testing.T) {
+	const typeStr = "test"
+	defaultCfg := config.NewConnectorSettings(config.NewComponentID(typeStr))
+	factory := NewConnectorFactory(
+		typeStr,
+		func() config.Connector { return &defaultCfg })
+	assert.EqualValues(t, typeStr, factory.Type())
+	assert.EqualValues(t, &defaultCfg, factory.CreateDefaultConfig())
+	_, err := factory.CreateTracesToTracesConnector(context.Background(), ConnectorCreateSettings{}, &defaultCfg, nil)
+	assert.Error(t, err)
+	_, err = factory.CreateTracesToMetricsConnector(context.Background(), ConnectorCreateSettings{}, &defaultCfg, nil)
+	assert.Error(t, err)
+	_, err = factory.CreateTracesToLogsConnector(context.Background(), ConnectorCreateSettings{}, &defaultCfg, nil)
+	assert.Error(t, err)
+	_, err = factory.CreateMetricsToTracesConnector(context.Background(), ConnectorCreateSettings{}, &defaultCfg, nil)
+	assert.Error(t, err)
+	_, err = factory.CreateMetricsToMetricsConnector(context.Background(), ConnectorCreateSettings{}, &defaultCfg, nil)
+	assert.Error(t, err)
+	_, err = factory.CreateMetricsToLogsConnector(context.Background(), ConnectorCreateSettings{}, &defaultCfg, nil)
+	assert.Error(t, err)
+	_, err = factory.CreateLogsToTracesConnector(context.Background(), ConnectorCreateSettings{}, &defaultCfg, nil)
+	assert.Error(t, err)
+	_, err = factory.CreateLogsToMetricsConnector(context.Background(), ConnectorCreateSettings{}, &defaultCfg, nil)
+	assert.Error(t, err)
+	_, err = factory.CreateLogsToLogsConnector(context.Background(), ConnectorCreateSettings{}, &defaultCfg, nil)
+	assert.Error(t, err)
+}
+
+func TestNewConnectorFactory_WithOptions(t *testing.T) {
+	const typeStr = "test"
+	defaultCfg := config.NewConnectorSettings(config.NewComponentID(typeStr))
+	factory := NewConnectorFactory(
+		typeStr,
+		func() config.Connector { return &defaultCfg },
+		WithTracesToTracesConnector(createTracesToTracesConnector),
+		WithTracesToMetricsConnector(createTracesToMetricsConnector),
+		WithTracesToLogsConnector(createTracesToLogsConnector),
+		WithMetricsToTracesConnector(createMetricsToTracesConnector),
+		WithMetricsToMetricsConnector(createMetricsToMetricsConnector),
+		WithMetricsToLogsConnector(createMetricsToLogsConnector),
+		WithLogsToTracesConnector(createLogsToTracesConnector),
+		WithLogsToMetricsConnector(createLogsToMetricsConnector),
+		WithLogsToLogsConnector(createLogsToLogsConnector))
+	assert.EqualValues(t, typeStr, factory.Type())
+	assert.EqualValues(t, &defaultCfg, factory.CreateDefaultConfig())
+
+	_, err := factory.CreateTracesToTracesConnector(context.Background(), ConnectorCreateSettings{}, &defaultCfg, nil)
+	assert.NoError(t, err)
+
+	_, err = factory.CreateTracesToMetricsConnector(context.Background(), ConnectorCreateSettings{}, &defaultCfg, nil)
+	assert.NoError(t, err)
+
+	_, err = factory.CreateTracesToLogsConnector(context.Background(), ConnectorCreateSettings{}, &defaultCfg, nil)
+	assert.NoError(t, err)
+
+	_, err = factory.CreateMetricsToTracesConnector(context.Background(), ConnectorCreateSettings{}, &defaultCfg, nil)
+	assert.NoError(t, err)
+
+	_, err = factory.CreateMetricsToMetricsConnector(context.Background(), ConnectorCreateSettings{}, &defaultCfg, nil)
+	assert.NoError(t, err)
+
+	_, err = factory.CreateMetricsToLogsConnector(context.Background(), ConnectorCreateSettings{}, &defaultCfg, nil)
+	assert.NoError(t, err)
+
+	_, err = factory.CreateLogsToTracesConnector(context.Background(), ConnectorCreateSettings{}, &defaultCfg, nil)
+	assert.NoError(t, err)
+
+	_, err = factory.CreateLogsToMetricsConnector(context.Background(), ConnectorCreateSettings{}, &defaultCfg, nil)
+	assert.NoError(t, err)
+
+	_, err = factory.CreateLogsToLogsConnector(context.Background(), ConnectorCreateSettings{}, &defaultCfg, nil)
+	assert.NoError(t, err)
+}
+
+func createTracesToTracesConnector(context.Context, ConnectorCreateSettings, config.Connector, consumer.Traces) (TracesConnector, error) {
+	return nil, nil
+}
+
+func createTracesToMetricsConnector(context.Context, ConnectorCreateSettings, config.Connector, consumer.Metrics) (TracesConnector, error) {
+	return nil, nil
+}
+
+func createTracesToLogsConnector(context.Context, ConnectorCreateSettings, config.Connector, consumer.Logs) (TracesConnector, error) {
+	return nil, nil
+}
+
+func createMetricsToTracesConnector(context.Context, ConnectorCreateSettings, config.Connector, consumer.Traces) (MetricsConnector, error) {
+	return nil, nil
+}
+
+func createMetricsToMetricsConnector(context.Context, ConnectorCreateSettings, config.Connector, consumer.Metrics) (MetricsConnector, error) {
+	return nil, nil
+}
+
+func createMetricsToLogsConnector(context.Context, ConnectorCreateSettings, config.Connector, consumer.Logs) (MetricsConnector, error) {
+	return nil, nil
+}
+
+func createLogsToTracesConnector(context.Context, ConnectorCreateSettings, config.Connector, consumer.Traces) (LogsConnector, error) {
+	return nil, nil
+}
+
+func createLogsToMetricsConnector(context.Context, ConnectorCreateSettings, config.Connector, consumer.Metrics) (LogsConnector, error) {
+	return nil, nil
+}
+
+func createLogsToLogsConnector(context.Context, ConnectorCreateSettings, config.Connector, consumer.Logs) (LogsConnector, error) {
+	return nil, nil
+}
@@ -39,6 +39,9 @@ type Config struct {
 	// Extensions is a map of ComponentID to extensions.
 	Extensions map[ComponentID]Extension
 
+	// Connectors is a map of ComponentID to connectors.
+	Connectors map[ComponentID]Connector
+
 	Service
 }
 
@@ -90,6 +93,20 @@ func (cfg *Config) Validate() error {
 		}
 	}
 
+	// Validate the connector configuration.
+	for connID, connCfg := range cfg.Connectors {
+		if err := connCfg.Validate(); err != nil {
+			return fmt.Errorf("connector %q has invalid configuration: %w", connID, err)
+		}
+		// A connector is referenced as a receiver or an exporter, so its ID must be unambiguous.
+		if _, ok := cfg.Receivers[connID]; ok {
+			return fmt.Errorf("connector %q has the same ID as a receiver", connID)
+		}
+		if _, ok := cfg.Exporters[connID]; ok {
+			return fmt.Errorf("connector %q has the same ID as an exporter", connID)
+		}
+	}
+
 	return cfg.validateService()
 }
 
@@ -117,8 +134,8 @@ func (cfg *Config) validateService() error {
 
 		// Validate pipeline receiver name references.
 		for _, ref := range pipeline.Receivers {
-			// Check that the name referenced in the pipeline's receivers exists in the top-level receivers.
-			if cfg.Receivers[ref] == nil {
+			// Check that the name referenced in the pipeline's receivers exists in the top-level receivers or connectors.
+			if cfg.Receivers[ref] == nil && cfg.Connectors[ref] == nil {
 				return fmt.Errorf("pipeline %q references receiver %q which does not exist", pipelineID, ref)
 			}
 		}
@@ -138,15 +155,43 @@ func (cfg *Config) validateService() error {
 
 		// Validate pipeline exporter name references.
 		for _, ref := range pipeline.Exporters {
-			// Check that the name referenced in the pipeline's Exporters exists in the top-level Exporters.
-			if cfg.Exporters[ref] == nil {
+			// Check that the name referenced in the pipeline's Exporters exists in the top-level Exporters or connectors.
+			if cfg.Exporters[ref] == nil && cfg.Connectors[ref] == nil {
 				return fmt.Errorf("pipeline %q references exporter %q which does not exist", pipelineID, ref)
 			}
 		}
+
+		if err := pipeline.Fanout.Validate(); err != nil {
+			return fmt.Errorf("pipeline %q has invalid fanout configuration: %w", pipelineID, err)
+		}
+	}
+
+	// Check that all connectors both receive data from a pipeline and send data to a pipeline.
+	for connID := range cfg.Connectors {
+		exporter, receiver := false, false
+		for _, pipeline := range cfg.Service.Pipelines {
+			exporter = exporter || containsID(pipeline.Exporters, connID)
+			receiver = receiver || containsID(pipeline.Receivers, connID)
+		}
+		if !exporter {
+			return fmt.Errorf("connector %q is not used as an exporter by any pipeline", connID)
+		}
+		if !receiver {
+			return fmt.Errorf("connector %q is not used as a receiver by any pipeline", connID)
+		}
 	}
 	return nil
 }
 
+func containsID(ids []ComponentID, id ComponentID) bool {
+	for _, ref := range ids {
+		if ref == id {
+			return true
+		}
+	}
+	return false
+}
+
 // Type is the component type as it is used in the config.
 type Type string
 
@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap/zapcore"
@@ -29,6 +30,7 @@ var errInvalidRecvConfig = errors.New("invalid receiver config")
 var errInvalidExpConfig = errors.New("invalid exporter config")
 var errInvalidProcConfig = errors.New("invalid processor config")
 var errInvalidExtConfig = errors.New("invalid extension config")
+var errInvalidConnConfig = errors.New("invalid connector config")
 
 type nopRecvConfig struct {
 	ReceiverSettings
@@ -74,6 +76,17 @@ func (nc *nopExtConfig) Validate() error {
 	return nil
 }
 
+type nopConnConfig struct {
+	ConnectorSettings
+}
+
+func (nc *nopConnConfig) Validate() error {
+	if nc.ID() != NewComponentID("forward") {
+		return errInvalidConnConfig
+	}
+	return nil
+}
+
 func TestConfigValidate(t *testing.T) {
 	var testCases = []struct {
 		name     string // test case name (also file name containing config yaml)
@@ -224,6 +237,98 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expected: fmt.Errorf(`extension "nop" has invalid configuration: %w`, errInvalidExtConfig),
 		},
+		{
+			name:     "valid-connector",
+			cfgFn:    generateConnectorConfig,
+			expected: nil,
+		},
+		{
+			name: "invalid-connector-config",
+			cfgFn: func() *Config {
+				cfg := generateConnectorConfig()
+				cfg.Connectors[NewComponentID("forward")] = &nopConnConfig{
+					ConnectorSettings: NewConnectorSettings(NewComponentID("invalid_conn_type")),
+				}
+				return cfg
+			},
+			expected: fmt.Errorf(`connector "forward" has invalid configuration: %w`, errInvalidConnConfig),
+		},
+		{
+			name: "connector-same-id-as-receiver",
+			cfgFn: func() *Config {
+				cfg := generateConnectorConfig()
+				cfg.Receivers[NewComponentID("forward")] = &nopRecvConfig{
+					ReceiverSettings: NewReceiverSettings(NewComponentID("nop")),
+				}
+				return cfg
+			},
+			expected: errors.New(`connector "forward" has the same ID as a receiver`),
+		},
+		{
+			name: "connector-same-id-as-exporter",
+			cfgFn: func() *Config {
+				cfg := generateConnectorConfig()
+				cfg.Exporters[NewComponentID("forward")] = &nopExpConfig{
+					ExporterSettings: NewExporterSettings(NewComponentID("nop")),
+				}
+				return cfg
+			},
+			expected: errors.New(`connector "forward" has the same ID as an exporter`),
+		},
+		{
+			name: "connector-not-used-as-exporter",
+			cfgFn: func() *Config {
+				cfg := generateConnectorConfig()
+				cfg.Service.Pipelines[NewComponentID("traces")].Exporters = []ComponentID{NewComponentID("nop")}
+				return cfg
+			},
+			expected: errors.New(`connector "forward" is not used as an exporter by any pipeline`),
+		},
+		{
+			name: "connector-not-used-as-receiver",
+			cfgFn: func() *Config {
+				cfg := generateConnectorConfig()
+				cfg.Service.Pipelines[NewComponentID("metrics")].Receivers = []ComponentID{NewComponentID("nop")}
+				return cfg
+			},
+			expected: errors.New(`connector "forward" is not used as a receiver by any pipeline`),
+		},
+		{
+			name: "valid-parallel-fanout",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Pipelines[NewComponentID("traces")].Fanout = PipelineFanout{Parallel: true, Timeout: time.Second, BufferSize: 5}
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "invalid-fanout-timeout",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Pipelines[NewComponentID("traces")].Fanout = PipelineFanout{Parallel: true, Timeout: -time.Second}
+				return cfg
+			},
+			expected: fmt.Errorf(`pipeline "traces" has invalid fanout configuration: %w`, errors.New("timeout must not be negative")),
+		},
+		{
+			name: "invalid-fanout-buffer-size",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Pipelines[NewComponentID("traces")].Fanout = PipelineFanout{Parallel: true, BufferSize: -1}
+				return cfg
+			},
+			expected: fmt.Errorf(`pipeline "traces" has invalid fanout configuration: %w`, errors.New("buffer_size must not be negative")),
+		},
+		{
+			name: "fanout-settings-without-parallel",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Pipelines[NewComponentID("traces")].Fanout = PipelineFanout{Timeout: time.Second}
+				return cfg
+			},
+			expected: fmt.Errorf(`pipeline "traces" has invalid fanout configuration: %w`, errors.New("timeout and buffer_size require parallel to be enabled")),
+		},
 	}
 
 	for _, test := range testCases {
@@ -284,3 +389,20 @@ func generateConfig() *Config {
 		},
 	}
 }
+
+// generateConnectorConfig generates a config whose traces pipeline exports to
+// the metrics pipeline through a connector.
+func generateConnectorConfig() *Config {
+	cfg := generateConfig()
+	cfg.Connectors = map[ComponentID]Connector{
+		NewComponentID("forward"): &nopConnConfig{
+			ConnectorSettings: NewConnectorSettings(NewComponentID("forward")),
+		},
+	}
+	cfg.Service.Pipelines[NewComponentID("traces")].Exporters = []ComponentID{NewComponentID("forward")}
+	cfg.Service.Pipelines[NewComponentID("metrics")] = &Pipeline{
+		Receivers: []ComponentID{NewComponentID("forward")},
+		Exporters: []ComponentID{NewComponentID("nop")},
+	}
+	return cfg
+}
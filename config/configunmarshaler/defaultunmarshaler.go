@@ -38,6 +38,7 @@ const (
 	errUnmarshalReceiver
 	errUnmarshalProcessor
 	errUnmarshalExporter
+	errUnmarshalConnector
 	errUnmarshalService
 )
 
@@ -63,6 +64,9 @@ const (
 	// processorsKeyName is the configuration key name for processors section.
 	processorsKeyName = "processors"
 
+	// connectorsKeyName is the configuration key name for connectors section.
+	connectorsKeyName = "connectors"
+
 	// pipelinesKeyName is the configuration key name for pipelines section.
 	pipelinesKeyName = "pipelines"
 )
@@ -72,6 +76,7 @@ type configSettings struct {
 	Processors map[config.ComponentID]map[string]interface{} `mapstructure:"processors"`
 	Exporters  map[config.ComponentID]map[string]interface{} `mapstructure:"exporters"`
 	Extensions map[config.ComponentID]map[string]interface{} `mapstructure:"extensions"`
+	Connectors map[config.ComponentID]map[string]interface{} `mapstructure:"connectors"`
 	Service    map[string]interface{}                        `mapstructure:"service"`
 }
 
@@ -126,6 +131,13 @@ func (*defaultUnmarshaler) Unmarshal(v *config.Map, factories component.Factorie
 		}
 	}
 
+	if cfg.Connectors, err = unmarshalConnectors(rawCfg.Connectors, factories.Connectors); err != nil {
+		return nil, &configError{
+			error: err,
+			code:  errUnmarshalConnector,
+		}
+	}
+
 	if cfg.Service, err = unmarshalService(rawCfg.Service); err != nil {
 		return nil, &configError{
 			error: err,
@@ -297,6 +309,34 @@ func unmarshalProcessors(procs map[config.ComponentID]map[string]interface{}, fa
 	return processors, nil
 }
 
+func unmarshalConnectors(conns map[config.ComponentID]map[string]interface{}, factories map[config.Type]component.ConnectorFactory) (map[config.ComponentID]config.Connector, error) {
+	// Prepare resulting map.
+	connectors := make(map[config.ComponentID]config.Connector)
+
+	// Iterate over connectors and create a config for each.
+	for id, value := range conns {
+		// Find connector factory based on "type" that we read from config source.
+		factory := factories[id.Type()]
+		if factory == nil {
+			return nil, errorUnknownType(connectorsKeyName, id, reflect.ValueOf(factories).MapKeys())
+		}
+
+		// Create the default config for this connector.
+		connectorCfg := factory.CreateDefaultConfig()
+		connectorCfg.SetIDName(id.Name())
+
+		// Now that the default config struct is created we can Unmarshal into it,
+		// and it will apply user-defined config on top of the default.
+		if err := unmarshal(config.NewMapFromStringMap(value), connectorCfg); err != nil {
+			return nil, errorUnmarshalError(connectorsKeyName, id, err)
+		}
+
+		connectors[id] = connectorCfg
+	}
+
+	return connectors, nil
+}
+
 func unmarshal(componentSection *config.Map, intoCfg interface{}) error {
 	if cu, ok := intoCfg.(config.Unmarshallable); ok {
 		return cu.Unmarshal(componentSection)
@@ -17,6 +17,7 @@ package configunmarshaler
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -98,6 +99,25 @@ func TestDecodeConfig(t *testing.T) {
 		cfg.Processors[config.NewComponentID("exampleprocessor")],
 		"Did not load processor config correctly")
 
+	// Verify Connectors
+	assert.Equal(t, 2, len(cfg.Connectors), "Incorrect connectors count")
+
+	assert.Equal(t,
+		&testcomponents.ExampleConnector{
+			ConnectorSettings: config.NewConnectorSettings(config.NewComponentID("exampleconnector")),
+			ExtraSetting:      "some connector string",
+		},
+		cfg.Connectors[config.NewComponentID("exampleconnector")],
+		"Did not load connector config correctly")
+
+	assert.Equal(t,
+		&testcomponents.ExampleConnector{
+			ConnectorSettings: config.NewConnectorSettings(config.NewComponentIDWithName("exampleconnector", "myconnector")),
+			ExtraSetting:      "some connector string 2",
+		},
+		cfg.Connectors[config.NewComponentIDWithName("exampleconnector", "myconnector")],
+		"Did not load connector config correctly")
+
 	// Verify Service Telemetry
 	assert.Equal(t,
 		config.ServiceTelemetry{
@@ -123,16 +143,25 @@ func TestDecodeConfig(t *testing.T) {
 	assert.Equal(t, config.NewComponentIDWithName("exampleextension", "1"), cfg.Service.Extensions[1])
 
 	// Verify Service Pipelines
-	assert.Equal(t, 1, len(cfg.Service.Pipelines), "Incorrect pipelines count")
+	assert.Equal(t, 2, len(cfg.Service.Pipelines), "Incorrect pipelines count")
 
 	assert.Equal(t,
 		&config.Pipeline{
 			Receivers:  []config.ComponentID{config.NewComponentID("examplereceiver")},
 			Processors: []config.ComponentID{config.NewComponentID("exampleprocessor")},
-			Exporters:  []config.ComponentID{config.NewComponentID("exampleexporter")},
+			Exporters:  []config.ComponentID{config.NewComponentID("exampleexporter"), config.NewComponentID("exampleconnector")},
+			Fanout:     config.PipelineFanout{Parallel: true, Timeout: 5 * time.Second, BufferSize: 20},
 		},
 		cfg.Service.Pipelines[config.NewComponentID("traces")],
 		"Did not load pipeline config correctly")
+
+	assert.Equal(t,
+		&config.Pipeline{
+			Receivers: []config.ComponentID{config.NewComponentID("exampleconnector")},
+			Exporters: []config.ComponentID{config.NewComponentID("exampleexporter")},
+		},
+		cfg.Service.Pipelines[config.NewComponentID("metrics")],
+		"Did not load pipeline config correctly")
 }
 
 func TestDecodeConfig_Invalid(t *testing.T) {
@@ -158,6 +187,7 @@ func TestDecodeConfig_Invalid(t *testing.T) {
 		{name: "unknown-receiver-type", expected: errUnmarshalReceiver, expectedMessage: "receivers"},
 		{name: "unknown-processor-type", expected: errUnmarshalProcessor, expectedMessage: "processors"},
 		{name: "unknown-exporter-type", expected: errUnmarshalExporter, expectedMessage: "exporters"},
+		{name: "unknown-connector-type", expected: errUnmarshalConnector, expectedMessage: "connectors"},
 		{name: "unknown-pipeline-type", expected: errUnmarshalService, expectedMessage: "pipelines"},
 
 		{name: "duplicate-extension", expected: errUnmarshalTopLevelStructure, expectedMessage: "duplicate name"},
@@ -171,6 +201,7 @@ func TestDecodeConfig_Invalid(t *testing.T) {
 		{name: "invalid-receiver-section", expected: errUnmarshalReceiver, expectedMessage: "receivers"},
 		{name: "invalid-processor-section", expected: errUnmarshalProcessor, expectedMessage: "processors"},
 		{name: "invalid-exporter-section", expected: errUnmarshalExporter, expectedMessage: "exporters"},
+		{name: "invalid-connector-section", expected: errUnmarshalConnector, expectedMessage: "connectors"},
 		{name: "invalid-service-section", expected: errUnmarshalService},
 		{name: "invalid-service-extensions-section", expected: errUnmarshalService},
 		{name: "invalid-pipeline-section", expected: errUnmarshalService, expectedMessage: "pipelines"},
@@ -15,6 +15,9 @@
 package config // import "go.opentelemetry.io/collector/config"
 
 import (
+	"errors"
+	"time"
+
 	"go.uber.org/zap/zapcore"
 
 	"go.opentelemetry.io/collector/config/configtelemetry"
@@ -126,11 +129,53 @@ const (
 	LogsDataType DataType = "logs"
 )
 
-// Pipeline defines a single pipeline.
+// Pipeline defines a single pipeline. Its receivers and exporters may reference
+// connectors, which send the data exported by a pipeline to the pipelines they
+// are a receiver of.
 type Pipeline struct {
 	Receivers  []ComponentID `mapstructure:"receivers"`
 	Processors []ComponentID `mapstructure:"processors"`
 	Exporters  []ComponentID `mapstructure:"exporters"`
+
+	// Fanout configures how the data is sent to the exporters of the pipeline.
+	Fanout PipelineFanout `mapstructure:"fanout"`
+}
+
+// PipelineFanout defines how a pipeline sends its data to its exporters.
+// By default, the exporters are called one after the other, so an exporter
+// without a queue slows down all the other exporters of the pipeline.
+type PipelineFanout struct {
+	// Parallel sends the data to the exporters concurrently, waiting for each
+	// of them at most Timeout and never keeping more than BufferSize batches
+	// in flight per exporter, so a slow exporter does not stall the others.
+	// (default = false)
+	Parallel bool `mapstructure:"parallel"`
+
+	// Timeout is the maximum time to wait for an exporter to consume a batch.
+	// The exporter is given this time, even when the caller of the pipeline
+	// returned earlier, and the batches it did not consume in time are only
+	// reported, not returned as errors. Zero means no timeout.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// BufferSize is the maximum number of batches an exporter may be processing
+	// at once, including the timed out ones. Batches are rejected for an exporter
+	// which reached it.
+	// (default = 10)
+	BufferSize int `mapstructure:"buffer_size"`
+}
+
+// Validate checks the pipeline fanout configuration is valid.
+func (pf *PipelineFanout) Validate() error {
+	if pf.Timeout < 0 {
+		return errors.New("timeout must not be negative")
+	}
+	if pf.BufferSize < 0 {
+		return errors.New("buffer_size must not be negative")
+	}
+	if !pf.Parallel && (pf.Timeout != 0 || pf.BufferSize != 0) {
+		return errors.New("timeout and buffer_size require parallel to be enabled")
+	}
+	return nil
 }
 
 // Pipelines is a map of names to Pipelines.
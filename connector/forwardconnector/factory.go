@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forwardconnector // import "go.opentelemetry.io/collector/connector/forwardconnector"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "forward"
+)
+
+// NewFactory creates a factory for the forward connector.
+func NewFactory() component.ConnectorFactory {
+	return component.NewConnectorFactory(
+		typeStr,
+		createDefaultConfig,
+		component.WithTracesToTracesConnector(createTracesToTracesConnector),
+		component.WithMetricsToMetricsConnector(createMetricsToMetricsConnector),
+		component.WithLogsToLogsConnector(createLogsToLogsConnector))
+}
+
+func createDefaultConfig() config.Connector {
+	return &Config{
+		ConnectorSettings: config.NewConnectorSettings(config.NewComponentID(typeStr)),
+	}
+}
+
+func createTracesToTracesConnector(
+	_ context.Context,
+	_ component.ConnectorCreateSettings,
+	_ config.Connector,
+	nextConsumer consumer.Traces,
+) (component.TracesConnector, error) {
+	return &forwardTraces{Traces: nextConsumer}, nil
+}
+
+func createMetricsToMetricsConnector(
+	_ context.Context,
+	_ component.ConnectorCreateSettings,
+	_ config.Connector,
+	nextConsumer consumer.Metrics,
+) (component.MetricsConnector, error) {
+	return &forwardMetrics{Metrics: nextConsumer}, nil
+}
+
+func createLogsToLogsConnector(
+	_ context.Context,
+	_ component.ConnectorCreateSettings,
+	_ config.Connector,
+	nextConsumer consumer.Logs,
+) (component.LogsConnector, error) {
+	return &forwardLogs{Logs: nextConsumer}, nil
+}
+
+// forwardTraces passes the consumed traces to the next consumer, its capabilities
+// being the capabilities of the next consumer.
+type forwardTraces struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumer.Traces
+}
+
+// forwardMetrics passes the consumed metrics to the next consumer, its capabilities
+// being the capabilities of the next consumer.
+type forwardMetrics struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumer.Metrics
+}
+
+// forwardLogs passes the consumed logs to the next consumer, its capabilities
+// being the capabilities of the next consumer.
+type forwardLogs struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumer.Logs
+}
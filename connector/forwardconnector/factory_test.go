@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forwardconnector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, configtest.CheckConfigStruct(cfg))
+}
+
+func TestForward(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	set := componenttest.NewNopConnectorCreateSettings()
+	host := componenttest.NewNopHost()
+
+	tracesSink := new(consumertest.TracesSink)
+	traces, err := factory.CreateTracesToTracesConnector(context.Background(), set, cfg, tracesSink)
+	require.NoError(t, err)
+	assert.NoError(t, traces.Start(context.Background(), host))
+	assert.NoError(t, traces.ConsumeTraces(context.Background(), pdata.NewTraces()))
+	assert.NoError(t, traces.Shutdown(context.Background()))
+	assert.Len(t, tracesSink.AllTraces(), 1)
+
+	metricsSink := new(consumertest.MetricsSink)
+	metrics, err := factory.CreateMetricsToMetricsConnector(context.Background(), set, cfg, metricsSink)
+	require.NoError(t, err)
+	assert.NoError(t, metrics.Start(context.Background(), host))
+	assert.NoError(t, metrics.ConsumeMetrics(context.Background(), pdata.NewMetrics()))
+	assert.NoError(t, metrics.Shutdown(context.Background()))
+	assert.Len(t, metricsSink.AllMetrics(), 1)
+
+	logsSink := new(consumertest.LogsSink)
+	logs, err := factory.CreateLogsToLogsConnector(context.Background(), set, cfg, logsSink)
+	require.NoError(t, err)
+	assert.NoError(t, logs.Start(context.Background(), host))
+	assert.NoError(t, logs.ConsumeLogs(context.Background(), pdata.NewLogs()))
+	assert.NoError(t, logs.Shutdown(context.Background()))
+	assert.Len(t, logsSink.AllLogs(), 1)
+}
+
+func TestCrossSignalNotSupported(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	set := componenttest.NewNopConnectorCreateSettings()
+
+	conn, err := factory.CreateTracesToMetricsConnector(context.Background(), set, cfg, consumertest.NewNop())
+	assert.Error(t, err)
+	assert.Nil(t, conn)
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumererror // import "go.opentelemetry.io/collector/consumer/consumererror"
+
+import (
+	"errors"
+	"strconv"
+)
+
+// partial is an error that indicates that only a part of the data was
+// refused, and that the rest was successfully processed.
+type partial struct {
+	err      error
+	rejected int
+}
+
+// NewPartial wraps an error to indicate that only the given number of items,
+// such as spans, data points or log records, was refused by the consumer, and
+// that the rest of the data was accepted. Partial errors must not be retried.
+func NewPartial(err error, rejected int) error {
+	return partial{
+		err:      err,
+		rejected: rejected,
+	}
+}
+
+func (p partial) Error() string {
+	return "Partial (" + strconv.Itoa(p.rejected) + " rejected), error: " + p.err.Error()
+}
+
+// Unwrap returns the wrapped error for functions Is and As in standard package errors.
+func (p partial) Unwrap() error {
+	return p.err
+}
+
+// IsPartial checks if an error was wrapped with the NewPartial function, which
+// is used to indicate that only a part of the data was refused.
+func IsPartial(err error) bool {
+	if err == nil {
+		return false
+	}
+	return errors.As(err, &partial{})
+}
+
+// GetRejectedCount returns the number of items refused according to an error
+// wrapped with the NewPartial function, or zero if the error is not a partial error.
+func GetRejectedCount(err error) int {
+	p := partial{}
+	if err == nil || !errors.As(err, &p) {
+		return 0
+	}
+	return p.rejected
+}
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumererror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPartial(t *testing.T) {
+	var err error
+	assert.False(t, IsPartial(err))
+	assert.Equal(t, 0, GetRejectedCount(err))
+
+	err = errors.New("testError")
+	assert.False(t, IsPartial(err))
+	assert.Equal(t, 0, GetRejectedCount(err))
+
+	err = NewPartial(err, 3)
+	assert.True(t, IsPartial(err))
+	assert.Equal(t, 3, GetRejectedCount(err))
+	assert.Equal(t, "Partial (3 rejected), error: testError", err.Error())
+
+	err = fmt.Errorf("%w", err)
+	assert.True(t, IsPartial(err))
+	assert.Equal(t, 3, GetRejectedCount(err))
+}
+
+func TestPartial_Unwrap(t *testing.T) {
+	var err error = testErrorType{"testError"}
+	require.False(t, IsPartial(err))
+
+	// Wrapping testErrorType err with partial error.
+	partialErr := NewPartial(err, 1)
+	require.True(t, IsPartial(partialErr))
+
+	target := testErrorType{}
+	require.NotEqual(t, err, target)
+
+	isTestErrorTypeWrapped := errors.As(partialErr, &target)
+	require.True(t, isTestErrorTypeWrapped)
+
+	require.Equal(t, err, target)
+}
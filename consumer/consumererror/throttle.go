@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumererror // import "go.opentelemetry.io/collector/consumer/consumererror"
+
+import (
+	"errors"
+	"time"
+)
+
+// throttle is an error that indicates that the data was refused because the
+// consumer is temporarily overloaded, and that the caller should retry after
+// the given delay.
+type throttle struct {
+	err   error
+	delay time.Duration
+}
+
+// NewThrottle wraps an error to indicate that the data was refused because the
+// consumer is temporarily overloaded. The delay is the suggested amount of time
+// the caller should wait before retrying.
+func NewThrottle(err error, delay time.Duration) error {
+	return throttle{
+		err:   err,
+		delay: delay,
+	}
+}
+
+func (t throttle) Error() string {
+	return "Throttle (" + t.delay.String() + "), error: " + t.err.Error()
+}
+
+// Unwrap returns the wrapped error for functions Is and As in standard package errors.
+func (t throttle) Unwrap() error {
+	return t.err
+}
+
+// IsThrottle checks if an error was wrapped with the NewThrottle function, which
+// is used to indicate that the consumer is temporarily overloaded.
+func IsThrottle(err error) bool {
+	if err == nil {
+		return false
+	}
+	return errors.As(err, &throttle{})
+}
+
+// GetThrottleDelay returns the retry delay suggested by an error wrapped with
+// the NewThrottle function, or zero if the error is not a throttle error.
+func GetThrottleDelay(err error) time.Duration {
+	t := throttle{}
+	if err == nil || !errors.As(err, &t) {
+		return 0
+	}
+	return t.delay
+}
@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumererror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsThrottle(t *testing.T) {
+	var err error
+	assert.False(t, IsThrottle(err))
+	assert.Equal(t, time.Duration(0), GetThrottleDelay(err))
+
+	err = errors.New("testError")
+	assert.False(t, IsThrottle(err))
+	assert.Equal(t, time.Duration(0), GetThrottleDelay(err))
+
+	err = NewThrottle(err, 5*time.Second)
+	assert.True(t, IsThrottle(err))
+	assert.Equal(t, 5*time.Second, GetThrottleDelay(err))
+	assert.Equal(t, "Throttle (5s), error: testError", err.Error())
+
+	err = fmt.Errorf("%w", err)
+	assert.True(t, IsThrottle(err))
+	assert.Equal(t, 5*time.Second, GetThrottleDelay(err))
+}
+
+func TestThrottle_Unwrap(t *testing.T) {
+	var err error = testErrorType{"testError"}
+	require.False(t, IsThrottle(err))
+
+	// Wrapping testErrorType err with throttle error.
+	throttleErr := NewThrottle(err, time.Second)
+	require.True(t, IsThrottle(throttleErr))
+
+	target := testErrorType{}
+	require.NotEqual(t, err, target)
+
+	isTestErrorTypeWrapped := errors.As(throttleErr, &target)
+	require.True(t, isTestErrorTypeWrapped)
+
+	require.Equal(t, err, target)
+}
@@ -180,10 +180,16 @@ func (rs *retrySender) send(req request) error {
 
 		// Immediately drop data on permanent errors.
 		if consumererror.IsPermanent(err) {
+			droppedItems := req.count()
+			if rejected := consumererror.GetRejectedCount(err); consumererror.IsPartial(err) && rejected >= 0 && rejected < droppedItems {
+				// Only the items rejected by the destination were dropped, as long as
+				// the destination does not report more items than were sent.
+				droppedItems = rejected
+			}
 			rs.logger.Error(
 				"Exporting failed. The error is not retryable. Dropping data.",
 				zap.Error(err),
-				zap.Int("dropped_items", req.count()),
+				zap.Int("dropped_items", droppedItems),
 			)
 			return err
 		}
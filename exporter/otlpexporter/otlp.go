@@ -21,6 +21,7 @@ import (
 	"runtime"
 	"time"
 
+	"go.uber.org/zap"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -99,22 +100,34 @@ func (e *exporter) shutdown(context.Context) error {
 func (e *exporter) pushTraces(ctx context.Context, td pdata.Traces) error {
 	req := otlpgrpc.NewTracesRequest()
 	req.SetTraces(td)
-	_, err := e.traceExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
-	return processError(err)
+	resp, err := e.traceExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
+	if err != nil {
+		return processError(err)
+	}
+	ps := resp.PartialSuccess()
+	return e.partialSuccessError(ps.RejectedSpans(), ps.ErrorMessage())
 }
 
 func (e *exporter) pushMetrics(ctx context.Context, md pdata.Metrics) error {
 	req := otlpgrpc.NewMetricsRequest()
 	req.SetMetrics(md)
-	_, err := e.metricExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
-	return processError(err)
+	resp, err := e.metricExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
+	if err != nil {
+		return processError(err)
+	}
+	ps := resp.PartialSuccess()
+	return e.partialSuccessError(ps.RejectedDataPoints(), ps.ErrorMessage())
 }
 
 func (e *exporter) pushLogs(ctx context.Context, ld pdata.Logs) error {
 	req := otlpgrpc.NewLogsRequest()
 	req.SetLogs(ld)
-	_, err := e.logExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
-	return processError(err)
+	resp, err := e.logExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
+	if err != nil {
+		return processError(err)
+	}
+	ps := resp.PartialSuccess()
+	return e.partialSuccessError(ps.RejectedLogRecords(), ps.ErrorMessage())
 }
 
 func (e *exporter) enhanceContext(ctx context.Context) context.Context {
@@ -159,6 +172,21 @@ func processError(err error) error {
 	return err
 }
 
+// partialSuccessError returns a permanent error carrying the number of items
+// rejected by the server when the response reports a partial success, so that
+// only the rejected items are recorded as failed and none of them is retried.
+// A warning sent by the server without rejecting any item is logged.
+func (e *exporter) partialSuccessError(rejected int64, errMsg string) error {
+	if rejected == 0 {
+		if errMsg != "" {
+			e.settings.Logger.Warn("Partial success response", zap.String("message", errMsg))
+		}
+		return nil
+	}
+	return consumererror.NewPermanent(consumererror.NewPartial(
+		fmt.Errorf("OTLP partial success: %q", errMsg), int(rejected)))
+}
+
 func shouldRetry(code codes.Code) bool {
 	switch code {
 	case codes.Canceled,
@@ -26,6 +26,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
@@ -34,9 +36,11 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/model/otlpgrpc"
 	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
 )
 
 type mockReceiver struct {
@@ -56,6 +60,7 @@ func (r *mockReceiver) GetMetadata() metadata.MD {
 type mockTracesReceiver struct {
 	mockReceiver
 	lastRequest pdata.Traces
+	rejected    int64
 }
 
 func (r *mockTracesReceiver) Export(ctx context.Context, req otlpgrpc.TracesRequest) (otlpgrpc.TracesResponse, error) {
@@ -66,7 +71,14 @@ func (r *mockTracesReceiver) Export(ctx context.Context, req otlpgrpc.TracesRequ
 	defer r.mux.Unlock()
 	r.lastRequest = td
 	r.metadata, _ = metadata.FromIncomingContext(ctx)
-	return otlpgrpc.NewTracesResponse(), nil
+	resp := otlpgrpc.NewTracesResponse()
+	if r.rejected > 0 {
+		ps := otlpgrpc.NewTracesPartialSuccess()
+		ps.SetRejectedSpans(r.rejected)
+		ps.SetErrorMessage("rejected by the mock receiver")
+		resp.SetPartialSuccess(ps)
+	}
+	return resp, nil
 }
 
 func (r *mockTracesReceiver) GetLastRequest() pdata.Traces {
@@ -255,6 +267,63 @@ func TestSendTraces(t *testing.T) {
 	require.Contains(t, md.Get("User-Agent")[0], "Collector/1.2.3test")
 }
 
+func TestSendTracesPartialSuccess(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	// Start an OTLP-compatible receiver rejecting a span of every request.
+	ln, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err, "Failed to find an available address to run the gRPC server: %v", err)
+	rcv, _ := otlpTracesReceiverOnGRPCServer(ln, false)
+	rcv.rejected = 1
+	// Also closes the connection.
+	defer rcv.srv.GracefulStop()
+
+	// Start an OTLP exporter and point to the receiver.
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.QueueSettings.Enabled = false
+	cfg.GRPCClientSettings = configgrpc.GRPCClientSettings{
+		Endpoint: ln.Addr().String(),
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+	}
+	exp, err := factory.CreateTracesExporter(context.Background(), tt.ToExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, exp)
+
+	defer func() {
+		assert.NoError(t, exp.Shutdown(context.Background()))
+	}()
+
+	assert.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	// A trace with 2 spans, one of them rejected.
+	err = exp.ConsumeTraces(context.Background(), testdata.GenerateTracesTwoSpansSameResource())
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Equal(t, 1, consumererror.GetRejectedCount(err))
+
+	// The request is not retried and only the rejected span failed to be sent.
+	assert.EqualValues(t, 1, atomic.LoadInt32(&rcv.requestCount))
+	require.NoError(t, obsreporttest.CheckExporterTraces(tt, cfg.ID(), 1, 1))
+}
+
+func TestPartialSuccessWarning(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	e := &exporter{settings: component.TelemetrySettings{Logger: zap.New(core)}}
+
+	// A partial success without rejected items is a success carrying a warning.
+	assert.NoError(t, e.partialSuccessError(0, "deprecated attribute"))
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, "deprecated attribute", logs.All()[0].ContextMap()["message"])
+
+	assert.NoError(t, e.partialSuccessError(0, ""))
+	assert.Equal(t, 1, logs.Len())
+}
+
 func TestSendTracesWhenEndpointHasHttpScheme(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -25,6 +25,7 @@ import (
 	"net/url"
 	"runtime"
 	"strconv"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
@@ -99,7 +100,7 @@ func (e *exporter) pushTraces(ctx context.Context, td pdata.Traces) error {
 		return consumererror.NewPermanent(err)
 	}
 
-	return e.export(ctx, e.tracesURL, request)
+	return e.export(ctx, e.tracesURL, request, tracesPartialSuccess)
 }
 
 func (e *exporter) pushMetrics(ctx context.Context, md pdata.Metrics) error {
@@ -109,7 +110,7 @@ func (e *exporter) pushMetrics(ctx context.Context, md pdata.Metrics) error {
 	if err != nil {
 		return consumererror.NewPermanent(err)
 	}
-	return e.export(ctx, e.metricsURL, request)
+	return e.export(ctx, e.metricsURL, request, metricsPartialSuccess)
 }
 
 func (e *exporter) pushLogs(ctx context.Context, ld pdata.Logs) error {
@@ -120,10 +121,53 @@ func (e *exporter) pushLogs(ctx context.Context, ld pdata.Logs) error {
 		return consumererror.NewPermanent(err)
 	}
 
-	return e.export(ctx, e.logsURL, request)
+	return e.export(ctx, e.logsURL, request, logsPartialSuccess)
 }
 
-func (e *exporter) export(ctx context.Context, url string, request []byte) error {
+// partialSuccessFunc decodes a successful export response and returns the
+// number of items rejected by the server with the associated error message.
+type partialSuccessFunc func(body []byte, contentType string) (int64, string, error)
+
+func tracesPartialSuccess(body []byte, contentType string) (int64, string, error) {
+	tr := otlpgrpc.NewTracesResponse()
+	if err := unmarshalResponse(tr, body, contentType); err != nil {
+		return 0, "", err
+	}
+	ps := tr.PartialSuccess()
+	return ps.RejectedSpans(), ps.ErrorMessage(), nil
+}
+
+func metricsPartialSuccess(body []byte, contentType string) (int64, string, error) {
+	mr := otlpgrpc.NewMetricsResponse()
+	if err := unmarshalResponse(mr, body, contentType); err != nil {
+		return 0, "", err
+	}
+	ps := mr.PartialSuccess()
+	return ps.RejectedDataPoints(), ps.ErrorMessage(), nil
+}
+
+func logsPartialSuccess(body []byte, contentType string) (int64, string, error) {
+	lr := otlpgrpc.NewLogsResponse()
+	if err := unmarshalResponse(lr, body, contentType); err != nil {
+		return 0, "", err
+	}
+	ps := lr.PartialSuccess()
+	return ps.RejectedLogRecords(), ps.ErrorMessage(), nil
+}
+
+type responseUnmarshaler interface {
+	UnmarshalProto(data []byte) error
+	UnmarshalJSON(data []byte) error
+}
+
+func unmarshalResponse(resp responseUnmarshaler, body []byte, contentType string) error {
+	if strings.HasPrefix(contentType, "application/json") {
+		return resp.UnmarshalJSON(body)
+	}
+	return resp.UnmarshalProto(body)
+}
+
+func (e *exporter) export(ctx context.Context, url string, request []byte, partialSuccess partialSuccessFunc) error {
 	e.logger.Debug("Preparing to make HTTP request", zap.String("url", url))
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(request))
 	if err != nil {
@@ -144,8 +188,8 @@ func (e *exporter) export(ctx context.Context, url string, request []byte) error
 	}()
 
 	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
-		// Request is successful.
-		return nil
+		// Request is successful, check if the server rejected a part of the data.
+		return e.readPartialSuccess(resp, partialSuccess)
 	}
 
 	respStatus := readResponse(resp)
@@ -186,6 +230,29 @@ func (e *exporter) export(ctx context.Context, url string, request []byte) error
 	return formattedErr
 }
 
+// Read the successful response and return a permanent partial error if the server
+// rejected some of the items, or log its warning if it did not. A response that
+// cannot be decoded is ignored, since the request was accepted.
+func (e *exporter) readPartialSuccess(resp *http.Response, partialSuccess partialSuccessFunc) error {
+	respBytes, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxHTTPResponseReadBytes))
+	if err != nil || len(respBytes) == 0 {
+		return nil
+	}
+	rejected, errMsg, err := partialSuccess(respBytes, resp.Header.Get("Content-Type"))
+	if err != nil {
+		e.logger.Debug("Failed to decode the export response", zap.Error(err))
+		return nil
+	}
+	if rejected == 0 {
+		if errMsg != "" {
+			e.logger.Warn("Partial success response", zap.String("message", errMsg))
+		}
+		return nil
+	}
+	return consumererror.NewPermanent(consumererror.NewPartial(
+		fmt.Errorf("OTLP partial success: %q", errMsg), int(rejected)))
+}
+
 // Read the response and decode the status.Status from the body.
 // Returns nil if the response is empty or cannot be decoded.
 func readResponse(resp *http.Response) *status.Status {
@@ -31,6 +31,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
@@ -93,6 +95,111 @@ func TestTraceError(t *testing.T) {
 	assert.Error(t, exp.ConsumeTraces(context.Background(), td))
 }
 
+func TestTracePartialSuccess(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+
+	startTracesReceiver(t, addr, consumertest.NewErr(consumererror.NewPartial(errors.New("my_error"), 1)))
+	exp := startTracesExporter(t, "", fmt.Sprintf("http://%s/v1/traces", addr))
+
+	td := testdata.GenerateTracesTwoSpansSameResource()
+	err := exp.ConsumeTraces(context.Background(), td)
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Equal(t, 1, consumererror.GetRejectedCount(err))
+	assert.Contains(t, err.Error(), "my_error")
+}
+
+func TestPartialSuccessResponses(t *testing.T) {
+	tests := []struct {
+		name             string
+		contentType      string
+		responseBody     func() ([]byte, error)
+		expectedRejected int
+	}{
+		{
+			name:        "JSON",
+			contentType: "application/json",
+			responseBody: func() ([]byte, error) {
+				lr := otlpgrpc.NewLogsResponse()
+				ps := otlpgrpc.NewLogsPartialSuccess()
+				ps.SetRejectedLogRecords(2)
+				lr.SetPartialSuccess(ps)
+				return lr.MarshalJSON()
+			},
+			expectedRejected: 2,
+		},
+		{
+			name:        "Proto",
+			contentType: "application/x-protobuf",
+			responseBody: func() ([]byte, error) {
+				lr := otlpgrpc.NewLogsResponse()
+				ps := otlpgrpc.NewLogsPartialSuccess()
+				ps.SetRejectedLogRecords(1)
+				lr.SetPartialSuccess(ps)
+				return lr.MarshalProto()
+			},
+			expectedRejected: 1,
+		},
+		{
+			name:        "NoRejected",
+			contentType: "application/x-protobuf",
+			responseBody: func() ([]byte, error) {
+				return otlpgrpc.NewLogsResponse().MarshalProto()
+			},
+		},
+		{
+			name:        "Invalid",
+			contentType: "application/x-protobuf",
+			responseBody: func() ([]byte, error) {
+				return []byte{0xff}, nil
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			body, err := test.responseBody()
+			require.NoError(t, err)
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", test.contentType)
+				_, errWrite := w.Write(body)
+				assert.NoError(t, errWrite)
+			}))
+			defer srv.Close()
+
+			exp := startLogsExporter(t, "", srv.URL)
+			err = exp.ConsumeLogs(context.Background(), testdata.GenerateLogsTwoLogRecordsSameResource())
+			if test.expectedRejected == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.True(t, consumererror.IsPermanent(err))
+			assert.Equal(t, test.expectedRejected, consumererror.GetRejectedCount(err))
+		})
+	}
+}
+
+func TestPartialSuccessWarning(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	e := &exporter{logger: zap.New(core)}
+
+	// A partial success without rejected items is a success carrying a warning.
+	lr := otlpgrpc.NewLogsResponse()
+	ps := otlpgrpc.NewLogsPartialSuccess()
+	ps.SetErrorMessage("deprecated attribute")
+	lr.SetPartialSuccess(ps)
+	body, err := lr.MarshalProto()
+	require.NoError(t, err)
+	resp := &http.Response{
+		Header: http.Header{"Content-Type": {"application/x-protobuf"}},
+		Body:   ioutil.NopCloser(bytes.NewReader(body)),
+	}
+	assert.NoError(t, e.readPartialSuccess(resp, logsPartialSuccess))
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, "deprecated attribute", logs.All()[0].ContextMap()["message"])
+}
+
 func TestTraceRoundTrip(t *testing.T) {
 	addr := testutil.GetAvailableLocalAddress(t)
 
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package conntracker tracks the connections accepted by the receivers listening
+// on raw sockets, so that they can be closed on shutdown.
+package conntracker // import "go.opentelemetry.io/collector/internal/conntracker"
+
+import (
+	"net"
+	"sync"
+)
+
+// Tracker tracks the open connections of a receiver. The zero value is ready to use.
+type Tracker struct {
+	mu     sync.Mutex
+	closed bool
+	conns  map[net.Conn]struct{}
+}
+
+// Add tracks an accepted connection. If the tracker is already closed, the
+// connection is closed immediately and Add returns false, so that a connection
+// accepted concurrently with the shutdown is not left open.
+func (t *Tracker) Add(conn net.Conn) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		_ = conn.Close()
+		return false
+	}
+	if t.conns == nil {
+		t.conns = map[net.Conn]struct{}{}
+	}
+	t.conns[conn] = struct{}{}
+	return true
+}
+
+// Remove stops tracking a connection, and closes it.
+func (t *Tracker) Remove(conn net.Conn) {
+	t.mu.Lock()
+	delete(t.conns, conn)
+	t.mu.Unlock()
+	_ = conn.Close()
+}
+
+// Close closes the tracked connections, and the connections added afterwards.
+func (t *Tracker) Close() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.closed = true
+	for conn := range t.conns {
+		_ = conn.Close()
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conntracker
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracker(t *testing.T) {
+	var tracker Tracker
+
+	c1, p1 := net.Pipe()
+	c2, p2 := net.Pipe()
+	require.True(t, tracker.Add(c1))
+	require.True(t, tracker.Add(c2))
+
+	tracker.Remove(c2)
+	_, err := p2.Write([]byte{0})
+	assert.Error(t, err)
+
+	tracker.Close()
+	_, err = p1.Write([]byte{0})
+	assert.Error(t, err)
+
+	// The connections accepted after the shutdown are closed immediately.
+	c3, p3 := net.Pipe()
+	assert.False(t, tracker.Add(c3))
+	_, err = p3.Write([]byte{0})
+	assert.Error(t, err)
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errorutil // import "go.opentelemetry.io/collector/internal/errorutil"
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const headerRetryAfter = "Retry-After"
+
+// HTTPError responds to an HTTP request with an error returned by the next consumer:
+// 429 Too Many Requests with a Retry-After header if the client is throttled, 400 Bad
+// Request if the error is permanent, or 500 Internal Server Error otherwise.
+func HTTPError(w http.ResponseWriter, err error) {
+	s := status.Convert(GetStatusFromError(err))
+	statusCode, isThrottle := ThrottleStatusCode(s)
+	switch {
+	case isThrottle:
+		SetRetryAfter(w, s)
+	case s.Code() == codes.InvalidArgument:
+		statusCode = http.StatusBadRequest
+	default:
+		statusCode = http.StatusInternalServerError
+	}
+	http.Error(w, err.Error(), statusCode)
+}
+
+// ThrottleStatusCode returns the HTTP status code used to signal to the client that it
+// is throttled, 429 Too Many Requests or 503 Service Unavailable, if the status
+// indicates so.
+func ThrottleStatusCode(s *status.Status) (int, bool) {
+	switch s.Code() {
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests, true
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable, true
+	}
+	return 0, false
+}
+
+// SetRetryAfter sets the Retry-After header, rounded up to whole seconds, if the status
+// carries RetryInfo details.
+func SetRetryAfter(w http.ResponseWriter, s *status.Status) {
+	for _, detail := range s.Details() {
+		if t, ok := detail.(*errdetails.RetryInfo); ok && t.RetryDelay != nil {
+			seconds := int64(math.Ceil(t.RetryDelay.AsDuration().Seconds()))
+			w.Header().Set(headerRetryAfter, strconv.FormatInt(seconds, 10))
+			return
+		}
+	}
+}
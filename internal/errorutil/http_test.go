@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errorutil
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+func TestHTTPError(t *testing.T) {
+	err := errors.New("my error")
+	tests := []struct {
+		name           string
+		err            error
+		wantStatusCode int
+		wantRetryAfter string
+	}{
+		{
+			name:           "error",
+			err:            err,
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:           "permanent",
+			err:            consumererror.NewPermanent(err),
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "throttle",
+			err:            consumererror.NewThrottle(err, 1500*time.Millisecond),
+			wantStatusCode: http.StatusTooManyRequests,
+			wantRetryAfter: "2",
+		},
+		{
+			name:           "throttle_without_delay",
+			err:            consumererror.NewThrottle(err, 0),
+			wantStatusCode: http.StatusTooManyRequests,
+		},
+		{
+			name:           "unavailable",
+			err:            status.Error(codes.Unavailable, "my error"),
+			wantStatusCode: http.StatusServiceUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			HTTPError(w, tt.err)
+			assert.Equal(t, tt.wantStatusCode, w.Code)
+			assert.Equal(t, tt.wantRetryAfter, w.Header().Get("Retry-After"))
+			assert.Contains(t, w.Body.String(), "my error")
+		})
+	}
+}
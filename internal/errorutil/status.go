@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package errorutil converts the errors returned by the next consumers into the
+// errors returned to the clients of the receivers.
+package errorutil // import "go.opentelemetry.io/collector/internal/errorutil"
+
+import (
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+// GetStatusFromError converts an error returned by the next consumer into a gRPC
+// status error telling the client whether to retry: a throttle error gets the code
+// RESOURCE_EXHAUSTED, carrying the suggested delay as RetryInfo details, and a
+// permanent error gets the code INVALID_ARGUMENT. Any other error is returned
+// unchanged.
+func GetStatusFromError(err error) error {
+	if consumererror.IsPermanent(err) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if !consumererror.IsThrottle(err) {
+		return err
+	}
+	s := status.New(codes.ResourceExhausted, err.Error())
+	if delay := consumererror.GetThrottleDelay(err); delay > 0 {
+		// Ignore the error, it can happen only if the details cannot be marshaled,
+		// in which case the status without the RetryInfo is still valid.
+		if sd, errDetails := s.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)}); errDetails == nil {
+			s = sd
+		}
+	}
+	return s.Err()
+}
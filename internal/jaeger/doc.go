@@ -0,0 +1,22 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jaeger translates the spans of the Jaeger model package
+// (github.com/jaegertracing/jaeger/model) to pdata and back.
+//
+// It lives in the collector module, next to the jaeger receiver which is its
+// only user: the receiver depends on the same Jaeger packages for the Thrift and
+// gRPC protocols, so a separate module would not remove them from the
+// dependencies of the collector.
+package jaeger // import "go.opentelemetry.io/collector/internal/jaeger"
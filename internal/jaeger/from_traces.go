@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger // import "go.opentelemetry.io/collector/internal/jaeger"
+
+import (
+	"encoding/binary"
+
+	"github.com/jaegertracing/jaeger/model"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.9.0"
+)
+
+// unknownServiceName is the service name of the processes of resources without service.name.
+const unknownServiceName = "unknown_service"
+
+// TracesToProto translates pdata.Traces to Jaeger Protobuf batches, one per resource.
+func TracesToProto(td pdata.Traces) []*model.Batch {
+	rss := td.ResourceSpans()
+	batches := make([]*model.Batch, 0, rss.Len())
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		batch := &model.Batch{Process: resourceToProcess(rs.Resource())}
+		sss := rs.ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			ss := sss.At(j)
+			spans := ss.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				batch.Spans = append(batch.Spans, spanToJaeger(spans.At(k), ss.Scope()))
+			}
+		}
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+func resourceToProcess(resource pdata.Resource) *model.Process {
+	attrs := resource.Attributes()
+	process := &model.Process{
+		ServiceName: unknownServiceName,
+		Tags:        make([]model.KeyValue, 0, attrs.Len()),
+	}
+	attrs.Range(func(k string, v pdata.Value) bool {
+		if k == conventions.AttributeServiceName && v.Type() == pdata.ValueTypeString {
+			process.ServiceName = v.StringVal()
+			return true
+		}
+		process.Tags = append(process.Tags, valueToKeyValue(k, v))
+		return true
+	})
+	return process
+}
+
+func spanToJaeger(span pdata.Span, scope pdata.InstrumentationScope) *model.Span {
+	traceID := traceIDToJaeger(span.TraceID())
+	start := span.StartTimestamp().AsTime()
+	jspan := &model.Span{
+		TraceID:       traceID,
+		SpanID:        spanIDToJaeger(span.SpanID()),
+		OperationName: span.Name(),
+		References:    linksToReferences(span.Links()),
+		StartTime:     start,
+		Duration:      span.EndTimestamp().AsTime().Sub(start),
+		Tags:          spanTags(span, scope),
+		Logs:          eventsToLogs(span.Events()),
+	}
+	if !span.ParentSpanID().IsEmpty() {
+		jspan.References = append([]model.SpanRef{model.NewChildOfRef(traceID, spanIDToJaeger(span.ParentSpanID()))}, jspan.References...)
+	}
+	return jspan
+}
+
+// linksToReferences converts the links into follows-from references, or child-of
+// references if their opentracing.ref_type attribute says so.
+func linksToReferences(links pdata.SpanLinkSlice) []model.SpanRef {
+	if links.Len() == 0 {
+		return nil
+	}
+	refs := make([]model.SpanRef, 0, links.Len())
+	for i := 0; i < links.Len(); i++ {
+		link := links.At(i)
+		refType := model.FollowsFrom
+		if v, ok := link.Attributes().Get(conventions.AttributeOpentracingRefType); ok && v.StringVal() == conventions.AttributeOpentracingRefTypeChildOf {
+			refType = model.ChildOf
+		}
+		refs = append(refs, model.SpanRef{
+			TraceID: traceIDToJaeger(link.TraceID()),
+			SpanID:  spanIDToJaeger(link.SpanID()),
+			RefType: refType,
+		})
+	}
+	return refs
+}
+
+// spanTags returns the attributes of the span as tags, along with the tags representing
+// its kind, status, trace state and instrumentation scope.
+func spanTags(span pdata.Span, scope pdata.InstrumentationScope) []model.KeyValue {
+	attrs := span.Attributes()
+	tags := make([]model.KeyValue, 0, attrs.Len()+6)
+	attrs.Range(func(k string, v pdata.Value) bool {
+		tags = append(tags, valueToKeyValue(k, v))
+		return true
+	})
+
+	if kind := spanKindToJaeger(span.Kind()); kind != "" {
+		tags = append(tags, model.String(TagSpanKind, kind))
+	}
+	switch status := span.Status(); status.Code() {
+	case pdata.StatusCodeOk:
+		tags = append(tags, model.String(conventions.OtelStatusCode, "OK"))
+	case pdata.StatusCodeError:
+		tags = append(tags, model.Bool(TagError, true), model.String(conventions.OtelStatusCode, "ERROR"))
+		if status.Message() != "" {
+			tags = append(tags, model.String(conventions.OtelStatusDescription, status.Message()))
+		}
+	}
+	if ts := span.TraceState(); ts != "" {
+		tags = append(tags, model.String(TagW3CTraceState, string(ts)))
+	}
+	if scope.Name() != "" {
+		tags = append(tags, model.String(conventions.OtelLibraryName, scope.Name()))
+	}
+	if scope.Version() != "" {
+		tags = append(tags, model.String(conventions.OtelLibraryVersion, scope.Version()))
+	}
+	return tags
+}
+
+func eventsToLogs(events pdata.SpanEventSlice) []model.Log {
+	if events.Len() == 0 {
+		return nil
+	}
+	logs := make([]model.Log, 0, events.Len())
+	for i := 0; i < events.Len(); i++ {
+		event := events.At(i)
+		fields := make([]model.KeyValue, 0, event.Attributes().Len()+1)
+		if event.Name() != "" {
+			fields = append(fields, model.String(LogFieldEvent, event.Name()))
+		}
+		event.Attributes().Range(func(k string, v pdata.Value) bool {
+			fields = append(fields, valueToKeyValue(k, v))
+			return true
+		})
+		logs = append(logs, model.Log{
+			Timestamp: event.Timestamp().AsTime(),
+			Fields:    fields,
+		})
+	}
+	return logs
+}
+
+// valueToKeyValue converts an attribute into a tag. Maps and slices are converted
+// into their JSON representation.
+func valueToKeyValue(key string, v pdata.Value) model.KeyValue {
+	switch v.Type() {
+	case pdata.ValueTypeBool:
+		return model.Bool(key, v.BoolVal())
+	case pdata.ValueTypeInt:
+		return model.Int64(key, v.IntVal())
+	case pdata.ValueTypeDouble:
+		return model.Float64(key, v.DoubleVal())
+	case pdata.ValueTypeBytes:
+		return model.Binary(key, v.BytesVal())
+	}
+	return model.String(key, v.AsString())
+}
+
+func spanKindToJaeger(kind pdata.SpanKind) string {
+	switch kind {
+	case pdata.SpanKindClient:
+		return "client"
+	case pdata.SpanKindServer:
+		return "server"
+	case pdata.SpanKindProducer:
+		return "producer"
+	case pdata.SpanKindConsumer:
+		return "consumer"
+	case pdata.SpanKindInternal:
+		return "internal"
+	}
+	return ""
+}
+
+func traceIDToJaeger(id pdata.TraceID) model.TraceID {
+	b := id.Bytes()
+	return model.NewTraceID(binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:]))
+}
+
+func spanIDToJaeger(id pdata.SpanID) model.SpanID {
+	b := id.Bytes()
+	return model.NewSpanID(binary.BigEndian.Uint64(b[:]))
+}
@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.9.0"
+)
+
+func TestTracesToProto(t *testing.T) {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString(conventions.AttributeServiceName, "frontend")
+	rs.Resource().Attributes().InsertString("host.name", "host1")
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("lib")
+	span := ss.Spans().AppendEmpty()
+	span.SetTraceID(pdata.NewTraceID([16]byte{0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 2}))
+	span.SetSpanID(pdata.NewSpanID([8]byte{0, 0, 0, 0, 0, 0, 0, 3}))
+	span.SetParentSpanID(pdata.NewSpanID([8]byte{0, 0, 0, 0, 0, 0, 0, 4}))
+	span.SetName("get")
+	span.SetKind(pdata.SpanKindServer)
+	span.SetStartTimestamp(pdata.NewTimestampFromTime(testStartTime))
+	span.SetEndTimestamp(pdata.NewTimestampFromTime(testStartTime.Add(time.Second)))
+	span.Status().SetCode(pdata.StatusCodeError)
+	span.Status().SetMessage("failed")
+	span.Attributes().InsertInt("http.status_code", 500)
+	span.Attributes().Insert("list", pdata.NewValueSlice())
+	link := span.Links().AppendEmpty()
+	link.SetTraceID(span.TraceID())
+	link.SetSpanID(pdata.NewSpanID([8]byte{0, 0, 0, 0, 0, 0, 0, 5}))
+	event := span.Events().AppendEmpty()
+	event.SetName("retry")
+	event.SetTimestamp(pdata.NewTimestampFromTime(testStartTime))
+	event.Attributes().InsertBool("final", true)
+
+	batches := TracesToProto(td)
+	require.Len(t, batches, 1)
+	assert.Equal(t, &model.Process{
+		ServiceName: "frontend",
+		Tags:        []model.KeyValue{model.String("host.name", "host1")},
+	}, batches[0].Process)
+
+	traceID := model.NewTraceID(1, 2)
+	require.Len(t, batches[0].Spans, 1)
+	assert.Equal(t, &model.Span{
+		TraceID:       traceID,
+		SpanID:        model.NewSpanID(3),
+		OperationName: "get",
+		References: []model.SpanRef{
+			model.NewChildOfRef(traceID, model.NewSpanID(4)),
+			model.NewFollowsFromRef(traceID, model.NewSpanID(5)),
+		},
+		StartTime: testStartTime,
+		Duration:  time.Second,
+		Tags: []model.KeyValue{
+			model.Int64("http.status_code", 500),
+			model.String("list", "[]"),
+			model.String(TagSpanKind, "server"),
+			model.Bool(TagError, true),
+			model.String(conventions.OtelStatusCode, "ERROR"),
+			model.String(conventions.OtelStatusDescription, "failed"),
+			model.String(conventions.OtelLibraryName, "lib"),
+		},
+		Logs: []model.Log{{
+			Timestamp: testStartTime,
+			Fields:    []model.KeyValue{model.String(LogFieldEvent, "retry"), model.Bool("final", true)},
+		}},
+	}, batches[0].Spans[0])
+}
+
+func TestTracesToProto_UnknownService(t *testing.T) {
+	td := pdata.NewTraces()
+	td.ResourceSpans().AppendEmpty()
+
+	batches := TracesToProto(td)
+	require.Len(t, batches, 1)
+	assert.Equal(t, unknownServiceName, batches[0].Process.ServiceName)
+	assert.Empty(t, batches[0].Spans)
+}
+
+func TestRoundTrip(t *testing.T) {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString(conventions.AttributeServiceName, "frontend")
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("lib")
+	ss.Scope().SetVersion("1.0")
+	span := ss.Spans().AppendEmpty()
+	span.SetTraceID(pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	span.SetSpanID(pdata.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+	span.SetParentSpanID(pdata.NewSpanID([8]byte{8, 7, 6, 5, 4, 3, 2, 1}))
+	span.SetName("get")
+	span.SetKind(pdata.SpanKindConsumer)
+	span.SetTraceState("k=v")
+	span.SetStartTimestamp(pdata.NewTimestampFromTime(testStartTime))
+	span.SetEndTimestamp(pdata.NewTimestampFromTime(testStartTime.Add(time.Second)))
+	span.Status().SetCode(pdata.StatusCodeOk)
+	span.Attributes().InsertDouble("ratio", 0.5)
+	link := span.Links().AppendEmpty()
+	link.SetTraceID(pdata.NewTraceID([16]byte{16}))
+	link.SetSpanID(pdata.NewSpanID([8]byte{8}))
+	link.Attributes().InsertString(conventions.AttributeOpentracingRefType, conventions.AttributeOpentracingRefTypeFollowsFrom)
+	event := span.Events().AppendEmpty()
+	event.SetName("retry")
+	event.SetTimestamp(pdata.NewTimestampFromTime(testStartTime))
+
+	assert.Equal(t, td, ProtoToTraces(TracesToProto(td)))
+}
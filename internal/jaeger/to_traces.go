@@ -0,0 +1,255 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger // import "go.opentelemetry.io/collector/internal/jaeger"
+
+import (
+	"encoding/binary"
+	"strings"
+
+	"github.com/jaegertracing/jaeger/model"
+	thriftconverter "github.com/jaegertracing/jaeger/model/converter/thrift/jaeger"
+	"github.com/jaegertracing/jaeger/thrift-gen/jaeger"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.9.0"
+)
+
+// Tags and log fields used to represent OTLP constructs that have no equivalent in Jaeger.
+const (
+	TagSpanKind      = "span.kind"
+	TagError         = "error"
+	TagW3CTraceState = "w3c.tracestate"
+
+	// LogFieldEvent is the log field holding the name of the span event.
+	LogFieldEvent = "event"
+)
+
+// ThriftToTraces translates a Jaeger Thrift batch to pdata.Traces.
+func ThriftToTraces(batch *jaeger.Batch) pdata.Traces {
+	return ProtoToTraces([]*model.Batch{{
+		Process: thriftconverter.ToDomainProcess(batch.Process),
+		Spans:   thriftconverter.ToDomain(batch.Spans, nil),
+	}})
+}
+
+// ProtoToTraces translates Jaeger Protobuf batches to pdata.Traces. Each process
+// becomes a resource, the spans of a batch using the process of the batch unless
+// they have their own. The spans are grouped by instrumentation library into scopes.
+func ProtoToTraces(batches []*model.Batch) pdata.Traces {
+	td := pdata.NewTraces()
+	for _, batch := range batches {
+		resources := map[*model.Process]pdata.ResourceSpans{}
+		scopes := map[scopeKey]pdata.ScopeSpans{}
+
+		for _, span := range batch.Spans {
+			if span == nil {
+				continue
+			}
+			process := batch.Process
+			if span.Process != nil {
+				process = span.Process
+			}
+			rs, ok := resources[process]
+			if !ok {
+				rs = td.ResourceSpans().AppendEmpty()
+				processToPdata(process, rs.Resource())
+				resources[process] = rs
+			}
+
+			key := scopeKey{process: process}
+			if kv, found := model.KeyValues(span.Tags).FindByKey(conventions.OtelLibraryName); found {
+				key.name = kv.AsString()
+			}
+			if kv, found := model.KeyValues(span.Tags).FindByKey(conventions.OtelLibraryVersion); found {
+				key.version = kv.AsString()
+			}
+			ss, ok := scopes[key]
+			if !ok {
+				ss = rs.ScopeSpans().AppendEmpty()
+				ss.Scope().SetName(key.name)
+				ss.Scope().SetVersion(key.version)
+				scopes[key] = ss
+			}
+
+			spanToPdata(span, ss.Spans().AppendEmpty())
+		}
+	}
+	return td
+}
+
+type scopeKey struct {
+	process *model.Process
+	name    string
+	version string
+}
+
+func processToPdata(process *model.Process, resource pdata.Resource) {
+	if process == nil {
+		return
+	}
+	attrs := resource.Attributes()
+	if process.ServiceName != "" {
+		attrs.InsertString(conventions.AttributeServiceName, process.ServiceName)
+	}
+	for i := range process.Tags {
+		attrs.Insert(process.Tags[i].Key, keyValueToPdata(&process.Tags[i]))
+	}
+}
+
+func spanToPdata(jspan *model.Span, span pdata.Span) {
+	span.SetTraceID(traceIDToPdata(jspan.TraceID))
+	span.SetSpanID(spanIDToPdata(jspan.SpanID))
+	span.SetName(jspan.OperationName)
+	span.SetStartTimestamp(pdata.NewTimestampFromTime(jspan.StartTime))
+	span.SetEndTimestamp(pdata.NewTimestampFromTime(jspan.StartTime.Add(jspan.Duration)))
+
+	referencesToPdata(jspan, span)
+	tagsToPdata(jspan.Tags, span)
+
+	events := span.Events()
+	events.EnsureCapacity(len(jspan.Logs))
+	for i := range jspan.Logs {
+		logToPdata(&jspan.Logs[i], events.AppendEmpty())
+	}
+}
+
+// referencesToPdata sets the parent of the span from its first child-of reference
+// in the same trace, and converts the other references into links.
+func referencesToPdata(jspan *model.Span, span pdata.Span) {
+	parentFound := false
+	links := span.Links()
+	for _, ref := range jspan.References {
+		if !parentFound && ref.RefType == model.ChildOf && ref.TraceID == jspan.TraceID {
+			span.SetParentSpanID(spanIDToPdata(ref.SpanID))
+			parentFound = true
+			continue
+		}
+		link := links.AppendEmpty()
+		link.SetTraceID(traceIDToPdata(ref.TraceID))
+		link.SetSpanID(spanIDToPdata(ref.SpanID))
+		link.Attributes().InsertString(conventions.AttributeOpentracingRefType, refTypeToString(ref.RefType))
+	}
+}
+
+// tagsToPdata sets the kind, status and trace state of the span from the tags
+// representing them, and adds the other tags to the attributes of the span.
+func tagsToPdata(tags []model.KeyValue, span pdata.Span) {
+	kvs := model.KeyValues(tags)
+	status := span.Status()
+	attrs := span.Attributes()
+	_, hasStatusCode := kvs.FindByKey(conventions.OtelStatusCode)
+	for i := range tags {
+		kv := &tags[i]
+		switch kv.Key {
+		case conventions.OtelLibraryName, conventions.OtelLibraryVersion, conventions.OtelStatusDescription:
+		case TagSpanKind:
+			span.SetKind(spanKindToPdata(kv.AsString()))
+		case conventions.OtelStatusCode:
+			status.SetCode(statusCodeToPdata(kv.AsString()))
+			if desc, ok := kvs.FindByKey(conventions.OtelStatusDescription); ok {
+				status.SetMessage(desc.AsString())
+			}
+		case TagError:
+			// The status tags take precedence over the error tag.
+			if !hasStatusCode && isTrue(kv) {
+				status.SetCode(pdata.StatusCodeError)
+			}
+		case TagW3CTraceState:
+			span.SetTraceState(pdata.TraceState(kv.AsString()))
+		default:
+			attrs.Insert(kv.Key, keyValueToPdata(kv))
+		}
+	}
+}
+
+func isTrue(kv *model.KeyValue) bool {
+	if kv.VType == model.ValueType_BOOL {
+		return kv.Bool()
+	}
+	return strings.EqualFold(kv.AsString(), "true")
+}
+
+func logToPdata(log *model.Log, event pdata.SpanEvent) {
+	event.SetTimestamp(pdata.NewTimestampFromTime(log.Timestamp))
+	attrs := event.Attributes()
+	for i := range log.Fields {
+		kv := &log.Fields[i]
+		if kv.Key == LogFieldEvent && kv.VType == model.ValueType_STRING && event.Name() == "" {
+			event.SetName(kv.VStr)
+			continue
+		}
+		attrs.Insert(kv.Key, keyValueToPdata(kv))
+	}
+}
+
+func keyValueToPdata(kv *model.KeyValue) pdata.Value {
+	switch kv.VType {
+	case model.ValueType_BOOL:
+		return pdata.NewValueBool(kv.Bool())
+	case model.ValueType_INT64:
+		return pdata.NewValueInt(kv.Int64())
+	case model.ValueType_FLOAT64:
+		return pdata.NewValueDouble(kv.Float64())
+	case model.ValueType_BINARY:
+		return pdata.NewValueBytes(kv.Binary())
+	}
+	return pdata.NewValueString(kv.VStr)
+}
+
+func spanKindToPdata(kind string) pdata.SpanKind {
+	switch kind {
+	case "client":
+		return pdata.SpanKindClient
+	case "server":
+		return pdata.SpanKindServer
+	case "producer":
+		return pdata.SpanKindProducer
+	case "consumer":
+		return pdata.SpanKindConsumer
+	case "internal":
+		return pdata.SpanKindInternal
+	}
+	return pdata.SpanKindUnspecified
+}
+
+func statusCodeToPdata(code string) pdata.StatusCode {
+	switch code {
+	case "OK", "STATUS_CODE_OK":
+		return pdata.StatusCodeOk
+	case "ERROR", "STATUS_CODE_ERROR":
+		return pdata.StatusCodeError
+	}
+	return pdata.StatusCodeUnset
+}
+
+func refTypeToString(refType model.SpanRefType) string {
+	if refType == model.ChildOf {
+		return conventions.AttributeOpentracingRefTypeChildOf
+	}
+	return conventions.AttributeOpentracingRefTypeFollowsFrom
+}
+
+func traceIDToPdata(id model.TraceID) pdata.TraceID {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], id.High)
+	binary.BigEndian.PutUint64(b[8:], id.Low)
+	return pdata.NewTraceID(b)
+}
+
+func spanIDToPdata(id model.SpanID) pdata.SpanID {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(id))
+	return pdata.NewSpanID(b)
+}
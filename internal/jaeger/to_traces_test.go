@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/thrift-gen/jaeger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.9.0"
+)
+
+var (
+	testStartTime = time.Date(2022, 3, 4, 5, 6, 7, 8000, time.UTC)
+	testTraceID   = model.NewTraceID(0x5982fe77008310cc, 0x80f1da5e10147517)
+)
+
+func TestProtoToTraces(t *testing.T) {
+	batch := &model.Batch{
+		Process: &model.Process{
+			ServiceName: "frontend",
+			Tags:        []model.KeyValue{model.String("host.name", "host1"), model.Int64("pid", 42)},
+		},
+		Spans: []*model.Span{
+			{
+				TraceID:       testTraceID,
+				SpanID:        model.NewSpanID(2),
+				OperationName: "get",
+				References: []model.SpanRef{
+					model.NewFollowsFromRef(testTraceID, model.NewSpanID(3)),
+					model.NewChildOfRef(testTraceID, model.NewSpanID(1)),
+				},
+				StartTime: testStartTime,
+				Duration:  time.Second,
+				Tags: []model.KeyValue{
+					model.String(TagSpanKind, "client"),
+					model.Bool(TagError, true),
+					model.String(TagW3CTraceState, "k=v"),
+					model.String(conventions.OtelLibraryName, "lib"),
+					model.String(conventions.OtelLibraryVersion, "1.0"),
+					model.Float64("ratio", 0.5),
+					model.Binary("raw", []byte{1, 2}),
+				},
+				Logs: []model.Log{{
+					Timestamp: testStartTime.Add(time.Millisecond),
+					Fields:    []model.KeyValue{model.String(LogFieldEvent, "retry"), model.Int64("attempt", 2)},
+				}},
+			},
+			{
+				TraceID:       testTraceID,
+				SpanID:        model.NewSpanID(4),
+				OperationName: "query",
+				StartTime:     testStartTime,
+				Process:       &model.Process{ServiceName: "db"},
+				Tags: []model.KeyValue{
+					model.String(conventions.OtelStatusCode, "ERROR"),
+					model.String(conventions.OtelStatusDescription, "timeout"),
+					model.Bool(TagError, false),
+				},
+			},
+		},
+	}
+
+	td := ProtoToTraces([]*model.Batch{batch})
+	require.Equal(t, 2, td.ResourceSpans().Len())
+
+	rs := td.ResourceSpans().At(0)
+	assert.Equal(t, map[string]interface{}{
+		conventions.AttributeServiceName: "frontend",
+		"host.name":                      "host1",
+		"pid":                            int64(42),
+	}, rs.Resource().Attributes().AsRaw())
+	require.Equal(t, 1, rs.ScopeSpans().Len())
+	scope := rs.ScopeSpans().At(0).Scope()
+	assert.Equal(t, "lib", scope.Name())
+	assert.Equal(t, "1.0", scope.Version())
+
+	span := rs.ScopeSpans().At(0).Spans().At(0)
+	assert.Equal(t, pdata.NewTraceID([16]byte{0x59, 0x82, 0xfe, 0x77, 0x00, 0x83, 0x10, 0xcc, 0x80, 0xf1, 0xda, 0x5e, 0x10, 0x14, 0x75, 0x17}), span.TraceID())
+	assert.Equal(t, pdata.NewSpanID([8]byte{0, 0, 0, 0, 0, 0, 0, 2}), span.SpanID())
+	assert.Equal(t, pdata.NewSpanID([8]byte{0, 0, 0, 0, 0, 0, 0, 1}), span.ParentSpanID())
+	assert.Equal(t, "get", span.Name())
+	assert.Equal(t, pdata.SpanKindClient, span.Kind())
+	assert.Equal(t, pdata.NewTimestampFromTime(testStartTime), span.StartTimestamp())
+	assert.Equal(t, pdata.NewTimestampFromTime(testStartTime.Add(time.Second)), span.EndTimestamp())
+	assert.Equal(t, pdata.StatusCodeError, span.Status().Code())
+	assert.Equal(t, pdata.TraceState("k=v"), span.TraceState())
+	assert.Equal(t, map[string]interface{}{"ratio": 0.5, "raw": []byte{1, 2}}, span.Attributes().AsRaw())
+
+	require.Equal(t, 1, span.Links().Len())
+	link := span.Links().At(0)
+	assert.Equal(t, pdata.NewSpanID([8]byte{0, 0, 0, 0, 0, 0, 0, 3}), link.SpanID())
+	assert.Equal(t, map[string]interface{}{
+		conventions.AttributeOpentracingRefType: conventions.AttributeOpentracingRefTypeFollowsFrom,
+	}, link.Attributes().AsRaw())
+
+	require.Equal(t, 1, span.Events().Len())
+	event := span.Events().At(0)
+	assert.Equal(t, "retry", event.Name())
+	assert.Equal(t, pdata.NewTimestampFromTime(testStartTime.Add(time.Millisecond)), event.Timestamp())
+	assert.Equal(t, map[string]interface{}{"attempt": int64(2)}, event.Attributes().AsRaw())
+
+	rs = td.ResourceSpans().At(1)
+	assert.Equal(t, map[string]interface{}{conventions.AttributeServiceName: "db"}, rs.Resource().Attributes().AsRaw())
+	span = rs.ScopeSpans().At(0).Spans().At(0)
+	assert.Equal(t, pdata.SpanKindUnspecified, span.Kind())
+	assert.Equal(t, pdata.StatusCodeError, span.Status().Code())
+	assert.Equal(t, "timeout", span.Status().Message())
+	assert.Equal(t, 0, span.Attributes().Len())
+}
+
+func TestThriftToTraces(t *testing.T) {
+	td := ThriftToTraces(&jaeger.Batch{
+		Process: &jaeger.Process{ServiceName: "frontend"},
+		Spans: []*jaeger.Span{{
+			TraceIdHigh:   1,
+			TraceIdLow:    2,
+			SpanId:        3,
+			ParentSpanId:  4,
+			OperationName: "get",
+			StartTime:     testStartTime.UnixNano() / 1000,
+			Duration:      1000,
+		}},
+	})
+
+	require.Equal(t, 1, td.SpanCount())
+	rs := td.ResourceSpans().At(0)
+	assert.Equal(t, map[string]interface{}{conventions.AttributeServiceName: "frontend"}, rs.Resource().Attributes().AsRaw())
+	span := rs.ScopeSpans().At(0).Spans().At(0)
+	assert.Equal(t, pdata.NewTraceID([16]byte{0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 2}), span.TraceID())
+	assert.Equal(t, pdata.NewSpanID([8]byte{0, 0, 0, 0, 0, 0, 0, 3}), span.SpanID())
+	assert.Equal(t, pdata.NewSpanID([8]byte{0, 0, 0, 0, 0, 0, 0, 4}), span.ParentSpanID())
+	assert.Equal(t, pdata.NewTimestampFromTime(testStartTime), span.StartTimestamp())
+	assert.Equal(t, pdata.NewTimestampFromTime(testStartTime.Add(time.Millisecond)), span.EndTimestamp())
+	assert.Equal(t, 0, span.Links().Len())
+}
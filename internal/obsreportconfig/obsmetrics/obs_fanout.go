@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package obsmetrics // import "go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+)
+
+const (
+	// FanoutKey used to identify the fan-out of a pipeline to its exporters in metrics.
+	FanoutKey = "fanout"
+	// PipelineKey used to identify pipelines in metrics.
+	PipelineKey = "pipeline"
+
+	// TimedOutBatchesKey used to identify the batches which an exporter did
+	// not consume within the fan-out timeout.
+	TimedOutBatchesKey = "timed_out_batches"
+	// RejectedBatchesKey used to identify the batches which were not sent to
+	// an exporter because its fan-out buffer was full.
+	RejectedBatchesKey = "rejected_batches"
+)
+
+const (
+	FanoutPrefix = FanoutKey + NameSep
+)
+
+var (
+	TagKeyPipeline, _ = tag.NewKey(PipelineKey)
+
+	FanoutTimedOutBatches = stats.Int64(
+		FanoutPrefix+TimedOutBatchesKey,
+		"Number of batches that an exporter did not consume within the fan-out timeout.",
+		stats.UnitDimensionless)
+	FanoutRejectedBatches = stats.Int64(
+		FanoutPrefix+RejectedBatchesKey,
+		"Number of batches that were not sent to an exporter because its fan-out buffer was full.",
+		stats.UnitDimensionless)
+)
@@ -43,6 +43,10 @@ const (
 	// RefusedLogRecordsKey used to identify log records refused (ie.: not ingested) by the
 	// Collector.
 	RefusedLogRecordsKey = "refused_log_records"
+
+	// RefusedRequestsKey used to identify requests refused by the Collector before
+	// their data was read.
+	RefusedRequestsKey = "refused_requests"
 )
 
 var (
@@ -83,4 +87,8 @@ var (
 		ReceiverPrefix+RefusedLogRecordsKey,
 		"Number of log records that could not be pushed into the pipeline.",
 		stats.UnitDimensionless)
+	ReceiverRefusedRequests = stats.Int64(
+		ReceiverPrefix+RefusedRequestsKey,
+		"Number of requests that were refused before their data was read.",
+		stats.UnitDimensionless)
 )
@@ -29,11 +29,27 @@ const (
 	// ErroredMetricPointsKey used to identify metric points errored (i.e.
 	// unable to be scraped) by the Collector.
 	ErroredMetricPointsKey = "errored_metric_points"
+	// ScrapedLogRecordsKey used to identify log records scraped by the
+	// Collector.
+	ScrapedLogRecordsKey = "scraped_log_records"
+	// ErroredLogRecordsKey used to identify log records errored (i.e.
+	// unable to be scraped) by the Collector.
+	ErroredLogRecordsKey = "errored_log_records"
+	// ScrapedSpansKey used to identify spans scraped by the Collector.
+	ScrapedSpansKey = "scraped_spans"
+	// ErroredSpansKey used to identify spans errored (i.e. unable to be
+	// scraped) by the Collector.
+	ErroredSpansKey = "errored_spans"
+	// TimedOutScrapesKey used to identify the scrapes which did not complete
+	// within their timeout.
+	TimedOutScrapesKey = "timed_out_scrapes"
 )
 
 const (
 	ScraperPrefix                 = ScraperKey + NameSep
 	ScraperMetricsOperationSuffix = NameSep + "MetricsScraped"
+	ScraperLogsOperationSuffix    = NameSep + "LogsScraped"
+	ScraperTracesOperationSuffix  = NameSep + "TracesScraped"
 )
 
 var (
@@ -47,4 +63,24 @@ var (
 		ScraperPrefix+ErroredMetricPointsKey,
 		"Number of metric points that were unable to be scraped.",
 		stats.UnitDimensionless)
+	ScraperScrapedLogRecords = stats.Int64(
+		ScraperPrefix+ScrapedLogRecordsKey,
+		"Number of log records successfully scraped.",
+		stats.UnitDimensionless)
+	ScraperErroredLogRecords = stats.Int64(
+		ScraperPrefix+ErroredLogRecordsKey,
+		"Number of log records that were unable to be scraped.",
+		stats.UnitDimensionless)
+	ScraperScrapedSpans = stats.Int64(
+		ScraperPrefix+ScrapedSpansKey,
+		"Number of spans successfully scraped.",
+		stats.UnitDimensionless)
+	ScraperErroredSpans = stats.Int64(
+		ScraperPrefix+ErroredSpansKey,
+		"Number of spans that were unable to be scraped.",
+		stats.UnitDimensionless)
+	ScraperTimedOutScrapes = stats.Int64(
+		ScraperPrefix+TimedOutScrapesKey,
+		"Number of scrapes that did not complete within their timeout.",
+		stats.UnitDimensionless)
 )
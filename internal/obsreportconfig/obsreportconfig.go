@@ -23,12 +23,28 @@ import (
 
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+	"go.opentelemetry.io/collector/service/featuregate"
+)
+
+const (
+	// UseOtelForInternalMetricsfeatureGateID is the feature gate ID that controls whether the collector uses open
+	// telemetry for internal metrics.
+	UseOtelForInternalMetricsfeatureGateID = "telemetry.useOtelForInternalMetrics"
 )
 
 var (
 	globalLevel = int32(configtelemetry.LevelBasic)
 )
 
+func init() {
+	//nolint:staticcheck
+	featuregate.Register(featuregate.Gate{
+		ID:          UseOtelForInternalMetricsfeatureGateID,
+		Description: "controls whether the collector to uses open telemetry for internal metrics",
+		Enabled:     false,
+	})
+}
+
 // ObsMetrics wraps OpenCensus View for Collector observability metrics
 type ObsMetrics struct {
 	Views []*view.View
@@ -66,6 +82,7 @@ func allViews() *ObsMetrics {
 		obsmetrics.ReceiverRefusedMetricPoints,
 		obsmetrics.ReceiverAcceptedLogRecords,
 		obsmetrics.ReceiverRefusedLogRecords,
+		obsmetrics.ReceiverRefusedRequests,
 	}
 	tagKeys := []tag.Key{
 		obsmetrics.TagKeyReceiver, obsmetrics.TagKeyTransport,
@@ -76,6 +93,11 @@ func allViews() *ObsMetrics {
 	measures = []*stats.Int64Measure{
 		obsmetrics.ScraperScrapedMetricPoints,
 		obsmetrics.ScraperErroredMetricPoints,
+		obsmetrics.ScraperScrapedLogRecords,
+		obsmetrics.ScraperErroredLogRecords,
+		obsmetrics.ScraperScrapedSpans,
+		obsmetrics.ScraperErroredSpans,
+		obsmetrics.ScraperTimedOutScrapes,
 	}
 	tagKeys = []tag.Key{obsmetrics.TagKeyReceiver, obsmetrics.TagKeyScraper}
 	views = append(views, genViews(measures, tagKeys, view.Sum())...)
@@ -100,6 +122,14 @@ func allViews() *ObsMetrics {
 	}
 	views = append(views, errorNumberView)
 
+	// Fanout views.
+	measures = []*stats.Int64Measure{
+		obsmetrics.FanoutTimedOutBatches,
+		obsmetrics.FanoutRejectedBatches,
+	}
+	tagKeys = []tag.Key{obsmetrics.TagKeyPipeline, obsmetrics.TagKeyExporter}
+	views = append(views, genViews(measures, tagKeys, view.Sum())...)
+
 	// Processor views.
 	measures = []*stats.Int64Measure{
 		obsmetrics.ProcessorAcceptedSpans,
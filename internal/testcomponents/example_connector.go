@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testcomponents // import "go.opentelemetry.io/collector/internal/testcomponents"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// ExampleConnector is for testing purposes. We are defining an example config and factory
+// for "exampleconnector" connector type.
+type ExampleConnector struct {
+	config.ConnectorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+	ExtraSetting             string                   `mapstructure:"extra"`
+}
+
+const connType = "exampleconnector"
+
+// ExampleConnectorFactory is factory for ExampleConnector.
+var ExampleConnectorFactory = component.NewConnectorFactory(
+	connType,
+	createConnectorDefaultConfig,
+	component.WithTracesToTracesConnector(createTracesToTracesConnector),
+	component.WithTracesToMetricsConnector(createTracesToMetricsConnector),
+	component.WithTracesToLogsConnector(createTracesToLogsConnector),
+	component.WithMetricsToTracesConnector(createMetricsToTracesConnector),
+	component.WithMetricsToMetricsConnector(createMetricsToMetricsConnector),
+	component.WithMetricsToLogsConnector(createMetricsToLogsConnector),
+	component.WithLogsToTracesConnector(createLogsToTracesConnector),
+	component.WithLogsToMetricsConnector(createLogsToMetricsConnector),
+	component.WithLogsToLogsConnector(createLogsToLogsConnector))
+
+// CreateDefaultConfig creates the default configuration for the Connector.
+func createConnectorDefaultConfig() config.Connector {
+	return &ExampleConnector{
+		ConnectorSettings: config.NewConnectorSettings(config.NewComponentID(connType)),
+		ExtraSetting:      "some connector string",
+	}
+}
+
+func createTracesToTracesConnector(_ context.Context, _ component.ConnectorCreateSettings, _ config.Connector, next consumer.Traces) (component.TracesConnector, error) {
+	return &ExampleConnectorConsumer{nextTraces: next}, nil
+}
+
+func createTracesToMetricsConnector(_ context.Context, _ component.ConnectorCreateSettings, _ config.Connector, next consumer.Metrics) (component.TracesConnector, error) {
+	return &ExampleConnectorConsumer{nextMetrics: next}, nil
+}
+
+func createTracesToLogsConnector(_ context.Context, _ component.ConnectorCreateSettings, _ config.Connector, next consumer.Logs) (component.TracesConnector, error) {
+	return &ExampleConnectorConsumer{nextLogs: next}, nil
+}
+
+func createMetricsToTracesConnector(_ context.Context, _ component.ConnectorCreateSettings, _ config.Connector, next consumer.Traces) (component.MetricsConnector, error) {
+	return &ExampleConnectorConsumer{nextTraces: next}, nil
+}
+
+func createMetricsToMetricsConnector(_ context.Context, _ component.ConnectorCreateSettings, _ config.Connector, next consumer.Metrics) (component.MetricsConnector, error) {
+	return &ExampleConnectorConsumer{nextMetrics: next}, nil
+}
+
+func createMetricsToLogsConnector(_ context.Context, _ component.ConnectorCreateSettings, _ config.Connector, next consumer.Logs) (component.MetricsConnector, error) {
+	return &ExampleConnectorConsumer{nextLogs: next}, nil
+}
+
+func createLogsToTracesConnector(_ context.Context, _ component.ConnectorCreateSettings, _ config.Connector, next consumer.Traces) (component.LogsConnector, error) {
+	return &ExampleConnectorConsumer{nextTraces: next}, nil
+}
+
+func createLogsToMetricsConnector(_ context.Context, _ component.ConnectorCreateSettings, _ config.Connector, next consumer.Metrics) (component.LogsConnector, error) {
+	return &ExampleConnectorConsumer{nextMetrics: next}, nil
+}
+
+func createLogsToLogsConnector(_ context.Context, _ component.ConnectorCreateSettings, _ config.Connector, next consumer.Logs) (component.LogsConnector, error) {
+	return &ExampleConnectorConsumer{nextLogs: next}, nil
+}
+
+// ExampleConnectorConsumer forwards the consumed data to its next consumer when
+// it consumes and produces the same data type. Otherwise, it sends empty data of
+// the produced type for each consumed data.
+type ExampleConnectorConsumer struct {
+	nextTraces        consumer.Traces
+	nextMetrics       consumer.Metrics
+	nextLogs          consumer.Logs
+	ConnectorStarted  bool
+	ConnectorShutdown bool
+}
+
+// Start tells the connector to start.
+func (c *ExampleConnectorConsumer) Start(_ context.Context, _ component.Host) error {
+	c.ConnectorStarted = true
+	return nil
+}
+
+func (c *ExampleConnectorConsumer) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// ConsumeTraces receives pdata.Traces for processing by the consumer.Traces.
+func (c *ExampleConnectorConsumer) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	if c.nextTraces != nil {
+		return c.nextTraces.ConsumeTraces(ctx, td)
+	}
+	return c.produce(ctx)
+}
+
+// ConsumeMetrics receives pdata.Metrics for processing by the consumer.Metrics.
+func (c *ExampleConnectorConsumer) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	if c.nextMetrics != nil {
+		return c.nextMetrics.ConsumeMetrics(ctx, md)
+	}
+	return c.produce(ctx)
+}
+
+// ConsumeLogs receives pdata.Logs for processing by the consumer.Logs.
+func (c *ExampleConnectorConsumer) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	if c.nextLogs != nil {
+		return c.nextLogs.ConsumeLogs(ctx, ld)
+	}
+	return c.produce(ctx)
+}
+
+// produce sends empty data to the next consumer.
+func (c *ExampleConnectorConsumer) produce(ctx context.Context) error {
+	switch {
+	case c.nextTraces != nil:
+		return c.nextTraces.ConsumeTraces(ctx, pdata.NewTraces())
+	case c.nextMetrics != nil:
+		return c.nextMetrics.ConsumeMetrics(ctx, pdata.NewMetrics())
+	default:
+		return c.nextLogs.ConsumeLogs(ctx, pdata.NewLogs())
+	}
+}
+
+// Shutdown is invoked during shutdown.
+func (c *ExampleConnectorConsumer) Shutdown(context.Context) error {
+	c.ConnectorShutdown = true
+	return nil
+}
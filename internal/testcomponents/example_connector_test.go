@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testcomponents
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestExampleConnectorConsumer(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	conn, err := ExampleConnectorFactory.CreateTracesToTracesConnector(context.Background(), componenttest.NewNopConnectorCreateSettings(), ExampleConnectorFactory.CreateDefaultConfig(), sink)
+	require.NoError(t, err)
+	exampleConn := conn.(*ExampleConnectorConsumer)
+	assert.False(t, exampleConn.ConnectorStarted)
+	assert.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+	assert.True(t, exampleConn.ConnectorStarted)
+
+	td := pdata.NewTraces()
+	td.ResourceSpans().AppendEmpty()
+	assert.NoError(t, conn.ConsumeTraces(context.Background(), td))
+	require.Len(t, sink.AllTraces(), 1)
+	assert.Equal(t, td, sink.AllTraces()[0])
+
+	assert.False(t, exampleConn.ConnectorShutdown)
+	assert.NoError(t, conn.Shutdown(context.Background()))
+	assert.True(t, exampleConn.ConnectorShutdown)
+}
+
+func TestExampleConnectorConsumerCrossSignal(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	conn, err := ExampleConnectorFactory.CreateLogsToMetricsConnector(context.Background(), componenttest.NewNopConnectorCreateSettings(), ExampleConnectorFactory.CreateDefaultConfig(), sink)
+	require.NoError(t, err)
+
+	assert.NoError(t, conn.ConsumeLogs(context.Background(), pdata.NewLogs()))
+	assert.NoError(t, conn.ConsumeLogs(context.Background(), pdata.NewLogs()))
+	assert.Len(t, sink.AllMetrics(), 2)
+}
@@ -41,7 +41,11 @@ func ExampleComponents() (
 		return
 	}
 
-	factories.Processors, err = component.MakeProcessorFactoryMap(ExampleProcessorFactory)
+	if factories.Processors, err = component.MakeProcessorFactoryMap(ExampleProcessorFactory); err != nil {
+		return
+	}
+
+	factories.Connectors, err = component.MakeConnectorFactoryMap(ExampleConnectorFactory)
 
 	return
 }
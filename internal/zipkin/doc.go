@@ -0,0 +1,20 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package zipkin translates Zipkin v2 spans to pdata.
+//
+// It is not part of internal/coreinternal/tracetranslator, since coreinternal is
+// a separate module depending on a released collector module, which the zipkin
+// receiver of the collector module cannot import.
+package zipkin // import "go.opentelemetry.io/collector/internal/zipkin"
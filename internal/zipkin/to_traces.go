@@ -0,0 +1,227 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zipkin // import "go.opentelemetry.io/collector/internal/zipkin"
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"net"
+	"sort"
+
+	zipkinmodel "github.com/openzipkin/zipkin-go/model"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.9.0"
+)
+
+// Tags used to represent OTLP constructs that have no equivalent in Zipkin.
+const (
+	TagError         = "error"
+	TagW3CTraceState = "w3c.tracestate"
+
+	// unknownServiceName is the service name of the spans without local endpoint.
+	unknownServiceName = "unknown_service"
+)
+
+// ToTraces translates Zipkin v2 spans to pdata.Traces. The spans are grouped by the
+// service name of their local endpoint into resources, and by their instrumentation
+// library into scopes.
+func ToTraces(zspans []*zipkinmodel.SpanModel) pdata.Traces {
+	td := pdata.NewTraces()
+	resources := map[string]pdata.ResourceSpans{}
+	scopes := map[scopeKey]pdata.ScopeSpans{}
+
+	for _, zspan := range zspans {
+		serviceName := unknownServiceName
+		if zspan.LocalEndpoint != nil && zspan.LocalEndpoint.ServiceName != "" {
+			serviceName = zspan.LocalEndpoint.ServiceName
+		}
+		rs, ok := resources[serviceName]
+		if !ok {
+			rs = td.ResourceSpans().AppendEmpty()
+			rs.Resource().Attributes().InsertString(conventions.AttributeServiceName, serviceName)
+			resources[serviceName] = rs
+		}
+
+		key := scopeKey{
+			serviceName: serviceName,
+			name:        zspan.Tags[conventions.OtelLibraryName],
+			version:     zspan.Tags[conventions.OtelLibraryVersion],
+		}
+		ss, ok := scopes[key]
+		if !ok {
+			ss = rs.ScopeSpans().AppendEmpty()
+			ss.Scope().SetName(key.name)
+			ss.Scope().SetVersion(key.version)
+			scopes[key] = ss
+		}
+
+		spanToPdata(zspan, ss.Spans().AppendEmpty())
+	}
+	return td
+}
+
+type scopeKey struct {
+	serviceName string
+	name        string
+	version     string
+}
+
+func spanToPdata(zspan *zipkinmodel.SpanModel, span pdata.Span) {
+	traceID := traceIDToPdata(zspan.TraceID)
+	span.SetTraceID(traceID)
+	span.SetSpanID(spanIDToPdata(zspan.ID))
+	if zspan.ParentID != nil {
+		span.SetParentSpanID(spanIDToPdata(*zspan.ParentID))
+	}
+	if zspan.Shared && zspan.Kind == zipkinmodel.Server {
+		// A shared server span has the same ID as the client span that called it.
+		// The span IDs must be unique in OTLP, so it becomes a child of the client span.
+		span.SetParentSpanID(spanIDToPdata(zspan.ID))
+		span.SetSpanID(sharedSpanID(traceID, zspan.ID))
+	}
+
+	span.SetName(zspan.Name)
+	span.SetKind(spanKindToPdata(zspan.Kind))
+	if !zspan.Timestamp.IsZero() {
+		span.SetStartTimestamp(pdata.NewTimestampFromTime(zspan.Timestamp))
+		span.SetEndTimestamp(pdata.NewTimestampFromTime(zspan.Timestamp.Add(zspan.Duration)))
+	}
+
+	attrs := span.Attributes()
+	if ep := zspan.LocalEndpoint; ep != nil {
+		insertEndpointAttributes(attrs, ep, conventions.AttributeNetHostIP, conventions.AttributeNetHostPort)
+	}
+	if ep := zspan.RemoteEndpoint; ep != nil {
+		if ep.ServiceName != "" {
+			attrs.InsertString(conventions.AttributePeerService, ep.ServiceName)
+		}
+		insertEndpointAttributes(attrs, ep, conventions.AttributeNetPeerIP, conventions.AttributeNetPeerPort)
+	}
+	tagsToPdata(zspan.Tags, span)
+
+	events := span.Events()
+	events.EnsureCapacity(len(zspan.Annotations))
+	for _, annotation := range zspan.Annotations {
+		event := events.AppendEmpty()
+		event.SetName(annotation.Value)
+		event.SetTimestamp(pdata.NewTimestampFromTime(annotation.Timestamp))
+	}
+}
+
+func insertEndpointAttributes(attrs pdata.Map, ep *zipkinmodel.Endpoint, ipKey, portKey string) {
+	if ip := endpointIP(ep); ip != nil {
+		attrs.InsertString(ipKey, ip.String())
+	}
+	if ep.Port != 0 {
+		attrs.InsertInt(portKey, int64(ep.Port))
+	}
+}
+
+func endpointIP(ep *zipkinmodel.Endpoint) net.IP {
+	if len(ep.IPv4) > 0 {
+		return ep.IPv4
+	}
+	if len(ep.IPv6) > 0 {
+		return ep.IPv6
+	}
+	return nil
+}
+
+// tagsToPdata sets the status and trace state of the span from the tags representing
+// them, and adds the other tags to the attributes of the span.
+func tagsToPdata(tags map[string]string, span pdata.Span) {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	status := span.Status()
+	attrs := span.Attributes()
+	for _, key := range keys {
+		value := tags[key]
+		switch key {
+		case conventions.OtelLibraryName, conventions.OtelLibraryVersion, conventions.OtelStatusDescription:
+		case conventions.OtelStatusCode:
+			status.SetCode(statusCodeToPdata(value))
+			status.SetMessage(tags[conventions.OtelStatusDescription])
+		case TagError:
+			// The status tags take precedence over the error tag.
+			if _, ok := tags[conventions.OtelStatusCode]; !ok {
+				status.SetCode(pdata.StatusCodeError)
+				if value != "" && value != "true" {
+					status.SetMessage(value)
+				}
+			}
+		case TagW3CTraceState:
+			span.SetTraceState(pdata.TraceState(value))
+		default:
+			attrs.InsertString(key, value)
+		}
+	}
+}
+
+func statusCodeToPdata(code string) pdata.StatusCode {
+	switch code {
+	case "OK", "STATUS_CODE_OK":
+		return pdata.StatusCodeOk
+	case "ERROR", "STATUS_CODE_ERROR":
+		return pdata.StatusCodeError
+	}
+	return pdata.StatusCodeUnset
+}
+
+func spanKindToPdata(kind zipkinmodel.Kind) pdata.SpanKind {
+	switch kind {
+	case zipkinmodel.Client:
+		return pdata.SpanKindClient
+	case zipkinmodel.Server:
+		return pdata.SpanKindServer
+	case zipkinmodel.Producer:
+		return pdata.SpanKindProducer
+	case zipkinmodel.Consumer:
+		return pdata.SpanKindConsumer
+	}
+	return pdata.SpanKindInternal
+}
+
+func traceIDToPdata(id zipkinmodel.TraceID) pdata.TraceID {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], id.High)
+	binary.BigEndian.PutUint64(b[8:], id.Low)
+	return pdata.NewTraceID(b)
+}
+
+func spanIDToPdata(id zipkinmodel.ID) pdata.SpanID {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(id))
+	return pdata.NewSpanID(b)
+}
+
+// sharedSpanID derives a span ID for a shared server span from its trace and span IDs,
+// so that all the copies of the same span get the same ID.
+func sharedSpanID(traceID pdata.TraceID, id zipkinmodel.ID) pdata.SpanID {
+	h := fnv.New64a()
+	traceIDBytes := traceID.Bytes()
+	_, _ = h.Write(traceIDBytes[:])
+	spanIDBytes := spanIDToPdata(id).Bytes()
+	_, _ = h.Write(spanIDBytes[:])
+	derived := zipkinmodel.ID(h.Sum64())
+	if derived == 0 || derived == id {
+		derived ^= 1
+	}
+	return spanIDToPdata(derived)
+}
@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zipkin
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	zipkinmodel "github.com/openzipkin/zipkin-go/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.9.0"
+)
+
+func TestToTraces(t *testing.T) {
+	start := time.Date(2022, 3, 4, 5, 6, 7, 0, time.UTC)
+	parentID := zipkinmodel.ID(0x0102030405060708)
+	zspans := []*zipkinmodel.SpanModel{
+		{
+			SpanContext: zipkinmodel.SpanContext{
+				TraceID:  zipkinmodel.TraceID{High: 0x0102030405060708, Low: 0x090a0b0c0d0e0f10},
+				ID:       zipkinmodel.ID(0x1112131415161718),
+				ParentID: &parentID,
+			},
+			Name:      "get /users",
+			Kind:      zipkinmodel.Client,
+			Timestamp: start,
+			Duration:  time.Second,
+			LocalEndpoint: &zipkinmodel.Endpoint{
+				ServiceName: "frontend",
+				IPv4:        net.ParseIP("10.0.0.1"),
+				Port:        8080,
+			},
+			RemoteEndpoint: &zipkinmodel.Endpoint{
+				ServiceName: "backend",
+				IPv6:        net.ParseIP("::1"),
+				Port:        9090,
+			},
+			Annotations: []zipkinmodel.Annotation{{Timestamp: start.Add(time.Millisecond), Value: "retry"}},
+			Tags: map[string]string{
+				"http.method":                  "GET",
+				TagError:                       "connection refused",
+				TagW3CTraceState:               "vendor=value",
+				conventions.OtelLibraryName:    "http-client",
+				conventions.OtelLibraryVersion: "1.0",
+			},
+		},
+		{
+			SpanContext: zipkinmodel.SpanContext{
+				TraceID: zipkinmodel.TraceID{Low: 1},
+				ID:      zipkinmodel.ID(2),
+			},
+			Name:          "local",
+			LocalEndpoint: &zipkinmodel.Endpoint{ServiceName: "frontend"},
+			Tags: map[string]string{
+				conventions.OtelStatusCode:        "ERROR",
+				conventions.OtelStatusDescription: "failed",
+				TagError:                          "true",
+			},
+		},
+		{
+			SpanContext: zipkinmodel.SpanContext{
+				TraceID: zipkinmodel.TraceID{Low: 1},
+				ID:      zipkinmodel.ID(3),
+			},
+			Name: "no endpoint",
+		},
+	}
+
+	td := ToTraces(zspans)
+	require.Equal(t, 2, td.ResourceSpans().Len())
+
+	rs := td.ResourceSpans().At(0)
+	assert.Equal(t, pdata.NewMapFromRaw(map[string]interface{}{conventions.AttributeServiceName: "frontend"}), rs.Resource().Attributes())
+	require.Equal(t, 2, rs.ScopeSpans().Len())
+	assert.Equal(t, "http-client", rs.ScopeSpans().At(0).Scope().Name())
+	assert.Equal(t, "1.0", rs.ScopeSpans().At(0).Scope().Version())
+	assert.Equal(t, "", rs.ScopeSpans().At(1).Scope().Name())
+
+	span := rs.ScopeSpans().At(0).Spans().At(0)
+	assert.Equal(t, pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}), span.TraceID())
+	assert.Equal(t, pdata.NewSpanID([8]byte{0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18}), span.SpanID())
+	assert.Equal(t, pdata.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}), span.ParentSpanID())
+	assert.Equal(t, "get /users", span.Name())
+	assert.Equal(t, pdata.SpanKindClient, span.Kind())
+	assert.Equal(t, pdata.NewTimestampFromTime(start), span.StartTimestamp())
+	assert.Equal(t, pdata.NewTimestampFromTime(start.Add(time.Second)), span.EndTimestamp())
+	assert.Equal(t, pdata.TraceState("vendor=value"), span.TraceState())
+	assert.Equal(t, pdata.StatusCodeError, span.Status().Code())
+	assert.Equal(t, "connection refused", span.Status().Message())
+	assert.Equal(t, pdata.NewMapFromRaw(map[string]interface{}{
+		"http.method":                    "GET",
+		conventions.AttributeNetHostIP:   "10.0.0.1",
+		conventions.AttributeNetHostPort: int64(8080),
+		conventions.AttributePeerService: "backend",
+		conventions.AttributeNetPeerIP:   "::1",
+		conventions.AttributeNetPeerPort: int64(9090),
+	}).Sort(), span.Attributes().Sort())
+	require.Equal(t, 1, span.Events().Len())
+	assert.Equal(t, "retry", span.Events().At(0).Name())
+	assert.Equal(t, pdata.NewTimestampFromTime(start.Add(time.Millisecond)), span.Events().At(0).Timestamp())
+
+	span = rs.ScopeSpans().At(1).Spans().At(0)
+	assert.Equal(t, pdata.SpanKindInternal, span.Kind())
+	assert.True(t, span.ParentSpanID().IsEmpty())
+	assert.Equal(t, pdata.StatusCodeError, span.Status().Code())
+	assert.Equal(t, "failed", span.Status().Message())
+	assert.Equal(t, 0, span.Attributes().Len())
+
+	rs = td.ResourceSpans().At(1)
+	assert.Equal(t, pdata.NewMapFromRaw(map[string]interface{}{conventions.AttributeServiceName: unknownServiceName}), rs.Resource().Attributes())
+	assert.Equal(t, "no endpoint", rs.ScopeSpans().At(0).Spans().At(0).Name())
+}
+
+func TestToTraces_SharedSpan(t *testing.T) {
+	spanContext := zipkinmodel.SpanContext{
+		TraceID: zipkinmodel.TraceID{Low: 1},
+		ID:      zipkinmodel.ID(2),
+	}
+	zspans := []*zipkinmodel.SpanModel{
+		{SpanContext: spanContext, Name: "call", Kind: zipkinmodel.Client, LocalEndpoint: &zipkinmodel.Endpoint{ServiceName: "client"}},
+		{SpanContext: spanContext, Name: "handle", Kind: zipkinmodel.Server, Shared: true, LocalEndpoint: &zipkinmodel.Endpoint{ServiceName: "server"}},
+	}
+
+	td := ToTraces(zspans)
+	require.Equal(t, 2, td.ResourceSpans().Len())
+	client := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	server := td.ResourceSpans().At(1).ScopeSpans().At(0).Spans().At(0)
+
+	assert.Equal(t, client.SpanID(), server.ParentSpanID())
+	assert.NotEqual(t, client.SpanID(), server.SpanID())
+	assert.False(t, server.SpanID().IsEmpty())
+	// The derived span ID is stable.
+	assert.Equal(t, server.SpanID(), ToTraces(zspans[1:]).ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SpanID())
+}
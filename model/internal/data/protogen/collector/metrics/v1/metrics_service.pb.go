@@ -79,14 +79,77 @@ func (m *ExportMetricsServiceRequest) GetResourceMetrics() []*v1.ResourceMetrics
 	return nil
 }
 
+type ExportMetricsPartialSuccess struct {
+	// The number of rejected data points.
+	//
+	// A `rejected_<signal>` field holding a `0` value indicates that the
+	// request was fully accepted.
+	RejectedDataPoints int64 `protobuf:"varint,1,opt,name=rejected_data_points,json=rejectedDataPoints,proto3" json:"rejected_data_points,omitempty"`
+	// A developer-facing human-readable message in English. It should be used
+	// either to explain why the server rejected parts of the data during a partial
+	// success or to convey warnings/suggestions during a full success.
+	ErrorMessage string `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+}
+
+func (m *ExportMetricsPartialSuccess) Reset()         { *m = ExportMetricsPartialSuccess{} }
+func (m *ExportMetricsPartialSuccess) String() string { return proto.CompactTextString(m) }
+func (*ExportMetricsPartialSuccess) ProtoMessage()    {}
+func (*ExportMetricsPartialSuccess) Descriptor() ([]byte, []int) {
+	return fileDescriptor_75fb6015e6e64798, []int{1}
+}
+func (m *ExportMetricsPartialSuccess) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExportMetricsPartialSuccess) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExportMetricsPartialSuccess.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ExportMetricsPartialSuccess) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportMetricsPartialSuccess.Merge(m, src)
+}
+func (m *ExportMetricsPartialSuccess) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExportMetricsPartialSuccess) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportMetricsPartialSuccess.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportMetricsPartialSuccess proto.InternalMessageInfo
+
+func (m *ExportMetricsPartialSuccess) GetRejectedDataPoints() int64 {
+	if m != nil {
+		return m.RejectedDataPoints
+	}
+	return 0
+}
+
+func (m *ExportMetricsPartialSuccess) GetErrorMessage() string {
+	if m != nil {
+		return m.ErrorMessage
+	}
+	return ""
+}
+
 type ExportMetricsServiceResponse struct {
+	// The details of a partially successful export request. A partial_success
+	// with rejected_<signal> = 0 and an empty error_message is equivalent to
+	// it not being set.
+	PartialSuccess *ExportMetricsPartialSuccess `protobuf:"bytes,1,opt,name=partial_success,json=partialSuccess,proto3" json:"partial_success,omitempty"`
 }
 
 func (m *ExportMetricsServiceResponse) Reset()         { *m = ExportMetricsServiceResponse{} }
 func (m *ExportMetricsServiceResponse) String() string { return proto.CompactTextString(m) }
 func (*ExportMetricsServiceResponse) ProtoMessage()    {}
 func (*ExportMetricsServiceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_75fb6015e6e64798, []int{1}
+	return fileDescriptor_75fb6015e6e64798, []int{2}
 }
 func (m *ExportMetricsServiceResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -115,8 +178,16 @@ func (m *ExportMetricsServiceResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ExportMetricsServiceResponse proto.InternalMessageInfo
 
+func (m *ExportMetricsServiceResponse) GetPartialSuccess() *ExportMetricsPartialSuccess {
+	if m != nil {
+		return m.PartialSuccess
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ExportMetricsServiceRequest)(nil), "opentelemetry.proto.collector.metrics.v1.ExportMetricsServiceRequest")
+	proto.RegisterType((*ExportMetricsPartialSuccess)(nil), "opentelemetry.proto.collector.metrics.v1.ExportMetricsPartialSuccess")
 	proto.RegisterType((*ExportMetricsServiceResponse)(nil), "opentelemetry.proto.collector.metrics.v1.ExportMetricsServiceResponse")
 }
 
@@ -125,26 +196,32 @@ func init() {
 }
 
 var fileDescriptor_75fb6015e6e64798 = []byte{
-	// 302 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x52, 0x3f, 0x4b, 0xc3, 0x40,
-	0x14, 0xcf, 0x21, 0x74, 0x38, 0x41, 0x25, 0x3a, 0x48, 0x94, 0x43, 0x32, 0x15, 0x94, 0x3b, 0x5a,
-	0x77, 0x87, 0x42, 0xdd, 0xc4, 0x12, 0xb7, 0x2e, 0x25, 0xa6, 0x8f, 0x10, 0x88, 0xf7, 0xe2, 0xdd,
-	0x35, 0xd8, 0x6f, 0xe1, 0xea, 0x77, 0xd0, 0xef, 0xe1, 0xd8, 0xd1, 0x51, 0x92, 0x2f, 0x22, 0xe9,
-	0x45, 0xe5, 0x34, 0x48, 0xc1, 0xed, 0xf1, 0x7b, 0xbf, 0x7f, 0xc9, 0x3d, 0x7a, 0x81, 0x05, 0x48,
-	0x03, 0x39, 0xdc, 0x81, 0x51, 0x4b, 0x51, 0x28, 0x34, 0x28, 0x12, 0xcc, 0x73, 0x48, 0x0c, 0x2a,
-	0xd1, 0xa0, 0x59, 0xa2, 0x45, 0x39, 0xf8, 0x1c, 0x67, 0x1a, 0x54, 0x99, 0x25, 0xc0, 0xd7, 0x54,
-	0xbf, 0xef, 0xe8, 0x2d, 0xc8, 0xbf, 0xf4, 0xbc, 0x15, 0xf1, 0x72, 0x10, 0x1c, 0xa4, 0x98, 0xa2,
-	0xf5, 0x6f, 0x26, 0x4b, 0x0d, 0xce, 0xba, 0xf2, 0x7f, 0xa7, 0x5a, 0x76, 0xb8, 0xa4, 0x47, 0xe3,
-	0x87, 0x02, 0x95, 0xb9, 0xb2, 0xf0, 0x8d, 0xed, 0x12, 0xc1, 0xfd, 0x02, 0xb4, 0xf1, 0xa7, 0x74,
-	0x4f, 0x81, 0xc6, 0x85, 0x4a, 0x60, 0xd6, 0x0a, 0x0f, 0xc9, 0xc9, 0x56, 0x7f, 0x7b, 0x28, 0x78,
-	0x57, 0xcf, 0xef, 0x76, 0x3c, 0x6a, 0x75, 0xad, 0x71, 0xb4, 0xab, 0x5c, 0x20, 0x64, 0xf4, 0xb8,
-	0x3b, 0x5a, 0x17, 0x28, 0x35, 0x0c, 0x9f, 0x09, 0xdd, 0x71, 0x57, 0xfe, 0x13, 0xa1, 0x3d, 0xab,
-	0xf1, 0xc7, 0x7c, 0xd3, 0xff, 0xc4, 0xff, 0xf8, 0xc0, 0xe0, 0xf2, 0xbf, 0x36, 0xb6, 0x6c, 0xe8,
-	0x8d, 0x5e, 0xc8, 0x6b, 0xc5, 0xc8, 0xaa, 0x62, 0xe4, 0xbd, 0x62, 0xe4, 0xb1, 0x66, 0xde, 0xaa,
-	0x66, 0xde, 0x5b, 0xcd, 0x3c, 0x7a, 0x9a, 0xe1, 0xc6, 0x31, 0xa3, 0x7d, 0x37, 0x61, 0xd2, 0x30,
-	0x27, 0x64, 0x7a, 0x9d, 0xfe, 0xf4, 0xc8, 0x9c, 0xb3, 0xc2, 0x39, 0xe4, 0x22, 0x93, 0x06, 0x94,
-	0x8c, 0x73, 0x31, 0x8f, 0x4d, 0x6c, 0x9f, 0x3e, 0x05, 0xd9, 0x79, 0x7d, 0xb7, 0xbd, 0xf5, 0xfa,
-	0xfc, 0x23, 0x00, 0x00, 0xff, 0xff, 0xbd, 0x25, 0x0c, 0xb5, 0xb0, 0x02, 0x00, 0x00,
+	// 397 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x53, 0xcf, 0x6e, 0xda, 0x30,
+	0x18, 0x8f, 0x87, 0x84, 0x34, 0xb3, 0xc1, 0xe4, 0x71, 0x40, 0x6c, 0x8a, 0x50, 0x76, 0x89, 0xb4,
+	0xc9, 0x19, 0xec, 0xbe, 0x03, 0x1a, 0xbb, 0xa1, 0x45, 0xe1, 0xc6, 0x25, 0xf2, 0xcc, 0xa7, 0x28,
+	0x53, 0x88, 0x33, 0xdb, 0xa0, 0xf2, 0x12, 0x55, 0xaf, 0x7d, 0x87, 0xf6, 0x3d, 0x7a, 0xe4, 0xd8,
+	0x63, 0x05, 0x2f, 0x52, 0x25, 0x0e, 0xad, 0x42, 0xa3, 0x0a, 0xb5, 0x37, 0xe7, 0xf7, 0xfd, 0xfe,
+	0x39, 0xb6, 0xf1, 0x4f, 0x91, 0x41, 0xaa, 0x21, 0x81, 0x25, 0x68, 0xb9, 0xf1, 0x32, 0x29, 0xb4,
+	0xf0, 0xb8, 0x48, 0x12, 0xe0, 0x5a, 0x48, 0x2f, 0x47, 0x63, 0xae, 0xbc, 0xf5, 0xf0, 0xb0, 0x0c,
+	0x15, 0xc8, 0x75, 0xcc, 0x81, 0x16, 0x54, 0xe2, 0x56, 0xf4, 0x06, 0xa4, 0x0f, 0x7a, 0x5a, 0x8a,
+	0xe8, 0x7a, 0xd8, 0xef, 0x46, 0x22, 0x12, 0xc6, 0x3f, 0x5f, 0x19, 0x6a, 0xff, 0x5b, 0x5d, 0xfe,
+	0xd3, 0x54, 0xc3, 0x76, 0x36, 0xf8, 0xd3, 0xe4, 0x2c, 0x13, 0x52, 0x4f, 0x0d, 0x3c, 0x33, 0x5d,
+	0x02, 0xf8, 0xbf, 0x02, 0xa5, 0xc9, 0x1c, 0x7f, 0x90, 0xa0, 0xc4, 0x4a, 0x72, 0x08, 0x4b, 0x61,
+	0x0f, 0x0d, 0x1a, 0x6e, 0x6b, 0xe4, 0xd1, 0xba, 0x9e, 0x8f, 0xed, 0x68, 0x50, 0xea, 0x4a, 0xe3,
+	0xa0, 0x23, 0xab, 0x80, 0xa3, 0x8f, 0xa2, 0x7d, 0x26, 0x75, 0xcc, 0x92, 0xd9, 0x8a, 0x73, 0x50,
+	0x8a, 0x7c, 0xc7, 0x5d, 0x09, 0xff, 0x80, 0x6b, 0x58, 0x84, 0x0b, 0xa6, 0x59, 0x98, 0x89, 0x38,
+	0xd5, 0x79, 0x3c, 0x72, 0x1b, 0x01, 0x39, 0xcc, 0x7e, 0x31, 0xcd, 0xfc, 0x62, 0x42, 0xbe, 0xe0,
+	0xf7, 0x20, 0xa5, 0x90, 0xe1, 0x12, 0x94, 0x62, 0x11, 0xf4, 0xde, 0x0c, 0x90, 0xfb, 0x36, 0x78,
+	0x57, 0x80, 0x53, 0x83, 0x39, 0xe7, 0x08, 0x7f, 0xae, 0xdf, 0xb1, 0xca, 0x44, 0xaa, 0x80, 0xa4,
+	0xb8, 0x93, 0x99, 0x26, 0xa1, 0x32, 0x55, 0x8a, 0xc8, 0xd6, 0x68, 0x42, 0x4f, 0x3d, 0x19, 0xfa,
+	0xcc, 0xbe, 0x82, 0x76, 0x56, 0xf9, 0x1e, 0x5d, 0x21, 0xdc, 0xae, 0x56, 0x21, 0x97, 0x08, 0x37,
+	0x8d, 0x05, 0x79, 0x69, 0x68, 0xf5, 0x1c, 0xfb, 0xbf, 0x5f, 0x6b, 0x63, 0x7e, 0x8e, 0x63, 0x8d,
+	0xaf, 0xd1, 0xcd, 0xce, 0x46, 0xdb, 0x9d, 0x8d, 0xee, 0x76, 0x36, 0xba, 0xd8, 0xdb, 0xd6, 0x76,
+	0x6f, 0x5b, 0xb7, 0x7b, 0xdb, 0xc2, 0x5f, 0x63, 0x71, 0x72, 0xcc, 0xf8, 0x63, 0x35, 0xc1, 0xcf,
+	0x99, 0x3e, 0x9a, 0xff, 0x89, 0x8e, 0x3d, 0xe2, 0xca, 0xeb, 0x11, 0x0b, 0x48, 0xbc, 0x38, 0xd5,
+	0x20, 0x53, 0x96, 0x78, 0xf9, 0xbd, 0x30, 0x37, 0x3c, 0x82, 0xb4, 0xf6, 0x91, 0xfd, 0x6d, 0x16,
+	0xe3, 0x1f, 0xf7, 0x03, 0x00, 0xd5, 0x05, 0x83, 0xe2, 0x97, 0x03, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -268,6 +345,41 @@ func (m *ExportMetricsServiceRequest) MarshalToSizedBuffer(dAtA []byte) (int, er
 	return len(dAtA) - i, nil
 }
 
+func (m *ExportMetricsPartialSuccess) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExportMetricsPartialSuccess) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExportMetricsPartialSuccess) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ErrorMessage) > 0 {
+		i -= len(m.ErrorMessage)
+		copy(dAtA[i:], m.ErrorMessage)
+		i = encodeVarintMetricsService(dAtA, i, uint64(len(m.ErrorMessage)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.RejectedDataPoints != 0 {
+		i = encodeVarintMetricsService(dAtA, i, uint64(m.RejectedDataPoints))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *ExportMetricsServiceResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -288,6 +400,18 @@ func (m *ExportMetricsServiceResponse) MarshalToSizedBuffer(dAtA []byte) (int, e
 	_ = i
 	var l int
 	_ = l
+	if m.PartialSuccess != nil {
+		{
+			size, err := m.PartialSuccess.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintMetricsService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
 	return len(dAtA) - i, nil
 }
 
@@ -317,12 +441,32 @@ func (m *ExportMetricsServiceRequest) Size() (n int) {
 	return n
 }
 
+func (m *ExportMetricsPartialSuccess) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.RejectedDataPoints != 0 {
+		n += 1 + sovMetricsService(uint64(m.RejectedDataPoints))
+	}
+	l = len(m.ErrorMessage)
+	if l > 0 {
+		n += 1 + l + sovMetricsService(uint64(l))
+	}
+	return n
+}
+
 func (m *ExportMetricsServiceResponse) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
+	if m.PartialSuccess != nil {
+		l = m.PartialSuccess.Size()
+		n += 1 + l + sovMetricsService(uint64(l))
+	}
 	return n
 }
 
@@ -416,6 +560,107 @@ func (m *ExportMetricsServiceRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ExportMetricsPartialSuccess) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMetricsService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExportMetricsPartialSuccess: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExportMetricsPartialSuccess: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RejectedDataPoints", wireType)
+			}
+			m.RejectedDataPoints = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetricsService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RejectedDataPoints |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ErrorMessage", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetricsService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMetricsService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetricsService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ErrorMessage = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMetricsService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthMetricsService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *ExportMetricsServiceResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -445,6 +690,42 @@ func (m *ExportMetricsServiceResponse) Unmarshal(dAtA []byte) error {
 			return fmt.Errorf("proto: ExportMetricsServiceResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PartialSuccess", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetricsService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMetricsService
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetricsService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.PartialSuccess == nil {
+				m.PartialSuccess = &ExportMetricsPartialSuccess{}
+			}
+			if err := m.PartialSuccess.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMetricsService(dAtA[iNdEx:])
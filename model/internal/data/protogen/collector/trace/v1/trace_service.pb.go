@@ -79,14 +79,77 @@ func (m *ExportTraceServiceRequest) GetResourceSpans() []*v1.ResourceSpans {
 	return nil
 }
 
+type ExportTracePartialSuccess struct {
+	// The number of rejected spans.
+	//
+	// A `rejected_<signal>` field holding a `0` value indicates that the
+	// request was fully accepted.
+	RejectedSpans int64 `protobuf:"varint,1,opt,name=rejected_spans,json=rejectedSpans,proto3" json:"rejected_spans,omitempty"`
+	// A developer-facing human-readable message in English. It should be used
+	// either to explain why the server rejected parts of the data during a partial
+	// success or to convey warnings/suggestions during a full success.
+	ErrorMessage string `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+}
+
+func (m *ExportTracePartialSuccess) Reset()         { *m = ExportTracePartialSuccess{} }
+func (m *ExportTracePartialSuccess) String() string { return proto.CompactTextString(m) }
+func (*ExportTracePartialSuccess) ProtoMessage()    {}
+func (*ExportTracePartialSuccess) Descriptor() ([]byte, []int) {
+	return fileDescriptor_192a962890318cf4, []int{1}
+}
+func (m *ExportTracePartialSuccess) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExportTracePartialSuccess) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExportTracePartialSuccess.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ExportTracePartialSuccess) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportTracePartialSuccess.Merge(m, src)
+}
+func (m *ExportTracePartialSuccess) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExportTracePartialSuccess) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportTracePartialSuccess.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportTracePartialSuccess proto.InternalMessageInfo
+
+func (m *ExportTracePartialSuccess) GetRejectedSpans() int64 {
+	if m != nil {
+		return m.RejectedSpans
+	}
+	return 0
+}
+
+func (m *ExportTracePartialSuccess) GetErrorMessage() string {
+	if m != nil {
+		return m.ErrorMessage
+	}
+	return ""
+}
+
 type ExportTraceServiceResponse struct {
+	// The details of a partially successful export request. A partial_success
+	// with rejected_<signal> = 0 and an empty error_message is equivalent to
+	// it not being set.
+	PartialSuccess *ExportTracePartialSuccess `protobuf:"bytes,1,opt,name=partial_success,json=partialSuccess,proto3" json:"partial_success,omitempty"`
 }
 
 func (m *ExportTraceServiceResponse) Reset()         { *m = ExportTraceServiceResponse{} }
 func (m *ExportTraceServiceResponse) String() string { return proto.CompactTextString(m) }
 func (*ExportTraceServiceResponse) ProtoMessage()    {}
 func (*ExportTraceServiceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_192a962890318cf4, []int{1}
+	return fileDescriptor_192a962890318cf4, []int{2}
 }
 func (m *ExportTraceServiceResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -115,8 +178,16 @@ func (m *ExportTraceServiceResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ExportTraceServiceResponse proto.InternalMessageInfo
 
+func (m *ExportTraceServiceResponse) GetPartialSuccess() *ExportTracePartialSuccess {
+	if m != nil {
+		return m.PartialSuccess
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ExportTraceServiceRequest)(nil), "opentelemetry.proto.collector.trace.v1.ExportTraceServiceRequest")
+	proto.RegisterType((*ExportTracePartialSuccess)(nil), "opentelemetry.proto.collector.trace.v1.ExportTracePartialSuccess")
 	proto.RegisterType((*ExportTraceServiceResponse)(nil), "opentelemetry.proto.collector.trace.v1.ExportTraceServiceResponse")
 }
 
@@ -125,27 +196,32 @@ func init() {
 }
 
 var fileDescriptor_192a962890318cf4 = []byte{
-	// 305 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x52, 0x3f, 0x4b, 0xc4, 0x30,
-	0x14, 0x6f, 0x10, 0x6e, 0x88, 0x7f, 0xc0, 0xe2, 0xa0, 0x45, 0x82, 0x74, 0x90, 0x13, 0x21, 0xe1,
-	0xce, 0xcd, 0xcd, 0x82, 0xa3, 0x70, 0xf4, 0x9c, 0x5c, 0xa4, 0xf6, 0x1e, 0xa5, 0xd0, 0xcb, 0xab,
-	0x49, 0xae, 0xe8, 0xb7, 0xd0, 0xaf, 0xe0, 0xe2, 0x57, 0x71, 0xbc, 0xd1, 0x51, 0xda, 0x2f, 0x22,
-	0x69, 0x54, 0x7a, 0x52, 0xe1, 0xc0, 0xed, 0xe5, 0xbd, 0xdf, 0xbf, 0x24, 0x8f, 0x9e, 0x63, 0x09,
-	0xd2, 0x40, 0x01, 0x73, 0x30, 0xea, 0x51, 0x94, 0x0a, 0x0d, 0x8a, 0x14, 0x8b, 0x02, 0x52, 0x83,
-	0x4a, 0x18, 0x95, 0xa4, 0x20, 0xaa, 0x91, 0x2b, 0x6e, 0x35, 0xa8, 0x2a, 0x4f, 0x81, 0xb7, 0x30,
-	0xff, 0x78, 0x85, 0xeb, 0x9a, 0xfc, 0x87, 0xcb, 0x5b, 0x0a, 0xaf, 0x46, 0xc1, 0x5e, 0x86, 0x19,
-	0x3a, 0x65, 0x5b, 0x39, 0x60, 0x30, 0xec, 0x73, 0x5e, 0xf5, 0x73, 0xc8, 0x10, 0xe9, 0xc1, 0xe5,
-	0x43, 0x89, 0xca, 0x5c, 0xdb, 0xe6, 0xd4, 0x65, 0x88, 0xe1, 0x7e, 0x01, 0xda, 0xf8, 0x31, 0xdd,
-	0x51, 0xa0, 0x71, 0xa1, 0x6c, 0xbc, 0x32, 0x91, 0x7a, 0x9f, 0x1c, 0x6d, 0x0c, 0x37, 0xc7, 0xa7,
-	0xbc, 0x2f, 0xdd, 0x77, 0x26, 0x1e, 0x7f, 0x71, 0xa6, 0x96, 0x12, 0x6f, 0xab, 0xee, 0x31, 0x3c,
-	0xa4, 0x41, 0x9f, 0xa1, 0x2e, 0x51, 0x6a, 0x18, 0xbf, 0x10, 0xba, 0xd5, 0x1d, 0xf8, 0xcf, 0x84,
-	0x0e, 0x1c, 0xde, 0xbf, 0xe0, 0xeb, 0xbd, 0x09, 0xff, 0xf3, 0x42, 0x41, 0xf4, 0x1f, 0x09, 0x17,
-	0x31, 0xf4, 0xa2, 0x57, 0xf2, 0x56, 0x33, 0xb2, 0xac, 0x19, 0xf9, 0xa8, 0x19, 0x79, 0x6a, 0x98,
-	0xb7, 0x6c, 0x98, 0xf7, 0xde, 0x30, 0x8f, 0x9e, 0xe4, 0xb8, 0xa6, 0x45, 0xb4, 0xdb, 0x55, 0x9f,
-	0x58, 0xd4, 0x84, 0xdc, 0x5c, 0x65, 0xbf, 0xf9, 0x79, 0x77, 0x65, 0xe6, 0x38, 0x83, 0x42, 0xe4,
-	0xd2, 0x80, 0x92, 0x49, 0x21, 0x66, 0x89, 0x49, 0xdc, 0xe7, 0x66, 0x20, 0x7b, 0x36, 0xeb, 0x6e,
-	0xd0, 0x0e, 0xcf, 0x3e, 0x03, 0x00, 0x00, 0xff, 0xff, 0x93, 0x1a, 0xfb, 0x04, 0x8a, 0x02, 0x00,
-	0x00,
+	// 387 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x93, 0xcf, 0x4a, 0xeb, 0x40,
+	0x14, 0xc6, 0x33, 0xb7, 0x50, 0xb8, 0xd3, 0x3f, 0x97, 0x1b, 0xee, 0xa2, 0x37, 0x8b, 0x50, 0x22,
+	0x4a, 0x44, 0x98, 0xd0, 0xba, 0x73, 0x67, 0xc1, 0x65, 0xa1, 0xa4, 0xae, 0xdc, 0x94, 0x31, 0x3d,
+	0x84, 0x94, 0x34, 0x13, 0xcf, 0x4c, 0x8b, 0xbe, 0x81, 0x4b, 0x7d, 0x05, 0x37, 0xbe, 0x8a, 0xcb,
+	0x2e, 0x5d, 0x4a, 0xfb, 0x22, 0x92, 0x8c, 0x2d, 0x89, 0x44, 0x28, 0xba, 0x9b, 0xf9, 0x72, 0xbe,
+	0xf3, 0xfb, 0x66, 0x32, 0x87, 0x9e, 0x89, 0x14, 0x12, 0x05, 0x31, 0xcc, 0x41, 0xe1, 0x9d, 0x97,
+	0xa2, 0x50, 0xc2, 0x0b, 0x44, 0x1c, 0x43, 0xa0, 0x04, 0x7a, 0x0a, 0x79, 0x00, 0xde, 0xb2, 0xa7,
+	0x17, 0x13, 0x09, 0xb8, 0x8c, 0x02, 0x60, 0x79, 0x99, 0x79, 0x54, 0xf2, 0x6a, 0x91, 0xed, 0xbc,
+	0x2c, 0xb7, 0xb0, 0x65, 0xcf, 0xfa, 0x17, 0x8a, 0x50, 0xe8, 0xce, 0xd9, 0x4a, 0x17, 0x5a, 0x6e,
+	0x15, 0xb9, 0xcc, 0xd3, 0x95, 0x8e, 0xa0, 0xff, 0x2f, 0x6e, 0x53, 0x81, 0xea, 0x32, 0x13, 0xc7,
+	0x3a, 0x83, 0x0f, 0x37, 0x0b, 0x90, 0xca, 0xf4, 0x69, 0x1b, 0x41, 0x8a, 0x05, 0x66, 0xf1, 0x52,
+	0x9e, 0xc8, 0x0e, 0xe9, 0xd6, 0xdc, 0x46, 0xff, 0x84, 0x55, 0xa5, 0xdb, 0x66, 0x62, 0xfe, 0x87,
+	0x67, 0x9c, 0x59, 0xfc, 0x16, 0x16, 0xb7, 0x4e, 0x58, 0x02, 0x8e, 0x38, 0xaa, 0x88, 0xc7, 0xe3,
+	0x45, 0x10, 0x80, 0x94, 0xe6, 0x61, 0x06, 0x9c, 0x41, 0xa0, 0x60, 0xba, 0x03, 0x12, 0xb7, 0xe6,
+	0xb7, 0xb6, 0x6a, 0xde, 0xc3, 0x3c, 0xa0, 0x2d, 0x40, 0x14, 0x38, 0x99, 0x83, 0x94, 0x3c, 0x84,
+	0xce, 0xaf, 0x2e, 0x71, 0x7f, 0xfb, 0xcd, 0x5c, 0x1c, 0x6a, 0xcd, 0xb9, 0x27, 0xd4, 0xaa, 0x3a,
+	0x9a, 0x4c, 0x45, 0x22, 0xc1, 0x9c, 0xd1, 0x3f, 0xa9, 0x86, 0x4f, 0xa4, 0xa6, 0xe7, 0xac, 0x46,
+	0xff, 0x9c, 0xed, 0x77, 0xf5, 0xec, 0xcb, 0x63, 0xf8, 0xed, 0xb4, 0xb4, 0xef, 0x3f, 0x11, 0xda,
+	0x2c, 0x86, 0x30, 0x1f, 0x09, 0xad, 0x6b, 0xbb, 0xf9, 0x1d, 0x5c, 0xf9, 0x37, 0x59, 0x83, 0x9f,
+	0xb4, 0xd0, 0xd7, 0xe1, 0x18, 0x83, 0x67, 0xf2, 0xb2, 0xb6, 0xc9, 0x6a, 0x6d, 0x93, 0xb7, 0xb5,
+	0x4d, 0x1e, 0x36, 0xb6, 0xb1, 0xda, 0xd8, 0xc6, 0xeb, 0xc6, 0x36, 0xe8, 0x71, 0x24, 0xf6, 0x44,
+	0x0c, 0xfe, 0x16, 0xbb, 0x8f, 0xb2, 0xaa, 0x11, 0xb9, 0x1a, 0x86, 0x9f, 0xfd, 0x51, 0x71, 0x10,
+	0xe6, 0x62, 0x0a, 0xb1, 0x17, 0x25, 0x0a, 0x30, 0xe1, 0xb1, 0x37, 0xe5, 0x8a, 0xeb, 0x27, 0x1b,
+	0x42, 0x52, 0x31, 0x2f, 0xd7, 0xf5, 0xfc, 0xe3, 0xe9, 0xfb, 0x00, 0xb2, 0x88, 0xec, 0x39, 0x60,
+	0x03, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -269,6 +345,41 @@ func (m *ExportTraceServiceRequest) MarshalToSizedBuffer(dAtA []byte) (int, erro
 	return len(dAtA) - i, nil
 }
 
+func (m *ExportTracePartialSuccess) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExportTracePartialSuccess) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExportTracePartialSuccess) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ErrorMessage) > 0 {
+		i -= len(m.ErrorMessage)
+		copy(dAtA[i:], m.ErrorMessage)
+		i = encodeVarintTraceService(dAtA, i, uint64(len(m.ErrorMessage)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.RejectedSpans != 0 {
+		i = encodeVarintTraceService(dAtA, i, uint64(m.RejectedSpans))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *ExportTraceServiceResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -289,6 +400,18 @@ func (m *ExportTraceServiceResponse) MarshalToSizedBuffer(dAtA []byte) (int, err
 	_ = i
 	var l int
 	_ = l
+	if m.PartialSuccess != nil {
+		{
+			size, err := m.PartialSuccess.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTraceService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
 	return len(dAtA) - i, nil
 }
 
@@ -318,12 +441,32 @@ func (m *ExportTraceServiceRequest) Size() (n int) {
 	return n
 }
 
+func (m *ExportTracePartialSuccess) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.RejectedSpans != 0 {
+		n += 1 + sovTraceService(uint64(m.RejectedSpans))
+	}
+	l = len(m.ErrorMessage)
+	if l > 0 {
+		n += 1 + l + sovTraceService(uint64(l))
+	}
+	return n
+}
+
 func (m *ExportTraceServiceResponse) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
+	if m.PartialSuccess != nil {
+		l = m.PartialSuccess.Size()
+		n += 1 + l + sovTraceService(uint64(l))
+	}
 	return n
 }
 
@@ -417,6 +560,107 @@ func (m *ExportTraceServiceRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ExportTracePartialSuccess) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTraceService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExportTracePartialSuccess: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExportTracePartialSuccess: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RejectedSpans", wireType)
+			}
+			m.RejectedSpans = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTraceService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RejectedSpans |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ErrorMessage", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTraceService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTraceService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTraceService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ErrorMessage = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTraceService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTraceService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *ExportTraceServiceResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -446,6 +690,42 @@ func (m *ExportTraceServiceResponse) Unmarshal(dAtA []byte) error {
 			return fmt.Errorf("proto: ExportTraceServiceResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PartialSuccess", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTraceService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTraceService
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTraceService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.PartialSuccess == nil {
+				m.PartialSuccess = &ExportTracePartialSuccess{}
+			}
+			if err := m.PartialSuccess.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTraceService(dAtA[iNdEx:])
@@ -65,6 +65,52 @@ func (lr LogsResponse) UnmarshalJSON(data []byte) error {
 	return jsonUnmarshaler.Unmarshal(bytes.NewReader(data), lr.orig)
 }
 
+// PartialSuccess returns the details of a partially successful export request, empty if
+// the response has none. A PartialSuccess with no rejected log records and no error message is
+// equivalent to a full success. Use SetPartialSuccess to set the details of a response
+// which has none.
+func (lr LogsResponse) PartialSuccess() LogsPartialSuccess {
+	if lr.orig.PartialSuccess == nil {
+		return NewLogsPartialSuccess()
+	}
+	return LogsPartialSuccess{orig: lr.orig.PartialSuccess}
+}
+
+// SetPartialSuccess sets the details of a partially successful export request.
+func (lr LogsResponse) SetPartialSuccess(ps LogsPartialSuccess) {
+	lr.orig.PartialSuccess = ps.orig
+}
+
+// LogsPartialSuccess represents the details of a partially successful logs export request.
+type LogsPartialSuccess struct {
+	orig *otlpcollectorlog.ExportLogsPartialSuccess
+}
+
+// NewLogsPartialSuccess returns an empty LogsPartialSuccess.
+func NewLogsPartialSuccess() LogsPartialSuccess {
+	return LogsPartialSuccess{orig: &otlpcollectorlog.ExportLogsPartialSuccess{}}
+}
+
+// RejectedLogRecords returns the number of log records rejected by the server.
+func (ps LogsPartialSuccess) RejectedLogRecords() int64 {
+	return ps.orig.RejectedLogRecords
+}
+
+// SetRejectedLogRecords sets the number of log records rejected by the server.
+func (ps LogsPartialSuccess) SetRejectedLogRecords(v int64) {
+	ps.orig.RejectedLogRecords = v
+}
+
+// ErrorMessage returns the message explaining why the log records were rejected.
+func (ps LogsPartialSuccess) ErrorMessage() string {
+	return ps.orig.ErrorMessage
+}
+
+// SetErrorMessage sets the message explaining why the log records were rejected.
+func (ps LogsPartialSuccess) SetErrorMessage(v string) {
+	ps.orig.ErrorMessage = v
+}
+
 // LogsRequest represents the response for gRPC client/server.
 type LogsRequest struct {
 	orig *otlpcollectorlog.ExportLogsServiceRequest
@@ -148,6 +148,34 @@ func TestLogsRequestJSONTransition(t *testing.T) {
 	}
 }
 
+func TestLogsResponsePartialSuccess(t *testing.T) {
+	tr := NewLogsResponse()
+	ps := NewLogsPartialSuccess()
+	ps.SetRejectedLogRecords(2)
+	ps.SetErrorMessage("filtered")
+	tr.SetPartialSuccess(ps)
+
+	got, err := tr.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"partialSuccess":{"rejectedLogRecords":"2","errorMessage":"filtered"}}`, string(got))
+	fromJSON := NewLogsResponse()
+	require.NoError(t, fromJSON.UnmarshalJSON(got))
+	assert.Equal(t, tr, fromJSON)
+
+	buf, err := tr.MarshalProto()
+	require.NoError(t, err)
+	fromProto := NewLogsResponse()
+	require.NoError(t, fromProto.UnmarshalProto(buf))
+	assert.Equal(t, int64(2), fromProto.PartialSuccess().RejectedLogRecords())
+	assert.Equal(t, "filtered", fromProto.PartialSuccess().ErrorMessage())
+
+	empty := NewLogsResponse()
+	assert.Equal(t, int64(0), empty.PartialSuccess().RejectedLogRecords())
+	assert.Equal(t, "", empty.PartialSuccess().ErrorMessage())
+	// Reading the details of a response does not modify it.
+	assert.Equal(t, NewLogsResponse(), empty)
+}
+
 func TestLogsGrpc(t *testing.T) {
 	lis := bufconn.Listen(1024 * 1024)
 	s := grpc.NewServer()
@@ -61,6 +61,52 @@ func (mr MetricsResponse) UnmarshalJSON(data []byte) error {
 	return jsonUnmarshaler.Unmarshal(bytes.NewReader(data), mr.orig)
 }
 
+// PartialSuccess returns the details of a partially successful export request, empty if
+// the response has none. A PartialSuccess with no rejected data points and no error message is
+// equivalent to a full success. Use SetPartialSuccess to set the details of a response
+// which has none.
+func (mr MetricsResponse) PartialSuccess() MetricsPartialSuccess {
+	if mr.orig.PartialSuccess == nil {
+		return NewMetricsPartialSuccess()
+	}
+	return MetricsPartialSuccess{orig: mr.orig.PartialSuccess}
+}
+
+// SetPartialSuccess sets the details of a partially successful export request.
+func (mr MetricsResponse) SetPartialSuccess(ps MetricsPartialSuccess) {
+	mr.orig.PartialSuccess = ps.orig
+}
+
+// MetricsPartialSuccess represents the details of a partially successful metrics export request.
+type MetricsPartialSuccess struct {
+	orig *otlpcollectormetrics.ExportMetricsPartialSuccess
+}
+
+// NewMetricsPartialSuccess returns an empty MetricsPartialSuccess.
+func NewMetricsPartialSuccess() MetricsPartialSuccess {
+	return MetricsPartialSuccess{orig: &otlpcollectormetrics.ExportMetricsPartialSuccess{}}
+}
+
+// RejectedDataPoints returns the number of data points rejected by the server.
+func (ps MetricsPartialSuccess) RejectedDataPoints() int64 {
+	return ps.orig.RejectedDataPoints
+}
+
+// SetRejectedDataPoints sets the number of data points rejected by the server.
+func (ps MetricsPartialSuccess) SetRejectedDataPoints(v int64) {
+	ps.orig.RejectedDataPoints = v
+}
+
+// ErrorMessage returns the message explaining why the data points were rejected.
+func (ps MetricsPartialSuccess) ErrorMessage() string {
+	return ps.orig.ErrorMessage
+}
+
+// SetErrorMessage sets the message explaining why the data points were rejected.
+func (ps MetricsPartialSuccess) SetErrorMessage(v string) {
+	ps.orig.ErrorMessage = v
+}
+
 // MetricsRequest represents the response for gRPC client/server.
 type MetricsRequest struct {
 	orig *otlpcollectormetrics.ExportMetricsServiceRequest
@@ -132,6 +132,34 @@ func TestMetricsRequestJSONTransition(t *testing.T) {
 	}
 }
 
+func TestMetricsResponsePartialSuccess(t *testing.T) {
+	tr := NewMetricsResponse()
+	ps := NewMetricsPartialSuccess()
+	ps.SetRejectedDataPoints(2)
+	ps.SetErrorMessage("filtered")
+	tr.SetPartialSuccess(ps)
+
+	got, err := tr.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"partialSuccess":{"rejectedDataPoints":"2","errorMessage":"filtered"}}`, string(got))
+	fromJSON := NewMetricsResponse()
+	require.NoError(t, fromJSON.UnmarshalJSON(got))
+	assert.Equal(t, tr, fromJSON)
+
+	buf, err := tr.MarshalProto()
+	require.NoError(t, err)
+	fromProto := NewMetricsResponse()
+	require.NoError(t, fromProto.UnmarshalProto(buf))
+	assert.Equal(t, int64(2), fromProto.PartialSuccess().RejectedDataPoints())
+	assert.Equal(t, "filtered", fromProto.PartialSuccess().ErrorMessage())
+
+	empty := NewMetricsResponse()
+	assert.Equal(t, int64(0), empty.PartialSuccess().RejectedDataPoints())
+	assert.Equal(t, "", empty.PartialSuccess().ErrorMessage())
+	// Reading the details of a response does not modify it.
+	assert.Equal(t, NewMetricsResponse(), empty)
+}
+
 func TestMetricsGrpc(t *testing.T) {
 	lis := bufconn.Listen(1024 * 1024)
 	s := grpc.NewServer()
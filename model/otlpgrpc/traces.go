@@ -61,6 +61,52 @@ func (tr TracesResponse) UnmarshalJSON(data []byte) error {
 	return jsonUnmarshaler.Unmarshal(bytes.NewReader(data), tr.orig)
 }
 
+// PartialSuccess returns the details of a partially successful export request, empty if
+// the response has none. A PartialSuccess with no rejected spans and no error message is
+// equivalent to a full success. Use SetPartialSuccess to set the details of a response
+// which has none.
+func (tr TracesResponse) PartialSuccess() TracesPartialSuccess {
+	if tr.orig.PartialSuccess == nil {
+		return NewTracesPartialSuccess()
+	}
+	return TracesPartialSuccess{orig: tr.orig.PartialSuccess}
+}
+
+// SetPartialSuccess sets the details of a partially successful export request.
+func (tr TracesResponse) SetPartialSuccess(ps TracesPartialSuccess) {
+	tr.orig.PartialSuccess = ps.orig
+}
+
+// TracesPartialSuccess represents the details of a partially successful traces export request.
+type TracesPartialSuccess struct {
+	orig *otlpcollectortrace.ExportTracePartialSuccess
+}
+
+// NewTracesPartialSuccess returns an empty TracesPartialSuccess.
+func NewTracesPartialSuccess() TracesPartialSuccess {
+	return TracesPartialSuccess{orig: &otlpcollectortrace.ExportTracePartialSuccess{}}
+}
+
+// RejectedSpans returns the number of spans rejected by the server.
+func (ps TracesPartialSuccess) RejectedSpans() int64 {
+	return ps.orig.RejectedSpans
+}
+
+// SetRejectedSpans sets the number of spans rejected by the server.
+func (ps TracesPartialSuccess) SetRejectedSpans(v int64) {
+	ps.orig.RejectedSpans = v
+}
+
+// ErrorMessage returns the message explaining why the spans were rejected.
+func (ps TracesPartialSuccess) ErrorMessage() string {
+	return ps.orig.ErrorMessage
+}
+
+// SetErrorMessage sets the message explaining why the spans were rejected.
+func (ps TracesPartialSuccess) SetErrorMessage(v string) {
+	ps.orig.ErrorMessage = v
+}
+
 // TracesRequest represents the response for gRPC client/server.
 type TracesRequest struct {
 	orig *otlpcollectortrace.ExportTraceServiceRequest
@@ -148,6 +148,34 @@ func TestTracesRequestJSONTransition(t *testing.T) {
 	}
 }
 
+func TestTracesResponsePartialSuccess(t *testing.T) {
+	tr := NewTracesResponse()
+	ps := NewTracesPartialSuccess()
+	ps.SetRejectedSpans(2)
+	ps.SetErrorMessage("filtered")
+	tr.SetPartialSuccess(ps)
+
+	got, err := tr.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"partialSuccess":{"rejectedSpans":"2","errorMessage":"filtered"}}`, string(got))
+	fromJSON := NewTracesResponse()
+	require.NoError(t, fromJSON.UnmarshalJSON(got))
+	assert.Equal(t, tr, fromJSON)
+
+	buf, err := tr.MarshalProto()
+	require.NoError(t, err)
+	fromProto := NewTracesResponse()
+	require.NoError(t, fromProto.UnmarshalProto(buf))
+	assert.Equal(t, int64(2), fromProto.PartialSuccess().RejectedSpans())
+	assert.Equal(t, "filtered", fromProto.PartialSuccess().ErrorMessage())
+
+	empty := NewTracesResponse()
+	assert.Equal(t, int64(0), empty.PartialSuccess().RejectedSpans())
+	assert.Equal(t, "", empty.PartialSuccess().ErrorMessage())
+	// Reading the details of a response does not modify it.
+	assert.Equal(t, NewTracesResponse(), empty)
+}
+
 func TestTracesGrpc(t *testing.T) {
 	lis := bufconn.Listen(1024 * 1024)
 	s := grpc.NewServer()
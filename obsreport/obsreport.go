@@ -17,6 +17,8 @@ package obsreport // import "go.opentelemetry.io/collector/obsreport"
 import (
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
 )
 
 func recordError(span trace.Span, err error) {
@@ -24,3 +26,16 @@ func recordError(span trace.Span, err error) {
 		span.SetStatus(codes.Error, err.Error())
 	}
 }
+
+// rejectedCount returns the number of items rejected by a partial error, within
+// [0, numItems] since it may come from an untrusted remote server.
+func rejectedCount(numItems int, err error) int {
+	rejected := consumererror.GetRejectedCount(err)
+	switch {
+	case rejected < 0:
+		return 0
+	case rejected > numItems:
+		return numItems
+	}
+	return rejected
+}
@@ -25,6 +25,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/internal/obsreportconfig"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 )
@@ -131,6 +132,10 @@ func endSpan(ctx context.Context, err error, numSent, numFailedToSend int64, sen
 }
 
 func toNumItems(numExportedItems int, err error) (int64, int64) {
+	if consumererror.IsPartial(err) {
+		numFailedToSend := rejectedCount(numExportedItems, err)
+		return int64(numExportedItems - numFailedToSend), int64(numFailedToSend)
+	}
 	if err != nil {
 		return 0, int64(numExportedItems)
 	}
@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package obsreport // import "go.opentelemetry.io/collector/obsreport"
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+)
+
+// Fanout is a helper to report the exporters slowing down a pipeline which
+// fans out its data to them concurrently.
+type Fanout struct {
+	level      configtelemetry.Level
+	pipelineID config.ComponentID
+}
+
+// FanoutSettings are settings for creating a Fanout.
+type FanoutSettings struct {
+	Level      configtelemetry.Level
+	PipelineID config.ComponentID
+}
+
+// NewFanout creates a new Fanout.
+func NewFanout(cfg FanoutSettings) *Fanout {
+	return &Fanout{
+		level:      cfg.Level,
+		pipelineID: cfg.PipelineID,
+	}
+}
+
+// ExporterTimedOut reports that the exporter did not consume a batch within
+// the fan-out timeout.
+func (fan *Fanout) ExporterTimedOut(ctx context.Context, exporterID config.ComponentID) {
+	fan.record(ctx, exporterID, obsmetrics.FanoutTimedOutBatches)
+}
+
+// ExporterRejected reports that a batch was not sent to the exporter because
+// its fan-out buffer was full.
+func (fan *Fanout) ExporterRejected(ctx context.Context, exporterID config.ComponentID) {
+	fan.record(ctx, exporterID, obsmetrics.FanoutRejectedBatches)
+}
+
+func (fan *Fanout) record(ctx context.Context, exporterID config.ComponentID, measure *stats.Int64Measure) {
+	if fan.level == configtelemetry.LevelNone {
+		return
+	}
+	// ignore the error for now; should not happen
+	_ = stats.RecordWithTags(
+		ctx,
+		[]tag.Mutator{
+			tag.Upsert(obsmetrics.TagKeyPipeline, fan.pipelineID.String(), tag.WithTTL(tag.TTLNoPropagation)),
+			tag.Upsert(obsmetrics.TagKeyExporter, exporterID.String(), tag.WithTTL(tag.TTLNoPropagation)),
+		},
+		measure.M(1),
+	)
+}
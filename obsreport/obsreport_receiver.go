@@ -25,6 +25,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/internal/obsreportconfig"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 )
@@ -119,6 +120,16 @@ func (rec *Receiver) EndMetricsOp(
 	rec.endOp(receiverCtx, format, numReceivedPoints, err, config.MetricsDataType)
 }
 
+// RequestRefused reports a request which was refused before its data was read,
+// for instance because the client exceeded its rate limit.
+func (rec *Receiver) RequestRefused(receiverCtx context.Context) {
+	if obsreportconfig.Level() == configtelemetry.LevelNone {
+		return
+	}
+	// ignore the error for now; should not happen
+	_ = stats.RecordWithTags(receiverCtx, rec.mutators, obsmetrics.ReceiverRefusedRequests.M(1))
+}
+
 // startOp creates the span used to trace the operation. Returning
 // the updated context with the created span.
 func (rec *Receiver) startOp(receiverCtx context.Context, operationSuffix string) context.Context {
@@ -154,7 +165,11 @@ func (rec *Receiver) endOp(
 ) {
 	numAccepted := numReceivedItems
 	numRefused := 0
-	if err != nil {
+	switch {
+	case consumererror.IsPartial(err):
+		numRefused = rejectedCount(numReceivedItems, err)
+		numAccepted = numReceivedItems - numRefused
+	case err != nil:
 		numAccepted = 0
 		numRefused = numReceivedItems
 	}
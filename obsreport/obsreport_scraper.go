@@ -16,6 +16,7 @@ package obsreport // import "go.opentelemetry.io/collector/obsreport"
 
 import (
 	"context"
+	"errors"
 
 	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
@@ -61,45 +62,127 @@ func NewScraper(cfg ScraperSettings) *Scraper {
 // returned context should be used in other calls to the obsreport functions
 // dealing with the same scrape operation.
 func (s *Scraper) StartMetricsOp(ctx context.Context) context.Context {
+	return s.startOp(ctx, obsmetrics.ScraperMetricsOperationSuffix)
+}
+
+// EndMetricsOp completes the scrape operation that was started with
+// StartMetricsOp. The scrape is reported as timed out if the error wraps
+// context.DeadlineExceeded.
+func (s *Scraper) EndMetricsOp(
+	scraperCtx context.Context,
+	numScrapedMetrics int,
+	err error,
+) {
+	s.endOp(scraperCtx, numScrapedMetrics, err, config.MetricsDataType)
+}
+
+// StartLogsOp is called when a scrape operation of logs is started. The
+// returned context should be used in other calls to the obsreport functions
+// dealing with the same scrape operation.
+func (s *Scraper) StartLogsOp(ctx context.Context) context.Context {
+	return s.startOp(ctx, obsmetrics.ScraperLogsOperationSuffix)
+}
+
+// EndLogsOp completes the scrape operation that was started with
+// StartLogsOp. The scrape is reported as timed out if the error wraps
+// context.DeadlineExceeded.
+func (s *Scraper) EndLogsOp(
+	scraperCtx context.Context,
+	numScrapedLogRecords int,
+	err error,
+) {
+	s.endOp(scraperCtx, numScrapedLogRecords, err, config.LogsDataType)
+}
+
+// StartTracesOp is called when a scrape operation of traces is started. The
+// returned context should be used in other calls to the obsreport functions
+// dealing with the same scrape operation.
+func (s *Scraper) StartTracesOp(ctx context.Context) context.Context {
+	return s.startOp(ctx, obsmetrics.ScraperTracesOperationSuffix)
+}
+
+// EndTracesOp completes the scrape operation that was started with
+// StartTracesOp. The scrape is reported as timed out if the error wraps
+// context.DeadlineExceeded.
+func (s *Scraper) EndTracesOp(
+	scraperCtx context.Context,
+	numScrapedSpans int,
+	err error,
+) {
+	s.endOp(scraperCtx, numScrapedSpans, err, config.TracesDataType)
+}
+
+func (s *Scraper) startOp(ctx context.Context, operationSuffix string) context.Context {
 	ctx, _ = tag.New(ctx, s.mutators...)
 
-	spanName := obsmetrics.ScraperPrefix + s.receiverID.String() + obsmetrics.NameSep + s.scraper.String() + obsmetrics.ScraperMetricsOperationSuffix
+	spanName := obsmetrics.ScraperPrefix + s.receiverID.String() + obsmetrics.NameSep + s.scraper.String() + operationSuffix
 	ctx, _ = s.tracer.Start(ctx, spanName)
 	return ctx
 }
 
-// EndMetricsOp completes the scrape operation that was started with
-// StartMetricsOp.
-func (s *Scraper) EndMetricsOp(
+func (s *Scraper) endOp(
 	scraperCtx context.Context,
-	numScrapedMetrics int,
+	numScrapedItems int,
 	err error,
+	dataType config.DataType,
 ) {
-	numErroredMetrics := 0
+	numErroredItems := 0
+	timedOut := int64(0)
+	if errors.Is(err, context.DeadlineExceeded) {
+		timedOut = 1
+	}
 	if err != nil {
 		if partialErr, isPartial := err.(scrapererror.PartialScrapeError); isPartial {
-			numErroredMetrics = partialErr.Failed
+			numErroredItems = partialErr.Failed
 		} else {
-			numErroredMetrics = numScrapedMetrics
-			numScrapedMetrics = 0
+			numErroredItems = numScrapedItems
+			numScrapedItems = 0
 		}
 	}
 
 	span := trace.SpanFromContext(scraperCtx)
 
 	if obsreportconfig.Level() != configtelemetry.LevelNone {
+		var scrapedMeasure, erroredMeasure *stats.Int64Measure
+		switch dataType {
+		case config.TracesDataType:
+			scrapedMeasure = obsmetrics.ScraperScrapedSpans
+			erroredMeasure = obsmetrics.ScraperErroredSpans
+		case config.MetricsDataType:
+			scrapedMeasure = obsmetrics.ScraperScrapedMetricPoints
+			erroredMeasure = obsmetrics.ScraperErroredMetricPoints
+		case config.LogsDataType:
+			scrapedMeasure = obsmetrics.ScraperScrapedLogRecords
+			erroredMeasure = obsmetrics.ScraperErroredLogRecords
+		}
+
 		stats.Record(
 			scraperCtx,
-			obsmetrics.ScraperScrapedMetricPoints.M(int64(numScrapedMetrics)),
-			obsmetrics.ScraperErroredMetricPoints.M(int64(numErroredMetrics)))
+			scrapedMeasure.M(int64(numScrapedItems)),
+			erroredMeasure.M(int64(numErroredItems)),
+			obsmetrics.ScraperTimedOutScrapes.M(timedOut))
 	}
 
 	// end span according to errors
 	if span.IsRecording() {
+		var scrapedItemsKey, erroredItemsKey string
+		switch dataType {
+		case config.TracesDataType:
+			scrapedItemsKey = obsmetrics.ScrapedSpansKey
+			erroredItemsKey = obsmetrics.ErroredSpansKey
+		case config.MetricsDataType:
+			scrapedItemsKey = obsmetrics.ScrapedMetricPointsKey
+			erroredItemsKey = obsmetrics.ErroredMetricPointsKey
+		case config.LogsDataType:
+			scrapedItemsKey = obsmetrics.ScrapedLogRecordsKey
+			erroredItemsKey = obsmetrics.ErroredLogRecordsKey
+		}
+
 		span.SetAttributes(
-			attribute.String(obsmetrics.FormatKey, string(config.MetricsDataType)),
-			attribute.Int64(obsmetrics.ScrapedMetricPointsKey, int64(numScrapedMetrics)),
-			attribute.Int64(obsmetrics.ErroredMetricPointsKey, int64(numErroredMetrics)),
+			attribute.String(obsmetrics.FormatKey, string(dataType)),
+			attribute.Int64(scrapedItemsKey, int64(numScrapedItems)),
+			attribute.Int64(erroredItemsKey, int64(numErroredItems)),
+			attribute.Int64(obsmetrics.TimedOutScrapesKey, timedOut),
 		)
 		recordError(span, err)
 	}
@@ -17,6 +17,7 @@ package obsreport
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -26,6 +27,7 @@ import (
 
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 	"go.opentelemetry.io/collector/obsreport/obsreporttest"
 	"go.opentelemetry.io/collector/receiver/scrapererror"
@@ -41,9 +43,11 @@ var (
 	scraper   = config.NewComponentID("fakeScraper")
 	processor = config.NewComponentID("fakeProcessor")
 	exporter  = config.NewComponentID("fakeExporter")
+	pipeline  = config.NewComponentID("traces")
 
 	errFake        = errors.New("errFake")
 	partialErrFake = scrapererror.NewPartialScrapeError(errFake, 1)
+	rejectErrFake  = consumererror.NewPartial(errFake, 4)
 )
 
 type testParams struct {
@@ -62,6 +66,7 @@ func TestReceiveTraceDataOp(t *testing.T) {
 	params := []testParams{
 		{items: 13, err: errFake},
 		{items: 42, err: nil},
+		{items: 10, err: rejectErrFake},
 	}
 	for i, param := range params {
 		rec := NewReceiver(ReceiverSettings{
@@ -92,6 +97,13 @@ func TestReceiveTraceDataOp(t *testing.T) {
 			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.RefusedSpansKey, Value: attribute.Int64Value(int64(params[i].items))})
 			assert.Equal(t, codes.Error, span.Status().Code)
 			assert.Equal(t, params[i].err.Error(), span.Status().Description)
+		case rejectErrFake:
+			acceptedSpans += params[i].items - 4
+			refusedSpans += 4
+			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.AcceptedSpansKey, Value: attribute.Int64Value(int64(params[i].items - 4))})
+			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.RefusedSpansKey, Value: attribute.Int64Value(4)})
+			assert.Equal(t, codes.Error, span.Status().Code)
+			assert.Equal(t, params[i].err.Error(), span.Status().Description)
 		default:
 			t.Fatalf("unexpected param: %v", params[i])
 		}
@@ -196,6 +208,43 @@ func TestReceiveMetricsOp(t *testing.T) {
 	require.NoError(t, obsreporttest.CheckReceiverMetrics(tt, receiver, transport, int64(acceptedMetricPoints), int64(refusedMetricPoints)))
 }
 
+func TestReceiveRefusedRequests(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	rec := NewReceiver(ReceiverSettings{
+		ReceiverID:             receiver,
+		Transport:              transport,
+		ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+	})
+	rec.RequestRefused(context.Background())
+	rec.RequestRefused(context.Background())
+
+	assert.Empty(t, tt.SpanRecorder.Ended())
+	require.NoError(t, obsreporttest.CheckReceiverRefusedRequests(tt, receiver, transport, 2))
+}
+
+func TestReceivePartialRejectedMoreThanReceived(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	rec := NewReceiver(ReceiverSettings{
+		ReceiverID:             receiver,
+		Transport:              transport,
+		ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+	})
+	ctx := rec.StartTracesOp(context.Background())
+	rec.EndTracesOp(ctx, format, 3, consumererror.NewPartial(errFake, 10))
+
+	spans := tt.SpanRecorder.Ended()
+	require.Len(t, spans, 1)
+	require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: obsmetrics.AcceptedSpansKey, Value: attribute.Int64Value(0)})
+	require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: obsmetrics.RefusedSpansKey, Value: attribute.Int64Value(3)})
+	require.NoError(t, obsreporttest.CheckReceiverTraces(tt, receiver, transport, 0, 3))
+}
+
 func TestScrapeMetricsDataOp(t *testing.T) {
 	tt, err := obsreporttest.SetupTelemetry()
 	require.NoError(t, err)
@@ -254,6 +303,62 @@ func TestScrapeMetricsDataOp(t *testing.T) {
 	require.NoError(t, obsreporttest.CheckScraperMetrics(tt, receiver, scraper, int64(scrapedMetricPoints), int64(erroredMetricPoints)))
 }
 
+func TestScrapeLogsAndTracesDataOp(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	scrp := NewScraper(ScraperSettings{
+		ReceiverID:             receiver,
+		Scraper:                scraper,
+		ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+	})
+	ctx := scrp.StartLogsOp(context.Background())
+	scrp.EndLogsOp(ctx, 13, partialErrFake)
+	ctx = scrp.StartLogsOp(context.Background())
+	scrp.EndLogsOp(ctx, 7, errFake)
+	ctx = scrp.StartTracesOp(context.Background())
+	scrp.EndTracesOp(ctx, 5, nil)
+
+	spans := tt.SpanRecorder.Ended()
+	require.Len(t, spans, 3)
+	assert.Equal(t, "scraper/"+receiver.String()+"/"+scraper.String()+"/LogsScraped", spans[0].Name())
+	require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: obsmetrics.ScrapedLogRecordsKey, Value: attribute.Int64Value(13)})
+	require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: obsmetrics.ErroredLogRecordsKey, Value: attribute.Int64Value(1)})
+	require.Contains(t, spans[1].Attributes(), attribute.KeyValue{Key: obsmetrics.ScrapedLogRecordsKey, Value: attribute.Int64Value(0)})
+	require.Contains(t, spans[1].Attributes(), attribute.KeyValue{Key: obsmetrics.ErroredLogRecordsKey, Value: attribute.Int64Value(7)})
+	assert.Equal(t, "scraper/"+receiver.String()+"/"+scraper.String()+"/TracesScraped", spans[2].Name())
+	require.Contains(t, spans[2].Attributes(), attribute.KeyValue{Key: obsmetrics.ScrapedSpansKey, Value: attribute.Int64Value(5)})
+
+	require.NoError(t, obsreporttest.CheckScraperLogs(tt, receiver, scraper, 13, 8))
+	require.NoError(t, obsreporttest.CheckScraperTraces(tt, receiver, scraper, 5, 0))
+}
+
+func TestScrapeMetricsDataOpTimedOut(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	scrp := NewScraper(ScraperSettings{
+		ReceiverID:             receiver,
+		Scraper:                scraper,
+		ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+	})
+	ctx := scrp.StartMetricsOp(context.Background())
+	scrp.EndMetricsOp(ctx, 0, fmt.Errorf("scrape timed out: %w", context.DeadlineExceeded))
+	ctx = scrp.StartMetricsOp(context.Background())
+	scrp.EndMetricsOp(ctx, 5, nil)
+
+	spans := tt.SpanRecorder.Ended()
+	require.Len(t, spans, 2)
+	require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: obsmetrics.TimedOutScrapesKey, Value: attribute.Int64Value(1)})
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	require.Contains(t, spans[1].Attributes(), attribute.KeyValue{Key: obsmetrics.TimedOutScrapesKey, Value: attribute.Int64Value(0)})
+
+	require.NoError(t, obsreporttest.CheckScraperMetrics(tt, receiver, scraper, 5, 0))
+	require.NoError(t, obsreporttest.CheckScraperTimedOutScrapes(tt, receiver, scraper, 1))
+}
+
 func TestExportTraceDataOp(t *testing.T) {
 	tt, err := obsreporttest.SetupTelemetry()
 	require.NoError(t, err)
@@ -271,6 +376,7 @@ func TestExportTraceDataOp(t *testing.T) {
 	params := []testParams{
 		{items: 22, err: nil},
 		{items: 14, err: errFake},
+		{items: 9, err: rejectErrFake},
 	}
 	for i := range params {
 		ctx := obsrep.StartTracesOp(parentCtx)
@@ -296,6 +402,13 @@ func TestExportTraceDataOp(t *testing.T) {
 			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendSpansKey, Value: attribute.Int64Value(int64(params[i].items))})
 			assert.Equal(t, codes.Error, span.Status().Code)
 			assert.Equal(t, params[i].err.Error(), span.Status().Description)
+		case rejectErrFake:
+			sentSpans += params[i].items - 4
+			failedToSendSpans += 4
+			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.SentSpansKey, Value: attribute.Int64Value(int64(params[i].items - 4))})
+			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendSpansKey, Value: attribute.Int64Value(4)})
+			assert.Equal(t, codes.Error, span.Status().Code)
+			assert.Equal(t, params[i].err.Error(), span.Status().Description)
 		default:
 			t.Fatalf("unexpected error: %v", params[i].err)
 		}
@@ -304,6 +417,31 @@ func TestExportTraceDataOp(t *testing.T) {
 	require.NoError(t, obsreporttest.CheckExporterTraces(tt, exporter, int64(sentSpans), int64(failedToSendSpans)))
 }
 
+func TestExportPartialRejectedMoreThanSent(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	obsrep := NewExporter(ExporterSettings{
+		Level:                  configtelemetry.LevelNormal,
+		ExporterID:             exporter,
+		ExporterCreateSettings: tt.ToExporterCreateSettings(),
+	})
+	// The rejected count reported by a remote server is not trusted.
+	ctx := obsrep.StartTracesOp(context.Background())
+	obsrep.EndTracesOp(ctx, 3, consumererror.NewPartial(errFake, 10))
+	ctx = obsrep.StartTracesOp(context.Background())
+	obsrep.EndTracesOp(ctx, 5, consumererror.NewPartial(errFake, -2))
+
+	spans := tt.SpanRecorder.Ended()
+	require.Len(t, spans, 2)
+	require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: obsmetrics.SentSpansKey, Value: attribute.Int64Value(0)})
+	require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendSpansKey, Value: attribute.Int64Value(3)})
+	require.Contains(t, spans[1].Attributes(), attribute.KeyValue{Key: obsmetrics.SentSpansKey, Value: attribute.Int64Value(5)})
+	require.Contains(t, spans[1].Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendSpansKey, Value: attribute.Int64Value(0)})
+	require.NoError(t, obsreporttest.CheckExporterTraces(tt, exporter, 5, 3))
+}
+
 func TestExportMetricsOp(t *testing.T) {
 	tt, err := obsreporttest.SetupTelemetry()
 	require.NoError(t, err)
@@ -543,3 +681,19 @@ func TestProcessorLogRecords(t *testing.T) {
 
 	require.NoError(t, obsreporttest.CheckProcessorLogs(tt, processor, acceptedRecords, refusedRecords, droppedRecords))
 }
+
+func TestFanoutExporters(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	obsrep := NewFanout(FanoutSettings{
+		Level:      configtelemetry.LevelNormal,
+		PipelineID: pipeline,
+	})
+	obsrep.ExporterTimedOut(context.Background(), exporter)
+	obsrep.ExporterTimedOut(context.Background(), exporter)
+	obsrep.ExporterRejected(context.Background(), exporter)
+
+	require.NoError(t, obsreporttest.CheckFanoutMetrics(tt, pipeline, exporter, 2, 1))
+}
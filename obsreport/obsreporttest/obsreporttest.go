@@ -45,6 +45,7 @@ var (
 	transportTag, _ = tag.NewKey("transport")
 	exporterTag, _  = tag.NewKey("exporter")
 	processorTag, _ = tag.NewKey("processor")
+	pipelineTag, _  = tag.NewKey("pipeline")
 )
 
 type TestTelemetry struct {
@@ -194,6 +195,13 @@ func CheckReceiverMetrics(_ TestTelemetry, receiver config.ComponentID, protocol
 		checkValueForView(receiverTags, droppedMetricPoints, "receiver/refused_metric_points"))
 }
 
+// CheckReceiverRefusedRequests checks that for the current exported value of the requests refused by a receiver
+// before reading their data matches the given value.
+// When this function is called it is required to also call SetupTelemetry as first thing.
+func CheckReceiverRefusedRequests(_ TestTelemetry, receiver config.ComponentID, protocol string, refusedRequests int64) error {
+	return checkValueForView(tagsForReceiverView(receiver, protocol), refusedRequests, "receiver/refused_requests")
+}
+
 // CheckScraperMetrics checks that for the current exported values for metrics scraper metrics match given values.
 // When this function is called it is required to also call SetupTelemetry as first thing.
 func CheckScraperMetrics(_ TestTelemetry, receiver config.ComponentID, scraper config.ComponentID, scrapedMetricPoints, erroredMetricPoints int64) error {
@@ -203,6 +211,40 @@ func CheckScraperMetrics(_ TestTelemetry, receiver config.ComponentID, scraper c
 		checkValueForView(scraperTags, erroredMetricPoints, "scraper/errored_metric_points"))
 }
 
+// CheckScraperLogs checks that for the current exported values for logs scraper metrics match given values.
+// When this function is called it is required to also call SetupTelemetry as first thing.
+func CheckScraperLogs(_ TestTelemetry, receiver config.ComponentID, scraper config.ComponentID, scrapedLogRecords, erroredLogRecords int64) error {
+	scraperTags := tagsForScraperView(receiver, scraper)
+	return multierr.Combine(
+		checkValueForView(scraperTags, scrapedLogRecords, "scraper/scraped_log_records"),
+		checkValueForView(scraperTags, erroredLogRecords, "scraper/errored_log_records"))
+}
+
+// CheckScraperTraces checks that for the current exported values for traces scraper metrics match given values.
+// When this function is called it is required to also call SetupTelemetry as first thing.
+func CheckScraperTraces(_ TestTelemetry, receiver config.ComponentID, scraper config.ComponentID, scrapedSpans, erroredSpans int64) error {
+	scraperTags := tagsForScraperView(receiver, scraper)
+	return multierr.Combine(
+		checkValueForView(scraperTags, scrapedSpans, "scraper/scraped_spans"),
+		checkValueForView(scraperTags, erroredSpans, "scraper/errored_spans"))
+}
+
+// CheckScraperTimedOutScrapes checks that for the current exported value of the timed out scrapes of a scraper matches the given value.
+// When this function is called it is required to also call SetupTelemetry as first thing.
+func CheckScraperTimedOutScrapes(_ TestTelemetry, receiver config.ComponentID, scraper config.ComponentID, timedOutScrapes int64) error {
+	return checkValueForView(tagsForScraperView(receiver, scraper), timedOutScrapes, "scraper/timed_out_scrapes")
+}
+
+// CheckFanoutMetrics checks that for the current exported values of the batches an exporter of a pipeline timed out on
+// or rejected match the given values.
+// When this function is called it is required to also call SetupTelemetry as first thing.
+func CheckFanoutMetrics(_ TestTelemetry, pipeline config.ComponentID, exporter config.ComponentID, timedOutBatches, rejectedBatches int64) error {
+	fanoutTags := tagsForFanoutView(pipeline, exporter)
+	return multierr.Combine(
+		checkValueForView(fanoutTags, timedOutBatches, "fanout/timed_out_batches"),
+		checkValueForView(fanoutTags, rejectedBatches, "fanout/rejected_batches"))
+}
+
 // checkValueForView checks that for the current exported value in the view with the given name
 // for {LegacyTagKeyReceiver: receiverName} is equal to "value".
 func checkValueForView(wantTags []tag.Tag, value int64, vName string) error {
@@ -262,6 +304,14 @@ func tagsForExporterView(exporter config.ComponentID) []tag.Tag {
 	}
 }
 
+// tagsForFanoutView returns the tags that are needed for the fanout views.
+func tagsForFanoutView(pipeline config.ComponentID, exporter config.ComponentID) []tag.Tag {
+	return []tag.Tag{
+		{Key: pipelineTag, Value: pipeline.String()},
+		{Key: exporterTag, Value: exporter.String()},
+	}
+}
+
 func sortTags(tags []tag.Tag) {
 	sort.SliceStable(tags, func(i, j int) bool {
 		return tags[i].Key.Name() < tags[j].Key.Name()
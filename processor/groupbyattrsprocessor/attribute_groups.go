@@ -0,0 +1,277 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbyattrsprocessor // import "go.opentelemetry.io/collector/processor/groupbyattrsprocessor"
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// encodedAttribute is an attribute encoded in the keys of the groups.
+type encodedAttribute struct {
+	key     string
+	encoded string
+}
+
+// encodeAttributes returns the encoded attributes sorted by key.
+func encodeAttributes(attrs pdata.Map) []encodedAttribute {
+	encoded := make([]encodedAttribute, 0, attrs.Len())
+	attrs.Range(func(k string, v pdata.Value) bool {
+		encoded = append(encoded, encodedAttribute{
+			key:     k,
+			encoded: strconv.Quote(k) + ":" + v.Type().String() + ":" + strconv.Quote(v.AsString()) + ";",
+		})
+		return true
+	})
+	sort.Slice(encoded, func(i, j int) bool { return encoded[i].key < encoded[j].key })
+	return encoded
+}
+
+// attributesKey returns a key that is equal for maps with equal attributes,
+// regardless of the order of the attributes.
+func attributesKey(attrs pdata.Map) string {
+	var b strings.Builder
+	for _, attr := range encodeAttributes(attrs) {
+		b.WriteString(attr.encoded)
+	}
+	return b.String()
+}
+
+// sourceResource is a resource of the incoming data, with its attributes encoded
+// once to compute the keys of the resources grouped from it without copying it.
+type sourceResource struct {
+	res       pdata.Resource
+	schemaURL string
+	prefix    string
+	attrs     []encodedAttribute
+	// key is the key of the resource without grouping attributes, computed lazily.
+	key string
+}
+
+func newSourceResource(res pdata.Resource, schemaURL string) *sourceResource {
+	return &sourceResource{
+		res:       res,
+		schemaURL: schemaURL,
+		prefix:    strconv.Quote(schemaURL) + "|" + strconv.FormatUint(uint64(res.DroppedAttributesCount()), 10) + "|",
+		attrs:     encodeAttributes(res.Attributes()),
+	}
+}
+
+// groupedKey returns the key of the resource with the grouping attributes added,
+// which is equal for the resources having the same attributes once grouped.
+func (s *sourceResource) groupedKey(groupingAttrs pdata.Map) string {
+	if groupingAttrs.Len() == 0 {
+		if s.key == "" {
+			s.key = s.prefix + joinAttributes(s.attrs, nil)
+		}
+		return s.key
+	}
+	return s.prefix + joinAttributes(s.attrs, encodeAttributes(groupingAttrs))
+}
+
+// copyTo copies the resource with the grouping attributes added to dest, the
+// grouping attributes overriding the resource attributes with the same names.
+func (s *sourceResource) copyTo(dest pdata.Resource, groupingAttrs pdata.Map) {
+	s.res.CopyTo(dest)
+	groupingAttrs.Range(func(k string, v pdata.Value) bool {
+		dest.Attributes().Upsert(k, v)
+		return true
+	})
+}
+
+// joinAttributes merges the sorted resource and grouping attributes into a key,
+// the grouping attributes overriding the resource attributes with the same names.
+func joinAttributes(resAttrs, groupingAttrs []encodedAttribute) string {
+	var b strings.Builder
+	i, j := 0, 0
+	for i < len(resAttrs) || j < len(groupingAttrs) {
+		switch {
+		case j == len(groupingAttrs) || (i < len(resAttrs) && resAttrs[i].key < groupingAttrs[j].key):
+			b.WriteString(resAttrs[i].encoded)
+			i++
+		case i == len(resAttrs) || groupingAttrs[j].key < resAttrs[i].key:
+			b.WriteString(groupingAttrs[j].encoded)
+			j++
+		default:
+			b.WriteString(groupingAttrs[j].encoded)
+			i++
+			j++
+		}
+	}
+	return b.String()
+}
+
+// scopeKey returns a key identifying an instrumentation scope and its schema URL.
+func scopeKey(scope pdata.InstrumentationScope, schemaURL string) string {
+	return strconv.Quote(schemaURL) + "|" + strconv.Quote(scope.Name()) + "|" + strconv.Quote(scope.Version())
+}
+
+// metricKey returns a key identifying the description of a metric, without its data points.
+func metricKey(metric pdata.Metric) string {
+	key := strconv.Quote(metric.Name()) + "|" + strconv.Quote(metric.Unit()) + "|" + strconv.Quote(metric.Description()) + "|" + metric.DataType().String()
+	switch metric.DataType() {
+	case pdata.MetricDataTypeSum:
+		key += "|" + metric.Sum().AggregationTemporality().String() + "|" + strconv.FormatBool(metric.Sum().IsMonotonic())
+	case pdata.MetricDataTypeHistogram:
+		key += "|" + metric.Histogram().AggregationTemporality().String()
+	case pdata.MetricDataTypeExponentialHistogram:
+		key += "|" + metric.ExponentialHistogram().AggregationTemporality().String()
+	}
+	return key
+}
+
+// copyMetricDescription copies all the properties of the metric, except the data points.
+func copyMetricDescription(src, dest pdata.Metric) {
+	dest.SetName(src.Name())
+	dest.SetDescription(src.Description())
+	dest.SetUnit(src.Unit())
+	dest.SetDataType(src.DataType())
+	switch src.DataType() {
+	case pdata.MetricDataTypeSum:
+		dest.Sum().SetAggregationTemporality(src.Sum().AggregationTemporality())
+		dest.Sum().SetIsMonotonic(src.Sum().IsMonotonic())
+	case pdata.MetricDataTypeHistogram:
+		dest.Histogram().SetAggregationTemporality(src.Histogram().AggregationTemporality())
+	case pdata.MetricDataTypeExponentialHistogram:
+		dest.ExponentialHistogram().SetAggregationTemporality(src.ExponentialHistogram().AggregationTemporality())
+	}
+}
+
+// spansGroups holds the ResourceSpans created while regrouping the spans.
+type spansGroups struct {
+	dest      pdata.ResourceSpansSlice
+	resources map[string]*spansGroup
+}
+
+type spansGroup struct {
+	rs     pdata.ResourceSpans
+	scopes map[string]pdata.ScopeSpans
+}
+
+func newSpansGroups(dest pdata.ResourceSpansSlice) *spansGroups {
+	return &spansGroups{dest: dest, resources: map[string]*spansGroup{}}
+}
+
+// scopeSpans returns the ScopeSpans matching the source resource with the grouping
+// attributes and the scope with the given key, creating it if it does not exist yet.
+func (g *spansGroups) scopeSpans(src *sourceResource, ss pdata.ScopeSpans, sKey string, groupingAttrs pdata.Map) pdata.ScopeSpans {
+	rKey := src.groupedKey(groupingAttrs)
+	group, ok := g.resources[rKey]
+	if !ok {
+		group = &spansGroup{rs: g.dest.AppendEmpty(), scopes: map[string]pdata.ScopeSpans{}}
+		src.copyTo(group.rs.Resource(), groupingAttrs)
+		group.rs.SetSchemaUrl(src.schemaURL)
+		g.resources[rKey] = group
+	}
+
+	scope, ok := group.scopes[sKey]
+	if !ok {
+		scope = group.rs.ScopeSpans().AppendEmpty()
+		ss.Scope().CopyTo(scope.Scope())
+		scope.SetSchemaUrl(ss.SchemaUrl())
+		group.scopes[sKey] = scope
+	}
+	return scope
+}
+
+// logsGroups holds the ResourceLogs created while regrouping the log records.
+type logsGroups struct {
+	dest      pdata.ResourceLogsSlice
+	resources map[string]*logsGroup
+}
+
+type logsGroup struct {
+	rl     pdata.ResourceLogs
+	scopes map[string]pdata.ScopeLogs
+}
+
+func newLogsGroups(dest pdata.ResourceLogsSlice) *logsGroups {
+	return &logsGroups{dest: dest, resources: map[string]*logsGroup{}}
+}
+
+// scopeLogs returns the ScopeLogs matching the source resource with the grouping
+// attributes and the scope with the given key, creating it if it does not exist yet.
+func (g *logsGroups) scopeLogs(src *sourceResource, sl pdata.ScopeLogs, sKey string, groupingAttrs pdata.Map) pdata.ScopeLogs {
+	rKey := src.groupedKey(groupingAttrs)
+	group, ok := g.resources[rKey]
+	if !ok {
+		group = &logsGroup{rl: g.dest.AppendEmpty(), scopes: map[string]pdata.ScopeLogs{}}
+		src.copyTo(group.rl.Resource(), groupingAttrs)
+		group.rl.SetSchemaUrl(src.schemaURL)
+		g.resources[rKey] = group
+	}
+
+	scope, ok := group.scopes[sKey]
+	if !ok {
+		scope = group.rl.ScopeLogs().AppendEmpty()
+		sl.Scope().CopyTo(scope.Scope())
+		scope.SetSchemaUrl(sl.SchemaUrl())
+		group.scopes[sKey] = scope
+	}
+	return scope
+}
+
+// metricsGroups holds the ResourceMetrics created while regrouping the data points.
+type metricsGroups struct {
+	dest      pdata.ResourceMetricsSlice
+	resources map[string]*metricsGroup
+}
+
+type metricsGroup struct {
+	rm     pdata.ResourceMetrics
+	scopes map[string]*metricsScopeGroup
+}
+
+type metricsScopeGroup struct {
+	sm      pdata.ScopeMetrics
+	metrics map[string]pdata.Metric
+}
+
+func newMetricsGroups(dest pdata.ResourceMetricsSlice) *metricsGroups {
+	return &metricsGroups{dest: dest, resources: map[string]*metricsGroup{}}
+}
+
+// metric returns the Metric matching the source resource with the grouping attributes,
+// the scope and the metric with the given keys, creating it without data points if it
+// does not exist yet.
+func (g *metricsGroups) metric(src *sourceResource, sm pdata.ScopeMetrics, sKey string, metric pdata.Metric, mKey string, groupingAttrs pdata.Map) pdata.Metric {
+	rKey := src.groupedKey(groupingAttrs)
+	group, ok := g.resources[rKey]
+	if !ok {
+		group = &metricsGroup{rm: g.dest.AppendEmpty(), scopes: map[string]*metricsScopeGroup{}}
+		src.copyTo(group.rm.Resource(), groupingAttrs)
+		group.rm.SetSchemaUrl(src.schemaURL)
+		g.resources[rKey] = group
+	}
+
+	scope, ok := group.scopes[sKey]
+	if !ok {
+		scope = &metricsScopeGroup{sm: group.rm.ScopeMetrics().AppendEmpty(), metrics: map[string]pdata.Metric{}}
+		sm.Scope().CopyTo(scope.sm.Scope())
+		scope.sm.SetSchemaUrl(sm.SchemaUrl())
+		group.scopes[sKey] = scope
+	}
+
+	dest, ok := scope.metrics[mKey]
+	if !ok {
+		dest = scope.sm.Metrics().AppendEmpty()
+		copyMetricDescription(metric, dest)
+		scope.metrics[mKey] = dest
+	}
+	return dest
+}
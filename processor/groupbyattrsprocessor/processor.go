@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbyattrsprocessor // import "go.opentelemetry.io/collector/processor/groupbyattrsprocessor"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+type groupByAttrsProcessor struct {
+	groupByKeys []string
+}
+
+func newGroupByAttrsProcessor(cfg *Config) *groupByAttrsProcessor {
+	return &groupByAttrsProcessor{
+		groupByKeys: cfg.GroupByKeys,
+	}
+}
+
+// extractGroupingAttributes removes the attributes matching the grouping keys from
+// the attributes of a record, and returns them.
+func (gap *groupByAttrsProcessor) extractGroupingAttributes(attrs pdata.Map) pdata.Map {
+	groupingAttrs := pdata.NewMap()
+	for _, key := range gap.groupByKeys {
+		if v, ok := attrs.Get(key); ok {
+			groupingAttrs.Upsert(key, v)
+			attrs.Remove(key)
+		}
+	}
+	return groupingAttrs
+}
+
+func (gap *groupByAttrsProcessor) processTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+	groupedTraces := pdata.NewTraces()
+	groups := newSpansGroups(groupedTraces.ResourceSpans())
+
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		src := newSourceResource(rs.Resource(), rs.SchemaUrl())
+		ilss := rs.ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			sKey := scopeKey(ils.Scope(), ils.SchemaUrl())
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				groupingAttrs := gap.extractGroupingAttributes(span.Attributes())
+				span.MoveTo(groups.scopeSpans(src, ils, sKey, groupingAttrs).Spans().AppendEmpty())
+			}
+		}
+	}
+
+	return groupedTraces, nil
+}
+
+func (gap *groupByAttrsProcessor) processLogs(_ context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	groupedLogs := pdata.NewLogs()
+	groups := newLogsGroups(groupedLogs.ResourceLogs())
+
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		src := newSourceResource(rl.Resource(), rl.SchemaUrl())
+		ills := rl.ScopeLogs()
+		for j := 0; j < ills.Len(); j++ {
+			ill := ills.At(j)
+			sKey := scopeKey(ill.Scope(), ill.SchemaUrl())
+			logs := ill.LogRecords()
+			for k := 0; k < logs.Len(); k++ {
+				log := logs.At(k)
+				groupingAttrs := gap.extractGroupingAttributes(log.Attributes())
+				log.MoveTo(groups.scopeLogs(src, ill, sKey, groupingAttrs).LogRecords().AppendEmpty())
+			}
+		}
+	}
+
+	return groupedLogs, nil
+}
+
+func (gap *groupByAttrsProcessor) processMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	groupedMetrics := pdata.NewMetrics()
+	groups := newMetricsGroups(groupedMetrics.ResourceMetrics())
+
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		src := newSourceResource(rm.Resource(), rm.SchemaUrl())
+		ilms := rm.ScopeMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			ilm := ilms.At(j)
+			sKey := scopeKey(ilm.Scope(), ilm.SchemaUrl())
+			metrics := ilm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				mKey := metricKey(metric)
+				switch metric.DataType() {
+				case pdata.MetricDataTypeGauge:
+					dps := metric.Gauge().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dp := dps.At(l)
+						groupingAttrs := gap.extractGroupingAttributes(dp.Attributes())
+						dp.MoveTo(groups.metric(src, ilm, sKey, metric, mKey, groupingAttrs).Gauge().DataPoints().AppendEmpty())
+					}
+				case pdata.MetricDataTypeSum:
+					dps := metric.Sum().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dp := dps.At(l)
+						groupingAttrs := gap.extractGroupingAttributes(dp.Attributes())
+						dp.MoveTo(groups.metric(src, ilm, sKey, metric, mKey, groupingAttrs).Sum().DataPoints().AppendEmpty())
+					}
+				case pdata.MetricDataTypeHistogram:
+					dps := metric.Histogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dp := dps.At(l)
+						groupingAttrs := gap.extractGroupingAttributes(dp.Attributes())
+						dp.MoveTo(groups.metric(src, ilm, sKey, metric, mKey, groupingAttrs).Histogram().DataPoints().AppendEmpty())
+					}
+				case pdata.MetricDataTypeExponentialHistogram:
+					dps := metric.ExponentialHistogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dp := dps.At(l)
+						groupingAttrs := gap.extractGroupingAttributes(dp.Attributes())
+						dp.MoveTo(groups.metric(src, ilm, sKey, metric, mKey, groupingAttrs).ExponentialHistogram().DataPoints().AppendEmpty())
+					}
+				case pdata.MetricDataTypeSummary:
+					dps := metric.Summary().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dp := dps.At(l)
+						groupingAttrs := gap.extractGroupingAttributes(dp.Attributes())
+						dp.MoveTo(groups.metric(src, ilm, sKey, metric, mKey, groupingAttrs).Summary().DataPoints().AppendEmpty())
+					}
+				}
+			}
+		}
+	}
+
+	return groupedMetrics, nil
+}
@@ -0,0 +1,271 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbyattrsprocessor
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func newTestProcessor(keys ...string) *groupByAttrsProcessor {
+	return newGroupByAttrsProcessor(&Config{GroupByKeys: keys})
+}
+
+func TestProcessTraces(t *testing.T) {
+	td := pdata.NewTraces()
+
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", "svc")
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("lib")
+	span := ss.Spans().AppendEmpty()
+	span.SetName("span-a")
+	span.Attributes().InsertString("host.name", "host-a")
+	span.Attributes().InsertString("other", "value")
+	span = ss.Spans().AppendEmpty()
+	span.SetName("span-b")
+	span.Attributes().InsertString("host.name", "host-b")
+
+	// Same resource as the first one, its span must be merged with span-a.
+	rs = td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", "svc")
+	ss = rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("lib")
+	span = ss.Spans().AppendEmpty()
+	span.SetName("span-c")
+	span.Attributes().InsertString("host.name", "host-a")
+
+	grouped, err := newTestProcessor("host.name").processTraces(context.Background(), td)
+	require.NoError(t, err)
+	assert.Equal(t, 3, grouped.SpanCount())
+	require.Equal(t, 2, grouped.ResourceSpans().Len())
+
+	rsA := grouped.ResourceSpans().At(0)
+	assert.Equal(t, map[string]interface{}{"service.name": "svc", "host.name": "host-a"}, rsA.Resource().Attributes().AsRaw())
+	require.Equal(t, 1, rsA.ScopeSpans().Len())
+	assert.Equal(t, "lib", rsA.ScopeSpans().At(0).Scope().Name())
+	spansA := rsA.ScopeSpans().At(0).Spans()
+	require.Equal(t, 2, spansA.Len())
+	assert.Equal(t, "span-a", spansA.At(0).Name())
+	assert.Equal(t, map[string]interface{}{"other": "value"}, spansA.At(0).Attributes().AsRaw())
+	assert.Equal(t, "span-c", spansA.At(1).Name())
+	assert.Equal(t, 0, spansA.At(1).Attributes().Len())
+
+	rsB := grouped.ResourceSpans().At(1)
+	assert.Equal(t, map[string]interface{}{"service.name": "svc", "host.name": "host-b"}, rsB.Resource().Attributes().AsRaw())
+	require.Equal(t, 1, rsB.ScopeSpans().At(0).Spans().Len())
+	assert.Equal(t, "span-b", rsB.ScopeSpans().At(0).Spans().At(0).Name())
+}
+
+func TestProcessTraces_OverrideResourceAttribute(t *testing.T) {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("host.name", "collector")
+	span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.Attributes().InsertString("host.name", "host-a")
+
+	grouped, err := newTestProcessor("host.name").processTraces(context.Background(), td)
+	require.NoError(t, err)
+	require.Equal(t, 1, grouped.ResourceSpans().Len())
+	assert.Equal(t, map[string]interface{}{"host.name": "host-a"}, grouped.ResourceSpans().At(0).Resource().Attributes().AsRaw())
+}
+
+func TestProcessLogs_Compaction(t *testing.T) {
+	ld := pdata.NewLogs()
+	for i := 0; i < 3; i++ {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().InsertString("service.name", "svc")
+		sl := rl.ScopeLogs().AppendEmpty()
+		sl.Scope().SetName("lib")
+		sl.LogRecords().AppendEmpty().Attributes().InsertString("host.name", "host-a")
+	}
+
+	grouped, err := newTestProcessor().processLogs(context.Background(), ld)
+	require.NoError(t, err)
+	assert.Equal(t, 3, grouped.LogRecordCount())
+	require.Equal(t, 1, grouped.ResourceLogs().Len())
+	rl := grouped.ResourceLogs().At(0)
+	assert.Equal(t, map[string]interface{}{"service.name": "svc"}, rl.Resource().Attributes().AsRaw())
+	require.Equal(t, 1, rl.ScopeLogs().Len())
+	logs := rl.ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 3, logs.Len())
+	for i := 0; i < logs.Len(); i++ {
+		assert.Equal(t, map[string]interface{}{"host.name": "host-a"}, logs.At(i).Attributes().AsRaw())
+	}
+}
+
+func TestProcessLogs_DifferentScopes(t *testing.T) {
+	ld := pdata.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	for _, name := range []string{"lib-a", "lib-b"} {
+		sl := rl.ScopeLogs().AppendEmpty()
+		sl.Scope().SetName(name)
+		sl.LogRecords().AppendEmpty().Attributes().InsertString("host.name", "host-a")
+	}
+
+	grouped, err := newTestProcessor("host.name").processLogs(context.Background(), ld)
+	require.NoError(t, err)
+	require.Equal(t, 1, grouped.ResourceLogs().Len())
+	rl = grouped.ResourceLogs().At(0)
+	assert.Equal(t, map[string]interface{}{"host.name": "host-a"}, rl.Resource().Attributes().AsRaw())
+	require.Equal(t, 2, rl.ScopeLogs().Len())
+	assert.Equal(t, "lib-a", rl.ScopeLogs().At(0).Scope().Name())
+	assert.Equal(t, "lib-b", rl.ScopeLogs().At(1).Scope().Name())
+}
+
+func TestProcessMetrics(t *testing.T) {
+	md := pdata.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	sm := rm.ScopeMetrics().AppendEmpty()
+
+	sum := sm.Metrics().AppendEmpty()
+	sum.SetName("requests")
+	sum.SetUnit("1")
+	sum.SetDataType(pdata.MetricDataTypeSum)
+	sum.Sum().SetIsMonotonic(true)
+	sum.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+	for _, host := range []string{"host-a", "host-b", "host-a"} {
+		dp := sum.Sum().DataPoints().AppendEmpty()
+		dp.Attributes().InsertString("host.name", host)
+		dp.Attributes().InsertString("method", "GET")
+		dp.SetIntVal(1)
+	}
+
+	hist := sm.Metrics().AppendEmpty()
+	hist.SetName("latency")
+	hist.SetDataType(pdata.MetricDataTypeHistogram)
+	hist.Histogram().SetAggregationTemporality(pdata.MetricAggregationTemporalityDelta)
+	hist.Histogram().DataPoints().AppendEmpty().Attributes().InsertString("host.name", "host-b")
+
+	for _, dt := range []pdata.MetricDataType{pdata.MetricDataTypeGauge, pdata.MetricDataTypeExponentialHistogram, pdata.MetricDataTypeSummary} {
+		m := sm.Metrics().AppendEmpty()
+		m.SetName(dt.String())
+		m.SetDataType(dt)
+	}
+	sm.Metrics().At(2).Gauge().DataPoints().AppendEmpty().Attributes().InsertString("host.name", "host-a")
+	sm.Metrics().At(3).ExponentialHistogram().DataPoints().AppendEmpty().Attributes().InsertString("host.name", "host-a")
+	sm.Metrics().At(4).Summary().DataPoints().AppendEmpty().Attributes().InsertString("host.name", "host-b")
+
+	grouped, err := newTestProcessor("host.name").processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	assert.Equal(t, 7, grouped.DataPointCount())
+	require.Equal(t, 2, grouped.ResourceMetrics().Len())
+
+	rmA := grouped.ResourceMetrics().At(0)
+	assert.Equal(t, map[string]interface{}{"host.name": "host-a"}, rmA.Resource().Attributes().AsRaw())
+	metricsA := rmA.ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 3, metricsA.Len())
+	assert.Equal(t, "requests", metricsA.At(0).Name())
+	assert.Equal(t, "1", metricsA.At(0).Unit())
+	assert.True(t, metricsA.At(0).Sum().IsMonotonic())
+	assert.Equal(t, pdata.MetricAggregationTemporalityCumulative, metricsA.At(0).Sum().AggregationTemporality())
+	require.Equal(t, 2, metricsA.At(0).Sum().DataPoints().Len())
+	assert.Equal(t, map[string]interface{}{"method": "GET"}, metricsA.At(0).Sum().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, pdata.MetricDataTypeGauge, metricsA.At(1).DataType())
+	assert.Equal(t, pdata.MetricDataTypeExponentialHistogram, metricsA.At(2).DataType())
+
+	rmB := grouped.ResourceMetrics().At(1)
+	assert.Equal(t, map[string]interface{}{"host.name": "host-b"}, rmB.Resource().Attributes().AsRaw())
+	metricsB := rmB.ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 3, metricsB.Len())
+	assert.Equal(t, 1, metricsB.At(0).Sum().DataPoints().Len())
+	assert.Equal(t, "latency", metricsB.At(1).Name())
+	assert.Equal(t, pdata.MetricAggregationTemporalityDelta, metricsB.At(1).Histogram().AggregationTemporality())
+	assert.Equal(t, pdata.MetricDataTypeSummary, metricsB.At(2).DataType())
+}
+
+func TestAttributesKey(t *testing.T) {
+	m1 := pdata.NewMap()
+	m1.InsertString("a", "1")
+	m1.InsertInt("b", 1)
+	m2 := pdata.NewMap()
+	m2.InsertInt("b", 1)
+	m2.InsertString("a", "1")
+	assert.Equal(t, attributesKey(m1), attributesKey(m2))
+
+	m3 := pdata.NewMap()
+	m3.InsertString("a", "1")
+	m3.InsertString("b", "1")
+	assert.NotEqual(t, attributesKey(m1), attributesKey(m3))
+}
+
+func TestTracesProcessorPipeline(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.GroupByKeys = []string{"host.name"}
+	tp, err := factory.CreateTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	assert.True(t, tp.Capabilities().MutatesData)
+
+	td := pdata.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().Attributes().InsertString("host.name", "host-a")
+	require.NoError(t, tp.ConsumeTraces(context.Background(), td))
+
+	require.Len(t, sink.AllTraces(), 1)
+	rs := sink.AllTraces()[0].ResourceSpans()
+	require.Equal(t, 1, rs.Len())
+	assert.Equal(t, map[string]interface{}{"host.name": "host-a"}, rs.At(0).Resource().Attributes().AsRaw())
+}
+
+func TestSourceResourceGroupedKey(t *testing.T) {
+	merged := pdata.NewResource()
+	merged.Attributes().InsertString("a", "1")
+	merged.Attributes().InsertString("b", "2")
+	mergedKey := newSourceResource(merged, "").groupedKey(pdata.NewMap())
+
+	res := pdata.NewResource()
+	res.Attributes().InsertString("a", "1")
+	res.Attributes().InsertString("b", "0")
+	src := newSourceResource(res, "")
+	grouping := pdata.NewMap()
+	grouping.InsertString("b", "2")
+	// The grouping attributes override the resource attributes.
+	assert.Equal(t, mergedKey, src.groupedKey(grouping))
+	assert.NotEqual(t, mergedKey, src.groupedKey(pdata.NewMap()))
+	assert.NotEqual(t, mergedKey, newSourceResource(merged, "https://example.com/schema").groupedKey(pdata.NewMap()))
+
+	dest := pdata.NewResource()
+	src.copyTo(dest, grouping)
+	assert.Equal(t, map[string]interface{}{"a": "1", "b": "2"}, dest.Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{"a": "1", "b": "0"}, res.Attributes().AsRaw())
+}
+
+func BenchmarkProcessTraces(b *testing.B) {
+	gap := newTestProcessor("host.name")
+	for n := 0; n < b.N; n++ {
+		b.StopTimer()
+		td := pdata.NewTraces()
+		rs := td.ResourceSpans().AppendEmpty()
+		for i := 0; i < 20; i++ {
+			rs.Resource().Attributes().InsertString(fmt.Sprintf("resource.%d", i), "value")
+		}
+		spans := rs.ScopeSpans().AppendEmpty().Spans()
+		for i := 0; i < 1000; i++ {
+			spans.AppendEmpty().Attributes().InsertString("host.name", fmt.Sprintf("host-%d", i%10))
+		}
+		b.StartTimer()
+		_, err := gap.processTraces(context.Background(), td)
+		require.NoError(b, err)
+	}
+}
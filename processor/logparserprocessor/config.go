@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logparserprocessor // import "go.opentelemetry.io/collector/processor/logparserprocessor"
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+const (
+	// Supported formats of the log bodies.
+	formatJSON     = "json"
+	formatLogfmt   = "logfmt"
+	formatKeyValue = "keyvalue"
+	formatRegex    = "regex"
+
+	// Supported targets of the parsed fields.
+	parseToAttributes = "attributes"
+	parseToBody       = "body"
+
+	// Supported layouts of the timestamps, in addition to the Go time layouts.
+	layoutUnix      = "unix"
+	layoutUnixMilli = "unix_ms"
+	layoutUnixMicro = "unix_us"
+	layoutUnixNano  = "unix_ns"
+)
+
+// Config defines configuration for log parser processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Format of the log bodies, one of "json", "logfmt", "keyvalue" or "regex".
+	Format string `mapstructure:"format"`
+
+	// Regex is the regular expression with named capture groups used to parse the
+	// log bodies when the format is "regex". Each named group becomes a field.
+	Regex string `mapstructure:"regex"`
+
+	// KeyValue configures the delimiters used to parse the log bodies when the format is "keyvalue".
+	KeyValue KeyValueConfig `mapstructure:"key_value"`
+
+	// ParseTo is the target of the parsed fields, either "attributes" to add them to
+	// the attributes of the log record, or "body" to replace the body with a map.
+	ParseTo string `mapstructure:"parse_to"`
+
+	// Timestamp configures the extraction of the timestamp from the parsed fields.
+	Timestamp *TimestampConfig `mapstructure:"timestamp"`
+
+	// Severity configures the extraction of the severity from the parsed fields.
+	Severity *SeverityConfig `mapstructure:"severity"`
+
+	// ErrorAttribute is the name of the attribute set with the error message on the
+	// log records that cannot be parsed. The log records are never dropped.
+	// Empty disables the attribute.
+	ErrorAttribute string `mapstructure:"error_attribute"`
+}
+
+// KeyValueConfig defines the delimiters of the "keyvalue" format.
+type KeyValueConfig struct {
+	// Delimiter separates the keys from the values.
+	Delimiter string `mapstructure:"delimiter"`
+
+	// PairDelimiter separates the key-value pairs.
+	PairDelimiter string `mapstructure:"pair_delimiter"`
+}
+
+// TimestampConfig defines how the timestamp is extracted from the parsed fields.
+type TimestampConfig struct {
+	// Field is the name of the parsed field holding the timestamp.
+	Field string `mapstructure:"field"`
+
+	// Layout is the Go time layout of the timestamp (e.g. "2006-01-02T15:04:05Z07:00"),
+	// or one of "unix", "unix_ms", "unix_us" or "unix_ns" for epoch timestamps.
+	Layout string `mapstructure:"layout"`
+
+	// Location is the IANA time zone name used for the timestamps without time zone.
+	// Defaults to UTC.
+	Location string `mapstructure:"location"`
+}
+
+// SeverityConfig defines how the severity is extracted from the parsed fields.
+type SeverityConfig struct {
+	// Field is the name of the parsed field holding the severity.
+	Field string `mapstructure:"field"`
+
+	// Mapping maps the severity names ("trace", "debug", "info", "warn", "error",
+	// "fatal", optionally followed by a number between 2 and 4, e.g. "error2") to the
+	// values of the field, compared case-insensitively. It is added to the default
+	// mapping of the common severity names.
+	Mapping map[string][]string `mapstructure:"mapping"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	switch cfg.Format {
+	case formatJSON, formatLogfmt:
+	case formatKeyValue:
+		if cfg.KeyValue.Delimiter == "" || cfg.KeyValue.PairDelimiter == "" {
+			return errors.New("key_value delimiter and pair_delimiter must not be empty")
+		}
+		if cfg.KeyValue.Delimiter == cfg.KeyValue.PairDelimiter {
+			return errors.New("key_value delimiter and pair_delimiter must be different")
+		}
+	case formatRegex:
+		if cfg.Regex == "" {
+			return errors.New("regex must be specified with the regex format")
+		}
+		re, err := regexp.Compile(cfg.Regex)
+		if err != nil {
+			return fmt.Errorf("invalid regex: %w", err)
+		}
+		if !hasNamedGroups(re) {
+			return errors.New("regex must contain at least one named capture group")
+		}
+	default:
+		return fmt.Errorf("unsupported format %q, supported: [%s, %s, %s, %s]", cfg.Format, formatJSON, formatLogfmt, formatKeyValue, formatRegex)
+	}
+
+	switch cfg.ParseTo {
+	case parseToAttributes, parseToBody:
+	default:
+		return fmt.Errorf("unsupported parse_to %q, supported: [%s, %s]", cfg.ParseTo, parseToAttributes, parseToBody)
+	}
+
+	if cfg.Timestamp != nil {
+		if cfg.Timestamp.Field == "" || cfg.Timestamp.Layout == "" {
+			return errors.New("timestamp field and layout must be specified")
+		}
+		if _, err := time.LoadLocation(cfg.Timestamp.Location); err != nil {
+			return fmt.Errorf("invalid timestamp location: %w", err)
+		}
+	}
+
+	if cfg.Severity != nil {
+		if cfg.Severity.Field == "" {
+			return errors.New("severity field must be specified")
+		}
+		for name := range cfg.Severity.Mapping {
+			if _, ok := severityNumbers[strings.ToLower(name)]; !ok {
+				return fmt.Errorf("unknown severity %q in severity mapping", name)
+			}
+		}
+	}
+	return nil
+}
+
+func hasNamedGroups(re *regexp.Regexp) bool {
+	for _, name := range re.SubexpNames() {
+		if name != "" {
+			return true
+		}
+	}
+	return false
+}
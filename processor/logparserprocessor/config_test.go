@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logparserprocessor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/service/servicetest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	cfg, err := servicetest.LoadConfigAndValidate(filepath.Join("testdata", "config.yaml"), factories)
+
+	require.Nil(t, err)
+	require.NotNil(t, cfg)
+
+	p0 := cfg.Processors[config.NewComponentID(typeStr)]
+	assert.Equal(t, p0, factory.CreateDefaultConfig())
+
+	p1 := cfg.Processors[config.NewComponentIDWithName(typeStr, "regex")]
+	assert.Equal(t, p1,
+		&Config{
+			ProcessorSettings: config.NewProcessorSettings(config.NewComponentIDWithName(typeStr, "regex")),
+			Format:            formatRegex,
+			Regex:             `^(?P<time>\S+) (?P<level>\w+) (?P<msg>.*)$`,
+			KeyValue:          KeyValueConfig{Delimiter: "=", PairDelimiter: " "},
+			ParseTo:           parseToBody,
+			Timestamp: &TimestampConfig{
+				Field:  "time",
+				Layout: "2006-01-02T15:04:05Z07:00",
+			},
+			Severity: &SeverityConfig{
+				Field: "level",
+				Mapping: map[string][]string{
+					"warn":  {"W"},
+					"error": {"E"},
+				},
+			},
+			ErrorAttribute: "parse.error",
+		})
+}
+
+func TestValidateConfig(t *testing.T) {
+	testCases := []struct {
+		name   string
+		modify func(cfg *Config)
+	}{
+		{
+			name:   "unknown format",
+			modify: func(cfg *Config) { cfg.Format = "xml" },
+		},
+		{
+			name:   "missing regex",
+			modify: func(cfg *Config) { cfg.Format = formatRegex },
+		},
+		{
+			name: "invalid regex",
+			modify: func(cfg *Config) {
+				cfg.Format = formatRegex
+				cfg.Regex = "(?P<a>"
+			},
+		},
+		{
+			name: "regex without named group",
+			modify: func(cfg *Config) {
+				cfg.Format = formatRegex
+				cfg.Regex = "(.*)"
+			},
+		},
+		{
+			name: "same key value delimiters",
+			modify: func(cfg *Config) {
+				cfg.Format = formatKeyValue
+				cfg.KeyValue.PairDelimiter = "="
+			},
+		},
+		{
+			name:   "unknown parse_to",
+			modify: func(cfg *Config) { cfg.ParseTo = "resource" },
+		},
+		{
+			name:   "missing timestamp layout",
+			modify: func(cfg *Config) { cfg.Timestamp = &TimestampConfig{Field: "time"} },
+		},
+		{
+			name: "invalid timestamp location",
+			modify: func(cfg *Config) {
+				cfg.Timestamp = &TimestampConfig{Field: "time", Layout: layoutUnix, Location: "Nowhere/Unknown"}
+			},
+		},
+		{
+			name:   "missing severity field",
+			modify: func(cfg *Config) { cfg.Severity = &SeverityConfig{} },
+		},
+		{
+			name: "unknown severity",
+			modify: func(cfg *Config) {
+				cfg.Severity = &SeverityConfig{Field: "level", Mapping: map[string][]string{"verbose": {"V"}}}
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			require.NoError(t, cfg.Validate())
+			tt.modify(cfg)
+			assert.Error(t, cfg.Validate())
+		})
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logparserprocessor // import "go.opentelemetry.io/collector/processor/logparserprocessor"
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// timestampParser extracts the timestamp of a log record from a parsed field.
+type timestampParser struct {
+	field    string
+	layout   string
+	location *time.Location
+}
+
+func newTimestampParser(cfg *TimestampConfig) (*timestampParser, error) {
+	loc, err := time.LoadLocation(cfg.Location)
+	if err != nil {
+		return nil, err
+	}
+	return &timestampParser{
+		field:    cfg.Field,
+		layout:   cfg.Layout,
+		location: loc,
+	}, nil
+}
+
+func (tp *timestampParser) parse(v interface{}) (time.Time, error) {
+	switch tp.layout {
+	case layoutUnix:
+		return parseEpoch(v, time.Second)
+	case layoutUnixMilli:
+		return parseEpoch(v, time.Millisecond)
+	case layoutUnixMicro:
+		return parseEpoch(v, time.Microsecond)
+	case layoutUnixNano:
+		return parseEpoch(v, time.Nanosecond)
+	}
+	s, ok := v.(string)
+	if !ok {
+		return time.Time{}, fmt.Errorf("timestamp field %q is not a string", tp.field)
+	}
+	return time.ParseInLocation(tp.layout, s, tp.location)
+}
+
+// parseEpoch converts a number of units since the Unix epoch to a time.Time.
+// The value can be a number or a string holding a number, and can be fractional.
+func parseEpoch(v interface{}, unit time.Duration) (time.Time, error) {
+	switch tv := v.(type) {
+	case int64:
+		return time.Unix(0, tv*int64(unit)).UTC(), nil
+	case float64:
+		return epochFromFloat(tv, unit), nil
+	case string:
+		if i, err := strconv.ParseInt(tv, 10, 64); err == nil {
+			return time.Unix(0, i*int64(unit)).UTC(), nil
+		}
+		f, err := strconv.ParseFloat(tv, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid epoch timestamp %q", tv)
+		}
+		return epochFromFloat(f, unit), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid epoch timestamp %v", v)
+}
+
+func epochFromFloat(f float64, unit time.Duration) time.Time {
+	sec, frac := math.Modf(f * float64(unit) / float64(time.Second))
+	return time.Unix(int64(sec), int64(frac*float64(time.Second))).UTC()
+}
+
+// severityNumbers maps the severity names accepted in the configuration to their number.
+var severityNumbers = map[string]pdata.SeverityNumber{
+	"trace":  pdata.SeverityNumberTRACE,
+	"trace2": pdata.SeverityNumberTRACE2,
+	"trace3": pdata.SeverityNumberTRACE3,
+	"trace4": pdata.SeverityNumberTRACE4,
+	"debug":  pdata.SeverityNumberDEBUG,
+	"debug2": pdata.SeverityNumberDEBUG2,
+	"debug3": pdata.SeverityNumberDEBUG3,
+	"debug4": pdata.SeverityNumberDEBUG4,
+	"info":   pdata.SeverityNumberINFO,
+	"info2":  pdata.SeverityNumberINFO2,
+	"info3":  pdata.SeverityNumberINFO3,
+	"info4":  pdata.SeverityNumberINFO4,
+	"warn":   pdata.SeverityNumberWARN,
+	"warn2":  pdata.SeverityNumberWARN2,
+	"warn3":  pdata.SeverityNumberWARN3,
+	"warn4":  pdata.SeverityNumberWARN4,
+	"error":  pdata.SeverityNumberERROR,
+	"error2": pdata.SeverityNumberERROR2,
+	"error3": pdata.SeverityNumberERROR3,
+	"error4": pdata.SeverityNumberERROR4,
+	"fatal":  pdata.SeverityNumberFATAL,
+	"fatal2": pdata.SeverityNumberFATAL2,
+	"fatal3": pdata.SeverityNumberFATAL3,
+	"fatal4": pdata.SeverityNumberFATAL4,
+}
+
+// defaultSeverityMapping maps the common severity values to their number.
+var defaultSeverityMapping = map[string]pdata.SeverityNumber{
+	"trace":       pdata.SeverityNumberTRACE,
+	"debug":       pdata.SeverityNumberDEBUG,
+	"info":        pdata.SeverityNumberINFO,
+	"information": pdata.SeverityNumberINFO,
+	"notice":      pdata.SeverityNumberINFO2,
+	"warn":        pdata.SeverityNumberWARN,
+	"warning":     pdata.SeverityNumberWARN,
+	"error":       pdata.SeverityNumberERROR,
+	"err":         pdata.SeverityNumberERROR,
+	"critical":    pdata.SeverityNumberERROR2,
+	"crit":        pdata.SeverityNumberERROR2,
+	"alert":       pdata.SeverityNumberERROR3,
+	"fatal":       pdata.SeverityNumberFATAL,
+	"emergency":   pdata.SeverityNumberFATAL,
+	"emerg":       pdata.SeverityNumberFATAL,
+	"panic":       pdata.SeverityNumberFATAL,
+}
+
+// severityParser extracts the severity of a log record from a parsed field.
+type severityParser struct {
+	field   string
+	mapping map[string]pdata.SeverityNumber
+}
+
+func newSeverityParser(cfg *SeverityConfig) *severityParser {
+	mapping := make(map[string]pdata.SeverityNumber, len(defaultSeverityMapping))
+	for value, number := range defaultSeverityMapping {
+		mapping[value] = number
+	}
+	for name, values := range cfg.Mapping {
+		for _, value := range values {
+			mapping[strings.ToLower(value)] = severityNumbers[strings.ToLower(name)]
+		}
+	}
+	return &severityParser{
+		field:   cfg.Field,
+		mapping: mapping,
+	}
+}
+
+// parse returns the severity text and number of a parsed field. The number is
+// pdata.SeverityNumberUNDEFINED if the value is not mapped to any severity.
+func (sp *severityParser) parse(v interface{}) (string, pdata.SeverityNumber) {
+	text := fmt.Sprint(v)
+	return text, sp.mapping[strings.ToLower(text)]
+}
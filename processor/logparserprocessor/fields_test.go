@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logparserprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestTimestampParser(t *testing.T) {
+	expected := time.Date(2022, 3, 4, 5, 6, 7, 500_000_000, time.UTC)
+	testCases := []struct {
+		name     string
+		layout   string
+		location string
+		value    interface{}
+		expected time.Time
+		err      bool
+	}{
+		{name: "layout", layout: time.RFC3339Nano, value: "2022-03-04T05:06:07.5Z", expected: expected},
+		{name: "layout with location", layout: "2006-01-02 15:04:05.0", location: "Europe/Paris", value: "2022-03-04 06:06:07.5", expected: expected},
+		{name: "layout not a string", layout: time.RFC3339, value: int64(1), err: true},
+		{name: "layout mismatch", layout: time.RFC3339, value: "yesterday", err: true},
+		{name: "unix float", layout: layoutUnix, value: float64(expected.UnixNano()) / 1e9, expected: expected},
+		{name: "unix string", layout: layoutUnix, value: "1646370367.5", expected: expected},
+		{name: "unix_ms int", layout: layoutUnixMilli, value: expected.UnixNano() / 1e6, expected: expected},
+		{name: "unix_us string", layout: layoutUnixMicro, value: "1646370367500000", expected: expected},
+		{name: "unix_ns int", layout: layoutUnixNano, value: expected.UnixNano(), expected: expected},
+		{name: "unix invalid", layout: layoutUnix, value: "now", err: true},
+		{name: "unix invalid type", layout: layoutUnix, value: true, err: true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			tp, err := newTimestampParser(&TimestampConfig{Field: "time", Layout: tt.layout, Location: tt.location})
+			require.NoError(t, err)
+
+			ts, err := tp.parse(tt.value)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.expected.Equal(ts), "expected %v, got %v", tt.expected, ts)
+		})
+	}
+}
+
+func TestSeverityParser(t *testing.T) {
+	sp := newSeverityParser(&SeverityConfig{
+		Field: "level",
+		Mapping: map[string][]string{
+			"ERROR3": {"E"},
+			"debug":  {"V", "10"},
+		},
+	})
+
+	testCases := []struct {
+		value    interface{}
+		text     string
+		expected pdata.SeverityNumber
+	}{
+		{value: "INFO", text: "INFO", expected: pdata.SeverityNumberINFO},
+		{value: "Warning", text: "Warning", expected: pdata.SeverityNumberWARN},
+		{value: "e", text: "e", expected: pdata.SeverityNumberERROR3},
+		{value: int64(10), text: "10", expected: pdata.SeverityNumberDEBUG},
+		{value: "verbose", text: "verbose", expected: pdata.SeverityNumberUNDEFINED},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.text, func(t *testing.T) {
+			text, number := sp.parse(tt.value)
+			assert.Equal(t, tt.text, text)
+			assert.Equal(t, tt.expected, number)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logparserprocessor // import "go.opentelemetry.io/collector/processor/logparserprocessor"
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+var (
+	processorTagKey = tag.MustNewKey(obsmetrics.ProcessorKey)
+	statParseErrors = stats.Int64("parse_errors", "Number of log records that could not be parsed", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metrics views related to log parsing
+func MetricViews() []*view.View {
+	countParseErrorsView := &view.View{
+		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statParseErrors.Name()),
+		Measure:     statParseErrors,
+		Description: statParseErrors.Description(),
+		TagKeys:     []tag.Key{processorTagKey},
+		Aggregation: view.Sum(),
+	}
+
+	return []*view.View{countParseErrorsView}
+}
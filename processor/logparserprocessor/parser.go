@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logparserprocessor // import "go.opentelemetry.io/collector/processor/logparserprocessor"
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/go-logfmt/logfmt"
+)
+
+// parser parses a log body into a set of named fields.
+type parser interface {
+	parse(body string) (map[string]interface{}, error)
+}
+
+func newParser(cfg *Config) (parser, error) {
+	switch cfg.Format {
+	case formatJSON:
+		return &jsonParser{}, nil
+	case formatLogfmt:
+		return &logfmtParser{}, nil
+	case formatKeyValue:
+		return &keyValueParser{
+			delimiter:     cfg.KeyValue.Delimiter,
+			pairDelimiter: cfg.KeyValue.PairDelimiter,
+		}, nil
+	case formatRegex:
+		re, err := regexp.Compile(cfg.Regex)
+		if err != nil {
+			return nil, err
+		}
+		return &regexParser{re: re}, nil
+	}
+	return nil, fmt.Errorf("unsupported format %q", cfg.Format)
+}
+
+// jsonParser parses bodies holding a JSON object.
+type jsonParser struct{}
+
+func (p *jsonParser) parse(body string) (map[string]interface{}, error) {
+	dec := json.NewDecoder(strings.NewReader(body))
+	// Keep the numbers as json.Number to preserve the integers.
+	dec.UseNumber()
+	fields := map[string]interface{}{}
+	if err := dec.Decode(&fields); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after the JSON object")
+	}
+	return normalizeJSON(fields).(map[string]interface{}), nil
+}
+
+// normalizeJSON replaces the json.Number values with int64 or float64 values.
+func normalizeJSON(v interface{}) interface{} {
+	switch tv := v.(type) {
+	case json.Number:
+		if i, err := tv.Int64(); err == nil {
+			return i
+		}
+		f, _ := tv.Float64()
+		return f
+	case map[string]interface{}:
+		for k, e := range tv {
+			tv[k] = normalizeJSON(e)
+		}
+	case []interface{}:
+		for i, e := range tv {
+			tv[i] = normalizeJSON(e)
+		}
+	}
+	return v
+}
+
+// logfmtParser parses bodies in the logfmt format, e.g. `level=info msg="hello world"`.
+type logfmtParser struct{}
+
+func (p *logfmtParser) parse(body string) (map[string]interface{}, error) {
+	dec := logfmt.NewDecoder(strings.NewReader(body))
+	fields := map[string]interface{}{}
+	for dec.ScanRecord() {
+		for dec.ScanKeyval() {
+			fields[string(dec.Key())] = string(dec.Value())
+		}
+	}
+	if err := dec.Err(); err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, errors.New("no key-value pair found")
+	}
+	return fields, nil
+}
+
+// keyValueParser parses bodies made of key-value pairs with configurable delimiters.
+type keyValueParser struct {
+	delimiter     string
+	pairDelimiter string
+}
+
+func (p *keyValueParser) parse(body string) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+	for _, pair := range strings.Split(body, p.pairDelimiter) {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		kv := strings.SplitN(pair, p.delimiter, 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid key-value pair %q", pair)
+		}
+		fields[kv[0]] = trimQuotes(kv[1])
+	}
+	if len(fields) == 0 {
+		return nil, errors.New("no key-value pair found")
+	}
+	return fields, nil
+}
+
+func trimQuotes(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// regexParser parses bodies with the named capture groups of a regular expression.
+type regexParser struct {
+	re *regexp.Regexp
+}
+
+func (p *regexParser) parse(body string) (map[string]interface{}, error) {
+	loc := p.re.FindStringSubmatchIndex(body)
+	if loc == nil {
+		return nil, errors.New("regex does not match")
+	}
+	fields := map[string]interface{}{}
+	for i, name := range p.re.SubexpNames() {
+		// Skip the unnamed and the unmatched groups.
+		if name == "" || loc[2*i] < 0 {
+			continue
+		}
+		fields[name] = body[loc[2*i]:loc[2*i+1]]
+	}
+	return fields, nil
+}
@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logparserprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsers(t *testing.T) {
+	testCases := []struct {
+		name     string
+		cfg      func(cfg *Config)
+		body     string
+		expected map[string]interface{}
+		err      bool
+	}{
+		{
+			name: "json",
+			cfg:  func(cfg *Config) {},
+			body: `{"msg":"hello","count":3,"ratio":0.5,"ok":true,"nested":{"id":1},"list":[1,"a"]}`,
+			expected: map[string]interface{}{
+				"msg":    "hello",
+				"count":  int64(3),
+				"ratio":  0.5,
+				"ok":     true,
+				"nested": map[string]interface{}{"id": int64(1)},
+				"list":   []interface{}{int64(1), "a"},
+			},
+		},
+		{
+			name: "json not an object",
+			cfg:  func(cfg *Config) {},
+			body: `["a"]`,
+			err:  true,
+		},
+		{
+			name: "json trailing data",
+			cfg:  func(cfg *Config) {},
+			body: `{"a":1} {"b":2}`,
+			err:  true,
+		},
+		{
+			name:     "logfmt",
+			cfg:      func(cfg *Config) { cfg.Format = formatLogfmt },
+			body:     `level=info msg="hello world" flag`,
+			expected: map[string]interface{}{"level": "info", "msg": "hello world", "flag": ""},
+		},
+		{
+			name: "logfmt invalid",
+			cfg:  func(cfg *Config) { cfg.Format = formatLogfmt },
+			body: `msg="unterminated`,
+			err:  true,
+		},
+		{
+			name:     "keyvalue",
+			cfg:      func(cfg *Config) { cfg.Format = formatKeyValue },
+			body:     `user=alice  action='login' url=/a?b=c`,
+			expected: map[string]interface{}{"user": "alice", "action": "login", "url": "/a?b=c"},
+		},
+		{
+			name: "keyvalue missing delimiter",
+			cfg:  func(cfg *Config) { cfg.Format = formatKeyValue },
+			body: `user=alice broken`,
+			err:  true,
+		},
+		{
+			name: "keyvalue custom delimiters",
+			cfg: func(cfg *Config) {
+				cfg.Format = formatKeyValue
+				cfg.KeyValue = KeyValueConfig{Delimiter: ":", PairDelimiter: ";"}
+			},
+			body:     `user: alice; action:"log in";`,
+			expected: map[string]interface{}{"user": " alice", "action": "log in"},
+		},
+		{
+			name: "keyvalue empty",
+			cfg:  func(cfg *Config) { cfg.Format = formatKeyValue },
+			body: `   `,
+			err:  true,
+		},
+		{
+			name: "regex",
+			cfg: func(cfg *Config) {
+				cfg.Format = formatRegex
+				cfg.Regex = `^(?P<level>\w+)( \[(?P<thread>\w+)\])? (?P<msg>.*)$`
+			},
+			body:     `INFO hello world`,
+			expected: map[string]interface{}{"level": "INFO", "msg": "hello world"},
+		},
+		{
+			name: "regex no match",
+			cfg: func(cfg *Config) {
+				cfg.Format = formatRegex
+				cfg.Regex = `^(?P<level>\d+)$`
+			},
+			body: `INFO`,
+			err:  true,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.cfg(cfg)
+			require.NoError(t, cfg.Validate())
+			p, err := newParser(cfg)
+			require.NoError(t, err)
+
+			fields, err := p.parse(tt.body)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, fields)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logparserprocessor // import "go.opentelemetry.io/collector/processor/logparserprocessor"
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+type logParserProcessor struct {
+	logger         *zap.Logger
+	parser         parser
+	parseTo        string
+	timestamp      *timestampParser
+	severity       *severityParser
+	errorAttribute string
+	statsCtx       context.Context
+}
+
+func newLogParserProcessor(logger *zap.Logger, cfg *Config) (*logParserProcessor, error) {
+	p, err := newParser(cfg)
+	if err != nil {
+		return nil, err
+	}
+	lpp := &logParserProcessor{
+		logger:         logger,
+		parser:         p,
+		parseTo:        cfg.ParseTo,
+		errorAttribute: cfg.ErrorAttribute,
+	}
+	if cfg.Timestamp != nil {
+		if lpp.timestamp, err = newTimestampParser(cfg.Timestamp); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.Severity != nil {
+		lpp.severity = newSeverityParser(cfg.Severity)
+	}
+	if lpp.statsCtx, err = tag.New(context.Background(), tag.Insert(processorTagKey, cfg.ID().String())); err != nil {
+		return nil, err
+	}
+	return lpp, nil
+}
+
+func (lpp *logParserProcessor) processLogs(_ context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		ills := rls.At(i).ScopeLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).LogRecords()
+			for k := 0; k < logs.Len(); k++ {
+				lpp.processLogRecord(logs.At(k))
+			}
+		}
+	}
+	return ld, nil
+}
+
+// processLogRecord parses the body of a log record. The log records that cannot be
+// parsed are kept unchanged, except for the error attribute.
+func (lpp *logParserProcessor) processLogRecord(lr pdata.LogRecord) {
+	body := lr.Body()
+	if body.Type() != pdata.ValueTypeString {
+		return
+	}
+
+	fields, err := lpp.parser.parse(body.StringVal())
+	if err != nil {
+		lpp.reportError(lr, err)
+		return
+	}
+
+	if lpp.timestamp != nil {
+		if v, ok := fields[lpp.timestamp.field]; ok {
+			ts, err := lpp.timestamp.parse(v)
+			if err != nil {
+				lpp.reportError(lr, err)
+			} else {
+				lr.SetTimestamp(pdata.NewTimestampFromTime(ts))
+			}
+		}
+	}
+
+	if lpp.severity != nil {
+		if v, ok := fields[lpp.severity.field]; ok {
+			text, number := lpp.severity.parse(v)
+			lr.SetSeverityText(text)
+			if number != pdata.SeverityNumberUNDEFINED {
+				lr.SetSeverityNumber(number)
+			}
+		}
+	}
+
+	parsed := pdata.NewMapFromRaw(fields).Sort()
+	switch lpp.parseTo {
+	case parseToAttributes:
+		attrs := lr.Attributes()
+		parsed.Range(func(k string, v pdata.Value) bool {
+			attrs.Upsert(k, v)
+			return true
+		})
+	case parseToBody:
+		mv := pdata.NewValueMap()
+		parsed.CopyTo(mv.MapVal())
+		mv.CopyTo(body)
+	}
+}
+
+func (lpp *logParserProcessor) reportError(lr pdata.LogRecord, err error) {
+	stats.Record(lpp.statsCtx, statParseErrors.M(1))
+	lpp.logger.Debug("Failed to parse log record", zap.Error(err))
+	if lpp.errorAttribute != "" {
+		lr.Attributes().UpsertString(lpp.errorAttribute, err.Error())
+	}
+}
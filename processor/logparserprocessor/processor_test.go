@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logparserprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func newTestLogs(bodies ...string) pdata.Logs {
+	ld := pdata.NewLogs()
+	logs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for _, body := range bodies {
+		lr := logs.AppendEmpty()
+		lr.Body().SetStringVal(body)
+		lr.Attributes().UpsertString("existing", "value")
+	}
+	return ld
+}
+
+func processTestLogs(t *testing.T, cfg *Config, ld pdata.Logs) pdata.LogRecordSlice {
+	require.NoError(t, cfg.Validate())
+	sink := new(consumertest.LogsSink)
+	lp, err := NewFactory().CreateLogsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, lp.ConsumeLogs(context.Background(), ld))
+
+	require.Len(t, sink.AllLogs(), 1)
+	return sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+}
+
+func TestProcessLogs_ParseToAttributes(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Timestamp = &TimestampConfig{Field: "ts", Layout: layoutUnixMilli}
+	cfg.Severity = &SeverityConfig{Field: "level"}
+
+	logs := processTestLogs(t, cfg, newTestLogs(`{"ts":1646370367500,"level":"WARN","msg":"disk full","existing":"replaced"}`))
+	require.Equal(t, 1, logs.Len())
+	lr := logs.At(0)
+
+	assert.Equal(t, pdata.NewValueString(`{"ts":1646370367500,"level":"WARN","msg":"disk full","existing":"replaced"}`), lr.Body())
+	assert.Equal(t, pdata.NewMapFromRaw(map[string]interface{}{
+		"existing": "replaced",
+		"level":    "WARN",
+		"msg":      "disk full",
+		"ts":       int64(1646370367500),
+	}).Sort(), lr.Attributes().Sort())
+	assert.Equal(t, time.Date(2022, 3, 4, 5, 6, 7, 500_000_000, time.UTC), lr.Timestamp().AsTime())
+	assert.Equal(t, "WARN", lr.SeverityText())
+	assert.Equal(t, pdata.SeverityNumberWARN, lr.SeverityNumber())
+}
+
+func TestProcessLogs_ParseToBody(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Format = formatLogfmt
+	cfg.ParseTo = parseToBody
+
+	logs := processTestLogs(t, cfg, newTestLogs(`level=info msg="hello world"`))
+	require.Equal(t, 1, logs.Len())
+	lr := logs.At(0)
+
+	assert.Equal(t, pdata.ValueTypeMap, lr.Body().Type())
+	assert.Equal(t, pdata.NewMapFromRaw(map[string]interface{}{
+		"level": "info",
+		"msg":   "hello world",
+	}).Sort(), lr.Body().MapVal().Sort())
+	assert.Equal(t, pdata.NewMapFromRaw(map[string]interface{}{"existing": "value"}), lr.Attributes())
+}
+
+func TestProcessLogs_ParseErrors(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Timestamp = &TimestampConfig{Field: "ts", Layout: time.RFC3339}
+
+	ld := newTestLogs(`not json`, `{"ts":"yesterday","msg":"hello"}`)
+	// Log records with a non-string body are not parsed.
+	lr := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().AppendEmpty()
+	lr.Body().SetIntVal(1)
+
+	logs := processTestLogs(t, cfg, ld)
+	require.Equal(t, 3, logs.Len())
+
+	// The body cannot be parsed, the log record is kept with the error attribute.
+	assert.Equal(t, pdata.NewValueString("not json"), logs.At(0).Body())
+	assert.Equal(t, 2, logs.At(0).Attributes().Len())
+	errAttr, ok := logs.At(0).Attributes().Get(defaultErrorAttribute)
+	require.True(t, ok)
+	assert.NotEmpty(t, errAttr.StringVal())
+
+	// The timestamp cannot be parsed, the other fields are still extracted.
+	_, ok = logs.At(1).Attributes().Get(defaultErrorAttribute)
+	assert.True(t, ok)
+	msg, ok := logs.At(1).Attributes().Get("msg")
+	require.True(t, ok)
+	assert.Equal(t, "hello", msg.StringVal())
+	assert.Equal(t, pdata.Timestamp(0), logs.At(1).Timestamp())
+
+	assert.Equal(t, pdata.NewValueInt(1), logs.At(2).Body())
+	assert.Equal(t, 0, logs.At(2).Attributes().Len())
+}
+
+func TestProcessLogs_NoErrorAttribute(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ErrorAttribute = ""
+
+	logs := processTestLogs(t, cfg, newTestLogs(`not json`))
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, pdata.NewMapFromRaw(map[string]interface{}{"existing": "value"}), logs.At(0).Attributes())
+}
@@ -47,11 +47,33 @@ type Config struct {
 	// MemorySpikePercentage is the maximum, in percents against the total memory,
 	// spike expected between the measurements of memory usage.
 	MemorySpikePercentage uint32 `mapstructure:"spike_limit_percentage"`
+
+	// RetryDelay is the delay suggested to the preceding components, and through
+	// the receivers to the clients, before retrying data that was refused due to
+	// high memory usage. Defaults to CheckInterval, the earliest time at which the
+	// memory usage is measured again.
+	RetryDelay time.Duration `mapstructure:"retry_delay"`
+
+	// UseGoMemoryLimit sets the Go runtime soft memory limit to the hard limit
+	// (MemoryLimitMiB or MemoryLimitPercentage of the total memory, which honors the
+	// cgroups limits), instead of forcing GCs when the limits are exceeded. With this
+	// option the ballast extension is not needed. Requires go1.19 or newer.
+	UseGoMemoryLimit bool `mapstructure:"use_go_memory_limit"`
 }
 
 var _ config.Processor = (*Config)(nil)
 
 // Validate checks if the processor configuration is valid
 func (cfg *Config) Validate() error {
+	if cfg.RetryDelay < 0 {
+		return errRetryDelayOutOfRange
+	}
 	return nil
 }
+
+func (cfg *Config) retryDelay() time.Duration {
+	if cfg.RetryDelay == 0 {
+		return cfg.CheckInterval
+	}
+	return cfg.RetryDelay
+}
@@ -52,5 +52,24 @@ func TestLoadConfig(t *testing.T) {
 			CheckInterval:       5 * time.Second,
 			MemoryLimitMiB:      4000,
 			MemorySpikeLimitMiB: 500,
+			RetryDelay:          10 * time.Second,
 		})
+
+	p2 := cfg.Processors[config.NewComponentIDWithName(typeStr, "go-memory-limit")]
+	assert.Equal(t, p2,
+		&Config{
+			ProcessorSettings:     config.NewProcessorSettings(config.NewComponentIDWithName(typeStr, "go-memory-limit")),
+			CheckInterval:         time.Second,
+			MemoryLimitPercentage: 80,
+			MemorySpikePercentage: 20,
+			UseGoMemoryLimit:      true,
+		})
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.RetryDelay = -time.Second
+	assert.Equal(t, errRetryDelayOutOfRange, cfg.Validate())
 }
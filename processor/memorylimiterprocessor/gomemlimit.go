@@ -0,0 +1,28 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.19
+// +build go1.19
+
+package memorylimiterprocessor // import "go.opentelemetry.io/collector/processor/memorylimiterprocessor"
+
+import "runtime/debug"
+
+// goMemoryLimitSupported indicates whether the Go runtime supports a soft memory limit.
+const goMemoryLimitSupported = true
+
+// setGoMemoryLimit sets the Go runtime soft memory limit and returns the previous one.
+func setGoMemoryLimit(limit int64) int64 {
+	return debug.SetMemoryLimit(limit)
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"runtime"
 	"sync"
 	"sync/atomic"
@@ -26,6 +27,7 @@ import (
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/extension/ballastextension"
 	"go.opentelemetry.io/collector/internal/iruntime"
 	"go.opentelemetry.io/collector/model/pdata"
@@ -37,8 +39,8 @@ const (
 )
 
 var (
-	// errForcedDrop will be returned to callers of ConsumeTraceData to indicate
-	// that data is being dropped due to high memory usage.
+	// errForcedDrop will be returned, wrapped as a throttle error, to callers of
+	// ConsumeTraceData to indicate that data is being dropped due to high memory usage.
 	errForcedDrop = errors.New("data dropped due to high memory usage")
 
 	// Construction errors
@@ -55,10 +57,19 @@ var (
 	errPercentageLimitOutOfRange = errors.New(
 		"memoryLimitPercentage and memorySpikePercentage must be greater than zero and less than or equal to hundred",
 	)
+
+	errRetryDelayOutOfRange = errors.New(
+		"retryDelay must not be negative")
+
+	errGoMemoryLimitUnsupported = errors.New(
+		"useGoMemoryLimit requires a Go runtime with soft memory limit support (go1.19 or newer)")
 )
 
 // make it overridable by tests
-var getMemoryFn = iruntime.TotalMemory
+var (
+	getMemoryFn      = iruntime.TotalMemory
+	setMemoryLimitFn = setGoMemoryLimit
+)
 
 type memoryLimiter struct {
 	usageChecker memUsageChecker
@@ -66,6 +77,15 @@ type memoryLimiter struct {
 	memCheckWait time.Duration
 	ballastSize  uint64
 
+	// retryDelay is the delay suggested to the callers when data is refused.
+	retryDelay time.Duration
+
+	// useGoMemoryLimit indicates that the Go runtime soft memory limit is set to
+	// the hard limit, in which case the runtime is responsible for the GCs.
+	useGoMemoryLimit bool
+	// prevGoMemoryLimit is the soft memory limit restored on shutdown.
+	prevGoMemoryLimit int64
+
 	// forceDrop is used atomically to indicate when data should be dropped.
 	forceDrop int64
 
@@ -99,6 +119,9 @@ func newMemoryLimiter(set component.ProcessorCreateSettings, cfg *Config) (*memo
 	if cfg.MemoryLimitMiB == 0 && cfg.MemoryLimitPercentage == 0 {
 		return nil, errLimitOutOfRange
 	}
+	if cfg.UseGoMemoryLimit && !goMemoryLimitSupported {
+		return nil, errGoMemoryLimitUnsupported
+	}
 
 	logger := set.Logger
 	usageChecker, err := getMemUsageChecker(cfg, logger)
@@ -109,14 +132,17 @@ func newMemoryLimiter(set component.ProcessorCreateSettings, cfg *Config) (*memo
 	logger.Info("Memory limiter configured",
 		zap.Uint64("limit_mib", usageChecker.memAllocLimit/mibBytes),
 		zap.Uint64("spike_limit_mib", usageChecker.memSpikeLimit/mibBytes),
-		zap.Duration("check_interval", cfg.CheckInterval))
+		zap.Duration("check_interval", cfg.CheckInterval),
+		zap.Bool("use_go_memory_limit", cfg.UseGoMemoryLimit))
 
 	ml := &memoryLimiter{
-		usageChecker:   *usageChecker,
-		memCheckWait:   cfg.CheckInterval,
-		ticker:         time.NewTicker(cfg.CheckInterval),
-		readMemStatsFn: runtime.ReadMemStats,
-		logger:         logger,
+		usageChecker:     *usageChecker,
+		memCheckWait:     cfg.CheckInterval,
+		retryDelay:       cfg.retryDelay(),
+		useGoMemoryLimit: cfg.UseGoMemoryLimit,
+		ticker:           time.NewTicker(cfg.CheckInterval),
+		readMemStatsFn:   runtime.ReadMemStats,
+		logger:           logger,
 		obsrep: obsreport.NewProcessor(obsreport.ProcessorSettings{
 			Level:                   set.MetricsLevel,
 			ProcessorID:             cfg.ID(),
@@ -149,6 +175,9 @@ func (ml *memoryLimiter) start(_ context.Context, host component.Host) error {
 	for _, extension := range extensions {
 		if ext, ok := extension.(*ballastextension.MemoryBallast); ok {
 			ml.ballastSize = ext.GetBallastSize()
+			if ml.useGoMemoryLimit {
+				ml.logger.Warn(`The ballast extension is not needed when "use_go_memory_limit" is enabled.`)
+			}
 			break
 		}
 	}
@@ -164,6 +193,9 @@ func (ml *memoryLimiter) shutdown(context.Context) error {
 		return fmt.Errorf("no existing monitoring routine is running")
 	} else if ml.refCounter == 1 {
 		ml.ticker.Stop()
+		if ml.useGoMemoryLimit {
+			setMemoryLimitFn(ml.prevGoMemoryLimit)
+		}
 	}
 	ml.refCounter--
 	return nil
@@ -179,7 +211,7 @@ func (ml *memoryLimiter) processTraces(ctx context.Context, td pdata.Traces) (pd
 		// 	callstack.
 		ml.obsrep.TracesRefused(ctx, numSpans)
 
-		return td, errForcedDrop
+		return td, ml.throttleErr()
 	}
 
 	// Even if the next consumer returns error record the data as accepted by
@@ -197,7 +229,7 @@ func (ml *memoryLimiter) processMetrics(ctx context.Context, md pdata.Metrics) (
 		// 	assumes that the pipeline is properly configured and a receiver is on the
 		// 	callstack.
 		ml.obsrep.MetricsRefused(ctx, numDataPoints)
-		return md, errForcedDrop
+		return md, ml.throttleErr()
 	}
 
 	// Even if the next consumer returns error record the data as accepted by
@@ -216,7 +248,7 @@ func (ml *memoryLimiter) processLogs(ctx context.Context, ld pdata.Logs) (pdata.
 		// 	callstack.
 		ml.obsrep.LogsRefused(ctx, numRecords)
 
-		return ld, errForcedDrop
+		return ld, ml.throttleErr()
 	}
 
 	// Even if the next consumer returns error record the data as accepted by
@@ -249,6 +281,9 @@ func (ml *memoryLimiter) startMonitoring() {
 
 	ml.refCounter++
 	if ml.refCounter == 1 {
+		if ml.useGoMemoryLimit {
+			ml.setGoMemoryLimit()
+		}
 		go func() {
 			for range ml.ticker.C {
 				ml.checkMemLimits()
@@ -257,6 +292,24 @@ func (ml *memoryLimiter) startMonitoring() {
 	}
 }
 
+// throttleErr returns the error for refused data, carrying the delay after
+// which callers are suggested to retry.
+func (ml *memoryLimiter) throttleErr() error {
+	return consumererror.NewThrottle(errForcedDrop, ml.retryDelay)
+}
+
+// setGoMemoryLimit sets the Go runtime soft memory limit to the hard limit, so that
+// the runtime collects garbage as needed to stay below it. The ballast, if any, is
+// accounted in the heap, so it is added to the limit.
+func (ml *memoryLimiter) setGoMemoryLimit() {
+	limit := ml.usageChecker.memAllocLimit + ml.ballastSize
+	if limit > math.MaxInt64 {
+		limit = math.MaxInt64
+	}
+	ml.prevGoMemoryLimit = setMemoryLimitFn(int64(limit))
+	ml.logger.Info("Go runtime soft memory limit set", zap.Uint64("limit_mib", limit/mibBytes))
+}
+
 // forcingDrop indicates when memory resources need to be released.
 func (ml *memoryLimiter) forcingDrop() bool {
 	return atomic.LoadInt64(&ml.forceDrop) != 0
@@ -287,7 +340,9 @@ func (ml *memoryLimiter) checkMemLimits() {
 
 	ml.logger.Debug("Currently used memory.", memstatToZapField(ms))
 
-	if ml.usageChecker.aboveHardLimit(ms) {
+	// When the Go runtime soft memory limit is used the runtime is responsible
+	// for collecting garbage to stay below the hard limit, no need to force GCs.
+	if !ml.useGoMemoryLimit && ml.usageChecker.aboveHardLimit(ms) {
 		ml.logger.Warn("Memory usage is above hard limit. Forcing a GC.", memstatToZapField(ms))
 		ms = ml.doGCandReadMemStats()
 	}
@@ -306,7 +361,7 @@ func (ml *memoryLimiter) checkMemLimits() {
 	if !wasForcingDrop && mustForceDrop {
 		// We are above soft limit, do a GC if it wasn't done recently and see if
 		// it brings memory usage below the soft limit.
-		if time.Since(ml.lastGCDone) > minGCIntervalWhenSoftLimited {
+		if !ml.useGoMemoryLimit && time.Since(ml.lastGCDone) > minGCIntervalWhenSoftLimited {
 			ml.logger.Info("Memory usage is above soft limit. Forcing a GC.", memstatToZapField(ms))
 			ms = ml.doGCandReadMemStats()
 			// Check the limit again to see if GC helped.
@@ -16,6 +16,7 @@ package memorylimiterprocessor
 
 import (
 	"context"
+	"math"
 	"runtime"
 	"testing"
 	"time"
@@ -28,6 +29,7 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/extension/ballastextension"
 	"go.opentelemetry.io/collector/internal/iruntime"
@@ -141,7 +143,7 @@ func TestMetricsMemoryPressureResponse(t *testing.T) {
 	// Above memAllocLimit.
 	currentMemAlloc = 1800
 	ml.checkMemLimits()
-	assert.Equal(t, errForcedDrop, mp.ConsumeMetrics(ctx, md))
+	assert.ErrorIs(t, mp.ConsumeMetrics(ctx, md), errForcedDrop)
 
 	// Check ballast effect
 	ml.ballastSize = 1000
@@ -154,7 +156,7 @@ func TestMetricsMemoryPressureResponse(t *testing.T) {
 	// Above memAllocLimit even accountiing for ballast.
 	currentMemAlloc = 1800 + ml.ballastSize
 	ml.checkMemLimits()
-	assert.Equal(t, errForcedDrop, mp.ConsumeMetrics(ctx, md))
+	assert.ErrorIs(t, mp.ConsumeMetrics(ctx, md), errForcedDrop)
 
 	// Restore ballast to default.
 	ml.ballastSize = 0
@@ -170,7 +172,7 @@ func TestMetricsMemoryPressureResponse(t *testing.T) {
 	// Above memSpikeLimit.
 	currentMemAlloc = 550
 	ml.checkMemLimits()
-	assert.Equal(t, errForcedDrop, mp.ConsumeMetrics(ctx, md))
+	assert.ErrorIs(t, mp.ConsumeMetrics(ctx, md), errForcedDrop)
 
 }
 
@@ -212,7 +214,7 @@ func TestTraceMemoryPressureResponse(t *testing.T) {
 	// Above memAllocLimit.
 	currentMemAlloc = 1800
 	ml.checkMemLimits()
-	assert.Equal(t, errForcedDrop, tp.ConsumeTraces(ctx, td))
+	assert.ErrorIs(t, tp.ConsumeTraces(ctx, td), errForcedDrop)
 
 	// Check ballast effect
 	ml.ballastSize = 1000
@@ -225,7 +227,7 @@ func TestTraceMemoryPressureResponse(t *testing.T) {
 	// Above memAllocLimit even accountiing for ballast.
 	currentMemAlloc = 1800 + ml.ballastSize
 	ml.checkMemLimits()
-	assert.Equal(t, errForcedDrop, tp.ConsumeTraces(ctx, td))
+	assert.ErrorIs(t, tp.ConsumeTraces(ctx, td), errForcedDrop)
 
 	// Restore ballast to default.
 	ml.ballastSize = 0
@@ -241,7 +243,7 @@ func TestTraceMemoryPressureResponse(t *testing.T) {
 	// Above memSpikeLimit.
 	currentMemAlloc = 550
 	ml.checkMemLimits()
-	assert.Equal(t, errForcedDrop, tp.ConsumeTraces(ctx, td))
+	assert.ErrorIs(t, tp.ConsumeTraces(ctx, td), errForcedDrop)
 
 }
 
@@ -283,7 +285,7 @@ func TestLogMemoryPressureResponse(t *testing.T) {
 	// Above memAllocLimit.
 	currentMemAlloc = 1800
 	ml.checkMemLimits()
-	assert.Equal(t, errForcedDrop, lp.ConsumeLogs(ctx, ld))
+	assert.ErrorIs(t, lp.ConsumeLogs(ctx, ld), errForcedDrop)
 
 	// Check ballast effect
 	ml.ballastSize = 1000
@@ -296,7 +298,7 @@ func TestLogMemoryPressureResponse(t *testing.T) {
 	// Above memAllocLimit even accountiing for ballast.
 	currentMemAlloc = 1800 + ml.ballastSize
 	ml.checkMemLimits()
-	assert.Equal(t, errForcedDrop, lp.ConsumeLogs(ctx, ld))
+	assert.ErrorIs(t, lp.ConsumeLogs(ctx, ld), errForcedDrop)
 
 	// Restore ballast to default.
 	ml.ballastSize = 0
@@ -312,7 +314,41 @@ func TestLogMemoryPressureResponse(t *testing.T) {
 	// Above memSpikeLimit.
 	currentMemAlloc = 550
 	ml.checkMemLimits()
-	assert.Equal(t, errForcedDrop, lp.ConsumeLogs(ctx, ld))
+	assert.ErrorIs(t, lp.ConsumeLogs(ctx, ld), errForcedDrop)
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryDelay time.Duration
+		expected   time.Duration
+	}{
+		{
+			name:     "default_check_interval",
+			expected: 100 * time.Millisecond,
+		},
+		{
+			name:       "configured",
+			retryDelay: 5 * time.Second,
+			expected:   5 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.CheckInterval = 100 * time.Millisecond
+			cfg.MemoryLimitMiB = 1024
+			cfg.RetryDelay = tt.retryDelay
+			ml, err := newMemoryLimiter(componenttest.NewNopProcessorCreateSettings(), cfg)
+			require.NoError(t, err)
+			ml.setForcingDrop(true)
+
+			_, err = ml.processTraces(context.Background(), pdata.NewTraces())
+			assert.ErrorIs(t, err, errForcedDrop)
+			assert.True(t, consumererror.IsThrottle(err))
+			assert.Equal(t, tt.expected, consumererror.GetThrottleDelay(err))
+		})
+	}
 }
 
 func TestGetDecision(t *testing.T) {
@@ -449,3 +485,39 @@ func TestBallastSizeMiB(t *testing.T) {
 		})
 	}
 }
+
+func TestGoMemoryLimit(t *testing.T) {
+	if !goMemoryLimitSupported {
+		t.Skip("Go runtime soft memory limit is not supported")
+	}
+	var currentLimit int64 = math.MaxInt64
+	setMemoryLimitFn = func(limit int64) int64 {
+		prev := currentLimit
+		currentLimit = limit
+		return prev
+	}
+	defer func() {
+		setMemoryLimitFn = setGoMemoryLimit
+	}()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.CheckInterval = time.Hour
+	cfg.MemoryLimitMiB = 1024
+	cfg.UseGoMemoryLimit = true
+	ml, err := newMemoryLimiter(componenttest.NewNopProcessorCreateSettings(), cfg)
+	require.NoError(t, err)
+
+	require.NoError(t, ml.start(context.Background(), componenttest.NewNopHost()))
+	assert.Equal(t, int64(1024*mibBytes), currentLimit)
+
+	// Above the hard limit the runtime is responsible for the GCs.
+	ml.readMemStatsFn = func(ms *runtime.MemStats) {
+		ms.Alloc = 2048 * mibBytes
+	}
+	ml.checkMemLimits()
+	assert.True(t, ml.lastGCDone.IsZero())
+	assert.True(t, ml.forcingDrop())
+
+	require.NoError(t, ml.shutdown(context.Background()))
+	assert.Equal(t, int64(math.MaxInt64), currentLimit)
+}
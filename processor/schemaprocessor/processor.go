@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaprocessor // import "go.opentelemetry.io/collector/processor/schemaprocessor"
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+type schemaProcessor struct {
+	logger *zap.Logger
+
+	targetSchemaURL string
+	// translations holds the translations to the target version, by source schema URL.
+	translations map[string]*translation
+}
+
+func newSchemaProcessor(set component.ProcessorCreateSettings, cfg *Config) (*schemaProcessor, error) {
+	s, err := loadEmbeddedSchema()
+	if err != nil {
+		return nil, err
+	}
+	if s.indexOf(cfg.TargetVersion) < 0 {
+		return nil, fmt.Errorf("unknown target_version %q", cfg.TargetVersion)
+	}
+
+	sp := &schemaProcessor{
+		logger:          set.Logger,
+		targetSchemaURL: schemaFamily + cfg.TargetVersion,
+		translations:    map[string]*translation{},
+	}
+	for _, name := range s.versionNames() {
+		t, _ := s.translation(name, cfg.TargetVersion)
+		sp.translations[schemaFamily+name] = t
+	}
+	return sp, nil
+}
+
+// translationFor returns the translation for data with the given schema URL, or nil
+// if the schema URL is not set or not known.
+func (sp *schemaProcessor) translationFor(schemaURL string) *translation {
+	if schemaURL == "" {
+		return nil
+	}
+	t, ok := sp.translations[schemaURL]
+	if !ok {
+		sp.logger.Debug("Unknown schema URL, data is not translated", zap.String("schema_url", schemaURL))
+	}
+	return t
+}
+
+// scopeTranslation returns the translation for the data of a scope, which is in the
+// version of the scope schema URL if set, or of the resource schema URL otherwise.
+func (sp *schemaProcessor) scopeTranslation(resourceTranslation *translation, scopeSchemaURL string) *translation {
+	if scopeSchemaURL == "" {
+		return resourceTranslation
+	}
+	return sp.translationFor(scopeSchemaURL)
+}
+
+func (sp *schemaProcessor) processTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resTranslation := sp.translationFor(rs.SchemaUrl())
+		if resTranslation != nil {
+			resTranslation.translateResource(rs.Resource())
+			rs.SetSchemaUrl(sp.targetSchemaURL)
+		}
+		ilss := rs.ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			t := sp.scopeTranslation(resTranslation, ils.SchemaUrl())
+			if t == nil {
+				continue
+			}
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				t.translateSpan(spans.At(k))
+			}
+			if ils.SchemaUrl() != "" {
+				ils.SetSchemaUrl(sp.targetSchemaURL)
+			}
+		}
+	}
+	return td, nil
+}
+
+func (sp *schemaProcessor) processLogs(_ context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resTranslation := sp.translationFor(rl.SchemaUrl())
+		if resTranslation != nil {
+			resTranslation.translateResource(rl.Resource())
+			rl.SetSchemaUrl(sp.targetSchemaURL)
+		}
+		ills := rl.ScopeLogs()
+		for j := 0; j < ills.Len(); j++ {
+			ill := ills.At(j)
+			t := sp.scopeTranslation(resTranslation, ill.SchemaUrl())
+			if t == nil {
+				continue
+			}
+			logs := ill.LogRecords()
+			for k := 0; k < logs.Len(); k++ {
+				t.translateLogRecord(logs.At(k))
+			}
+			if ill.SchemaUrl() != "" {
+				ill.SetSchemaUrl(sp.targetSchemaURL)
+			}
+		}
+	}
+	return ld, nil
+}
+
+func (sp *schemaProcessor) processMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resTranslation := sp.translationFor(rm.SchemaUrl())
+		if resTranslation != nil {
+			resTranslation.translateResource(rm.Resource())
+			rm.SetSchemaUrl(sp.targetSchemaURL)
+		}
+		ilms := rm.ScopeMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			ilm := ilms.At(j)
+			t := sp.scopeTranslation(resTranslation, ilm.SchemaUrl())
+			if t == nil {
+				continue
+			}
+			metrics := ilm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				t.translateMetric(metrics.At(k))
+			}
+			if ilm.SchemaUrl() != "" {
+				ilm.SetSchemaUrl(sp.targetSchemaURL)
+			}
+		}
+	}
+	return md, nil
+}
@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/model/pdata"
+	semconv170 "go.opentelemetry.io/collector/model/semconv/v1.7.0"
+	semconv190 "go.opentelemetry.io/collector/model/semconv/v1.9.0"
+)
+
+func newTestProcessor(t *testing.T, targetVersion string) *schemaProcessor {
+	cfg := createDefaultConfig().(*Config)
+	cfg.TargetVersion = targetVersion
+	sp, err := newSchemaProcessor(componenttest.NewNopProcessorCreateSettings(), cfg)
+	require.NoError(t, err)
+	return sp
+}
+
+func TestProcessTraces_Upgrade(t *testing.T) {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.SetSchemaUrl(semconv170.SchemaURL)
+	ss := rs.ScopeSpans().AppendEmpty()
+	span := ss.Spans().AppendEmpty()
+	span.Attributes().InsertString("db.cassandra.keyspace", "users")
+
+	// Scope with its own schema URL, already in the target version.
+	ss = rs.ScopeSpans().AppendEmpty()
+	ss.SetSchemaUrl(semconv190.SchemaURL)
+	ss.Spans().AppendEmpty().Attributes().InsertString("db.cassandra.keyspace", "users")
+
+	td, err := newTestProcessor(t, "1.9.0").processTraces(context.Background(), td)
+	require.NoError(t, err)
+
+	rs = td.ResourceSpans().At(0)
+	assert.Equal(t, semconv190.SchemaURL, rs.SchemaUrl())
+	assert.Equal(t, "", rs.ScopeSpans().At(0).SchemaUrl())
+	assert.Equal(t, map[string]interface{}{"db.name": "users"}, rs.ScopeSpans().At(0).Spans().At(0).Attributes().AsRaw())
+	assert.Equal(t, semconv190.SchemaURL, rs.ScopeSpans().At(1).SchemaUrl())
+	assert.Equal(t, map[string]interface{}{"db.cassandra.keyspace": "users"}, rs.ScopeSpans().At(1).Spans().At(0).Attributes().AsRaw())
+}
+
+func TestProcessTraces_Downgrade(t *testing.T) {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.SetSchemaUrl(semconv190.SchemaURL)
+	rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().Attributes().InsertString("db.name", "users")
+
+	td, err := newTestProcessor(t, "1.7.0").processTraces(context.Background(), td)
+	require.NoError(t, err)
+
+	rs = td.ResourceSpans().At(0)
+	assert.Equal(t, semconv170.SchemaURL, rs.SchemaUrl())
+	// Multiple attributes were renamed to "db.name", the rename cannot be reverted.
+	assert.Equal(t, map[string]interface{}{"db.name": "users"}, rs.ScopeSpans().At(0).Spans().At(0).Attributes().AsRaw())
+}
+
+func TestProcessTraces_UnknownSchema(t *testing.T) {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.SetSchemaUrl("https://example.com/schemas/1.0.0")
+	rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().Attributes().InsertString("db.cassandra.keyspace", "users")
+	// Data without schema URL.
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().Attributes().InsertString("db.cassandra.keyspace", "users")
+
+	expected := td.Clone()
+	td, err := newTestProcessor(t, "1.9.0").processTraces(context.Background(), td)
+	require.NoError(t, err)
+	assert.Equal(t, expected, td)
+}
+
+func TestProcessLogs(t *testing.T) {
+	ld := pdata.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.SetSchemaUrl(semconv170.SchemaURL)
+	rl.Resource().Attributes().InsertString("host.name", "host")
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.LogRecords().AppendEmpty().Attributes().InsertString("key", "value")
+
+	ld, err := newTestProcessor(t, "1.9.0").processLogs(context.Background(), ld)
+	require.NoError(t, err)
+	rl = ld.ResourceLogs().At(0)
+	assert.Equal(t, semconv190.SchemaURL, rl.SchemaUrl())
+	assert.Equal(t, map[string]interface{}{"host.name": "host"}, rl.Resource().Attributes().AsRaw())
+	assert.Equal(t, 1, ld.LogRecordCount())
+}
+
+func TestProcessMetrics(t *testing.T) {
+	md := pdata.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.SetSchemaUrl(semconv170.SchemaURL)
+	m := sm.Metrics().AppendEmpty()
+	m.SetName("requests")
+	m.SetDataType(pdata.MetricDataTypeSum)
+	m.Sum().DataPoints().AppendEmpty().SetIntVal(1)
+
+	md, err := newTestProcessor(t, "1.9.0").processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	rm = md.ResourceMetrics().At(0)
+	// Only the scope has a schema URL.
+	assert.Equal(t, "", rm.SchemaUrl())
+	assert.Equal(t, semconv190.SchemaURL, rm.ScopeMetrics().At(0).SchemaUrl())
+	assert.Equal(t, "requests", rm.ScopeMetrics().At(0).Metrics().At(0).Name())
+}
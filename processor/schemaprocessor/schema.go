@@ -0,0 +1,226 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaprocessor // import "go.opentelemetry.io/collector/processor/schemaprocessor"
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// schemaFamily is the prefix of the schema URLs of the OpenTelemetry semantic conventions.
+const schemaFamily = "https://opentelemetry.io/schemas/"
+
+//go:embed schemas
+var schemaFiles embed.FS
+
+// schemaFile is the representation of a schema file, see
+// https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/schemas/file_format_v1.0.0.md
+type schemaFile struct {
+	FileFormat string                     `yaml:"file_format"`
+	SchemaURL  string                     `yaml:"schema_url"`
+	Versions   map[string]*versionChanges `yaml:"versions"`
+}
+
+// versionChanges are the transformations to apply to convert data from the previous
+// version to this version.
+type versionChanges struct {
+	All        changesSection `yaml:"all"`
+	Resources  changesSection `yaml:"resources"`
+	Spans      changesSection `yaml:"spans"`
+	SpanEvents changesSection `yaml:"span_events"`
+	Metrics    changesSection `yaml:"metrics"`
+	Logs       changesSection `yaml:"logs"`
+}
+
+type changesSection struct {
+	Changes []change `yaml:"changes"`
+}
+
+// change is a single transformation, only one of its fields is set. Depending on
+// the section only some of the transformations are allowed.
+type change struct {
+	RenameAttributes *renameAttributesChange `yaml:"rename_attributes"`
+	RenameEvents     *renameEventsChange     `yaml:"rename_events"`
+	RenameMetrics    map[string]string       `yaml:"rename_metrics"`
+}
+
+type renameAttributesChange struct {
+	AttributeMap   map[string]string `yaml:"attribute_map"`
+	ApplyToSpans   []string          `yaml:"apply_to_spans"`
+	ApplyToEvents  []string          `yaml:"apply_to_events"`
+	ApplyToMetrics []string          `yaml:"apply_to_metrics"`
+}
+
+type renameEventsChange struct {
+	NameMap map[string]string `yaml:"name_map"`
+}
+
+// version is a semantic conventions version, e.g. 1.6.1.
+type version [3]int
+
+func parseVersion(s string) (version, error) {
+	var v version
+	parts := strings.Split(s, ".")
+	if len(parts) > len(v) {
+		return v, fmt.Errorf("invalid schema version %q", s)
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return v, fmt.Errorf("invalid schema version %q", s)
+		}
+		v[i] = n
+	}
+	return v, nil
+}
+
+func (v version) less(o version) bool {
+	for i := range v {
+		if v[i] != o[i] {
+			return v[i] < o[i]
+		}
+	}
+	return false
+}
+
+// versionFromSchemaURL returns the version of a schema URL of the OpenTelemetry
+// semantic conventions, or false if the URL is from another schema family.
+func versionFromSchemaURL(schemaURL string) (string, bool) {
+	if !strings.HasPrefix(schemaURL, schemaFamily) {
+		return "", false
+	}
+	return strings.TrimPrefix(schemaURL, schemaFamily), true
+}
+
+// knownVersion is a version with its changes, ordered in the schema from the oldest
+// to the newest.
+type knownVersion struct {
+	name    string
+	version version
+	changes *versionChanges
+}
+
+// schema holds the changes of all the known versions of the semantic conventions.
+type schema struct {
+	versions []knownVersion
+}
+
+// loadEmbeddedSchema merges all the embedded schema files.
+func loadEmbeddedSchema() (*schema, error) {
+	entries, err := schemaFiles.ReadDir("schemas")
+	if err != nil {
+		return nil, err
+	}
+	files := make([][]byte, 0, len(entries))
+	for _, entry := range entries {
+		buf, err := schemaFiles.ReadFile(path.Join("schemas", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, buf)
+	}
+	return newSchema(files...)
+}
+
+// newSchema parses and merges the given schema files. A version present in multiple
+// files must have the same changes in all of them, the first definition is used.
+func newSchema(files ...[]byte) (*schema, error) {
+	known := map[string]knownVersion{}
+	for _, buf := range files {
+		sf := &schemaFile{}
+		if err := yaml.UnmarshalStrict(buf, sf); err != nil {
+			return nil, fmt.Errorf("failed to parse schema file: %w", err)
+		}
+		if !strings.HasPrefix(sf.FileFormat, "1.") {
+			return nil, fmt.Errorf("unsupported schema file format %q", sf.FileFormat)
+		}
+		if _, ok := versionFromSchemaURL(sf.SchemaURL); !ok {
+			return nil, fmt.Errorf("unsupported schema family %q", sf.SchemaURL)
+		}
+		for name, changes := range sf.Versions {
+			if _, ok := known[name]; ok {
+				continue
+			}
+			v, err := parseVersion(name)
+			if err != nil {
+				return nil, err
+			}
+			if changes == nil {
+				changes = &versionChanges{}
+			}
+			known[name] = knownVersion{name: name, version: v, changes: changes}
+		}
+	}
+
+	s := &schema{versions: make([]knownVersion, 0, len(known))}
+	for _, kv := range known {
+		s.versions = append(s.versions, kv)
+	}
+	sort.Slice(s.versions, func(i, j int) bool {
+		return s.versions[i].version.less(s.versions[j].version)
+	})
+	return s, nil
+}
+
+func (s *schema) indexOf(name string) int {
+	for i, kv := range s.versions {
+		if kv.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// versionNames returns the names of the known versions, from the oldest to the newest.
+func (s *schema) versionNames() []string {
+	names := make([]string, 0, len(s.versions))
+	for _, kv := range s.versions {
+		names = append(names, kv.name)
+	}
+	return names
+}
+
+// latestVersion returns the newest known version.
+func (s *schema) latestVersion() string {
+	if len(s.versions) == 0 {
+		return ""
+	}
+	return s.versions[len(s.versions)-1].name
+}
+
+// translation returns the translation from the given version to the target version,
+// or false if any of the versions is unknown.
+func (s *schema) translation(from, to string) (*translation, bool) {
+	fromIdx, toIdx := s.indexOf(from), s.indexOf(to)
+	if fromIdx < 0 || toIdx < 0 {
+		return nil, false
+	}
+	t := &translation{}
+	// Upgrade by applying the changes of the newer versions, from the oldest to the newest.
+	for i := fromIdx + 1; i <= toIdx; i++ {
+		t.steps = append(t.steps, newTranslationStep(s.versions[i].changes, false))
+	}
+	// Downgrade by reverting the changes of the newer versions, from the newest to the oldest.
+	for i := fromIdx; i > toIdx; i-- {
+		t.steps = append(t.steps, newTranslationStep(s.versions[i].changes, true))
+	}
+	return t, true
+}
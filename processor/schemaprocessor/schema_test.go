@@ -0,0 +1,213 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+var testSchemaFile = []byte(`
+file_format: 1.0.0
+schema_url: https://opentelemetry.io/schemas/1.2.0
+versions:
+  1.2.0:
+    all:
+      changes:
+        - rename_attributes:
+            attribute_map:
+              old.all: new.all
+    resources:
+      changes:
+        - rename_attributes:
+            attribute_map:
+              old.resource: new.resource
+    spans:
+      changes:
+        - rename_attributes:
+            attribute_map:
+              old.span: new.span
+            apply_to_spans:
+              - GET
+    span_events:
+      changes:
+        - rename_events:
+            name_map:
+              old.event: new.event
+        - rename_attributes:
+            attribute_map:
+              old.event.attr: new.event.attr
+            apply_to_events:
+              - new.event
+    metrics:
+      changes:
+        - rename_metrics:
+            old.metric: new.metric
+        - rename_attributes:
+            attribute_map:
+              old.dp: new.dp
+            apply_to_metrics:
+              - new.metric
+    logs:
+      changes:
+        - rename_attributes:
+            attribute_map:
+              old.log: new.log
+  1.1.0:
+    spans:
+      changes:
+        - rename_attributes:
+            attribute_map:
+              a: b
+              c: b
+  1.0.0:
+`)
+
+func TestEmbeddedSchema(t *testing.T) {
+	s, err := loadEmbeddedSchema()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1.4.0", "1.5.0", "1.6.1", "1.7.0", "1.8.0", "1.9.0"}, s.versionNames())
+	assert.Equal(t, "1.9.0", s.latestVersion())
+	assert.Equal(t, defaultTargetVersion, s.latestVersion())
+}
+
+func TestNewSchema_Invalid(t *testing.T) {
+	_, err := newSchema([]byte("file_format: 2.0.0\nschema_url: https://opentelemetry.io/schemas/1.0.0\n"))
+	assert.Error(t, err)
+
+	_, err = newSchema([]byte("file_format: 1.0.0\nschema_url: https://example.com/schemas/1.0.0\n"))
+	assert.Error(t, err)
+
+	_, err = newSchema([]byte("file_format: 1.0.0\nschema_url: https://opentelemetry.io/schemas/1.0.0\nversions:\n  1.x.0:\n"))
+	assert.Error(t, err)
+
+	_, err = newSchema([]byte("file_format: 1.0.0\nunknown_field: true\n"))
+	assert.Error(t, err)
+}
+
+func TestParseVersion(t *testing.T) {
+	v1, err := parseVersion("1.6.1")
+	require.NoError(t, err)
+	assert.Equal(t, version{1, 6, 1}, v1)
+	v2, err := parseVersion("1.10")
+	require.NoError(t, err)
+	assert.True(t, v1.less(v2))
+	assert.False(t, v2.less(v1))
+	assert.False(t, v1.less(v1))
+
+	_, err = parseVersion("1.2.3.4")
+	assert.Error(t, err)
+	_, err = parseVersion("1.-2")
+	assert.Error(t, err)
+}
+
+func TestTranslation_UnknownVersion(t *testing.T) {
+	s, err := newSchema(testSchemaFile)
+	require.NoError(t, err)
+	_, ok := s.translation("0.9.0", "1.2.0")
+	assert.False(t, ok)
+	_, ok = s.translation("1.0.0", "1.3.0")
+	assert.False(t, ok)
+}
+
+func TestTranslation_Upgrade(t *testing.T) {
+	s, err := newSchema(testSchemaFile)
+	require.NoError(t, err)
+	tr, ok := s.translation("1.0.0", "1.2.0")
+	require.True(t, ok)
+
+	res := pdata.NewResource()
+	res.Attributes().InsertString("old.all", "v")
+	res.Attributes().InsertString("old.resource", "v")
+	res.Attributes().InsertString("old.span", "v")
+	tr.translateResource(res)
+	assert.Equal(t, map[string]interface{}{"new.all": "v", "new.resource": "v", "old.span": "v"}, res.Attributes().AsRaw())
+
+	span := pdata.NewSpan()
+	span.SetName("GET")
+	span.Attributes().InsertString("old.span", "v")
+	span.Attributes().InsertString("a", "v")
+	event := span.Events().AppendEmpty()
+	event.SetName("old.event")
+	event.Attributes().InsertString("old.event.attr", "v")
+	tr.translateSpan(span)
+	assert.Equal(t, map[string]interface{}{"new.span": "v", "b": "v"}, span.Attributes().AsRaw())
+	assert.Equal(t, "new.event", event.Name())
+	assert.Equal(t, map[string]interface{}{"new.event.attr": "v"}, event.Attributes().AsRaw())
+
+	// The span renames only apply to the GET spans.
+	span = pdata.NewSpan()
+	span.SetName("POST")
+	span.Attributes().InsertString("old.span", "v")
+	tr.translateSpan(span)
+	assert.Equal(t, map[string]interface{}{"old.span": "v"}, span.Attributes().AsRaw())
+
+	metric := pdata.NewMetric()
+	metric.SetName("old.metric")
+	metric.SetDataType(pdata.MetricDataTypeSum)
+	dp := metric.Sum().DataPoints().AppendEmpty()
+	dp.Attributes().InsertString("old.dp", "v")
+	dp.Attributes().InsertString("old.all", "v")
+	tr.translateMetric(metric)
+	assert.Equal(t, "new.metric", metric.Name())
+	assert.Equal(t, map[string]interface{}{"new.dp": "v", "new.all": "v"}, dp.Attributes().AsRaw())
+
+	log := pdata.NewLogRecord()
+	log.Attributes().InsertString("old.log", "v")
+	tr.translateLogRecord(log)
+	assert.Equal(t, map[string]interface{}{"new.log": "v"}, log.Attributes().AsRaw())
+}
+
+func TestTranslation_Downgrade(t *testing.T) {
+	s, err := newSchema(testSchemaFile)
+	require.NoError(t, err)
+	tr, ok := s.translation("1.2.0", "1.0.0")
+	require.True(t, ok)
+
+	span := pdata.NewSpan()
+	span.SetName("GET")
+	span.Attributes().InsertString("new.span", "v")
+	span.Attributes().InsertString("b", "v")
+	event := span.Events().AppendEmpty()
+	event.SetName("new.event")
+	event.Attributes().InsertString("new.event.attr", "v")
+	tr.translateSpan(span)
+	// The rename of "a" and "c" to "b" cannot be reverted.
+	assert.Equal(t, map[string]interface{}{"old.span": "v", "b": "v"}, span.Attributes().AsRaw())
+	assert.Equal(t, "old.event", event.Name())
+	assert.Equal(t, map[string]interface{}{"old.event.attr": "v"}, event.Attributes().AsRaw())
+
+	metric := pdata.NewMetric()
+	metric.SetName("new.metric")
+	metric.SetDataType(pdata.MetricDataTypeGauge)
+	dp := metric.Gauge().DataPoints().AppendEmpty()
+	dp.Attributes().InsertString("new.dp", "v")
+	tr.translateMetric(metric)
+	assert.Equal(t, "old.metric", metric.Name())
+	assert.Equal(t, map[string]interface{}{"old.dp": "v"}, dp.Attributes().AsRaw())
+}
+
+func TestRenameAttributes_Swap(t *testing.T) {
+	attrs := pdata.NewMap()
+	attrs.InsertString("a", "1")
+	attrs.InsertString("b", "2")
+	attrs.InsertString("c", "3")
+	renameAttributes(attrs, map[string]string{"a": "b", "b": "a"})
+	assert.Equal(t, map[string]interface{}{"a": "2", "b": "1", "c": "3"}, attrs.AsRaw())
+}
@@ -0,0 +1,249 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaprocessor // import "go.opentelemetry.io/collector/processor/schemaprocessor"
+
+import (
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// nameSet is the set of names of the spans, events or metrics a change applies to.
+// An empty set applies to all names.
+type nameSet map[string]struct{}
+
+func newNameSet(names []string) nameSet {
+	ns := make(nameSet, len(names))
+	for _, name := range names {
+		ns[name] = struct{}{}
+	}
+	return ns
+}
+
+func (ns nameSet) matches(name string) bool {
+	if len(ns) == 0 {
+		return true
+	}
+	_, ok := ns[name]
+	return ok
+}
+
+type attributesRename struct {
+	attributes map[string]string
+	spans      nameSet
+	events     nameSet
+	metrics    nameSet
+}
+
+// compiledChange is a change ready to be applied, in the direction of the translation.
+type compiledChange struct {
+	attributes *attributesRename
+	// names renames the events or the metrics, depending on the section.
+	names map[string]string
+}
+
+// translationStep holds the changes to upgrade to a version, or to revert them.
+type translationStep struct {
+	revert     bool
+	all        []compiledChange
+	resources  []compiledChange
+	spans      []compiledChange
+	spanEvents []compiledChange
+	metrics    []compiledChange
+	logs       []compiledChange
+}
+
+func newTranslationStep(vc *versionChanges, revert bool) translationStep {
+	return translationStep{
+		revert:     revert,
+		all:        compileChanges(vc.All.Changes, revert),
+		resources:  compileChanges(vc.Resources.Changes, revert),
+		spans:      compileChanges(vc.Spans.Changes, revert),
+		spanEvents: compileChanges(vc.SpanEvents.Changes, revert),
+		metrics:    compileChanges(vc.Metrics.Changes, revert),
+		logs:       compileChanges(vc.Logs.Changes, revert),
+	}
+}
+
+func compileChanges(changes []change, revert bool) []compiledChange {
+	compiled := make([]compiledChange, 0, len(changes))
+	for _, c := range changes {
+		cc := compiledChange{}
+		if ra := c.RenameAttributes; ra != nil {
+			cc.attributes = &attributesRename{
+				attributes: renameMap(ra.AttributeMap, revert),
+				spans:      newNameSet(ra.ApplyToSpans),
+				events:     newNameSet(ra.ApplyToEvents),
+				metrics:    newNameSet(ra.ApplyToMetrics),
+			}
+		}
+		if c.RenameEvents != nil {
+			cc.names = renameMap(c.RenameEvents.NameMap, revert)
+		}
+		if c.RenameMetrics != nil {
+			cc.names = renameMap(c.RenameMetrics, revert)
+		}
+		compiled = append(compiled, cc)
+	}
+	if revert {
+		for i, j := 0, len(compiled)-1; i < j; i, j = i+1, j-1 {
+			compiled[i], compiled[j] = compiled[j], compiled[i]
+		}
+	}
+	return compiled
+}
+
+// renameMap returns the renames to apply, inverted when reverting the changes. The renames
+// that cannot be reverted, because multiple names were renamed to the same name, are ignored.
+func renameMap(renames map[string]string, revert bool) map[string]string {
+	if !revert {
+		return renames
+	}
+	inverted := make(map[string]string, len(renames))
+	ambiguous := map[string]bool{}
+	for from, to := range renames {
+		if _, ok := inverted[to]; ok {
+			ambiguous[to] = true
+		}
+		inverted[to] = from
+	}
+	for name := range ambiguous {
+		delete(inverted, name)
+	}
+	return inverted
+}
+
+// sections returns the changes of the given section together with the changes that
+// apply to all the data, in the order they must be applied.
+func (s translationStep) sections(section []compiledChange) [2][]compiledChange {
+	if s.revert {
+		return [2][]compiledChange{section, s.all}
+	}
+	return [2][]compiledChange{s.all, section}
+}
+
+// renameAttributes renames the attributes, overriding the attributes with the new
+// names if they already exist.
+func renameAttributes(attrs pdata.Map, renames map[string]string) {
+	renamed := pdata.NewMap()
+	for from, to := range renames {
+		if v, ok := attrs.Get(from); ok {
+			renamed.Upsert(to, v)
+			attrs.Remove(from)
+		}
+	}
+	renamed.Range(func(k string, v pdata.Value) bool {
+		attrs.Upsert(k, v)
+		return true
+	})
+}
+
+// translation translates data from a version of the semantic conventions to another.
+type translation struct {
+	steps []translationStep
+}
+
+func (t *translation) translateResource(res pdata.Resource) {
+	for _, step := range t.steps {
+		for _, section := range step.sections(step.resources) {
+			for _, c := range section {
+				if c.attributes != nil {
+					renameAttributes(res.Attributes(), c.attributes.attributes)
+				}
+			}
+		}
+	}
+}
+
+func (t *translation) translateSpan(span pdata.Span) {
+	for _, step := range t.steps {
+		for _, section := range step.sections(step.spans) {
+			for _, c := range section {
+				if c.attributes != nil && c.attributes.spans.matches(span.Name()) {
+					renameAttributes(span.Attributes(), c.attributes.attributes)
+				}
+			}
+		}
+		events := span.Events()
+		for i := 0; i < events.Len(); i++ {
+			event := events.At(i)
+			for _, section := range step.sections(step.spanEvents) {
+				for _, c := range section {
+					if newName, ok := c.names[event.Name()]; ok {
+						event.SetName(newName)
+					}
+					if c.attributes != nil && c.attributes.spans.matches(span.Name()) && c.attributes.events.matches(event.Name()) {
+						renameAttributes(event.Attributes(), c.attributes.attributes)
+					}
+				}
+			}
+		}
+	}
+}
+
+func (t *translation) translateLogRecord(log pdata.LogRecord) {
+	for _, step := range t.steps {
+		for _, section := range step.sections(step.logs) {
+			for _, c := range section {
+				if c.attributes != nil {
+					renameAttributes(log.Attributes(), c.attributes.attributes)
+				}
+			}
+		}
+	}
+}
+
+func (t *translation) translateMetric(metric pdata.Metric) {
+	for _, step := range t.steps {
+		for _, section := range step.sections(step.metrics) {
+			for _, c := range section {
+				if newName, ok := c.names[metric.Name()]; ok {
+					metric.SetName(newName)
+				}
+				if c.attributes != nil && c.attributes.metrics.matches(metric.Name()) {
+					renameDataPointsAttributes(metric, c.attributes.attributes)
+				}
+			}
+		}
+	}
+}
+
+func renameDataPointsAttributes(metric pdata.Metric, renames map[string]string) {
+	switch metric.DataType() {
+	case pdata.MetricDataTypeGauge:
+		dps := metric.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			renameAttributes(dps.At(i).Attributes(), renames)
+		}
+	case pdata.MetricDataTypeSum:
+		dps := metric.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			renameAttributes(dps.At(i).Attributes(), renames)
+		}
+	case pdata.MetricDataTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			renameAttributes(dps.At(i).Attributes(), renames)
+		}
+	case pdata.MetricDataTypeExponentialHistogram:
+		dps := metric.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			renameAttributes(dps.At(i).Attributes(), renames)
+		}
+	case pdata.MetricDataTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			renameAttributes(dps.At(i).Attributes(), renames)
+		}
+	}
+}
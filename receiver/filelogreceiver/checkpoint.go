@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filelogreceiver // import "go.opentelemetry.io/collector/receiver/filelogreceiver"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+// knownFilesKey is the storage key of the checkpoints of the known files.
+const knownFilesKey = "knownFiles"
+
+// checkpoint is the persisted reading progress of a file.
+type checkpoint struct {
+	Fingerprint []byte `json:"fingerprint"`
+	Offset      int64  `json:"offset"`
+	// Path is the last known path of the file, for troubleshooting only.
+	Path string `json:"path"`
+}
+
+// getStorageClient returns a client of the storage extension with the given ID, or a nop
+// client if no storage is configured.
+func getStorageClient(ctx context.Context, host component.Host, storageID *config.ComponentID, receiverID config.ComponentID) (storage.Client, error) {
+	if storageID == nil {
+		return storage.NewNopClient(), nil
+	}
+	ext, ok := host.GetExtensions()[*storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension %q not found", storageID)
+	}
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("extension %q is not a storage extension", storageID)
+	}
+	return storageExt.GetClient(ctx, component.KindReceiver, receiverID, "")
+}
+
+// loadCheckpoints returns readers, without an opened file, for the files known from the storage.
+func loadCheckpoints(ctx context.Context, client storage.Client) ([]*fileReader, error) {
+	data, err := client.Get(ctx, knownFilesKey)
+	if err != nil || data == nil {
+		return nil, err
+	}
+	var checkpoints []checkpoint
+	if err = json.Unmarshal(data, &checkpoints); err != nil {
+		return nil, fmt.Errorf("invalid checkpoints: %w", err)
+	}
+	readers := make([]*fileReader, 0, len(checkpoints))
+	for _, cp := range checkpoints {
+		readers = append(readers, &fileReader{
+			path:        cp.Path,
+			fingerprint: cp.Fingerprint,
+			offset:      cp.Offset,
+		})
+	}
+	return readers, nil
+}
+
+// saveCheckpoints persists the reading progress of the readers in the storage.
+func saveCheckpoints(ctx context.Context, client storage.Client, readers []*fileReader) error {
+	checkpoints := make([]checkpoint, 0, len(readers))
+	for _, fr := range readers {
+		checkpoints = append(checkpoints, checkpoint{
+			Fingerprint: fr.fingerprint,
+			Offset:      fr.offset,
+			Path:        fr.path,
+		})
+	}
+	data, err := json.Marshal(checkpoints)
+	if err != nil {
+		return err
+	}
+	return client.Set(ctx, knownFilesKey, data)
+}
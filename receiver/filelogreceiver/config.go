@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filelogreceiver // import "go.opentelemetry.io/collector/receiver/filelogreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+const (
+	startAtBeginning = "beginning"
+	startAtEnd       = "end"
+)
+
+// Config defines configuration for the filelog receiver.
+type Config struct {
+	config.ReceiverSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Include is the list of glob patterns of the files to read.
+	Include []string `mapstructure:"include"`
+
+	// Exclude is the list of glob patterns of the files to ignore among the included files.
+	Exclude []string `mapstructure:"exclude"`
+
+	// StartAt defines where to start reading the files found at startup, either
+	// "beginning" or "end". The files found later are always read from the beginning.
+	// It does not apply to the files with a checkpoint in the storage.
+	StartAt string `mapstructure:"start_at"`
+
+	// PollInterval is the interval between the checks for new files and new data.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+
+	// FingerprintSize is the number of bytes from the beginning of the files used to
+	// identify them across renames and truncations.
+	FingerprintSize int `mapstructure:"fingerprint_size"`
+
+	// MaxLogSize is the maximum size in bytes of a log entry. Longer entries are split.
+	MaxLogSize int `mapstructure:"max_log_size"`
+
+	// Encoding of the files, one of "utf-8", "utf-16le", "utf-16be", "ascii", "latin1" or "nop".
+	// With "nop", the bytes of the entries are not decoded.
+	Encoding string `mapstructure:"encoding"`
+
+	// Multiline groups several lines into a single log entry. By default, each line is an entry.
+	Multiline *MultilineConfig `mapstructure:"multiline"`
+
+	// ForceFlushPeriod is the duration after which an incomplete entry at the end of a
+	// file is emitted if no new data is written to the file.
+	ForceFlushPeriod time.Duration `mapstructure:"force_flush_period"`
+
+	// IncludeFileName adds the name of the file as the "log.file.name" attribute.
+	IncludeFileName bool `mapstructure:"include_file_name"`
+
+	// IncludeFilePath adds the path of the file as the "log.file.path" attribute.
+	IncludeFilePath bool `mapstructure:"include_file_path"`
+
+	// Storage is the ID of the storage extension used to checkpoint the file offsets,
+	// so that the files are read from where they stopped after a restart.
+	// Without storage, the offsets are only kept in memory.
+	Storage *config.ComponentID `mapstructure:"storage"`
+}
+
+// MultilineConfig defines how the lines are grouped into log entries.
+// Exactly one of the patterns must be set.
+type MultilineConfig struct {
+	// LineStartPattern is a regular expression matching the first line of the entries.
+	LineStartPattern string `mapstructure:"line_start_pattern"`
+
+	// LineEndPattern is a regular expression matching the last line of the entries.
+	LineEndPattern string `mapstructure:"line_end_pattern"`
+}
+
+var _ config.Receiver = (*Config)(nil)
+
+// Validate checks the receiver configuration is valid
+func (cfg *Config) Validate() error {
+	if len(cfg.Include) == 0 {
+		return errors.New("at least one include pattern must be specified")
+	}
+	for _, pattern := range append(append([]string{}, cfg.Include...), cfg.Exclude...) {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid glob pattern %q: %w", pattern, err)
+		}
+	}
+
+	switch cfg.StartAt {
+	case startAtBeginning, startAtEnd:
+	default:
+		return fmt.Errorf("invalid start_at %q, must be %q or %q", cfg.StartAt, startAtBeginning, startAtEnd)
+	}
+
+	if cfg.PollInterval <= 0 {
+		return errors.New("poll_interval must be positive")
+	}
+	if cfg.FingerprintSize < minFingerprintSize {
+		return fmt.Errorf("fingerprint_size must be at least %d", minFingerprintSize)
+	}
+	if cfg.MaxLogSize <= 0 {
+		return errors.New("max_log_size must be positive")
+	}
+	if cfg.ForceFlushPeriod < 0 {
+		return errors.New("force_flush_period must not be negative")
+	}
+
+	if _, err := lookupEncoding(cfg.Encoding); err != nil {
+		return err
+	}
+
+	if cfg.Multiline != nil {
+		if (cfg.Multiline.LineStartPattern == "") == (cfg.Multiline.LineEndPattern == "") {
+			return errors.New("exactly one of line_start_pattern and line_end_pattern must be specified")
+		}
+		if _, err := regexp.Compile(cfg.Multiline.LineStartPattern + cfg.Multiline.LineEndPattern); err != nil {
+			return fmt.Errorf("invalid multiline pattern: %w", err)
+		}
+	}
+	return nil
+}
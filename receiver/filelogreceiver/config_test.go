@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filelogreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/service/servicetest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := servicetest.LoadConfigAndValidate(filepath.Join("testdata", "config.yaml"), factories)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, len(cfg.Receivers), 2)
+
+	r0 := cfg.Receivers[config.NewComponentID(typeStr)].(*Config)
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.Include = []string{"/var/log/*.log"}
+	assert.Equal(t, defaultCfg, r0)
+
+	storageID := config.NewComponentID("file_storage")
+	r1 := cfg.Receivers[config.NewComponentIDWithName(typeStr, "custom")].(*Config)
+	assert.Equal(t,
+		&Config{
+			ReceiverSettings: config.NewReceiverSettings(config.NewComponentIDWithName(typeStr, "custom")),
+			Include:          []string{"/var/log/app/*.log", "/var/log/app/*.log.1"},
+			Exclude:          []string{"/var/log/app/debug.log"},
+			StartAt:          startAtBeginning,
+			PollInterval:     time.Second,
+			FingerprintSize:  100,
+			MaxLogSize:       65536,
+			Encoding:         "utf-16le",
+			Multiline: &MultilineConfig{
+				LineStartPattern: `^\d{4}-\d{2}-\d{2}`,
+			},
+			ForceFlushPeriod: 2 * time.Second,
+			IncludeFileName:  false,
+			IncludeFilePath:  true,
+			Storage:          &storageID,
+		}, r1)
+}
+
+func TestValidateConfig(t *testing.T) {
+	testCases := []struct {
+		name   string
+		modify func(cfg *Config)
+	}{
+		{
+			name:   "no include",
+			modify: func(cfg *Config) { cfg.Include = nil },
+		},
+		{
+			name:   "invalid glob",
+			modify: func(cfg *Config) { cfg.Exclude = []string{"[a"} },
+		},
+		{
+			name:   "invalid start_at",
+			modify: func(cfg *Config) { cfg.StartAt = "middle" },
+		},
+		{
+			name:   "zero poll_interval",
+			modify: func(cfg *Config) { cfg.PollInterval = 0 },
+		},
+		{
+			name:   "small fingerprint_size",
+			modify: func(cfg *Config) { cfg.FingerprintSize = 4 },
+		},
+		{
+			name:   "zero max_log_size",
+			modify: func(cfg *Config) { cfg.MaxLogSize = 0 },
+		},
+		{
+			name:   "negative force_flush_period",
+			modify: func(cfg *Config) { cfg.ForceFlushPeriod = -time.Second },
+		},
+		{
+			name:   "unknown encoding",
+			modify: func(cfg *Config) { cfg.Encoding = "ebcdic" },
+		},
+		{
+			name:   "no multiline pattern",
+			modify: func(cfg *Config) { cfg.Multiline = &MultilineConfig{} },
+		},
+		{
+			name: "both multiline patterns",
+			modify: func(cfg *Config) {
+				cfg.Multiline = &MultilineConfig{LineStartPattern: "^a", LineEndPattern: "b$"}
+			},
+		},
+		{
+			name:   "invalid multiline pattern",
+			modify: func(cfg *Config) { cfg.Multiline = &MultilineConfig{LineEndPattern: "(b"} },
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Include = []string{"/var/log/*.log"}
+			require.NoError(t, cfg.Validate())
+			tt.modify(cfg)
+			assert.Error(t, cfg.Validate())
+		})
+	}
+}
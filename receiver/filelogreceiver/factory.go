@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filelogreceiver // import "go.opentelemetry.io/collector/receiver/filelogreceiver"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "filelog"
+
+	defaultPollInterval     = 200 * time.Millisecond
+	defaultFingerprintSize  = 1000
+	defaultMaxLogSize       = 1024 * 1024
+	defaultEncoding         = "utf-8"
+	defaultForceFlushPeriod = 500 * time.Millisecond
+)
+
+// NewFactory creates a factory for the filelog receiver.
+func NewFactory() component.ReceiverFactory {
+	return component.NewReceiverFactory(
+		typeStr,
+		createDefaultConfig,
+		component.WithLogsReceiver(createLogsReceiver))
+}
+
+func createDefaultConfig() config.Receiver {
+	return &Config{
+		ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
+		StartAt:          startAtEnd,
+		PollInterval:     defaultPollInterval,
+		FingerprintSize:  defaultFingerprintSize,
+		MaxLogSize:       defaultMaxLogSize,
+		Encoding:         defaultEncoding,
+		ForceFlushPeriod: defaultForceFlushPeriod,
+		IncludeFileName:  true,
+	}
+}
+
+func createLogsReceiver(
+	_ context.Context,
+	set component.ReceiverCreateSettings,
+	cfg config.Receiver,
+	nextConsumer consumer.Logs,
+) (component.LogsReceiver, error) {
+	return newFileLogReceiver(cfg.(*Config), set, nextConsumer)
+}
@@ -0,0 +1,286 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filelogreceiver // import "go.opentelemetry.io/collector/receiver/filelogreceiver"
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+const (
+	transport  = "file"
+	dataFormat = "text"
+
+	attributeFileName = "log.file.name"
+	attributeFilePath = "log.file.path"
+)
+
+type fileLogReceiver struct {
+	cfg          *Config
+	logger       *zap.Logger
+	nextConsumer consumer.Logs
+	obsrecv      *obsreport.Receiver
+	splitter     *splitter
+
+	client storage.Client
+	// readers are the files found by the last poll, or loaded from the checkpoints
+	// before the first poll.
+	readers   []*fileReader
+	firstPoll bool
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newFileLogReceiver(cfg *Config, set component.ReceiverCreateSettings, nextConsumer consumer.Logs) (*fileLogReceiver, error) {
+	s, err := newSplitter(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &fileLogReceiver{
+		cfg:          cfg,
+		logger:       set.Logger,
+		nextConsumer: nextConsumer,
+		obsrecv: obsreport.NewReceiver(obsreport.ReceiverSettings{
+			ReceiverID:             cfg.ID(),
+			Transport:              transport,
+			ReceiverCreateSettings: set,
+		}),
+		splitter:  s,
+		firstPoll: true,
+	}, nil
+}
+
+// Start loads the checkpoints of the known files and starts polling the files.
+func (r *fileLogReceiver) Start(ctx context.Context, host component.Host) error {
+	client, err := getStorageClient(ctx, host, r.cfg.Storage, r.cfg.ID())
+	if err != nil {
+		return err
+	}
+	r.client = client
+
+	if r.readers, err = loadCheckpoints(ctx, r.client); err != nil {
+		r.logger.Warn("Failed to load the checkpoints, the known files are ignored", zap.Error(err))
+	}
+
+	var pollCtx context.Context
+	pollCtx, r.cancel = context.WithCancel(context.Background())
+	r.wg.Add(1)
+	go r.pollLoop(pollCtx)
+	return nil
+}
+
+// Shutdown stops polling the files, and saves the checkpoints of the known files.
+func (r *fileLogReceiver) Shutdown(ctx context.Context) error {
+	if r.cancel == nil {
+		return nil
+	}
+	r.cancel()
+	r.wg.Wait()
+
+	err := saveCheckpoints(ctx, r.client, r.readers)
+	for _, fr := range r.readers {
+		fr.close()
+	}
+	if closeErr := r.client.Close(ctx); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func (r *fileLogReceiver) pollLoop(ctx context.Context) {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+
+	r.poll(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.poll(ctx)
+		}
+	}
+}
+
+// poll finds the files to read, matches them with the known files, and reads the
+// data written since the last poll.
+func (r *fileLogReceiver) poll(ctx context.Context) {
+	var readers []*fileReader
+	found := make([]bool, len(r.readers))
+
+	for _, path := range r.findFiles() {
+		// #nosec G304
+		file, err := os.Open(path)
+		if err != nil {
+			r.logger.Debug("Failed to open file", zap.String("path", path), zap.Error(err))
+			continue
+		}
+		fingerprint, err := readFingerprint(file, r.cfg.FingerprintSize)
+		if err != nil || len(fingerprint) == 0 {
+			// Empty files cannot be identified yet, they are checked again at the next poll.
+			_ = file.Close()
+			continue
+		}
+
+		if isDuplicate(readers, fingerprint) {
+			// The file is a copy of a file already found, e.g. by a copy-truncate rotation.
+			_ = file.Close()
+			continue
+		}
+
+		if i := findReader(r.readers, found, fingerprint); i >= 0 {
+			found[i] = true
+			r.readers[i].setFile(file, path, fingerprint)
+			readers = append(readers, r.readers[i])
+			continue
+		}
+
+		fr := &fileReader{file: file, path: path, fingerprint: fingerprint}
+		if r.firstPoll && r.cfg.StartAt == startAtEnd {
+			if info, err := file.Stat(); err == nil {
+				fr.offset = info.Size()
+			}
+		}
+		readers = append(readers, fr)
+	}
+
+	// The known files that were not found anymore have been rotated away, e.g. by renaming
+	// them, read their remaining data first. If they were truncated, the new data is read
+	// from the file found at their path.
+	for i, fr := range r.readers {
+		if !found[i] && fr.file != nil {
+			r.readFile(ctx, fr, false)
+			fr.close()
+		}
+	}
+	for _, fr := range readers {
+		r.readFile(ctx, fr, true)
+	}
+
+	r.readers = readers
+	r.firstPoll = false
+
+	if err := saveCheckpoints(ctx, r.client, r.readers); err != nil {
+		r.logger.Error("Failed to save the checkpoints", zap.Error(err))
+	}
+}
+
+// findFiles returns the sorted paths of the files matching the include patterns and not
+// matching the exclude patterns.
+func (r *fileLogReceiver) findFiles() []string {
+	seen := map[string]struct{}{}
+	var paths []string
+	for _, include := range r.cfg.Include {
+		matches, _ := filepath.Glob(include)
+		for _, path := range matches {
+			if _, ok := seen[path]; ok || r.isExcluded(path) {
+				continue
+			}
+			if info, err := os.Stat(path); err != nil || info.IsDir() {
+				continue
+			}
+			seen[path] = struct{}{}
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func (r *fileLogReceiver) isExcluded(path string) bool {
+	for _, exclude := range r.cfg.Exclude {
+		if ok, _ := filepath.Match(exclude, path); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isDuplicate returns true if the fingerprint identifies a file that was already found.
+func isDuplicate(readers []*fileReader, fingerprint []byte) bool {
+	for _, fr := range readers {
+		if bytes.HasPrefix(fingerprint, fr.fingerprint) || bytes.HasPrefix(fr.fingerprint, fingerprint) {
+			return true
+		}
+	}
+	return false
+}
+
+// findReader returns the index of the reader identified by the fingerprint which was not
+// found yet, or -1.
+func findReader(readers []*fileReader, found []bool, fingerprint []byte) int {
+	for i, fr := range readers {
+		if !found[i] && fr.matches(fingerprint) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *fileLogReceiver) readFile(ctx context.Context, fr *fileReader, resetTruncated bool) {
+	err := fr.readToEnd(ctx, r.splitter, r.cfg.ForceFlushPeriod, resetTruncated, func(entries []string) error {
+		return r.consumeEntries(ctx, fr, entries)
+	})
+	if err != nil && ctx.Err() == nil {
+		r.logger.Error("Failed to read file", zap.String("path", fr.path), zap.Error(err))
+	}
+}
+
+// consumeEntries passes the entries of a file to the next consumer. The entries refused
+// with a permanent error are dropped, the other errors are returned so that the entries
+// are read again at the next poll.
+func (r *fileLogReceiver) consumeEntries(ctx context.Context, fr *fileReader, entries []string) error {
+	ld := pdata.NewLogs()
+	logs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	logs.EnsureCapacity(len(entries))
+	now := pdata.NewTimestampFromTime(time.Now())
+	for _, entry := range entries {
+		lr := logs.AppendEmpty()
+		lr.SetTimestamp(now)
+		lr.Body().SetStringVal(entry)
+		if r.cfg.IncludeFileName {
+			lr.Attributes().InsertString(attributeFileName, filepath.Base(fr.path))
+		}
+		if r.cfg.IncludeFilePath {
+			lr.Attributes().InsertString(attributeFilePath, fr.path)
+		}
+	}
+
+	obsCtx := r.obsrecv.StartLogsOp(ctx)
+	err := r.nextConsumer.ConsumeLogs(obsCtx, ld)
+	r.obsrecv.EndLogsOp(obsCtx, dataFormat, len(entries), err)
+	if consumererror.IsPermanent(err) {
+		r.logger.Error("Dropping log entries refused permanently", zap.String("path", fr.path), zap.Error(err))
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,297 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filelogreceiver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func newTestConfig(dir string) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Include = []string{filepath.Join(dir, "*.log")}
+	cfg.StartAt = startAtBeginning
+	cfg.PollInterval = 10 * time.Millisecond
+	cfg.FingerprintSize = minFingerprintSize
+	return cfg
+}
+
+func startTestReceiver(t *testing.T, cfg *Config, host component.Host) (component.LogsReceiver, *consumertest.LogsSink) {
+	sink := new(consumertest.LogsSink)
+	rcv, err := NewFactory().CreateLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, rcv.Start(context.Background(), host))
+	return rcv, sink
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	require.NoError(t, err)
+	_, err = f.WriteString(content)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+}
+
+// bodies returns the bodies of all the log records received by the sink.
+func bodies(sink *consumertest.LogsSink) []string {
+	var result []string
+	for _, ld := range sink.AllLogs() {
+		rls := ld.ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			ills := rls.At(i).ScopeLogs()
+			for j := 0; j < ills.Len(); j++ {
+				logs := ills.At(j).LogRecords()
+				for k := 0; k < logs.Len(); k++ {
+					result = append(result, logs.At(k).Body().StringVal())
+				}
+			}
+		}
+	}
+	return result
+}
+
+func waitForBodies(t *testing.T, sink *consumertest.LogsSink, expected ...string) {
+	assert.Eventually(t, func() bool {
+		return len(bodies(sink)) >= len(expected)
+	}, 5*time.Second, 5*time.Millisecond)
+	assert.Equal(t, expected, bodies(sink))
+}
+
+func TestReadFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	writeFile(t, path, "2022-03-04 first line\n2022-03-04 second line\n")
+	writeFile(t, filepath.Join(dir, "app.txt"), "2022-03-04 not included\n")
+
+	cfg := newTestConfig(dir)
+	cfg.IncludeFilePath = true
+	rcv, sink := startTestReceiver(t, cfg, componenttest.NewNopHost())
+	defer func() { require.NoError(t, rcv.Shutdown(context.Background())) }()
+
+	waitForBodies(t, sink, "2022-03-04 first line", "2022-03-04 second line")
+	writeFile(t, path, "2022-03-04 third line\n")
+	waitForBodies(t, sink, "2022-03-04 first line", "2022-03-04 second line", "2022-03-04 third line")
+
+	lr := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.NotZero(t, lr.Timestamp())
+	assert.Equal(t, pdata.NewMapFromRaw(map[string]interface{}{
+		attributeFileName: "app.log",
+		attributeFilePath: path,
+	}).Sort(), lr.Attributes().Sort())
+}
+
+func TestStartAtEnd(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	writeFile(t, path, "2022-03-04 existing line\n")
+
+	cfg := newTestConfig(dir)
+	cfg.StartAt = startAtEnd
+	rcv, sink := startTestReceiver(t, cfg, componenttest.NewNopHost())
+	defer func() { require.NoError(t, rcv.Shutdown(context.Background())) }()
+
+	// Wait for the first poll to skip the existing content.
+	time.Sleep(5 * cfg.PollInterval)
+	writeFile(t, path, "2022-03-04 new line\n")
+	// Files created after the first poll are read from the beginning.
+	writeFile(t, filepath.Join(dir, "new.log"), "2022-03-04 new file line\n")
+	assert.Eventually(t, func() bool { return len(bodies(sink)) == 2 }, 5*time.Second, 5*time.Millisecond)
+	assert.ElementsMatch(t, []string{"2022-03-04 new line", "2022-03-04 new file line"}, bodies(sink))
+}
+
+func TestRotationRename(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	writeFile(t, path, "2022-03-04 line 1\n")
+
+	cfg := newTestConfig(dir)
+	rcv, sink := startTestReceiver(t, cfg, componenttest.NewNopHost())
+	defer func() { require.NoError(t, rcv.Shutdown(context.Background())) }()
+	waitForBodies(t, sink, "2022-03-04 line 1")
+
+	// The line written just before the rotation is read from the rotated file,
+	// which is not matched by the include pattern.
+	writeFile(t, path, "2022-03-04 line 2\n")
+	require.NoError(t, os.Rename(path, filepath.Join(dir, "app.log.1")))
+	writeFile(t, path, "2022-03-05 line 3\n")
+
+	waitForBodies(t, sink, "2022-03-04 line 1", "2022-03-04 line 2", "2022-03-05 line 3")
+}
+
+func TestRotationCopyTruncate(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	writeFile(t, path, "2022-03-04 line 1\n2022-03-04 line 2\n")
+
+	cfg := newTestConfig(dir)
+	rcv, sink := startTestReceiver(t, cfg, componenttest.NewNopHost())
+	defer func() { require.NoError(t, rcv.Shutdown(context.Background())) }()
+	waitForBodies(t, sink, "2022-03-04 line 1", "2022-03-04 line 2")
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.log.1"), content, 0600))
+	require.NoError(t, os.Truncate(path, 0))
+	time.Sleep(5 * cfg.PollInterval)
+	writeFile(t, path, "2022-03-05 line 3\n")
+
+	waitForBodies(t, sink, "2022-03-04 line 1", "2022-03-04 line 2", "2022-03-05 line 3")
+	// Nothing is read again.
+	time.Sleep(5 * cfg.PollInterval)
+	assert.Len(t, bodies(sink), 3)
+}
+
+func TestMultiline(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	writeFile(t, path, "2022-03-04 error\n  at main\n2022-03-04 info\n")
+
+	cfg := newTestConfig(dir)
+	cfg.Multiline = &MultilineConfig{LineStartPattern: `^\d{4}-\d{2}-\d{2} `}
+	cfg.ForceFlushPeriod = 50 * time.Millisecond
+	rcv, sink := startTestReceiver(t, cfg, componenttest.NewNopHost())
+	defer func() { require.NoError(t, rcv.Shutdown(context.Background())) }()
+
+	// The last entry is flushed once the file stops growing.
+	waitForBodies(t, sink, "2022-03-04 error\n  at main", "2022-03-04 info")
+}
+
+func TestEncoding(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	writeFile(t, path, "caf\xe9 cr\xe8me\n")
+
+	cfg := newTestConfig(dir)
+	cfg.Encoding = "latin1"
+	rcv, sink := startTestReceiver(t, cfg, componenttest.NewNopHost())
+	defer func() { require.NoError(t, rcv.Shutdown(context.Background())) }()
+
+	waitForBodies(t, sink, "café crème")
+}
+
+func TestCheckpoints(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	writeFile(t, path, "2022-03-04 line 1\n2022-03-04 line 2\n")
+
+	storageID := config.NewComponentID("test_storage")
+	host := &storageHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{storageID: &testStorage{client: newTestClient()}},
+	}
+	cfg := newTestConfig(dir)
+	cfg.Storage = &storageID
+
+	rcv, sink := startTestReceiver(t, cfg, host)
+	waitForBodies(t, sink, "2022-03-04 line 1", "2022-03-04 line 2")
+	require.NoError(t, rcv.Shutdown(context.Background()))
+
+	// The lines written while the receiver is stopped are read after the restart,
+	// without reading the previous lines again.
+	writeFile(t, path, "2022-03-04 line 3\n")
+	rcv, sink = startTestReceiver(t, cfg, host)
+	waitForBodies(t, sink, "2022-03-04 line 3")
+	require.NoError(t, rcv.Shutdown(context.Background()))
+}
+
+func TestMissingStorage(t *testing.T) {
+	cfg := newTestConfig(t.TempDir())
+	storageID := config.NewComponentID("missing")
+	cfg.Storage = &storageID
+
+	rcv, err := NewFactory().CreateLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	assert.Error(t, rcv.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, rcv.Shutdown(context.Background()))
+}
+
+type storageHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func (h *storageHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+type testStorage struct {
+	client storage.Client
+}
+
+var _ storage.Extension = (*testStorage)(nil)
+
+func (s *testStorage) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (s *testStorage) Shutdown(context.Context) error {
+	return nil
+}
+
+func (s *testStorage) GetClient(context.Context, component.Kind, config.ComponentID, string) (storage.Client, error) {
+	return s.client, nil
+}
+
+// testClient is an in-memory storage client.
+type testClient struct {
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func newTestClient() *testClient {
+	return &testClient{data: map[string][]byte{}}
+}
+
+func (c *testClient) Get(_ context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.data[key], nil
+}
+
+func (c *testClient) Set(_ context.Context, key string, value []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.data[key] = value
+	return nil
+}
+
+func (c *testClient) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.data, key)
+	return nil
+}
+
+func (c *testClient) Batch(ctx context.Context, ops ...storage.Operation) error {
+	return nil
+}
+
+func (c *testClient) Close(context.Context) error {
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filelogreceiver // import "go.opentelemetry.io/collector/receiver/filelogreceiver"
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"time"
+)
+
+const (
+	// minFingerprintSize is the minimum size of the fingerprints, to limit the
+	// collisions between files starting with the same content.
+	minFingerprintSize = 16
+
+	// readChunkSize is the number of bytes read from a file at once.
+	readChunkSize = 64 * 1024
+)
+
+// fileReader tracks the reading progress of a file. A file is identified by its
+// fingerprint, which are the first bytes of its content, so that it can be followed
+// when it is renamed.
+type fileReader struct {
+	// file is nil for the files known from a checkpoint that are not opened yet.
+	file        *os.File
+	path        string
+	fingerprint []byte
+	// offset is the position in the file after the last emitted entry.
+	offset int64
+	// lastSize and lastGrowth are used to detect when the file stopped growing,
+	// to flush its last incomplete entry.
+	lastSize   int64
+	lastGrowth time.Time
+}
+
+// readFingerprint returns the first bytes of the file, up to size bytes.
+func readFingerprint(file *os.File, size int) ([]byte, error) {
+	buf := make([]byte, size)
+	n, err := file.ReadAt(buf, 0)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	return buf[:n], nil
+}
+
+// matches returns true if the fingerprint identifies the same file as the reader,
+// possibly after it grew.
+func (fr *fileReader) matches(fingerprint []byte) bool {
+	return len(fr.fingerprint) > 0 && bytes.HasPrefix(fingerprint, fr.fingerprint)
+}
+
+// setFile replaces the file of the reader, after it was found again at the given path.
+func (fr *fileReader) setFile(file *os.File, path string, fingerprint []byte) {
+	if fr.file != nil {
+		_ = fr.file.Close()
+	}
+	fr.file = file
+	fr.path = path
+	fr.fingerprint = fingerprint
+}
+
+func (fr *fileReader) close() {
+	if fr.file != nil {
+		_ = fr.file.Close()
+		fr.file = nil
+	}
+}
+
+// readToEnd reads the entries written to the file since the offset, and passes them
+// to emit in batches. The offset is advanced only after emit returns successfully.
+// If resetTruncated is false and the file is smaller than the offset, nothing is read.
+// Otherwise, the file is considered truncated and read from its beginning.
+func (fr *fileReader) readToEnd(ctx context.Context, s *splitter, forceFlushPeriod time.Duration, resetTruncated bool, emit func([]string) error) error {
+	info, err := fr.file.Stat()
+	if err != nil {
+		return err
+	}
+	size := info.Size()
+	if size < fr.offset {
+		if !resetTruncated {
+			return nil
+		}
+		fr.offset = 0
+		fr.lastSize = 0
+	}
+
+	now := time.Now()
+	if size != fr.lastSize || fr.lastGrowth.IsZero() {
+		fr.lastSize = size
+		fr.lastGrowth = now
+	}
+	flush := now.Sub(fr.lastGrowth) >= forceFlushPeriod
+
+	section := io.NewSectionReader(fr.file, fr.offset, size-fr.offset)
+	buf := make([]byte, 0, readChunkSize)
+	for ctx.Err() == nil {
+		if cap(buf)-len(buf) < readChunkSize {
+			grown := make([]byte, len(buf), len(buf)+readChunkSize)
+			copy(grown, buf)
+			buf = grown
+		}
+		n, err := section.Read(buf[len(buf):cap(buf)])
+		buf = buf[:len(buf)+n]
+		eof := err == io.EOF
+		if err != nil && !eof {
+			return err
+		}
+
+		entries, consumed := s.split(buf, eof && flush)
+		if len(entries) > 0 {
+			if err := emit(entries); err != nil {
+				return err
+			}
+		}
+		fr.offset += int64(consumed)
+		buf = buf[:copy(buf, buf[consumed:])]
+
+		if eof {
+			return nil
+		}
+	}
+	return ctx.Err()
+}
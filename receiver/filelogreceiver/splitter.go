@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filelogreceiver // import "go.opentelemetry.io/collector/receiver/filelogreceiver"
+
+import (
+	"bytes"
+	"regexp"
+
+	"golang.org/x/text/encoding"
+)
+
+// splitter splits the raw content of the files into decoded log entries.
+type splitter struct {
+	decoder *encoding.Decoder
+	// newline and carriageReturn are encoded with the encoding of the files.
+	newline        []byte
+	carriageReturn []byte
+	// unit is the size in bytes of the smallest code unit of the encoding.
+	unit             int
+	maxLogSize       int
+	lineStartPattern *regexp.Regexp
+	lineEndPattern   *regexp.Regexp
+}
+
+func newSplitter(cfg *Config) (*splitter, error) {
+	enc, err := lookupEncoding(cfg.Encoding)
+	if err != nil {
+		return nil, err
+	}
+	newline, err := enc.NewEncoder().Bytes([]byte("\n"))
+	if err != nil {
+		return nil, err
+	}
+	carriageReturn, err := enc.NewEncoder().Bytes([]byte("\r"))
+	if err != nil {
+		return nil, err
+	}
+
+	s := &splitter{
+		decoder:        enc.NewDecoder(),
+		newline:        newline,
+		carriageReturn: carriageReturn,
+		unit:           len(newline),
+		maxLogSize:     cfg.MaxLogSize,
+	}
+	// Keep the entries aligned on the code units of the encoding.
+	if s.maxLogSize -= s.maxLogSize % s.unit; s.maxLogSize == 0 {
+		s.maxLogSize = s.unit
+	}
+
+	if cfg.Multiline != nil {
+		if cfg.Multiline.LineStartPattern != "" {
+			if s.lineStartPattern, err = regexp.Compile(cfg.Multiline.LineStartPattern); err != nil {
+				return nil, err
+			}
+		}
+		if cfg.Multiline.LineEndPattern != "" {
+			if s.lineEndPattern, err = regexp.Compile(cfg.Multiline.LineEndPattern); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return s, nil
+}
+
+// split returns the complete log entries at the beginning of buf, and the number of
+// bytes they consumed. buf must start at the beginning of an entry. If flush is true,
+// the remaining incomplete entry is returned as well.
+func (s *splitter) split(buf []byte, flush bool) (entries []string, consumed int) {
+	// start is the beginning of the current entry, lineStart of the current line.
+	start, lineStart := 0, 0
+	for {
+		i := s.indexNewline(buf[lineStart:])
+		lineEnd := lineStart + i
+		if i < 0 {
+			lineEnd = len(buf)
+		}
+
+		if lineEnd-start > s.maxLogSize {
+			entries = s.appendEntry(entries, buf[start:start+s.maxLogSize])
+			start += s.maxLogSize
+			if lineStart < start {
+				lineStart = start
+			}
+			continue
+		}
+		if i < 0 {
+			break
+		}
+		next := lineEnd + len(s.newline)
+
+		switch {
+		case s.lineStartPattern != nil:
+			if lineStart > start && s.lineStartPattern.Match(s.decode(buf[lineStart:lineEnd])) {
+				entries = s.appendEntry(entries, buf[start:lineStart-len(s.newline)])
+				start = lineStart
+			}
+		case s.lineEndPattern != nil:
+			if s.lineEndPattern.Match(s.decode(buf[lineStart:lineEnd])) {
+				entries = s.appendEntry(entries, buf[start:lineEnd])
+				start = next
+			}
+		default:
+			entries = s.appendEntry(entries, buf[start:lineEnd])
+			start = next
+		}
+		lineStart = next
+	}
+
+	if flush && start < len(buf) {
+		entries = s.appendEntry(entries, bytes.TrimSuffix(buf[start:], s.newline))
+		start = len(buf)
+	}
+	return entries, start
+}
+
+// indexNewline returns the index of the first newline in buf aligned on a code unit, or -1.
+func (s *splitter) indexNewline(buf []byte) int {
+	offset := 0
+	for {
+		i := bytes.Index(buf[offset:], s.newline)
+		if i < 0 {
+			return -1
+		}
+		if (offset+i)%s.unit == 0 {
+			return offset + i
+		}
+		offset += i + 1
+	}
+}
+
+// appendEntry decodes an entry and appends it to the entries, unless it is empty.
+func (s *splitter) appendEntry(entries []string, raw []byte) []string {
+	raw = bytes.TrimSuffix(raw, s.carriageReturn)
+	if len(raw) == 0 {
+		return entries
+	}
+	return append(entries, string(s.decode(raw)))
+}
+
+func (s *splitter) decode(raw []byte) []byte {
+	decoded, err := s.decoder.Bytes(raw)
+	if err != nil {
+		// The decoders replace the invalid sequences, keep the raw bytes in any other case.
+		return raw
+	}
+	return decoded
+}
@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filelogreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/unicode"
+)
+
+func TestSplitter(t *testing.T) {
+	testCases := []struct {
+		name             string
+		modify           func(cfg *Config)
+		input            string
+		flush            bool
+		expected         []string
+		expectedConsumed int
+	}{
+		{
+			name:             "lines",
+			input:            "first\r\nsecond\n\nthird",
+			expected:         []string{"first", "second"},
+			expectedConsumed: len("first\r\nsecond\n\n"),
+		},
+		{
+			name:             "lines flushed",
+			input:            "first\nsecond",
+			flush:            true,
+			expected:         []string{"first", "second"},
+			expectedConsumed: len("first\nsecond"),
+		},
+		{
+			name:             "max log size",
+			modify:           func(cfg *Config) { cfg.MaxLogSize = 4 },
+			input:            "abcdefghij\nabc\nxyz",
+			expected:         []string{"abcd", "efgh", "ij", "abc"},
+			expectedConsumed: len("abcdefghij\nabc\n"),
+		},
+		{
+			name:             "max log size incomplete line",
+			modify:           func(cfg *Config) { cfg.MaxLogSize = 4 },
+			input:            "abcdefghij",
+			expected:         []string{"abcd", "efgh"},
+			expectedConsumed: 8,
+		},
+		{
+			name: "line start pattern",
+			modify: func(cfg *Config) {
+				cfg.Multiline = &MultilineConfig{LineStartPattern: `^\d+ `}
+			},
+			input:            "junk\n1 error\n  at main\n  at init\n2 info\n3 warn\n  detail\n",
+			expected:         []string{"junk", "1 error\n  at main\n  at init", "2 info"},
+			expectedConsumed: len("junk\n1 error\n  at main\n  at init\n2 info\n"),
+		},
+		{
+			name: "line start pattern flushed",
+			modify: func(cfg *Config) {
+				cfg.Multiline = &MultilineConfig{LineStartPattern: `^\d+ `}
+			},
+			input:            "1 error\n  at main\n2 warn\n  detail\n",
+			flush:            true,
+			expected:         []string{"1 error\n  at main", "2 warn\n  detail"},
+			expectedConsumed: len("1 error\n  at main\n2 warn\n  detail\n"),
+		},
+		{
+			name: "line end pattern",
+			modify: func(cfg *Config) {
+				cfg.Multiline = &MultilineConfig{LineEndPattern: `;$`}
+			},
+			input:            "select *\nfrom t;\nselect 1;\nselect\n",
+			expected:         []string{"select *\nfrom t;", "select 1;"},
+			expectedConsumed: len("select *\nfrom t;\nselect 1;\n"),
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			if tt.modify != nil {
+				tt.modify(cfg)
+			}
+			s, err := newSplitter(cfg)
+			require.NoError(t, err)
+
+			entries, consumed := s.split([]byte(tt.input), tt.flush)
+			assert.Equal(t, tt.expected, entries)
+			assert.Equal(t, tt.expectedConsumed, consumed)
+		})
+	}
+}
+
+func TestSplitterUTF16(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Encoding = "utf-16be"
+	s, err := newSplitter(cfg)
+	require.NoError(t, err)
+
+	// U+0A0A is encoded as 0x0A 0x0A in UTF-16BE, it must not be taken for a newline.
+	input, err := unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM).NewEncoder().Bytes([]byte("hਊi\r\nthere\nincomplete"))
+	require.NoError(t, err)
+
+	entries, consumed := s.split(input, false)
+	assert.Equal(t, []string{"hਊi", "there"}, entries)
+	assert.Equal(t, 2*len("hxi\r\nthere\n"), consumed)
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardreceiver // import "go.opentelemetry.io/collector/receiver/fluentforwardreceiver"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confignet"
+)
+
+// Config defines configuration for the Fluent Forward receiver.
+type Config struct {
+	config.ReceiverSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// NetAddr is the address to listen on. The transport must be one of "tcp", "tcp4",
+	// "tcp6" or "unix".
+	confignet.NetAddr `mapstructure:",squash"`
+
+	// Heartbeat enables answering the heartbeats sent by the clients over UDP, on
+	// the same address as the TCP connections. Not supported with Unix sockets.
+	Heartbeat bool `mapstructure:"heartbeat"`
+
+	// MaxMessageSize is the maximum size in bytes of a Forward message, after
+	// the decompression of its entries.
+	MaxMessageSize int `mapstructure:"max_message_size"`
+}
+
+var _ config.Receiver = (*Config)(nil)
+
+// Validate checks the receiver configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("endpoint must be specified")
+	}
+	switch {
+	case isTCP(cfg.Transport):
+	case cfg.Transport == "unix":
+		if cfg.Heartbeat {
+			return errors.New("heartbeat is not supported with the unix transport")
+		}
+	default:
+		return fmt.Errorf("unsupported transport %q, must be tcp or unix", cfg.Transport)
+	}
+	if cfg.MaxMessageSize <= 0 {
+		return errors.New("max_message_size must be positive")
+	}
+	return nil
+}
+
+func isTCP(transport string) bool {
+	return transport == "tcp" || transport == "tcp4" || transport == "tcp6"
+}
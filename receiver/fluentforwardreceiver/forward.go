@@ -0,0 +1,288 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardreceiver // import "go.opentelemetry.io/collector/receiver/fluentforwardreceiver"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+const (
+	// attributeFluentTag is the attribute set to the tag of the events.
+	attributeFluentTag = "fluent.tag"
+
+	// eventTimeExtension is the msgpack extension type of the EventTime.
+	eventTimeExtension = 0
+)
+
+// bodyKeys are the keys of the record fields used as the body of the log
+// record, in order of preference. The other fields are set as attributes.
+var bodyKeys = []string{"log", "message"}
+
+// forwardMessage is a message of the Forward protocol, in any of its modes.
+type forwardMessage struct {
+	logs pdata.Logs
+	// chunk is the identifier of the chunk to acknowledge, empty if the client
+	// does not expect an acknowledgement.
+	chunk string
+}
+
+// parseMessage converts a message of the Forward protocol to logs. The mode of
+// the message is detected from the type of its second element: the time of the
+// Message mode [tag, time, record, option?], the array of [time, record] entries
+// of the Forward mode [tag, entries, option?], or the bin or str of concatenated
+// msgpack entries of the PackedForward mode, compressed with gzip in the
+// CompressedPackedForward mode.
+func parseMessage(v interface{}, maxSize int) (forwardMessage, error) {
+	arr, ok := v.([]interface{})
+	if !ok || len(arr) < 2 {
+		return forwardMessage{}, errors.New("message must be an array of at least 2 elements")
+	}
+	tag, ok := arr[0].(string)
+	if !ok {
+		return forwardMessage{}, errors.New("tag must be a string")
+	}
+
+	msg := forwardMessage{logs: pdata.NewLogs()}
+	records := msg.logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	var opts options
+	var err error
+	switch entries := arr[1].(type) {
+	case []interface{}:
+		if opts, err = parseOptions(arr, 2); err != nil {
+			return forwardMessage{}, err
+		}
+		records.EnsureCapacity(len(entries))
+		for _, entry := range entries {
+			if err = appendEntry(records, tag, entry); err != nil {
+				return forwardMessage{}, err
+			}
+		}
+	case string:
+		if opts, err = parseOptions(arr, 2); err != nil {
+			return forwardMessage{}, err
+		}
+		err = appendPackedEntries(records, tag, []byte(entries), opts.compressed, maxSize)
+	case []byte:
+		if opts, err = parseOptions(arr, 2); err != nil {
+			return forwardMessage{}, err
+		}
+		err = appendPackedEntries(records, tag, entries, opts.compressed, maxSize)
+	default:
+		if len(arr) < 3 {
+			return forwardMessage{}, errors.New("message mode requires a time and a record")
+		}
+		if opts, err = parseOptions(arr, 3); err != nil {
+			return forwardMessage{}, err
+		}
+		err = appendRecord(records, tag, arr[1], arr[2])
+	}
+	if err != nil {
+		return forwardMessage{}, err
+	}
+	msg.chunk = opts.chunk
+	return msg, nil
+}
+
+// options are the options of a message.
+type options struct {
+	chunk      string
+	compressed string
+}
+
+// parseOptions parses the options of a message at the given index, if present.
+func parseOptions(arr []interface{}, i int) (options, error) {
+	var opts options
+	if len(arr) <= i || arr[i] == nil {
+		return opts, nil
+	}
+	m, ok := arr[i].([]keyValue)
+	if !ok {
+		return opts, errors.New("option must be a map")
+	}
+	for _, kv := range m {
+		switch kv.key {
+		case "chunk":
+			if opts.chunk, ok = kv.value.(string); !ok {
+				return opts, errors.New("chunk option must be a string")
+			}
+		case "compressed":
+			if opts.compressed, ok = kv.value.(string); !ok {
+				return opts, errors.New("compressed option must be a string")
+			}
+		}
+	}
+	return opts, nil
+}
+
+// appendPackedEntries decodes the entries of the PackedForward and
+// CompressedPackedForward modes.
+func appendPackedEntries(records pdata.LogRecordSlice, tag string, entries []byte, compressed string, maxSize int) error {
+	var r io.Reader = bytes.NewReader(entries)
+	switch compressed {
+	case "", "text":
+	case "gzip":
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			return fmt.Errorf("invalid gzip entries: %w", err)
+		}
+		defer gr.Close()
+		r = gr
+	default:
+		return fmt.Errorf("unsupported compression %q", compressed)
+	}
+
+	d := newDecoder(r, maxSize)
+	for {
+		entry, err := d.decode()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid packed entries: %w", err)
+		}
+		if err := appendEntry(records, tag, entry); err != nil {
+			return err
+		}
+	}
+}
+
+// appendEntry appends the record of a [time, record] entry.
+func appendEntry(records pdata.LogRecordSlice, tag string, entry interface{}) error {
+	arr, ok := entry.([]interface{})
+	if !ok || len(arr) != 2 {
+		return errors.New("entry must be an array of a time and a record")
+	}
+	return appendRecord(records, tag, arr[0], arr[1])
+}
+
+func appendRecord(records pdata.LogRecordSlice, tag string, t interface{}, record interface{}) error {
+	ts, err := parseTime(t)
+	if err != nil {
+		return err
+	}
+	fields, ok := record.([]keyValue)
+	if !ok {
+		return errors.New("record must be a map")
+	}
+
+	lr := records.AppendEmpty()
+	lr.SetTimestamp(ts)
+	attrs := lr.Attributes()
+	attrs.EnsureCapacity(len(fields) + 1)
+	attrs.InsertString(attributeFluentTag, tag)
+	bodyKey := findBodyKey(fields)
+	for _, f := range fields {
+		key := keyString(f.key)
+		if key == bodyKey {
+			toValue(f.value).CopyTo(lr.Body())
+			continue
+		}
+		attrs.Insert(key, toValue(f.value))
+	}
+	return nil
+}
+
+func findBodyKey(fields []keyValue) string {
+	for _, key := range bodyKeys {
+		for _, f := range fields {
+			if f.key == key {
+				return key
+			}
+		}
+	}
+	return ""
+}
+
+// parseTime parses a time, either an integer of seconds since the epoch, a
+// float for the clients with a sub-second precision, or an EventTime.
+func parseTime(t interface{}) (pdata.Timestamp, error) {
+	switch t := t.(type) {
+	case int64:
+		return pdata.NewTimestampFromTime(time.Unix(t, 0)), nil
+	case uint64:
+		return pdata.Timestamp(t * uint64(time.Second)), nil
+	case float64:
+		sec, frac := math.Modf(t)
+		return pdata.NewTimestampFromTime(time.Unix(int64(sec), int64(frac*1e9))), nil
+	case extension:
+		if t.typ != eventTimeExtension || len(t.data) != 8 {
+			return 0, fmt.Errorf("unsupported time extension of type %d", t.typ)
+		}
+		sec := binary.BigEndian.Uint32(t.data)
+		nsec := binary.BigEndian.Uint32(t.data[4:])
+		return pdata.NewTimestampFromTime(time.Unix(int64(sec), int64(nsec))), nil
+	}
+	return 0, fmt.Errorf("invalid time of type %T", t)
+}
+
+// keyString converts a map key to a string, the keys being strings in most records.
+func keyString(k interface{}) string {
+	switch k := k.(type) {
+	case string:
+		return k
+	case []byte:
+		return string(k)
+	}
+	return fmt.Sprint(k)
+}
+
+// toValue converts a decoded msgpack value to a pdata.Value.
+func toValue(v interface{}) pdata.Value {
+	switch v := v.(type) {
+	case bool:
+		return pdata.NewValueBool(v)
+	case int64:
+		return pdata.NewValueInt(v)
+	case uint64:
+		return pdata.NewValueDouble(float64(v))
+	case float64:
+		return pdata.NewValueDouble(v)
+	case string:
+		return pdata.NewValueString(v)
+	case []byte:
+		return pdata.NewValueBytes(v)
+	case extension:
+		if ts, err := parseTime(v); err == nil {
+			return pdata.NewValueString(ts.AsTime().Format(time.RFC3339Nano))
+		}
+		return pdata.NewValueBytes(v.data)
+	case []interface{}:
+		av := pdata.NewValueSlice()
+		s := av.SliceVal()
+		s.EnsureCapacity(len(v))
+		for _, e := range v {
+			toValue(e).CopyTo(s.AppendEmpty())
+		}
+		return av
+	case []keyValue:
+		av := pdata.NewValueMap()
+		m := av.MapVal()
+		m.EnsureCapacity(len(v))
+		for _, kv := range v {
+			m.Insert(keyString(kv.key), toValue(kv.value))
+		}
+		return av
+	}
+	return pdata.NewValueEmpty()
+}
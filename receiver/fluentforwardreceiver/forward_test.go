@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardreceiver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func testRecord(log string) []keyValue {
+	return []keyValue{
+		{key: "stream", value: "stdout"},
+		{key: "log", value: log},
+		{key: "kubernetes", value: []keyValue{{key: "pod_name", value: "app-1"}}},
+	}
+}
+
+func packEntries(entries ...interface{}) []byte {
+	var b []byte
+	for _, e := range entries {
+		b = appendValue(b, e)
+	}
+	return b
+}
+
+func gzipEntries(t *testing.T, entries []byte) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write(entries)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func logRecords(msg forwardMessage) pdata.LogRecordSlice {
+	return msg.logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+}
+
+func TestParseMessageModes(t *testing.T) {
+	entries := packEntries(
+		[]interface{}{int64(1600000000), testRecord("first")},
+		[]interface{}{eventTime(1600000001, 500), testRecord("second")},
+	)
+	testCases := []struct {
+		name    string
+		message []interface{}
+	}{
+		{
+			name: "forward",
+			message: []interface{}{"app.logs", []interface{}{
+				[]interface{}{int64(1600000000), testRecord("first")},
+				[]interface{}{eventTime(1600000001, 500), testRecord("second")},
+			}},
+		},
+		{
+			name:    "packed forward bin",
+			message: []interface{}{"app.logs", entries, []keyValue{{key: "size", value: int64(2)}}},
+		},
+		{
+			name:    "packed forward str",
+			message: []interface{}{"app.logs", string(entries)},
+		},
+		{
+			name:    "compressed packed forward",
+			message: []interface{}{"app.logs", gzipEntries(t, entries), []keyValue{{key: "compressed", value: "gzip"}}},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := parseMessage(tt.message, 1024)
+			require.NoError(t, err)
+			assert.Empty(t, msg.chunk)
+
+			records := logRecords(msg)
+			require.Equal(t, 2, records.Len())
+			lr := records.At(0)
+			assert.Equal(t, pdata.NewTimestampFromTime(time.Unix(1600000000, 0)), lr.Timestamp())
+			assert.Equal(t, "first", lr.Body().StringVal())
+			assert.Equal(t, map[string]interface{}{
+				"fluent.tag": "app.logs",
+				"stream":     "stdout",
+				"kubernetes": map[string]interface{}{"pod_name": "app-1"},
+			}, lr.Attributes().AsRaw())
+
+			lr = records.At(1)
+			assert.Equal(t, pdata.NewTimestampFromTime(time.Unix(1600000001, 500)), lr.Timestamp())
+			assert.Equal(t, "second", lr.Body().StringVal())
+		})
+	}
+}
+
+func TestParseMessageMode(t *testing.T) {
+	msg, err := parseMessage([]interface{}{
+		"app.logs",
+		1600000000.25,
+		[]keyValue{
+			{key: "message", value: "hello"},
+			{key: int64(1), value: []interface{}{true, uint64(1 << 63), nil}},
+			{key: "raw", value: []byte{1}},
+		},
+		[]keyValue{{key: "chunk", value: "abc"}},
+	}, 1024)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", msg.chunk)
+
+	records := logRecords(msg)
+	require.Equal(t, 1, records.Len())
+	lr := records.At(0)
+	assert.Equal(t, pdata.NewTimestampFromTime(time.Unix(1600000000, 250000000)), lr.Timestamp())
+	assert.Equal(t, "hello", lr.Body().StringVal())
+	assert.Equal(t, map[string]interface{}{
+		"fluent.tag": "app.logs",
+		"1":          []interface{}{true, float64(1 << 63), nil},
+		"raw":        []byte{1},
+	}, lr.Attributes().AsRaw())
+}
+
+func TestParseMessageErrors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		message interface{}
+	}{
+		{name: "not an array", message: "app.logs"},
+		{name: "no entries", message: []interface{}{"app.logs"}},
+		{name: "tag not a string", message: []interface{}{int64(1), []interface{}{}}},
+		{name: "message mode without record", message: []interface{}{"app.logs", int64(1600000000)}},
+		{name: "record not a map", message: []interface{}{"app.logs", int64(1600000000), "record"}},
+		{name: "invalid time", message: []interface{}{"app.logs", true, []keyValue{}}},
+		{name: "invalid time extension", message: []interface{}{"app.logs", extension{typ: 1, data: make([]byte, 8)}, []keyValue{}}},
+		{name: "invalid entry", message: []interface{}{"app.logs", []interface{}{int64(1)}}},
+		{name: "option not a map", message: []interface{}{"app.logs", []interface{}{}, "option"}},
+		{name: "chunk not a string", message: []interface{}{"app.logs", []interface{}{}, []keyValue{{key: "chunk", value: int64(1)}}}},
+		{name: "unsupported compression", message: []interface{}{"app.logs", []byte{}, []keyValue{{key: "compressed", value: "zstd"}}}},
+		{name: "invalid gzip", message: []interface{}{"app.logs", []byte{1, 2}, []keyValue{{key: "compressed", value: "gzip"}}}},
+		{name: "truncated packed entries", message: []interface{}{"app.logs", []byte{0x92, 0x01}}},
+		{
+			name: "packed entries over limit",
+			message: []interface{}{"app.logs", gzipEntries(t, packEntries(
+				[]interface{}{int64(1600000000), testRecord(string(make([]byte, 2048)))},
+			)), []keyValue{{key: "compressed", value: "gzip"}}},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseMessage(tt.message, 1024)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,273 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardreceiver // import "go.opentelemetry.io/collector/receiver/fluentforwardreceiver"
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// maxDepth is the maximum nesting of the msgpack arrays and maps.
+const maxDepth = 64
+
+var errMessageTooLarge = errors.New("message too large")
+
+// extension is a msgpack extension value, such as the EventTime of the Forward protocol.
+type extension struct {
+	typ  int8
+	data []byte
+}
+
+// keyValue is an entry of a msgpack map, whose keys can be of any type.
+type keyValue struct {
+	key   interface{}
+	value interface{}
+}
+
+// decoder decodes msgpack values from a stream. The values are decoded as nil,
+// bool, int64, uint64 (for the integers above math.MaxInt64), float64, string,
+// []byte, extension, []interface{} and []keyValue.
+type decoder struct {
+	r *bufio.Reader
+	// remaining is the number of bytes that can be read before the size limit is reached.
+	remaining int
+}
+
+func newDecoder(r io.Reader, limit int) *decoder {
+	return &decoder{r: bufio.NewReader(r), remaining: limit}
+}
+
+// setLimit sets the number of bytes that can be read by the next decodes.
+func (d *decoder) setLimit(limit int) {
+	d.remaining = limit
+}
+
+// decode decodes the next value, or returns io.EOF if the stream ends before it.
+func (d *decoder) decode() (interface{}, error) {
+	return d.value(0)
+}
+
+func (d *decoder) value(depth int) (interface{}, error) {
+	if depth > maxDepth {
+		return nil, errors.New("msgpack values nested too deeply")
+	}
+	c, err := d.r.ReadByte()
+	if err != nil {
+		if err == io.EOF && depth > 0 {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	if d.remaining <= 0 {
+		return nil, errMessageTooLarge
+	}
+	d.remaining--
+
+	switch {
+	case c <= 0x7f:
+		return int64(c), nil
+	case c >= 0xe0:
+		return int64(int8(c)), nil
+	case c >= 0x80 && c <= 0x8f:
+		return d.mapValue(int(c&0x0f), depth)
+	case c >= 0x90 && c <= 0x9f:
+		return d.arrayValue(int(c&0x0f), depth)
+	case c >= 0xa0 && c <= 0xbf:
+		b, err := d.bytes(int(c & 0x1f))
+		return string(b), err
+	case c >= 0xd4 && c <= 0xd8:
+		return d.extension(1 << (c - 0xd4))
+	}
+
+	switch c {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xc4, 0xc5, 0xc6:
+		n, err := d.length(c - 0xc4)
+		if err != nil {
+			return nil, err
+		}
+		return d.bytes(n)
+	case 0xc7, 0xc8, 0xc9:
+		n, err := d.length(c - 0xc7)
+		if err != nil {
+			return nil, err
+		}
+		return d.extension(n)
+	case 0xca:
+		b, err := d.bytes(4)
+		if err != nil {
+			return nil, err
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(b))), nil
+	case 0xcb:
+		b, err := d.bytes(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), nil
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		u, err := d.uint(1 << (c - 0xcc))
+		if err != nil {
+			return nil, err
+		}
+		if u > math.MaxInt64 {
+			return u, nil
+		}
+		return int64(u), nil
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (c - 0xd0)
+		u, err := d.uint(size)
+		if err != nil {
+			return nil, err
+		}
+		// Sign-extend the value from its size.
+		shift := 64 - 8*size
+		return int64(u<<shift) >> shift, nil
+	case 0xd9, 0xda, 0xdb:
+		n, err := d.length(c - 0xd9)
+		if err != nil {
+			return nil, err
+		}
+		b, err := d.bytes(n)
+		return string(b), err
+	case 0xdc, 0xdd:
+		n, err := d.length(c - 0xdc + 1)
+		if err != nil {
+			return nil, err
+		}
+		return d.arrayValue(n, depth)
+	case 0xde, 0xdf:
+		n, err := d.length(c - 0xde + 1)
+		if err != nil {
+			return nil, err
+		}
+		return d.mapValue(n, depth)
+	}
+	return nil, fmt.Errorf("unsupported msgpack type 0x%02x", c)
+}
+
+// length reads the length of a value, encoded as an unsigned integer of
+// 1, 2 or 4 bytes for the sizes 0, 1 and 2.
+func (d *decoder) length(size byte) (int, error) {
+	u, err := d.uint(1 << size)
+	if err != nil {
+		return 0, err
+	}
+	// Each element takes at least one byte, which avoids allocating more than the limit.
+	if u > uint64(d.remaining) {
+		return 0, errMessageTooLarge
+	}
+	return int(u), nil
+}
+
+func (d *decoder) uint(size int) (uint64, error) {
+	b, err := d.bytes(size)
+	if err != nil {
+		return 0, err
+	}
+	var u uint64
+	for _, c := range b {
+		u = u<<8 | uint64(c)
+	}
+	return u, nil
+}
+
+func (d *decoder) bytes(n int) ([]byte, error) {
+	if n > d.remaining {
+		return nil, errMessageTooLarge
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(d.r, b); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	d.remaining -= n
+	return b, nil
+}
+
+func (d *decoder) extension(n int) (interface{}, error) {
+	b, err := d.bytes(n + 1)
+	if err != nil {
+		return nil, err
+	}
+	return extension{typ: int8(b[0]), data: b[1:]}, nil
+}
+
+func (d *decoder) arrayValue(n int, depth int) (interface{}, error) {
+	if n > d.remaining {
+		return nil, errMessageTooLarge
+	}
+	arr := make([]interface{}, n)
+	for i := range arr {
+		v, err := d.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		arr[i] = v
+	}
+	return arr, nil
+}
+
+func (d *decoder) mapValue(n int, depth int) (interface{}, error) {
+	if 2*n > d.remaining {
+		return nil, errMessageTooLarge
+	}
+	m := make([]keyValue, n)
+	for i := range m {
+		k, err := d.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		v, err := d.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		m[i] = keyValue{key: k, value: v}
+	}
+	return m, nil
+}
+
+// appendAck appends the msgpack encoding of the response acknowledging a chunk.
+func appendAck(b []byte, chunk string) []byte {
+	// A map of one entry.
+	b = append(b, 0x81)
+	b = appendString(b, "ack")
+	return appendString(b, chunk)
+}
+
+func appendString(b []byte, s string) []byte {
+	switch n := len(s); {
+	case n < 32:
+		b = append(b, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		b = append(b, 0xd9, byte(n))
+	case n <= math.MaxUint16:
+		b = append(b, 0xda, byte(n>>8), byte(n))
+	default:
+		b = append(b, 0xdb, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	}
+	return append(b, s...)
+}
@@ -0,0 +1,206 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardreceiver
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// appendValue appends the msgpack encoding of a value, using the largest
+// formats so that the decoding of all the sizes is covered with the small ones.
+func appendValue(b []byte, v interface{}) []byte {
+	switch v := v.(type) {
+	case nil:
+		return append(b, 0xc0)
+	case bool:
+		if v {
+			return append(b, 0xc3)
+		}
+		return append(b, 0xc2)
+	case int:
+		return appendValue(b, int64(v))
+	case int64:
+		if v >= 0 && v <= 0x7f {
+			return append(b, byte(v))
+		}
+		return appendUint(append(b, 0xd3), uint64(v), 8)
+	case uint64:
+		return appendUint(append(b, 0xcf), v, 8)
+	case float64:
+		return appendUint(append(b, 0xcb), math.Float64bits(v), 8)
+	case string:
+		return appendString(b, v)
+	case []byte:
+		b = appendUint(append(b, 0xc6), uint64(len(v)), 4)
+		return append(b, v...)
+	case extension:
+		b = appendUint(append(b, 0xc9), uint64(len(v.data)), 4)
+		return append(append(b, byte(v.typ)), v.data...)
+	case []interface{}:
+		b = appendUint(append(b, 0xdd), uint64(len(v)), 4)
+		for _, e := range v {
+			b = appendValue(b, e)
+		}
+		return b
+	case []keyValue:
+		b = appendUint(append(b, 0xdf), uint64(len(v)), 4)
+		for _, kv := range v {
+			b = appendValue(appendValue(b, kv.key), kv.value)
+		}
+		return b
+	}
+	panic("unsupported type")
+}
+
+func appendUint(b []byte, u uint64, size int) []byte {
+	for i := size - 1; i >= 0; i-- {
+		b = append(b, byte(u>>(8*i)))
+	}
+	return b
+}
+
+func eventTime(sec, nsec uint32) extension {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint32(data, sec)
+	binary.BigEndian.PutUint32(data[4:], nsec)
+	return extension{typ: eventTimeExtension, data: data}
+}
+
+func TestDecodeRoundTrip(t *testing.T) {
+	values := []interface{}{
+		nil,
+		true,
+		false,
+		int64(5),
+		int64(-1 << 40),
+		uint64(math.MaxUint64),
+		1.5,
+		"",
+		strings.Repeat("a", 300),
+		[]byte{1, 2, 3},
+		eventTime(1600000000, 5),
+		[]interface{}{int64(1), "a", []interface{}{}},
+		[]keyValue{{key: "k", value: []keyValue{{key: int64(1), value: nil}}}},
+	}
+	var b []byte
+	for _, v := range values {
+		b = appendValue(b, v)
+	}
+
+	d := newDecoder(bytes.NewReader(b), len(b))
+	for _, want := range values {
+		got, err := d.decode()
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	_, err := d.decode()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestDecodeFormats(t *testing.T) {
+	testCases := []struct {
+		name string
+		data []byte
+		want interface{}
+	}{
+		{name: "negative fixint", data: []byte{0xff}, want: int64(-1)},
+		{name: "uint8", data: []byte{0xcc, 0xff}, want: int64(255)},
+		{name: "uint16", data: []byte{0xcd, 0x01, 0x00}, want: int64(256)},
+		{name: "int8", data: []byte{0xd0, 0x80}, want: int64(-128)},
+		{name: "int16", data: []byte{0xd1, 0xff, 0x00}, want: int64(-256)},
+		{name: "int32", data: []byte{0xd2, 0x00, 0x01, 0x00, 0x00}, want: int64(65536)},
+		{name: "float32", data: []byte{0xca, 0x3f, 0xc0, 0x00, 0x00}, want: 1.5},
+		{name: "fixstr", data: []byte{0xa2, 'h', 'i'}, want: "hi"},
+		{name: "str16", data: []byte{0xda, 0x00, 0x01, 'a'}, want: "a"},
+		{name: "bin8", data: []byte{0xc4, 0x01, 0x07}, want: []byte{7}},
+		{name: "fixext1", data: []byte{0xd4, 0x05, 0x01}, want: extension{typ: 5, data: []byte{1}}},
+		{name: "fixarray", data: []byte{0x92, 0x01, 0xc0}, want: []interface{}{int64(1), nil}},
+		{name: "array16", data: []byte{0xdc, 0x00, 0x01, 0xc3}, want: []interface{}{true}},
+		{name: "fixmap", data: []byte{0x81, 0xa1, 'k', 0x02}, want: []keyValue{{key: "k", value: int64(2)}}},
+		{name: "map16", data: []byte{0xde, 0x00, 0x00}, want: []keyValue{}},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newDecoder(bytes.NewReader(tt.data), 100).decode()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	nested := bytes.Repeat([]byte{0x91}, maxDepth+2)
+	testCases := []struct {
+		name  string
+		data  []byte
+		limit int
+		err   error
+	}{
+		{name: "truncated string", data: []byte{0xa3, 'a'}, limit: 100, err: io.ErrUnexpectedEOF},
+		{name: "truncated array", data: []byte{0x92, 0x01}, limit: 100, err: io.ErrUnexpectedEOF},
+		{name: "truncated uint", data: []byte{0xcd, 0x01}, limit: 100, err: io.ErrUnexpectedEOF},
+		{name: "string over limit", data: []byte{0xa3, 'a', 'b', 'c'}, limit: 3, err: errMessageTooLarge},
+		{name: "array length over limit", data: []byte{0xdd, 0xff, 0xff, 0xff, 0xff}, limit: 100, err: errMessageTooLarge},
+		{name: "map length over limit", data: []byte{0x8f}, limit: 10, err: errMessageTooLarge},
+		{name: "unused type", data: []byte{0xc1}, limit: 100},
+		{name: "nested too deeply", data: nested, limit: 100},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newDecoder(bytes.NewReader(tt.data), tt.limit).decode()
+			require.Error(t, err)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestDecodeSetLimit(t *testing.T) {
+	b := appendValue(appendValue(nil, "abc"), "def")
+	d := newDecoder(bytes.NewReader(b), 4)
+	v, err := d.decode()
+	require.NoError(t, err)
+	assert.Equal(t, "abc", v)
+	_, err = d.decode()
+	assert.ErrorIs(t, err, errMessageTooLarge)
+
+	d = newDecoder(bytes.NewReader(b), 4)
+	_, err = d.decode()
+	require.NoError(t, err)
+	d.setLimit(4)
+	v, err = d.decode()
+	require.NoError(t, err)
+	assert.Equal(t, "def", v)
+}
+
+func TestAppendAck(t *testing.T) {
+	for _, chunk := range []string{"p8n9gmxTQVC8/nh2wlKKeQ==", strings.Repeat("c", 40), strings.Repeat("c", 300), strings.Repeat("c", 70000)} {
+		b := appendAck(nil, chunk)
+		v, err := newDecoder(bytes.NewReader(b), len(b)).decode()
+		require.NoError(t, err)
+		assert.Equal(t, []keyValue{{key: "ack", value: chunk}}, v)
+	}
+}
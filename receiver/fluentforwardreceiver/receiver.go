@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardreceiver // import "go.opentelemetry.io/collector/receiver/fluentforwardreceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/conntracker"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+const format = "forward"
+
+type forwardReceiver struct {
+	cfg          *Config
+	logger       *zap.Logger
+	nextConsumer consumer.Logs
+	obsrecv      *obsreport.Receiver
+
+	listener      net.Listener
+	heartbeatConn net.PacketConn
+
+	conns conntracker.Tracker
+	wg    sync.WaitGroup
+}
+
+func newForwardReceiver(cfg *Config, set component.ReceiverCreateSettings, nextConsumer consumer.Logs) *forwardReceiver {
+	return &forwardReceiver{
+		cfg:          cfg,
+		logger:       set.Logger,
+		nextConsumer: nextConsumer,
+		obsrecv: obsreport.NewReceiver(obsreport.ReceiverSettings{
+			ReceiverID:             cfg.ID(),
+			Transport:              cfg.Transport,
+			ReceiverCreateSettings: set,
+		}),
+	}
+}
+
+// Start listens on the configured address, and for the heartbeats if enabled.
+func (r *forwardReceiver) Start(_ context.Context, host component.Host) error {
+	ln, err := r.cfg.NetAddr.Listen()
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", r.cfg.Endpoint, err)
+	}
+	if r.cfg.Heartbeat {
+		// The heartbeats are received on the port of the TCP listener, which is
+		// only known after listening when the configured port is 0.
+		network := "udp" + strings.TrimPrefix(r.cfg.Transport, "tcp")
+		pc, err := net.ListenPacket(network, ln.Addr().String())
+		if err != nil {
+			_ = ln.Close()
+			return fmt.Errorf("failed to listen for heartbeats on %s: %w", ln.Addr(), err)
+		}
+		r.heartbeatConn = pc
+		r.wg.Add(1)
+		go r.answerHeartbeats(host)
+	}
+	r.listener = ln
+	r.wg.Add(1)
+	go r.acceptConnections(host)
+	return nil
+}
+
+// Shutdown stops listening and closes the open connections.
+func (r *forwardReceiver) Shutdown(context.Context) error {
+	var err error
+	if r.listener != nil {
+		err = r.listener.Close()
+	}
+	if r.heartbeatConn != nil {
+		if closeErr := r.heartbeatConn.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	r.conns.Close()
+	r.wg.Wait()
+	return err
+}
+
+func (r *forwardReceiver) acceptConnections(host component.Host) {
+	defer r.wg.Done()
+	for {
+		conn, err := r.listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				host.ReportFatalError(err)
+			}
+			return
+		}
+		if !r.conns.Add(conn) {
+			continue
+		}
+
+		r.wg.Add(1)
+		go r.handleConnection(conn)
+	}
+}
+
+func (r *forwardReceiver) handleConnection(conn net.Conn) {
+	defer r.wg.Done()
+	defer r.conns.Remove(conn)
+
+	d := newDecoder(conn, r.cfg.MaxMessageSize)
+	for {
+		d.setLimit(r.cfg.MaxMessageSize)
+		v, err := d.decode()
+		if err != nil {
+			// The stream cannot be resynchronized after an invalid msgpack value.
+			if err != io.EOF && !errors.Is(err, net.ErrClosed) {
+				r.reportMalformed(err)
+				r.logger.Debug("Closing forward connection", zap.Stringer("remote_addr", conn.RemoteAddr()), zap.Error(err))
+			}
+			return
+		}
+
+		msg, err := parseMessage(v, r.cfg.MaxMessageSize)
+		if err != nil {
+			r.reportMalformed(err)
+			continue
+		}
+		// The chunks that are not acknowledged are sent again by the clients.
+		if err = r.consumeLogs(msg.logs); err != nil || msg.chunk == "" {
+			continue
+		}
+		if _, err = conn.Write(appendAck(nil, msg.chunk)); err != nil {
+			r.logger.Debug("Failed to acknowledge forward chunk", zap.Stringer("remote_addr", conn.RemoteAddr()), zap.Error(err))
+			return
+		}
+	}
+}
+
+// answerHeartbeats answers the UDP heartbeats of the clients with a null byte.
+func (r *forwardReceiver) answerHeartbeats(host component.Host) {
+	defer r.wg.Done()
+	buf := make([]byte, 64)
+	for {
+		_, addr, err := r.heartbeatConn.ReadFrom(buf)
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				host.ReportFatalError(err)
+			}
+			return
+		}
+		if _, err = r.heartbeatConn.WriteTo([]byte{0}, addr); err != nil {
+			r.logger.Debug("Failed to answer forward heartbeat", zap.Stringer("remote_addr", addr), zap.Error(err))
+		}
+	}
+}
+
+func (r *forwardReceiver) consumeLogs(ld pdata.Logs) error {
+	ctx := r.obsrecv.StartLogsOp(context.Background())
+	err := r.nextConsumer.ConsumeLogs(ctx, ld)
+	r.obsrecv.EndLogsOp(ctx, format, ld.LogRecordCount(), err)
+	return err
+}
+
+// reportMalformed records a message that cannot be decoded as refused.
+func (r *forwardReceiver) reportMalformed(err error) {
+	ctx := r.obsrecv.StartLogsOp(context.Background())
+	r.obsrecv.EndLogsOp(ctx, format, 1, fmt.Errorf("malformed forward message: %w", err))
+	r.logger.Debug("Malformed forward message", zap.Error(err))
+}
@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardreceiver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testutil"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
+)
+
+func startReceiver(t *testing.T, cfg *Config, set component.ReceiverCreateSettings, next consumer.Logs) {
+	rcv, err := NewFactory().CreateLogsReceiver(context.Background(), set, cfg, next)
+	require.NoError(t, err)
+	require.NoError(t, rcv.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, rcv.Shutdown(context.Background())) })
+}
+
+func readAck(t *testing.T, conn net.Conn) interface{} {
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	v, err := newDecoder(conn, 1024).decode()
+	require.NoError(t, err)
+	return v
+}
+
+func TestReceiveTCP(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	defer func() { require.NoError(t, tt.Shutdown(context.Background())) }()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	sink := new(consumertest.LogsSink)
+	startReceiver(t, cfg, tt.ToReceiverCreateSettings(), sink)
+
+	conn, err := net.Dial("tcp", cfg.Endpoint)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	message := appendValue(nil, []interface{}{"app.logs", []interface{}{
+		[]interface{}{int64(1600000000), testRecord("first")},
+		[]interface{}{int64(1600000001), testRecord("second")},
+	}, []keyValue{{key: "chunk", value: "chunk-1"}}})
+	_, err = conn.Write(message)
+	require.NoError(t, err)
+	assert.Equal(t, []keyValue{{key: "ack", value: "chunk-1"}}, readAck(t, conn))
+
+	// A message that is not a valid Forward message is refused without closing the connection.
+	_, err = conn.Write(appendValue(nil, []interface{}{int64(1), "entries"}))
+	require.NoError(t, err)
+	_, err = conn.Write(appendValue(nil, []interface{}{"app.logs", int64(1600000002), testRecord("third")}))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 3 }, 5*time.Second, 10*time.Millisecond)
+	logs := sink.AllLogs()
+	require.Len(t, logs, 2)
+	assert.Equal(t, "third", logs[1].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().StringVal())
+
+	assert.Eventually(t, func() bool {
+		return obsreporttest.CheckReceiverLogs(tt, cfg.ID(), "tcp", 3, 1) == nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestReceiveInvalidMsgpack(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.MaxMessageSize = 16
+	startReceiver(t, cfg, componenttest.NewNopReceiverCreateSettings(), consumertest.NewNop())
+
+	conn, err := net.Dial("tcp", cfg.Endpoint)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write(appendValue(nil, []interface{}{"app.logs", int64(1600000000), testRecord("too large")}))
+	require.NoError(t, err)
+
+	// The connection is closed since the stream cannot be resynchronized.
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, err = conn.Read(make([]byte, 1))
+	assert.Error(t, err)
+	var netErr net.Error
+	assert.False(t, errors.As(err, &netErr) && netErr.Timeout())
+}
+
+func TestReceiveConsumerError(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	startReceiver(t, cfg, componenttest.NewNopReceiverCreateSettings(), consumertest.NewErr(errors.New("consumer error")))
+
+	conn, err := net.Dial("tcp", cfg.Endpoint)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write(appendValue(nil, []interface{}{"app.logs", int64(1600000000), testRecord("first"), []keyValue{{key: "chunk", value: "chunk-1"}}}))
+	require.NoError(t, err)
+
+	// The chunk is not acknowledged so that the client sends it again.
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(100*time.Millisecond)))
+	_, err = conn.Read(make([]byte, 1))
+	var netErr net.Error
+	require.True(t, errors.As(err, &netErr))
+	assert.True(t, netErr.Timeout())
+}
+
+func TestReceiveUnix(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = filepath.Join(t.TempDir(), "fluent.sock")
+	cfg.Transport = "unix"
+	cfg.Heartbeat = false
+	sink := new(consumertest.LogsSink)
+	startReceiver(t, cfg, componenttest.NewNopReceiverCreateSettings(), sink)
+
+	conn, err := net.Dial("unix", cfg.Endpoint)
+	require.NoError(t, err)
+	defer conn.Close()
+	entries := packEntries([]interface{}{eventTime(1600000000, 0), testRecord("first")})
+	_, err = conn.Write(appendValue(nil, []interface{}{"app.logs", gzipEntries(t, entries), []keyValue{
+		{key: "compressed", value: "gzip"},
+		{key: "chunk", value: "chunk-1"},
+	}}))
+	require.NoError(t, err)
+	assert.Equal(t, []keyValue{{key: "ack", value: "chunk-1"}}, readAck(t, conn))
+
+	require.Equal(t, 1, sink.LogRecordCount())
+	tag, ok := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().Get(attributeFluentTag)
+	require.True(t, ok)
+	assert.Equal(t, "app.logs", tag.StringVal())
+}
+
+func TestHeartbeat(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	startReceiver(t, cfg, componenttest.NewNopReceiverCreateSettings(), consumertest.NewNop())
+
+	conn, err := net.Dial("udp", cfg.Endpoint)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write([]byte{0})
+	require.NoError(t, err)
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	buf := make([]byte, 8)
+	n, err := conn.Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0}, buf[:n])
+}
+
+func TestStartErrors(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = ln.Addr().String()
+	rcv, err := NewFactory().CreateLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	assert.Error(t, rcv.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, rcv.Shutdown(context.Background()))
+
+	pc, err := net.ListenPacket("udp", "localhost:0")
+	require.NoError(t, err)
+	defer pc.Close()
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.Endpoint = pc.LocalAddr().String()
+	rcv, err = NewFactory().CreateLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	// The TCP port is free but the heartbeat port is not.
+	assert.Error(t, rcv.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, rcv.Shutdown(context.Background()))
+}
@@ -0,0 +1,177 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hostmetricsreceiver // import "go.opentelemetry.io/collector/receiver/hostmetricsreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+)
+
+const (
+	scrapersFieldName = "scrapers"
+
+	cpuScraperName        = "cpu"
+	memoryScraperName     = "memory"
+	loadScraperName       = "load"
+	diskScraperName       = "disk"
+	filesystemScraperName = "filesystem"
+	networkScraperName    = "network"
+	processScraperName    = "process"
+)
+
+// Config defines configuration for the host metrics receiver.
+type Config struct {
+	scraperhelper.ScraperControllerSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// RootPath is the root of the host filesystem, under which /proc and /sys are
+	// read. It can be set to read a host filesystem mounted into a container.
+	RootPath string `mapstructure:"root_path"`
+
+	// Scrapers are the enabled scrapers. A scraper missing in the configuration is disabled.
+	Scrapers `mapstructure:"scrapers"`
+}
+
+// Scrapers is the configuration of the scrapers.
+type Scrapers struct {
+	CPU        *CPUConfig        `mapstructure:"cpu"`
+	Memory     *MemoryConfig     `mapstructure:"memory"`
+	Load       *LoadConfig       `mapstructure:"load"`
+	Disk       *DiskConfig       `mapstructure:"disk"`
+	Filesystem *FilesystemConfig `mapstructure:"filesystem"`
+	Network    *NetworkConfig    `mapstructure:"network"`
+	Process    *ProcessConfig    `mapstructure:"process"`
+}
+
+// CPUConfig defines configuration for the CPU scraper.
+type CPUConfig struct{}
+
+// MemoryConfig defines configuration for the memory scraper.
+type MemoryConfig struct{}
+
+// LoadConfig defines configuration for the load scraper.
+type LoadConfig struct{}
+
+// DiskConfig defines configuration for the disk scraper.
+type DiskConfig struct {
+	// ExcludeDevices are regular expressions matching the names of the excluded devices.
+	ExcludeDevices []string `mapstructure:"exclude_devices"`
+}
+
+// FilesystemConfig defines configuration for the filesystem scraper.
+type FilesystemConfig struct {
+	// ExcludeFSTypes are the excluded filesystem types.
+	ExcludeFSTypes []string `mapstructure:"exclude_fs_types"`
+
+	// ExcludeMountPoints are regular expressions matching the excluded mount points.
+	ExcludeMountPoints []string `mapstructure:"exclude_mount_points"`
+}
+
+// NetworkConfig defines configuration for the network scraper.
+type NetworkConfig struct {
+	// ExcludeInterfaces are regular expressions matching the names of the excluded interfaces.
+	ExcludeInterfaces []string `mapstructure:"exclude_interfaces"`
+}
+
+// ProcessConfig defines configuration for the process scraper.
+type ProcessConfig struct {
+	// IncludeNames are regular expressions matching the executable names of the
+	// included processes. All the processes are included if empty.
+	IncludeNames []string `mapstructure:"include_names"`
+
+	// ExcludeNames are regular expressions matching the executable names of the
+	// excluded processes.
+	ExcludeNames []string `mapstructure:"exclude_names"`
+}
+
+var _ config.Receiver = (*Config)(nil)
+var _ config.Unmarshallable = (*Config)(nil)
+
+// Validate checks the receiver configuration is valid
+func (cfg *Config) Validate() error {
+	if !filepath.IsAbs(cfg.RootPath) {
+		return fmt.Errorf("root_path %q must be an absolute path", cfg.RootPath)
+	}
+	s := cfg.Scrapers
+	if s.CPU == nil && s.Memory == nil && s.Load == nil && s.Disk == nil &&
+		s.Filesystem == nil && s.Network == nil && s.Process == nil {
+		return errors.New("must specify at least one scraper when using the host metrics receiver")
+	}
+
+	var patterns []string
+	if s.Disk != nil {
+		patterns = append(patterns, s.Disk.ExcludeDevices...)
+	}
+	if s.Filesystem != nil {
+		patterns = append(patterns, s.Filesystem.ExcludeMountPoints...)
+	}
+	if s.Network != nil {
+		patterns = append(patterns, s.Network.ExcludeInterfaces...)
+	}
+	if s.Process != nil {
+		patterns = append(patterns, s.Process.IncludeNames...)
+		patterns = append(patterns, s.Process.ExcludeNames...)
+	}
+	for _, pattern := range patterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid regular expression %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// Unmarshal a config.Map into the config struct.
+func (cfg *Config) Unmarshal(componentParser *config.Map) error {
+	if componentParser == nil {
+		return nil
+	}
+	// first load the config normally
+	if err := componentParser.UnmarshalExact(cfg); err != nil {
+		return err
+	}
+
+	// next manually search for scrapers in the config.Map, a scraper without settings
+	// is enabled with its default settings.
+	scrapers, err := componentParser.Sub(scrapersFieldName)
+	if err != nil {
+		return err
+	}
+	if scrapers.IsSet(cpuScraperName) && cfg.CPU == nil {
+		cfg.CPU = &CPUConfig{}
+	}
+	if scrapers.IsSet(memoryScraperName) && cfg.Memory == nil {
+		cfg.Memory = &MemoryConfig{}
+	}
+	if scrapers.IsSet(loadScraperName) && cfg.Load == nil {
+		cfg.Load = &LoadConfig{}
+	}
+	if scrapers.IsSet(diskScraperName) && cfg.Disk == nil {
+		cfg.Disk = &DiskConfig{}
+	}
+	if scrapers.IsSet(filesystemScraperName) && cfg.Filesystem == nil {
+		cfg.Filesystem = &FilesystemConfig{}
+	}
+	if scrapers.IsSet(networkScraperName) && cfg.Network == nil {
+		cfg.Network = &NetworkConfig{}
+	}
+	if scrapers.IsSet(processScraperName) && cfg.Process == nil {
+		cfg.Process = &ProcessConfig{}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hostmetricsreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+	"go.opentelemetry.io/collector/service/servicetest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := servicetest.LoadConfigAndValidate(filepath.Join("testdata", "config.yaml"), factories)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, len(cfg.Receivers), 2)
+
+	r0 := cfg.Receivers[config.NewComponentID(typeStr)]
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.CPU = &CPUConfig{}
+	assert.Equal(t, defaultCfg, r0)
+
+	r1 := cfg.Receivers[config.NewComponentIDWithName(typeStr, "customname")]
+	assert.Equal(t,
+		&Config{
+			ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+				ReceiverSettings:   config.NewReceiverSettings(config.NewComponentIDWithName(typeStr, "customname")),
+				CollectionInterval: 30 * time.Second,
+			},
+			RootPath: "/hostfs",
+			Scrapers: Scrapers{
+				CPU:    &CPUConfig{},
+				Memory: &MemoryConfig{},
+				Load:   &LoadConfig{},
+				Disk: &DiskConfig{
+					ExcludeDevices: []string{"^loop"},
+				},
+				Filesystem: &FilesystemConfig{
+					ExcludeFSTypes:     []string{"proc", "sysfs"},
+					ExcludeMountPoints: []string{"^/run"},
+				},
+				Network: &NetworkConfig{
+					ExcludeInterfaces: []string{"^lo$"},
+				},
+				Process: &ProcessConfig{
+					IncludeNames: []string{"^otelcol"},
+				},
+			},
+		}, r1)
+}
+
+func TestValidateConfig(t *testing.T) {
+	testCases := []struct {
+		name   string
+		modify func(cfg *Config)
+	}{
+		{
+			name:   "relative root_path",
+			modify: func(cfg *Config) { cfg.RootPath = "hostfs" },
+		},
+		{
+			name:   "no scrapers",
+			modify: func(cfg *Config) { cfg.CPU = nil },
+		},
+		{
+			name:   "invalid exclude_devices",
+			modify: func(cfg *Config) { cfg.Disk = &DiskConfig{ExcludeDevices: []string{"("}} },
+		},
+		{
+			name:   "invalid exclude_mount_points",
+			modify: func(cfg *Config) { cfg.Filesystem = &FilesystemConfig{ExcludeMountPoints: []string{"("}} },
+		},
+		{
+			name:   "invalid exclude_interfaces",
+			modify: func(cfg *Config) { cfg.Network = &NetworkConfig{ExcludeInterfaces: []string{"("}} },
+		},
+		{
+			name:   "invalid include_names",
+			modify: func(cfg *Config) { cfg.Process = &ProcessConfig{IncludeNames: []string{"("}} },
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.CPU = &CPUConfig{}
+			require.NoError(t, cfg.Validate())
+			tt.modify(cfg)
+			assert.Error(t, cfg.Validate())
+		})
+	}
+}
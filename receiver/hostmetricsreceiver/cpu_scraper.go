@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hostmetricsreceiver // import "go.opentelemetry.io/collector/receiver/hostmetricsreceiver"
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// cpuStates are the states of the CPU times, in the order of /proc/stat.
+var cpuStates = []string{"user", "nice", "system", "idle", "wait", "interrupt", "softirq", "steal"}
+
+// cpuScraper scrapes the CPU times from /proc/stat and the CPU frequencies from
+// /sys/devices/system/cpu.
+type cpuScraper struct {
+	fs hostFS
+}
+
+func (s *cpuScraper) scrape(context.Context) (pdata.Metrics, error) {
+	md := pdata.NewMetrics()
+	lines, err := s.fs.readLines("proc", "stat")
+	if err != nil {
+		return md, err
+	}
+	bootTime, err := parseBootTime(lines)
+	if err != nil {
+		return md, err
+	}
+
+	mb := newMetricsBuilder(md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics(),
+		bootTime, pdata.NewTimestampFromTime(time.Now()))
+	times := mb.sum("system.cpu.time", "Total CPU seconds broken down by different states.", "s", true)
+	var cpus []string
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		// The first line is the sum of all the CPUs.
+		if !strings.HasPrefix(fields[0], "cpu") || fields[0] == "cpu" {
+			continue
+		}
+		values, err := parseUints(fields[1:])
+		if err != nil {
+			return md, fmt.Errorf("invalid line %q in /proc/stat: %w", line, err)
+		}
+		for i, state := range cpuStates {
+			if i < len(values) {
+				times.addDouble(ticksToSeconds(values[i]), attributeCPU, fields[0], attributeState, state)
+			}
+		}
+		cpus = append(cpus, fields[0])
+	}
+
+	// The frequencies are not available on all the hosts, such as most virtual machines.
+	var frequencies *dataPoints
+	for _, cpu := range cpus {
+		data, err := s.fs.readFile("sys", "devices", "system", "cpu", cpu, "cpufreq", "scaling_cur_freq")
+		if err != nil {
+			continue
+		}
+		khz, err := strconv.ParseUint(strings.TrimSpace(data), 10, 64)
+		if err != nil {
+			continue
+		}
+		if frequencies == nil {
+			frequencies = mb.gauge("system.cpu.frequency", "Current frequency of the CPU.", "Hz")
+		}
+		frequencies.addInt(int64(khz)*1000, attributeCPU, cpu)
+	}
+	return md, nil
+}
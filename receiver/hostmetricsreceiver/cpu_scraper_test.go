@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hostmetricsreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestCPUScraper(t *testing.T) {
+	md, err := (&cpuScraper{fs: testFS}).scrape(context.Background())
+	require.NoError(t, err)
+	metrics := metricsByName(t, md)
+
+	cpuTime := metrics["system.cpu.time"]
+	require.Equal(t, pdata.MetricDataTypeSum, cpuTime.DataType())
+	assert.True(t, cpuTime.Sum().IsMonotonic())
+	assert.Equal(t, "s", cpuTime.Unit())
+	assert.Equal(t, 2*len(cpuStates), cpuTime.Sum().DataPoints().Len())
+	dp := numberPoint(t, cpuTime, "cpu", "cpu1", "state", "user")
+	assert.Equal(t, 20.0, dp.DoubleVal())
+	assert.Equal(t, testBootTime, dp.StartTimestamp())
+	assert.Equal(t, 2.0, numberPoint(t, cpuTime, "cpu", "cpu0", "state", "wait").DoubleVal())
+	assert.Equal(t, 0.05, numberPoint(t, cpuTime, "cpu", "cpu0", "state", "steal").DoubleVal())
+
+	// Only the frequency of cpu0 is available.
+	frequency := metrics["system.cpu.frequency"]
+	require.Equal(t, 1, frequency.Gauge().DataPoints().Len())
+	assert.Equal(t, int64(2400000000), numberPoint(t, frequency, "cpu", "cpu0").IntVal())
+}
+
+func TestCPUScraperErrors(t *testing.T) {
+	_, err := (&cpuScraper{fs: hostFS{root: t.TempDir()}}).scrape(context.Background())
+	assert.Error(t, err)
+}
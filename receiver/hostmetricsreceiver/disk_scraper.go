@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hostmetricsreceiver // import "go.opentelemetry.io/collector/receiver/hostmetricsreceiver"
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// sectorSize is the size of the sectors counted in /proc/diskstats, independent of the device.
+const sectorSize = 512
+
+// diskScraper scrapes the disk I/O statistics from /proc/diskstats.
+type diskScraper struct {
+	fs      hostFS
+	exclude []*regexp.Regexp
+}
+
+func newDiskScraper(fs hostFS, cfg *DiskConfig) *diskScraper {
+	return &diskScraper{fs: fs, exclude: compilePatterns(cfg.ExcludeDevices)}
+}
+
+// diskStats are the statistics of a device in /proc/diskstats.
+type diskStats struct {
+	device                          string
+	reads, readsMerged              uint64
+	readSectors, readTime           uint64
+	writes, writesMerged            uint64
+	writtenSectors, writeTime       uint64
+	pending, ioTime, weightedIOTime uint64
+}
+
+func (s *diskScraper) scrape(context.Context) (pdata.Metrics, error) {
+	md := pdata.NewMetrics()
+	lines, err := s.fs.readLines("proc", "diskstats")
+	if err != nil {
+		return md, err
+	}
+	bootTime, err := s.fs.bootTime()
+	if err != nil {
+		return md, err
+	}
+
+	var stats []diskStats
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) < 14 {
+			return md, fmt.Errorf("invalid line %q in /proc/diskstats", line)
+		}
+		if matchesAny(s.exclude, fields[2]) {
+			continue
+		}
+		values, err := parseUints(fields[3:14])
+		if err != nil {
+			return md, fmt.Errorf("invalid line %q in /proc/diskstats: %w", line, err)
+		}
+		stats = append(stats, diskStats{
+			device:         fields[2],
+			reads:          values[0],
+			readsMerged:    values[1],
+			readSectors:    values[2],
+			readTime:       values[3],
+			writes:         values[4],
+			writesMerged:   values[5],
+			writtenSectors: values[6],
+			writeTime:      values[7],
+			pending:        values[8],
+			ioTime:         values[9],
+			weightedIOTime: values[10],
+		})
+	}
+
+	mb := newMetricsBuilder(md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics(),
+		bootTime, pdata.NewTimestampFromTime(time.Now()))
+	io := mb.sum("system.disk.io", "Disk bytes transferred.", "By", true)
+	operations := mb.sum("system.disk.operations", "Disk operations count.", "{operations}", true)
+	ioTime := mb.sum("system.disk.io_time", "Time disk spent activated.", "s", true)
+	operationTime := mb.sum("system.disk.operation_time", "Time spent in disk operations.", "s", true)
+	weightedIOTime := mb.sum("system.disk.weighted_io_time", "Time disk spent activated multiplied by the queue length.", "s", true)
+	pending := mb.sum("system.disk.pending_operations", "The queue size of pending I/O operations.", "{operations}", false)
+	merged := mb.sum("system.disk.merged", "The number of disk reads merged into single physical disk access operations.", "{operations}", true)
+	for _, st := range stats {
+		io.addInt(int64(st.readSectors*sectorSize), attributeDevice, st.device, attributeDirection, "read")
+		io.addInt(int64(st.writtenSectors*sectorSize), attributeDevice, st.device, attributeDirection, "write")
+		operations.addInt(int64(st.reads), attributeDevice, st.device, attributeDirection, "read")
+		operations.addInt(int64(st.writes), attributeDevice, st.device, attributeDirection, "write")
+		ioTime.addDouble(millisToSeconds(st.ioTime), attributeDevice, st.device)
+		operationTime.addDouble(millisToSeconds(st.readTime), attributeDevice, st.device, attributeDirection, "read")
+		operationTime.addDouble(millisToSeconds(st.writeTime), attributeDevice, st.device, attributeDirection, "write")
+		weightedIOTime.addDouble(millisToSeconds(st.weightedIOTime), attributeDevice, st.device)
+		pending.addInt(int64(st.pending), attributeDevice, st.device)
+		merged.addInt(int64(st.readsMerged), attributeDevice, st.device, attributeDirection, "read")
+		merged.addInt(int64(st.writesMerged), attributeDevice, st.device, attributeDirection, "write")
+	}
+	return md, nil
+}
+
+func millisToSeconds(ms uint64) float64 {
+	return float64(ms) / 1000
+}
@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hostmetricsreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskScraper(t *testing.T) {
+	md, err := newDiskScraper(testFS, &DiskConfig{}).scrape(context.Background())
+	require.NoError(t, err)
+	metrics := metricsByName(t, md)
+
+	io := metrics["system.disk.io"]
+	assert.Equal(t, 4, io.Sum().DataPoints().Len())
+	dp := numberPoint(t, io, "device", "sda", "direction", "read")
+	assert.Equal(t, int64(20000*512), dp.IntVal())
+	assert.Equal(t, testBootTime, dp.StartTimestamp())
+	assert.Equal(t, int64(40000*512), numberPoint(t, io, "device", "sda", "direction", "write").IntVal())
+
+	operations := metrics["system.disk.operations"]
+	assert.Equal(t, int64(1000), numberPoint(t, operations, "device", "sda", "direction", "read").IntVal())
+	assert.Equal(t, int64(2000), numberPoint(t, operations, "device", "sda", "direction", "write").IntVal())
+	assert.Equal(t, 7.0, numberPoint(t, metrics["system.disk.io_time"], "device", "sda").DoubleVal())
+	assert.Equal(t, 6.0, numberPoint(t, metrics["system.disk.operation_time"], "device", "sda", "direction", "write").DoubleVal())
+	assert.Equal(t, 9.0, numberPoint(t, metrics["system.disk.weighted_io_time"], "device", "sda").DoubleVal())
+	assert.Equal(t, int64(2), numberPoint(t, metrics["system.disk.pending_operations"], "device", "sda").IntVal())
+	assert.Equal(t, int64(100), numberPoint(t, metrics["system.disk.merged"], "device", "sda", "direction", "write").IntVal())
+}
+
+func TestDiskScraperExcludeDevices(t *testing.T) {
+	md, err := newDiskScraper(testFS, &DiskConfig{ExcludeDevices: []string{"^loop"}}).scrape(context.Background())
+	require.NoError(t, err)
+	io := metricsByName(t, md)["system.disk.io"]
+	assert.Equal(t, 2, io.Sum().DataPoints().Len())
+}
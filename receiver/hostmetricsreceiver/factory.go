@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hostmetricsreceiver // import "go.opentelemetry.io/collector/receiver/hostmetricsreceiver"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "hostmetrics"
+
+	defaultRootPath = "/"
+)
+
+// NewFactory creates a factory for the host metrics receiver.
+func NewFactory() component.ReceiverFactory {
+	return component.NewReceiverFactory(
+		typeStr,
+		createDefaultConfig,
+		component.WithMetricsReceiver(createMetricsReceiver))
+}
+
+func createDefaultConfig() config.Receiver {
+	return &Config{
+		ScraperControllerSettings: scraperhelper.NewDefaultScraperControllerSettings(typeStr),
+		RootPath:                  defaultRootPath,
+	}
+}
+
+func createMetricsReceiver(
+	_ context.Context,
+	set component.ReceiverCreateSettings,
+	cfg config.Receiver,
+	nextConsumer consumer.Metrics,
+) (component.MetricsReceiver, error) {
+	rCfg := cfg.(*Config)
+	scrapers, err := newScrapers(rCfg)
+	if err != nil {
+		return nil, err
+	}
+	options := make([]scraperhelper.ScraperControllerOption, 0, len(scrapers))
+	for _, scraper := range scrapers {
+		options = append(options, scraperhelper.AddScraper(scraper))
+	}
+	return scraperhelper.NewScraperControllerReceiver(&rCfg.ScraperControllerSettings, set, nextConsumer, options...)
+}
+
+// newScrapers creates the scrapers enabled in the configuration.
+func newScrapers(cfg *Config) ([]scraperhelper.Scraper, error) {
+	fs := hostFS{root: cfg.RootPath}
+	var scrapers []scraperhelper.Scraper
+	var err error
+	add := func(name string, scrape scraperhelper.ScrapeFunc) {
+		if err != nil {
+			return
+		}
+		var scraper scraperhelper.Scraper
+		if scraper, err = scraperhelper.NewScraper(name, scrape); err == nil {
+			scrapers = append(scrapers, scraper)
+		}
+	}
+	if cfg.CPU != nil {
+		add(cpuScraperName, (&cpuScraper{fs: fs}).scrape)
+	}
+	if cfg.Memory != nil {
+		add(memoryScraperName, (&memoryScraper{fs: fs}).scrape)
+	}
+	if cfg.Load != nil {
+		add(loadScraperName, (&loadScraper{fs: fs}).scrape)
+	}
+	if cfg.Disk != nil {
+		add(diskScraperName, newDiskScraper(fs, cfg.Disk).scrape)
+	}
+	if cfg.Filesystem != nil {
+		add(filesystemScraperName, newFilesystemScraper(fs, cfg.Filesystem).scrape)
+	}
+	if cfg.Network != nil {
+		add(networkScraperName, newNetworkScraper(fs, cfg.Network).scrape)
+	}
+	if cfg.Process != nil {
+		add(processScraperName, newProcessScraper(fs, cfg.Process).scrape)
+	}
+
+	return scrapers, err
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hostmetricsreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig()
+	assert.Equal(t, &Config{
+		ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+			ReceiverSettings:   config.NewReceiverSettings(config.NewComponentID(typeStr)),
+			CollectionInterval: time.Minute,
+		},
+		RootPath: "/",
+	}, cfg)
+	assert.NoError(t, configtest.CheckConfigStruct(cfg))
+}
+
+func TestNewScrapers(t *testing.T) {
+	tests := []struct {
+		name     string
+		scrapers Scrapers
+		want     []string
+	}{
+		{
+			name: "none",
+		},
+		{
+			name: "all",
+			scrapers: Scrapers{
+				CPU:        &CPUConfig{},
+				Memory:     &MemoryConfig{},
+				Load:       &LoadConfig{},
+				Disk:       &DiskConfig{},
+				Filesystem: &FilesystemConfig{},
+				Network:    &NetworkConfig{},
+				Process:    &ProcessConfig{},
+			},
+			want: []string{"cpu", "memory", "load", "disk", "filesystem", "network", "process"},
+		},
+		{
+			name: "some",
+			scrapers: Scrapers{
+				Memory:  &MemoryConfig{},
+				Network: &NetworkConfig{},
+			},
+			want: []string{"memory", "network"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			cfg.Scrapers = tt.scrapers
+
+			scrapers, err := newScrapers(cfg)
+			require.NoError(t, err)
+			var got []string
+			for _, scraper := range scrapers {
+				got = append(got, string(scraper.ID().Type()))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hostmetricsreceiver // import "go.opentelemetry.io/collector/receiver/hostmetricsreceiver"
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+)
+
+// filesystemMetricCount is the number of metrics of the filesystem scraper.
+const filesystemMetricCount = 2
+
+// fsStats are the statistics of a filesystem returned by statfs.
+type fsStats struct {
+	blockSize   uint64
+	blocks      uint64
+	freeBlocks  uint64
+	availBlocks uint64
+	inodes      uint64
+	freeInodes  uint64
+}
+
+// filesystemScraper scrapes the usage of the filesystems mounted in /proc/1/mounts.
+// The mounts of the first process are read, so that the filesystems are the ones of
+// the host when the collector runs in a container with the host /proc mounted.
+type filesystemScraper struct {
+	fs                 hostFS
+	excludeFSTypes     map[string]bool
+	excludeMountPoints []*regexp.Regexp
+}
+
+func newFilesystemScraper(fs hostFS, cfg *FilesystemConfig) *filesystemScraper {
+	s := &filesystemScraper{
+		fs:                 fs,
+		excludeFSTypes:     map[string]bool{},
+		excludeMountPoints: compilePatterns(cfg.ExcludeMountPoints),
+	}
+	for _, fsType := range cfg.ExcludeFSTypes {
+		s.excludeFSTypes[fsType] = true
+	}
+	return s
+}
+
+// mount is a line of /proc/mounts.
+type mount struct {
+	device     string
+	mountPoint string
+	fsType     string
+	mode       string
+}
+
+func (s *filesystemScraper) scrape(context.Context) (pdata.Metrics, error) {
+	md := pdata.NewMetrics()
+	lines, err := s.fs.readLines("proc", "1", "mounts")
+	if err != nil {
+		return md, err
+	}
+	bootTime, err := s.fs.bootTime()
+	if err != nil {
+		return md, err
+	}
+
+	mb := newMetricsBuilder(md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics(),
+		bootTime, pdata.NewTimestampFromTime(time.Now()))
+	usage := mb.sum("system.filesystem.usage", "Filesystem bytes used.", "By", false)
+	inodes := mb.sum("system.filesystem.inodes.usage", "FileSystem inodes used.", "{inodes}", false)
+
+	var errs scrapererror.ScrapeErrors
+	for _, line := range lines {
+		m, err := parseMount(line)
+		if err != nil {
+			return md, err
+		}
+		if s.excludeFSTypes[m.fsType] || matchesAny(s.excludeMountPoints, m.mountPoint) {
+			continue
+		}
+		st, err := statFS(s.fs.path(m.mountPoint))
+		if err != nil {
+			errs.AddPartial(filesystemMetricCount, fmt.Errorf("failed to read the usage of %s: %w", m.mountPoint, err))
+			continue
+		}
+
+		attrs := []string{attributeDevice, m.device, attributeMountPoint, m.mountPoint, attributeType, m.fsType, attributeMode, m.mode}
+		usage.addInt(int64((st.blocks-st.freeBlocks)*st.blockSize), append(attrs, attributeState, "used")...)
+		usage.addInt(int64(st.availBlocks*st.blockSize), append(attrs, attributeState, "free")...)
+		usage.addInt(int64((st.freeBlocks-st.availBlocks)*st.blockSize), append(attrs, attributeState, "reserved")...)
+		inodes.addInt(int64(st.inodes-st.freeInodes), append(attrs, attributeState, "used")...)
+		inodes.addInt(int64(st.freeInodes), append(attrs, attributeState, "free")...)
+	}
+	return md, errs.Combine()
+}
+
+func parseMount(line string) (mount, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 4 {
+		return mount{}, fmt.Errorf("invalid line %q in /proc/mounts", line)
+	}
+	m := mount{
+		device:     unescapeMountField(fields[0]),
+		mountPoint: unescapeMountField(fields[1]),
+		fsType:     fields[2],
+		mode:       "rw",
+	}
+	for _, option := range strings.Split(fields[3], ",") {
+		if option == "ro" {
+			m.mode = "ro"
+		}
+	}
+	return m, nil
+}
+
+// unescapeMountField replaces the octal escape sequences of the spaces, tabs,
+// newlines and backslashes in /proc/mounts.
+func unescapeMountField(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) {
+			if c, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(c))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
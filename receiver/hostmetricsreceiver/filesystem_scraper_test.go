@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hostmetricsreceiver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+)
+
+func TestFilesystemScraper(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("filesystem statistics are only supported on Linux")
+	}
+	md, err := newFilesystemScraper(testFS, &FilesystemConfig{
+		ExcludeFSTypes: []string{"proc"},
+	}).scrape(context.Background())
+	require.NoError(t, err)
+	metrics := metricsByName(t, md)
+
+	usage := metrics["system.filesystem.usage"]
+	assert.False(t, usage.Sum().IsMonotonic())
+	require.Equal(t, 6, usage.Sum().DataPoints().Len())
+	dp := numberPoint(t, usage, "device", "/dev/sda1", "mountpoint", "/", "type", "ext4", "mode", "rw", "state", "free")
+	assert.Greater(t, dp.IntVal(), int64(0))
+	assert.Equal(t, testBootTime, dp.StartTimestamp())
+	numberPoint(t, usage, "device", "tmpfs", "mountpoint", "/run dir", "type", "tmpfs", "mode", "ro", "state", "used")
+
+	inodes := metrics["system.filesystem.inodes.usage"]
+	require.Equal(t, 4, inodes.Sum().DataPoints().Len())
+}
+
+func TestFilesystemScraperExcludeMountPoints(t *testing.T) {
+	md, err := newFilesystemScraper(testFS, &FilesystemConfig{
+		ExcludeMountPoints: []string{"^/proc$", "^/run"},
+	}).scrape(context.Background())
+	if runtime.GOOS != "linux" {
+		require.Error(t, err)
+		return
+	}
+	require.NoError(t, err)
+	assert.Equal(t, 3, metricsByName(t, md)["system.filesystem.usage"].Sum().DataPoints().Len())
+}
+
+func TestFilesystemScraperMissingMountPoint(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "proc", "1"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "proc", "stat"), []byte("btime 1600000000\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "proc", "1", "mounts"), []byte("/dev/sdb1 /missing ext4 rw 0 0\n"), 0600))
+
+	_, err := newFilesystemScraper(hostFS{root: root}, &FilesystemConfig{}).scrape(context.Background())
+	require.Error(t, err)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+}
+
+func TestParseMount(t *testing.T) {
+	m, err := parseMount(`/dev/disk\040a /mnt/with\040space\134 xfs ro,noatime 0 0`)
+	require.NoError(t, err)
+	assert.Equal(t, mount{device: "/dev/disk a", mountPoint: `/mnt/with space\`, fsType: "xfs", mode: "ro"}, m)
+
+	_, err = parseMount("/dev/sda1 /")
+	assert.Error(t, err)
+}
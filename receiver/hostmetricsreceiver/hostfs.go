@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hostmetricsreceiver // import "go.opentelemetry.io/collector/receiver/hostmetricsreceiver"
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// clockTicks is the number of clock ticks per second of the times in procfs,
+// USER_HZ, which is 100 on all the supported architectures.
+const clockTicks = 100
+
+// hostFS reads the files of the host filesystem under its root path.
+type hostFS struct {
+	root string
+}
+
+func (fs hostFS) path(elem ...string) string {
+	return filepath.Join(append([]string{fs.root}, elem...)...)
+}
+
+func (fs hostFS) readFile(elem ...string) (string, error) {
+	data, err := os.ReadFile(fs.path(elem...))
+	return string(data), err
+}
+
+// readLines returns the non-empty lines of a file.
+func (fs hostFS) readLines(elem ...string) ([]string, error) {
+	f, err := os.Open(fs.path(elem...))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// bootTime returns the boot time of the host, from /proc/stat.
+func (fs hostFS) bootTime() (pdata.Timestamp, error) {
+	lines, err := fs.readLines("proc", "stat")
+	if err != nil {
+		return 0, err
+	}
+	return parseBootTime(lines)
+}
+
+// parseBootTime returns the boot time from the btime line of the lines of /proc/stat.
+func parseBootTime(lines []string) (pdata.Timestamp, error) {
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[0] == "btime" {
+			seconds, err := strconv.ParseInt(fields[1], 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid btime %q", fields[1])
+			}
+			return pdata.NewTimestampFromTime(time.Unix(seconds, 0)), nil
+		}
+	}
+	return 0, errors.New("missing btime in /proc/stat")
+}
+
+// parseUints parses the fields as unsigned integers.
+func parseUints(fields []string) ([]uint64, error) {
+	values := make([]uint64, len(fields))
+	for i, field := range fields {
+		v, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q", field)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// ticksToSeconds converts clock ticks into seconds.
+func ticksToSeconds(ticks uint64) float64 {
+	return float64(ticks) / clockTicks
+}
+
+// compilePatterns compiles regular expressions validated by the configuration.
+func compilePatterns(patterns []string) []*regexp.Regexp {
+	regexps := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		regexps = append(regexps, regexp.MustCompile(pattern))
+	}
+	return regexps
+}
+
+func matchesAny(regexps []*regexp.Regexp, s string) bool {
+	for _, re := range regexps {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
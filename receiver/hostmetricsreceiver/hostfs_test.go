@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hostmetricsreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// testFS is a host filesystem fixture.
+var testFS = hostFS{root: filepath.Join("testdata", "root")}
+
+var testBootTime = pdata.NewTimestampFromTime(time.Unix(1600000000, 0))
+
+func TestBootTime(t *testing.T) {
+	bootTime, err := testFS.bootTime()
+	require.NoError(t, err)
+	assert.Equal(t, testBootTime, bootTime)
+
+	_, err = parseBootTime([]string{"cpu 1 2 3"})
+	assert.Error(t, err)
+	_, err = parseBootTime([]string{"btime abc"})
+	assert.Error(t, err)
+	_, err = hostFS{root: t.TempDir()}.bootTime()
+	assert.Error(t, err)
+}
+
+// metricsByName returns the metrics of the single resource of md by name.
+func metricsByName(t *testing.T, md pdata.Metrics) map[string]pdata.Metric {
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	return resourceMetricsByName(md.ResourceMetrics().At(0))
+}
+
+func resourceMetricsByName(rm pdata.ResourceMetrics) map[string]pdata.Metric {
+	metrics := map[string]pdata.Metric{}
+	ms := rm.ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		metrics[ms.At(i).Name()] = ms.At(i)
+	}
+	return metrics
+}
+
+// numberPoint returns the data point of a sum or gauge with the given attributes,
+// as key and value pairs.
+func numberPoint(t *testing.T, m pdata.Metric, attrs ...string) pdata.NumberDataPoint {
+	var dps pdata.NumberDataPointSlice
+	switch m.DataType() {
+	case pdata.MetricDataTypeSum:
+		dps = m.Sum().DataPoints()
+	case pdata.MetricDataTypeGauge:
+		dps = m.Gauge().DataPoints()
+	default:
+		require.Failf(t, "not a number metric", "metric %q is a %v", m.Name(), m.DataType())
+	}
+	want := map[string]interface{}{}
+	for i := 0; i+1 < len(attrs); i += 2 {
+		want[attrs[i]] = attrs[i+1]
+	}
+	for i := 0; i < dps.Len(); i++ {
+		if assert.ObjectsAreEqual(want, dps.At(i).Attributes().AsRaw()) {
+			return dps.At(i)
+		}
+	}
+	require.Failf(t, "missing data point", "metric %s has no data point with attributes %v", m.Name(), want)
+	return pdata.NumberDataPoint{}
+}
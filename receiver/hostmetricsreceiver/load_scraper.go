@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hostmetricsreceiver // import "go.opentelemetry.io/collector/receiver/hostmetricsreceiver"
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// loadScraper scrapes the load averages from /proc/loadavg.
+type loadScraper struct {
+	fs hostFS
+}
+
+func (s *loadScraper) scrape(context.Context) (pdata.Metrics, error) {
+	md := pdata.NewMetrics()
+	data, err := s.fs.readFile("proc", "loadavg")
+	if err != nil {
+		return md, err
+	}
+	fields := strings.Fields(data)
+	if len(fields) < 3 {
+		return md, fmt.Errorf("invalid /proc/loadavg %q", data)
+	}
+	var loads [3]float64
+	for i := range loads {
+		if loads[i], err = strconv.ParseFloat(fields[i], 64); err != nil {
+			return md, fmt.Errorf("invalid load average %q", fields[i])
+		}
+	}
+
+	mb := newMetricsBuilder(md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics(),
+		0, pdata.NewTimestampFromTime(time.Now()))
+	mb.gauge("system.cpu.load_average.1m", "Average CPU Load over 1 minute.", "1").addDouble(loads[0])
+	mb.gauge("system.cpu.load_average.5m", "Average CPU Load over 5 minutes.", "1").addDouble(loads[1])
+	mb.gauge("system.cpu.load_average.15m", "Average CPU Load over 15 minutes.", "1").addDouble(loads[2])
+	return md, nil
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hostmetricsreceiver // import "go.opentelemetry.io/collector/receiver/hostmetricsreceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// memoryScraper scrapes the memory usage from /proc/meminfo.
+type memoryScraper struct {
+	fs hostFS
+}
+
+func (s *memoryScraper) scrape(context.Context) (pdata.Metrics, error) {
+	md := pdata.NewMetrics()
+	lines, err := s.fs.readLines("proc", "meminfo")
+	if err != nil {
+		return md, err
+	}
+	info := map[string]uint64{}
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		v, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return md, fmt.Errorf("invalid line %q in /proc/meminfo", line)
+		}
+		if len(fields) == 3 && fields[2] == "kB" {
+			v *= 1024
+		}
+		info[strings.TrimSuffix(fields[0], ":")] = v
+	}
+	total := info["MemTotal"]
+	if total == 0 {
+		return md, errors.New("missing MemTotal in /proc/meminfo")
+	}
+
+	states := []struct {
+		name  string
+		value uint64
+	}{
+		{"used", 0},
+		{"free", info["MemFree"]},
+		{"buffered", info["Buffers"]},
+		{"cached", info["Cached"]},
+		{"slab_reclaimable", info["SReclaimable"]},
+		{"slab_unreclaimable", info["SUnreclaim"]},
+	}
+	used := int64(total)
+	for _, state := range states[1:] {
+		used -= int64(state.value)
+	}
+	if used > 0 {
+		states[0].value = uint64(used)
+	}
+
+	bootTime, err := s.fs.bootTime()
+	if err != nil {
+		return md, err
+	}
+
+	mb := newMetricsBuilder(md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics(),
+		bootTime, pdata.NewTimestampFromTime(time.Now()))
+	usage := mb.sum("system.memory.usage", "Bytes of memory in use.", "By", false)
+	utilization := mb.gauge("system.memory.utilization", "Percentage of memory bytes in use.", "1")
+	for _, state := range states {
+		usage.addInt(int64(state.value), attributeState, state.name)
+		utilization.addDouble(float64(state.value)/float64(total), attributeState, state.name)
+	}
+	return md, nil
+}
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hostmetricsreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryScraper(t *testing.T) {
+	md, err := (&memoryScraper{fs: testFS}).scrape(context.Background())
+	require.NoError(t, err)
+	metrics := metricsByName(t, md)
+
+	usage := metrics["system.memory.usage"]
+	assert.False(t, usage.Sum().IsMonotonic())
+	assert.Equal(t, "By", usage.Unit())
+	for state, kb := range map[string]int64{
+		"used":               3600000,
+		"free":               2000000,
+		"buffered":           500000,
+		"cached":             1500000,
+		"slab_reclaimable":   300000,
+		"slab_unreclaimable": 100000,
+	} {
+		assert.Equal(t, kb*1024, numberPoint(t, usage, "state", state).IntVal(), state)
+	}
+
+	utilization := metrics["system.memory.utilization"]
+	assert.Equal(t, 0.45, numberPoint(t, utilization, "state", "used").DoubleVal())
+}
+
+func TestLoadScraper(t *testing.T) {
+	md, err := (&loadScraper{fs: testFS}).scrape(context.Background())
+	require.NoError(t, err)
+	metrics := metricsByName(t, md)
+
+	assert.Equal(t, 0.5, numberPoint(t, metrics["system.cpu.load_average.1m"]).DoubleVal())
+	assert.Equal(t, 0.25, numberPoint(t, metrics["system.cpu.load_average.5m"]).DoubleVal())
+	assert.Equal(t, 0.1, numberPoint(t, metrics["system.cpu.load_average.15m"]).DoubleVal())
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hostmetricsreceiver // import "go.opentelemetry.io/collector/receiver/hostmetricsreceiver"
+
+import (
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// The attributes of the data points.
+const (
+	attributeCPU        = "cpu"
+	attributeState      = "state"
+	attributeDevice     = "device"
+	attributeDirection  = "direction"
+	attributeMountPoint = "mountpoint"
+	attributeType       = "type"
+	attributeMode       = "mode"
+)
+
+// metricsBuilder appends metrics with the same timestamps to a metric slice.
+type metricsBuilder struct {
+	metrics pdata.MetricSlice
+	start   pdata.Timestamp
+	now     pdata.Timestamp
+}
+
+func newMetricsBuilder(ms pdata.MetricSlice, start, now pdata.Timestamp) *metricsBuilder {
+	return &metricsBuilder{metrics: ms, start: start, now: now}
+}
+
+// sum appends a cumulative sum and returns its data points.
+func (mb *metricsBuilder) sum(name, description, unit string, monotonic bool) *dataPoints {
+	m := mb.metric(name, description, unit, pdata.MetricDataTypeSum)
+	m.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+	m.Sum().SetIsMonotonic(monotonic)
+	return &dataPoints{dps: m.Sum().DataPoints(), start: mb.start, now: mb.now}
+}
+
+// gauge appends a gauge and returns its data points.
+func (mb *metricsBuilder) gauge(name, description, unit string) *dataPoints {
+	m := mb.metric(name, description, unit, pdata.MetricDataTypeGauge)
+	return &dataPoints{dps: m.Gauge().DataPoints(), now: mb.now}
+}
+
+func (mb *metricsBuilder) metric(name, description, unit string, dataType pdata.MetricDataType) pdata.Metric {
+	m := mb.metrics.AppendEmpty()
+	m.SetName(name)
+	m.SetDescription(description)
+	m.SetUnit(unit)
+	m.SetDataType(dataType)
+	return m
+}
+
+// dataPoints appends the data points of a metric.
+type dataPoints struct {
+	dps   pdata.NumberDataPointSlice
+	start pdata.Timestamp
+	now   pdata.Timestamp
+}
+
+// addInt appends a data point with the given attributes, as key and value pairs.
+func (d *dataPoints) addInt(value int64, attrs ...string) {
+	d.add(attrs).SetIntVal(value)
+}
+
+// addDouble appends a data point with the given attributes, as key and value pairs.
+func (d *dataPoints) addDouble(value float64, attrs ...string) {
+	d.add(attrs).SetDoubleVal(value)
+}
+
+func (d *dataPoints) add(attrs []string) pdata.NumberDataPoint {
+	dp := d.dps.AppendEmpty()
+	dp.SetStartTimestamp(d.start)
+	dp.SetTimestamp(d.now)
+	for i := 0; i+1 < len(attrs); i += 2 {
+		dp.Attributes().UpsertString(attrs[i], attrs[i+1])
+	}
+	return dp
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hostmetricsreceiver // import "go.opentelemetry.io/collector/receiver/hostmetricsreceiver"
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// networkScraper scrapes the network interfaces statistics from /proc/1/net/dev. The
+// file of the first process is read, so that the interfaces are the ones of the host
+// when the collector runs in a container with the host /proc mounted.
+type networkScraper struct {
+	fs      hostFS
+	exclude []*regexp.Regexp
+}
+
+func newNetworkScraper(fs hostFS, cfg *NetworkConfig) *networkScraper {
+	return &networkScraper{fs: fs, exclude: compilePatterns(cfg.ExcludeInterfaces)}
+}
+
+func (s *networkScraper) scrape(context.Context) (pdata.Metrics, error) {
+	md := pdata.NewMetrics()
+	lines, err := s.fs.readLines("proc", "1", "net", "dev")
+	if err != nil {
+		return md, err
+	}
+	bootTime, err := s.fs.bootTime()
+	if err != nil {
+		return md, err
+	}
+
+	mb := newMetricsBuilder(md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics(),
+		bootTime, pdata.NewTimestampFromTime(time.Now()))
+	io := mb.sum("system.network.io", "The number of bytes transmitted and received.", "By", true)
+	packets := mb.sum("system.network.packets", "The number of packets transferred.", "{packets}", true)
+	errs := mb.sum("system.network.errors", "The number of errors encountered.", "{errors}", true)
+	dropped := mb.sum("system.network.dropped", "The number of packets dropped.", "{packets}", true)
+	for _, line := range lines {
+		// The first two lines are headers.
+		sep := strings.IndexByte(line, ':')
+		if sep < 0 {
+			continue
+		}
+		device := strings.TrimSpace(line[:sep])
+		if matchesAny(s.exclude, device) {
+			continue
+		}
+		fields := strings.Fields(line[sep+1:])
+		if len(fields) < 16 {
+			return md, fmt.Errorf("invalid line %q in /proc/net/dev", line)
+		}
+		values, err := parseUints(fields[:16])
+		if err != nil {
+			return md, fmt.Errorf("invalid line %q in /proc/net/dev: %w", line, err)
+		}
+		io.addInt(int64(values[0]), attributeDevice, device, attributeDirection, "receive")
+		io.addInt(int64(values[8]), attributeDevice, device, attributeDirection, "transmit")
+		packets.addInt(int64(values[1]), attributeDevice, device, attributeDirection, "receive")
+		packets.addInt(int64(values[9]), attributeDevice, device, attributeDirection, "transmit")
+		errs.addInt(int64(values[2]), attributeDevice, device, attributeDirection, "receive")
+		errs.addInt(int64(values[10]), attributeDevice, device, attributeDirection, "transmit")
+		dropped.addInt(int64(values[3]), attributeDevice, device, attributeDirection, "receive")
+		dropped.addInt(int64(values[11]), attributeDevice, device, attributeDirection, "transmit")
+	}
+	return md, nil
+}
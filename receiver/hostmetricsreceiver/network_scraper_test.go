@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hostmetricsreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkScraper(t *testing.T) {
+	md, err := newNetworkScraper(testFS, &NetworkConfig{ExcludeInterfaces: []string{"^lo$"}}).scrape(context.Background())
+	require.NoError(t, err)
+	metrics := metricsByName(t, md)
+
+	io := metrics["system.network.io"]
+	require.Equal(t, 2, io.Sum().DataPoints().Len())
+	dp := numberPoint(t, io, "device", "eth0", "direction", "receive")
+	assert.Equal(t, int64(500000), dp.IntVal())
+	assert.Equal(t, testBootTime, dp.StartTimestamp())
+	assert.Equal(t, int64(200000), numberPoint(t, io, "device", "eth0", "direction", "transmit").IntVal())
+	assert.Equal(t, int64(1500), numberPoint(t, metrics["system.network.packets"], "device", "eth0", "direction", "transmit").IntVal())
+	assert.Equal(t, int64(1), numberPoint(t, metrics["system.network.errors"], "device", "eth0", "direction", "receive").IntVal())
+	assert.Equal(t, int64(4), numberPoint(t, metrics["system.network.dropped"], "device", "eth0", "direction", "transmit").IntVal())
+}
@@ -0,0 +1,223 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hostmetricsreceiver // import "go.opentelemetry.io/collector/receiver/hostmetricsreceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.9.0"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+)
+
+// processMetricCount is the number of metrics of the process scraper.
+const processMetricCount = 4
+
+// processScraper scrapes the processes from /proc/<pid>, each process being a resource.
+type processScraper struct {
+	fs       hostFS
+	include  []*regexp.Regexp
+	exclude  []*regexp.Regexp
+	pageSize uint64
+}
+
+func newProcessScraper(hfs hostFS, cfg *ProcessConfig) *processScraper {
+	return &processScraper{
+		fs:       hfs,
+		include:  compilePatterns(cfg.IncludeNames),
+		exclude:  compilePatterns(cfg.ExcludeNames),
+		pageSize: uint64(os.Getpagesize()),
+	}
+}
+
+// process is the information of a process read from procfs.
+type process struct {
+	pid            string
+	executableName string
+	executablePath string
+	command        string
+	commandLine    string
+	owner          string
+
+	startTime       pdata.Timestamp
+	userTime        float64
+	systemTime      float64
+	residentMemory  uint64
+	virtualMemory   uint64
+	readBytes       uint64
+	writtenBytes    uint64
+	hasIOStatistics bool
+}
+
+func (s *processScraper) scrape(context.Context) (pdata.Metrics, error) {
+	md := pdata.NewMetrics()
+	entries, err := os.ReadDir(s.fs.path("proc"))
+	if err != nil {
+		return md, err
+	}
+	bootTime, err := s.fs.bootTime()
+	if err != nil {
+		return md, err
+	}
+	users := s.users()
+	now := pdata.NewTimestampFromTime(time.Now())
+
+	var errs scrapererror.ScrapeErrors
+	for _, entry := range entries {
+		pid := entry.Name()
+		if _, err := strconv.ParseUint(pid, 10, 64); err != nil || !entry.IsDir() {
+			continue
+		}
+		p, err := s.readProcess(pid, bootTime, users)
+		if err != nil {
+			// The processes that exited since the directory was listed are ignored.
+			if !errors.Is(err, fs.ErrNotExist) {
+				errs.AddPartial(processMetricCount, fmt.Errorf("failed to read process %s: %w", pid, err))
+			}
+			continue
+		}
+		if (len(s.include) > 0 && !matchesAny(s.include, p.executableName)) || matchesAny(s.exclude, p.executableName) {
+			continue
+		}
+		p.appendMetrics(md.ResourceMetrics().AppendEmpty(), now)
+	}
+	return md, errs.Combine()
+}
+
+func (s *processScraper) readProcess(pid string, bootTime pdata.Timestamp, users map[string]string) (*process, error) {
+	stat, err := s.fs.readFile("proc", pid, "stat")
+	if err != nil {
+		return nil, err
+	}
+	// The command name is between parentheses and can contain spaces and parentheses.
+	nameStart, nameEnd := strings.IndexByte(stat, '('), strings.LastIndexByte(stat, ')')
+	if nameStart < 0 || nameEnd < nameStart {
+		return nil, fmt.Errorf("invalid stat %q", stat)
+	}
+	fields := strings.Fields(stat[nameEnd+1:])
+	if len(fields) < 22 {
+		return nil, fmt.Errorf("invalid stat %q", stat)
+	}
+	values, err := parseUints([]string{fields[11], fields[12], fields[19], fields[20], fields[21]})
+	if err != nil {
+		return nil, fmt.Errorf("invalid stat %q: %w", stat, err)
+	}
+
+	p := &process{
+		pid:            pid,
+		executableName: stat[nameStart+1 : nameEnd],
+		userTime:       ticksToSeconds(values[0]),
+		systemTime:     ticksToSeconds(values[1]),
+		startTime:      bootTime + pdata.Timestamp(values[2]*uint64(time.Second)/clockTicks),
+		virtualMemory:  values[3],
+		residentMemory: values[4] * s.pageSize,
+	}
+
+	// The executable path and the I/O statistics are only readable by the owner of the process.
+	if path, err := os.Readlink(s.fs.path("proc", pid, "exe")); err == nil {
+		p.executablePath = path
+		p.executableName = filepath.Base(path)
+	}
+	if cmdline, err := s.fs.readFile("proc", pid, "cmdline"); err == nil {
+		args := strings.Split(strings.TrimRight(cmdline, "\x00"), "\x00")
+		p.command = args[0]
+		p.commandLine = strings.Join(args, " ")
+	}
+	if lines, err := s.fs.readLines("proc", pid, "status"); err == nil {
+		for _, line := range lines {
+			if fields := strings.Fields(line); len(fields) > 1 && fields[0] == "Uid:" {
+				p.owner = fields[1]
+				if name, ok := users[fields[1]]; ok {
+					p.owner = name
+				}
+			}
+		}
+	}
+	if lines, err := s.fs.readLines("proc", pid, "io"); err == nil {
+		for _, line := range lines {
+			fields := strings.Fields(line)
+			if len(fields) != 2 {
+				continue
+			}
+			v, err := strconv.ParseUint(fields[1], 10, 64)
+			if err != nil {
+				continue
+			}
+			switch fields[0] {
+			case "read_bytes:":
+				p.readBytes = v
+				p.hasIOStatistics = true
+			case "write_bytes:":
+				p.writtenBytes = v
+				p.hasIOStatistics = true
+			}
+		}
+	}
+	return p, nil
+}
+
+// users returns the names of the users of the host by ID, from /etc/passwd.
+func (s *processScraper) users() map[string]string {
+	users := map[string]string{}
+	lines, err := s.fs.readLines("etc", "passwd")
+	if err != nil {
+		return users
+	}
+	for _, line := range lines {
+		fields := strings.Split(line, ":")
+		if len(fields) > 2 {
+			users[fields[2]] = fields[0]
+		}
+	}
+	return users
+}
+
+func (p *process) appendMetrics(rm pdata.ResourceMetrics, now pdata.Timestamp) {
+	attrs := rm.Resource().Attributes()
+	pid, _ := strconv.ParseInt(p.pid, 10, 64)
+	attrs.InsertInt(conventions.AttributeProcessPID, pid)
+	attrs.InsertString(conventions.AttributeProcessExecutableName, p.executableName)
+	if p.executablePath != "" {
+		attrs.InsertString(conventions.AttributeProcessExecutablePath, p.executablePath)
+	}
+	if p.command != "" {
+		attrs.InsertString(conventions.AttributeProcessCommand, p.command)
+		attrs.InsertString(conventions.AttributeProcessCommandLine, p.commandLine)
+	}
+	if p.owner != "" {
+		attrs.InsertString(conventions.AttributeProcessOwner, p.owner)
+	}
+
+	mb := newMetricsBuilder(rm.ScopeMetrics().AppendEmpty().Metrics(), p.startTime, now)
+	cpuTime := mb.sum("process.cpu.time", "Total CPU seconds broken down by different states.", "s", true)
+	cpuTime.addDouble(p.userTime, attributeState, "user")
+	cpuTime.addDouble(p.systemTime, attributeState, "system")
+	mb.sum("process.memory.physical_usage", "The amount of physical memory in use.", "By", false).addInt(int64(p.residentMemory))
+	mb.sum("process.memory.virtual_usage", "Virtual memory size.", "By", false).addInt(int64(p.virtualMemory))
+	if p.hasIOStatistics {
+		io := mb.sum("process.disk.io", "Disk bytes transferred.", "By", true)
+		io.addInt(int64(p.readBytes), attributeDirection, "read")
+		io.addInt(int64(p.writtenBytes), attributeDirection, "write")
+	}
+}
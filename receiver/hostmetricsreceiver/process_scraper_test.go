@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hostmetricsreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestProcessScraper(t *testing.T) {
+	md, err := newProcessScraper(testFS, &ProcessConfig{}).scrape(context.Background())
+	require.NoError(t, err)
+	// The fixture process 1 only has the mounts and network statistics of the host.
+	require.Equal(t, 2, md.ResourceMetrics().Len())
+
+	rm := md.ResourceMetrics().At(0)
+	assert.Equal(t, map[string]interface{}{
+		"process.pid":             int64(42),
+		"process.executable.name": "my-server",
+		"process.executable.path": "/usr/bin/my-server",
+		"process.command":         "my-server",
+		"process.command_line":    "my-server --port 8080",
+		"process.owner":           "app",
+	}, rm.Resource().Attributes().AsRaw())
+
+	metrics := resourceMetricsByName(rm)
+	cpuTime := metrics["process.cpu.time"]
+	dp := numberPoint(t, cpuTime, "state", "user")
+	assert.Equal(t, 2.5, dp.DoubleVal())
+	assert.Equal(t, testBootTime+pdata.Timestamp(10*time.Second), dp.StartTimestamp())
+	assert.Equal(t, 0.5, numberPoint(t, cpuTime, "state", "system").DoubleVal())
+	assert.Equal(t, int64(256*newProcessScraper(testFS, &ProcessConfig{}).pageSize), numberPoint(t, metrics["process.memory.physical_usage"]).IntVal())
+	assert.Equal(t, int64(10485760), numberPoint(t, metrics["process.memory.virtual_usage"]).IntVal())
+	assert.Equal(t, int64(8192), numberPoint(t, metrics["process.disk.io"], "direction", "write").IntVal())
+
+	// The optional files of the process 43 are missing.
+	rm = md.ResourceMetrics().At(1)
+	assert.Equal(t, map[string]interface{}{
+		"process.pid":             int64(43),
+		"process.executable.name": "cron",
+	}, rm.Resource().Attributes().AsRaw())
+	assert.NotContains(t, resourceMetricsByName(rm), "process.disk.io")
+}
+
+func TestProcessScraperFilters(t *testing.T) {
+	md, err := newProcessScraper(testFS, &ProcessConfig{IncludeNames: []string{"^my-"}}).scrape(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+
+	md, err = newProcessScraper(testFS, &ProcessConfig{ExcludeNames: []string{"^my-"}}).scrape(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	pid, _ := md.ResourceMetrics().At(0).Resource().Attributes().Get("process.pid")
+	assert.Equal(t, int64(43), pid.IntVal())
+}
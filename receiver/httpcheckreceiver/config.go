@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpcheckreceiver // import "go.opentelemetry.io/collector/receiver/httpcheckreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+)
+
+// Config defines configuration for the HTTP check receiver.
+type Config struct {
+	scraperhelper.ScraperControllerSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Targets are the checked HTTP endpoints.
+	Targets []TargetConfig `mapstructure:"targets"`
+}
+
+// TargetConfig defines a checked HTTP endpoint.
+type TargetConfig struct {
+	// HTTPClientSettings configures the HTTP client checking the target, its
+	// endpoint being the requested URL.
+	confighttp.HTTPClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Method is the method of the requests. Defaults to "GET".
+	Method string `mapstructure:"method"`
+
+	// ExpectedStatusCodes are the status codes of a successful check. Defaults to
+	// any 2xx status code.
+	ExpectedStatusCodes []int `mapstructure:"expected_status_codes"`
+
+	// ExpectedBody, if set, is a regular expression the body of the responses
+	// must match for the check to succeed.
+	ExpectedBody string `mapstructure:"expected_body"`
+}
+
+var _ config.Receiver = (*Config)(nil)
+
+// Validate checks the receiver configuration is valid
+func (cfg *Config) Validate() error {
+	if len(cfg.Targets) == 0 {
+		return errors.New("at least one target must be specified")
+	}
+	for i, target := range cfg.Targets {
+		if err := target.validate(); err != nil {
+			return fmt.Errorf("target %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (target *TargetConfig) validate() error {
+	if target.Endpoint == "" {
+		return errors.New("endpoint must be specified")
+	}
+	u, err := url.Parse(target.Endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid endpoint: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported scheme %q of endpoint %q, must be http or https", u.Scheme, target.Endpoint)
+	}
+	for _, code := range target.ExpectedStatusCodes {
+		if code < 100 || code > 599 {
+			return fmt.Errorf("invalid expected status code %d", code)
+		}
+	}
+	if _, err := regexp.Compile(target.ExpectedBody); err != nil {
+		return fmt.Errorf("invalid expected_body: %w", err)
+	}
+	return nil
+}
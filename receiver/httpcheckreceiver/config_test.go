@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpcheckreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+	"go.opentelemetry.io/collector/service/servicetest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := servicetest.LoadConfigAndValidate(filepath.Join("testdata", "config.yaml"), factories)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, len(cfg.Receivers), 2)
+
+	r0 := cfg.Receivers[config.NewComponentID(typeStr)]
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.Targets = []TargetConfig{{HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: "http://localhost:8080/health"}}}
+	assert.Equal(t, defaultCfg, r0)
+
+	r1 := cfg.Receivers[config.NewComponentIDWithName(typeStr, "customname")]
+	assert.Equal(t,
+		&Config{
+			ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+				ReceiverSettings:   config.NewReceiverSettings(config.NewComponentIDWithName(typeStr, "customname")),
+				CollectionInterval: 30 * time.Second,
+			},
+			Targets: []TargetConfig{
+				{
+					HTTPClientSettings: confighttp.HTTPClientSettings{
+						Endpoint: "https://api.example.com/status",
+						Timeout:  5 * time.Second,
+						TLSSetting: configtls.TLSClientSetting{
+							InsecureSkipVerify: true,
+						},
+						Headers: map[string]string{"Authorization": "Bearer token"},
+					},
+					Method:              "HEAD",
+					ExpectedStatusCodes: []int{200, 204},
+					ExpectedBody:        `"status":\s*"ok"`,
+				},
+			},
+		}, r1)
+}
+
+func TestValidateConfig(t *testing.T) {
+	testCases := []struct {
+		name   string
+		modify func(cfg *Config)
+	}{
+		{
+			name:   "no targets",
+			modify: func(cfg *Config) { cfg.Targets = nil },
+		},
+		{
+			name:   "no endpoint",
+			modify: func(cfg *Config) { cfg.Targets[0].Endpoint = "" },
+		},
+		{
+			name:   "invalid endpoint",
+			modify: func(cfg *Config) { cfg.Targets[0].Endpoint = "http://[::1" },
+		},
+		{
+			name:   "unsupported scheme",
+			modify: func(cfg *Config) { cfg.Targets[0].Endpoint = "ftp://localhost/file" },
+		},
+		{
+			name:   "invalid expected status code",
+			modify: func(cfg *Config) { cfg.Targets[0].ExpectedStatusCodes = []int{200, 600} },
+		},
+		{
+			name:   "invalid expected_body",
+			modify: func(cfg *Config) { cfg.Targets[0].ExpectedBody = "(" },
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Targets = []TargetConfig{{HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: "http://localhost:8080/health"}}}
+			require.NoError(t, cfg.Validate())
+			tt.modify(cfg)
+			assert.Error(t, cfg.Validate())
+		})
+	}
+}
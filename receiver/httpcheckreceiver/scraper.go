@@ -0,0 +1,179 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpcheckreceiver // import "go.opentelemetry.io/collector/receiver/httpcheckreceiver"
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/model/pdata"
+	semconv "go.opentelemetry.io/collector/model/semconv/v1.5.0"
+)
+
+const (
+	defaultMethod = http.MethodGet
+
+	// maxBodySize is the maximum number of bytes read from a body, and matched
+	// against the expected body.
+	maxBodySize = 1 << 20
+
+	metricDuration   = "httpcheck.duration"
+	metricStatusCode = "httpcheck.status_code"
+	metricSuccess    = "httpcheck.success"
+	metricTLSExpiry  = "httpcheck.tls.expiry"
+)
+
+// checkScraper checks a target.
+type checkScraper struct {
+	cfg          TargetConfig
+	settings     component.TelemetrySettings
+	method       string
+	expectedBody *regexp.Regexp
+	client       *http.Client
+	now          func() time.Time
+}
+
+func newCheckScraper(cfg TargetConfig, settings component.TelemetrySettings) (*checkScraper, error) {
+	cs := &checkScraper{cfg: cfg, settings: settings, method: cfg.Method, now: time.Now}
+	if cs.method == "" {
+		cs.method = defaultMethod
+	}
+	if cfg.ExpectedBody != "" {
+		var err error
+		if cs.expectedBody, err = regexp.Compile(cfg.ExpectedBody); err != nil {
+			return nil, err
+		}
+	}
+	return cs, nil
+}
+
+func (s *checkScraper) start(_ context.Context, host component.Host) error {
+	var err error
+	s.client, err = s.cfg.ToClient(host.GetExtensions(), s.settings)
+	return err
+}
+
+// scrape checks the target. A failed check is not a scrape error, it is
+// reported by the httpcheck.success metric.
+func (s *checkScraper) scrape(ctx context.Context) (pdata.Metrics, error) {
+	md := pdata.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+
+	start := s.now()
+	ts := pdata.NewTimestampFromTime(start)
+	resp, err := s.do(ctx)
+	duration := s.now().Sub(start)
+
+	success := err == nil
+	if err != nil {
+		s.settings.Logger.Debug("HTTP check failed", zap.String("endpoint", s.cfg.Endpoint), zap.Error(err))
+	}
+	s.appendDoubleGauge(ms, metricDuration, "Duration of the check, response body included.", "s", ts, duration.Seconds())
+	if resp != nil {
+		success = success && s.checkStatusCode(resp.statusCode) && resp.bodyMatched
+		s.appendIntGauge(ms, metricStatusCode, "Status code of the response.", "1", ts, int64(resp.statusCode))
+		if resp.tlsExpiry != nil {
+			s.appendDoubleGauge(ms, metricTLSExpiry, "Time until the earliest expiry of the certificates presented by the target.", "s", ts, resp.tlsExpiry.Sub(start).Seconds())
+		}
+	}
+	var successValue int64
+	if success {
+		successValue = 1
+	}
+	s.appendIntGauge(ms, metricSuccess, "Whether the check succeeded (1) or failed (0).", "1", ts, successValue)
+	return md, nil
+}
+
+// checkResponse is the outcome of a request which received a response.
+type checkResponse struct {
+	statusCode  int
+	bodyMatched bool
+	tlsExpiry   *time.Time
+}
+
+// do requests the target. It returns a response, if any, even along an error
+// reading its body.
+func (s *checkScraper) do(ctx context.Context) (*checkResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, s.method, s.cfg.Endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	cr := &checkResponse{statusCode: resp.StatusCode, bodyMatched: true}
+	if resp.TLS != nil {
+		for _, cert := range resp.TLS.PeerCertificates {
+			if cr.tlsExpiry == nil || cert.NotAfter.Before(*cr.tlsExpiry) {
+				notAfter := cert.NotAfter
+				cr.tlsExpiry = &notAfter
+			}
+		}
+	}
+	if s.expectedBody == nil {
+		_, err = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, maxBodySize))
+		return cr, err
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		cr.bodyMatched = false
+		return cr, err
+	}
+	cr.bodyMatched = s.expectedBody.Match(body)
+	return cr, nil
+}
+
+func (s *checkScraper) checkStatusCode(code int) bool {
+	if len(s.cfg.ExpectedStatusCodes) == 0 {
+		return code >= 200 && code < 300
+	}
+	for _, expected := range s.cfg.ExpectedStatusCodes {
+		if code == expected {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *checkScraper) appendDoubleGauge(ms pdata.MetricSlice, name, description, unit string, ts pdata.Timestamp, value float64) {
+	s.appendGauge(ms, name, description, unit, ts).SetDoubleVal(value)
+}
+
+func (s *checkScraper) appendIntGauge(ms pdata.MetricSlice, name, description, unit string, ts pdata.Timestamp, value int64) {
+	s.appendGauge(ms, name, description, unit, ts).SetIntVal(value)
+}
+
+func (s *checkScraper) appendGauge(ms pdata.MetricSlice, name, description, unit string, ts pdata.Timestamp) pdata.NumberDataPoint {
+	m := ms.AppendEmpty()
+	m.SetName(name)
+	m.SetDescription(description)
+	m.SetUnit(unit)
+	m.SetDataType(pdata.MetricDataTypeGauge)
+	dp := m.Gauge().DataPoints().AppendEmpty()
+	dp.SetTimestamp(ts)
+	dp.Attributes().UpsertString(semconv.AttributeHTTPURL, s.cfg.Endpoint)
+	dp.Attributes().UpsertString(semconv.AttributeHTTPMethod, s.method)
+	return dp
+}
@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpcheckreceiver
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func startTestServer(t *testing.T, status int, body string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestCheckScraper(t *testing.T, cfg TargetConfig) *checkScraper {
+	cs, err := newCheckScraper(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	require.NoError(t, cs.start(context.Background(), componenttest.NewNopHost()))
+	return cs
+}
+
+func TestScrape(t *testing.T) {
+	testCases := []struct {
+		name    string
+		status  int
+		body    string
+		cfg     TargetConfig
+		success int64
+	}{
+		{
+			name:    "success",
+			status:  http.StatusOK,
+			body:    "ok",
+			success: 1,
+		},
+		{
+			name:    "unexpected status code",
+			status:  http.StatusServiceUnavailable,
+			success: 0,
+		},
+		{
+			name:    "expected status code",
+			status:  http.StatusServiceUnavailable,
+			cfg:     TargetConfig{ExpectedStatusCodes: []int{http.StatusServiceUnavailable}},
+			success: 1,
+		},
+		{
+			name:    "body match",
+			status:  http.StatusOK,
+			body:    `{"status": "ok"}`,
+			cfg:     TargetConfig{ExpectedBody: `"status":\s*"ok"`},
+			success: 1,
+		},
+		{
+			name:    "body mismatch",
+			status:  http.StatusOK,
+			body:    `{"status": "degraded"}`,
+			cfg:     TargetConfig{ExpectedBody: `"status":\s*"ok"`},
+			success: 0,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := startTestServer(t, tt.status, tt.body)
+			tt.cfg.Endpoint = server.URL
+			cs := newTestCheckScraper(t, tt.cfg)
+
+			md, err := cs.scrape(context.Background())
+			require.NoError(t, err)
+			metrics := metricsByName(md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics())
+			assert.Len(t, metrics, 3)
+			assert.Equal(t, tt.success, metrics[metricSuccess].Gauge().DataPoints().At(0).IntVal())
+			assert.EqualValues(t, tt.status, metrics[metricStatusCode].Gauge().DataPoints().At(0).IntVal())
+			assert.GreaterOrEqual(t, metrics[metricDuration].Gauge().DataPoints().At(0).DoubleVal(), 0.0)
+
+			attrs := metrics[metricSuccess].Gauge().DataPoints().At(0).Attributes().AsRaw()
+			assert.Equal(t, map[string]interface{}{"http.url": server.URL, "http.method": "GET"}, attrs)
+		})
+	}
+}
+
+func TestScrapeTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(server.Close)
+	cs := newTestCheckScraper(t, TargetConfig{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint:   server.URL,
+			TLSSetting: configtls.TLSClientSetting{InsecureSkipVerify: true},
+		},
+		Method: http.MethodHead,
+	})
+
+	md, err := cs.scrape(context.Background())
+	require.NoError(t, err)
+	metrics := metricsByName(md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics())
+	assert.Len(t, metrics, 4)
+	assert.EqualValues(t, 1, metrics[metricSuccess].Gauge().DataPoints().At(0).IntVal())
+	expiry := server.Certificate().NotAfter
+	assert.InDelta(t, time.Until(expiry).Seconds(), metrics[metricTLSExpiry].Gauge().DataPoints().At(0).DoubleVal(), 60)
+	assert.Equal(t, "HEAD", metrics[metricTLSExpiry].Gauge().DataPoints().At(0).Attributes().AsRaw()["http.method"])
+}
+
+func TestScrapeEndlessBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buf := make([]byte, 32<<10)
+		for r.Context().Err() == nil {
+			if _, err := w.Write(buf); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	cs := newTestCheckScraper(t, TargetConfig{HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: server.URL}})
+
+	// Only the beginning of the body is read.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	md, err := cs.scrape(ctx)
+	require.NoError(t, err)
+	metrics := metricsByName(md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics())
+	assert.EqualValues(t, 1, metrics[metricSuccess].Gauge().DataPoints().At(0).IntVal())
+}
+
+func TestScrapeConnectionRefused(t *testing.T) {
+	l, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	endpoint := "http://" + l.Addr().String()
+	require.NoError(t, l.Close())
+	cs := newTestCheckScraper(t, TargetConfig{HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: endpoint}})
+
+	md, err := cs.scrape(context.Background())
+	require.NoError(t, err)
+	metrics := metricsByName(md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics())
+	assert.Len(t, metrics, 2)
+	assert.Contains(t, metrics, metricDuration)
+	assert.EqualValues(t, 0, metrics[metricSuccess].Gauge().DataPoints().At(0).IntVal())
+}
+
+func TestReceiver(t *testing.T) {
+	server := startTestServer(t, http.StatusOK, "ok")
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.CollectionInterval = 10 * time.Millisecond
+	cfg.Targets = []TargetConfig{{HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: server.URL}}}
+	sink := new(consumertest.MetricsSink)
+	rcv, err := NewFactory().CreateMetricsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, rcv.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, rcv.Shutdown(context.Background())) }()
+
+	assert.Eventually(t, func() bool { return len(sink.AllMetrics()) > 0 }, 5*time.Second, 10*time.Millisecond)
+	md := sink.AllMetrics()[0]
+	metrics := metricsByName(md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics())
+	assert.EqualValues(t, 1, metrics[metricSuccess].Gauge().DataPoints().At(0).IntVal())
+}
+
+func metricsByName(ms pdata.MetricSlice) map[string]pdata.Metric {
+	metrics := map[string]pdata.Metric{}
+	for i := 0; i < ms.Len(); i++ {
+		metrics[ms.At(i).Name()] = ms.At(i)
+	}
+	return metrics
+}
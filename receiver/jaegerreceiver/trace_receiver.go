@@ -0,0 +1,224 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerreceiver // import "go.opentelemetry.io/collector/receiver/jaegerreceiver"
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sync"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/proto-gen/api_v2"
+	"github.com/jaegertracing/jaeger/thrift-gen/jaeger"
+	"google.golang.org/grpc"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/errorutil"
+	jaegertranslator "go.opentelemetry.io/collector/internal/jaeger"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+const (
+	grpcTransport       = "grpc"
+	thriftHTTPTransport = "http"
+
+	dataFormatProtobuf = "protobuf"
+	dataFormatThrift   = "thrift"
+
+	tracesPath = "/api/traces"
+)
+
+// The content types of the Thrift binary protocol accepted by the Jaeger collector.
+var thriftContentTypes = map[string]bool{
+	"application/x-thrift":                 true,
+	"application/vnd.apache.thrift.binary": true,
+}
+
+type jaegerReceiver struct {
+	cfg          *Config
+	settings     component.ReceiverCreateSettings
+	nextConsumer consumer.Traces
+
+	grpcObsrecv *obsreport.Receiver
+	httpObsrecv *obsreport.Receiver
+
+	serverGRPC *grpc.Server
+	serverHTTP *http.Server
+	shutdownWG sync.WaitGroup
+}
+
+var _ api_v2.CollectorServiceServer = (*jaegerReceiver)(nil)
+
+func newJaegerReceiver(cfg *Config, set component.ReceiverCreateSettings, nextConsumer consumer.Traces) *jaegerReceiver {
+	return &jaegerReceiver{
+		cfg:          cfg,
+		settings:     set,
+		nextConsumer: nextConsumer,
+		grpcObsrecv: obsreport.NewReceiver(obsreport.ReceiverSettings{
+			ReceiverID:             cfg.ID(),
+			Transport:              grpcTransport,
+			ReceiverCreateSettings: set,
+		}),
+		httpObsrecv: obsreport.NewReceiver(obsreport.ReceiverSettings{
+			ReceiverID:             cfg.ID(),
+			Transport:              thriftHTTPTransport,
+			ReceiverCreateSettings: set,
+		}),
+	}
+}
+
+// Start starts the servers of the configured protocols.
+func (r *jaegerReceiver) Start(_ context.Context, host component.Host) error {
+	if r.cfg.GRPC != nil {
+		if err := r.startGRPCServer(host); err != nil {
+			return err
+		}
+	}
+	if r.cfg.ThriftHTTP != nil {
+		if err := r.startThriftHTTPServer(host); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *jaegerReceiver) startGRPCServer(host component.Host) error {
+	opts, err := r.cfg.GRPC.ToServerOption(host, r.settings.TelemetrySettings)
+	if err != nil {
+		return err
+	}
+	r.serverGRPC = grpc.NewServer(opts...)
+	api_v2.RegisterCollectorServiceServer(r.serverGRPC, r)
+
+	r.settings.Logger.Info("Starting GRPC server on endpoint " + r.cfg.GRPC.NetAddr.Endpoint)
+	gln, err := r.cfg.GRPC.ToListener()
+	if err != nil {
+		return err
+	}
+	r.shutdownWG.Add(1)
+	go func() {
+		defer r.shutdownWG.Done()
+		if errGrpc := r.serverGRPC.Serve(gln); errGrpc != nil && errGrpc != grpc.ErrServerStopped {
+			host.ReportFatalError(errGrpc)
+		}
+	}()
+	return nil
+}
+
+func (r *jaegerReceiver) startThriftHTTPServer(host component.Host) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc(tracesPath, r.handleThriftHTTP)
+
+	var err error
+	r.serverHTTP, err = r.cfg.ThriftHTTP.ToServer(host, r.settings.TelemetrySettings, mux)
+	if err != nil {
+		return err
+	}
+
+	r.settings.Logger.Info("Starting HTTP server on endpoint " + r.cfg.ThriftHTTP.Endpoint)
+	hln, err := r.cfg.ThriftHTTP.ToListener()
+	if err != nil {
+		return err
+	}
+	r.shutdownWG.Add(1)
+	go func() {
+		defer r.shutdownWG.Done()
+		if errHTTP := r.serverHTTP.Serve(hln); errHTTP != http.ErrServerClosed {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the servers.
+func (r *jaegerReceiver) Shutdown(ctx context.Context) error {
+	var err error
+	if r.serverHTTP != nil {
+		err = r.serverHTTP.Shutdown(ctx)
+	}
+	if r.serverGRPC != nil {
+		r.serverGRPC.GracefulStop()
+	}
+	r.shutdownWG.Wait()
+	return err
+}
+
+// PostSpans implements the Jaeger gRPC CollectorService.
+func (r *jaegerReceiver) PostSpans(ctx context.Context, req *api_v2.PostSpansRequest) (*api_v2.PostSpansResponse, error) {
+	td := jaegertranslator.ProtoToTraces([]*model.Batch{&req.Batch})
+	numSpans := td.SpanCount()
+	if numSpans == 0 {
+		return &api_v2.PostSpansResponse{}, nil
+	}
+
+	ctx = r.grpcObsrecv.StartTracesOp(ctx)
+	err := r.nextConsumer.ConsumeTraces(ctx, td)
+	r.grpcObsrecv.EndTracesOp(ctx, dataFormatProtobuf, numSpans, err)
+	if err != nil {
+		return nil, errorutil.GetStatusFromError(err)
+	}
+	return &api_v2.PostSpansResponse{}, nil
+}
+
+// handleThriftHTTP receives a Jaeger Thrift batch encoded with the binary protocol.
+func (r *jaegerReceiver) handleThriftHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, fmt.Sprintf("%v method not allowed, supported: [POST]", req.Method), http.StatusMethodNotAllowed)
+		return
+	}
+	mediaType, _, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil || !thriftContentTypes[mediaType] {
+		http.Error(w, fmt.Sprintf("unsupported content type %q", req.Header.Get("Content-Type")), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	ctx := r.httpObsrecv.StartTracesOp(req.Context())
+	batch, err := decodeThriftBatch(ctx, req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		r.httpObsrecv.EndTracesOp(ctx, dataFormatThrift, 0, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	td := jaegertranslator.ThriftToTraces(batch)
+	err = r.nextConsumer.ConsumeTraces(ctx, td)
+	r.httpObsrecv.EndTracesOp(ctx, dataFormatThrift, td.SpanCount(), err)
+	if err != nil {
+		errorutil.HTTPError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func decodeThriftBatch(ctx context.Context, body io.Reader) (*jaeger.Batch, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	buf := thrift.NewTMemoryBufferLen(len(data))
+	_, _ = buf.Write(data)
+	batch := &jaeger.Batch{}
+	if err = batch.Read(ctx, thrift.NewTBinaryProtocolConf(buf, &thrift.TConfiguration{})); err != nil {
+		return nil, fmt.Errorf("failed to decode the Thrift batch: %w", err)
+	}
+	return batch, nil
+}
@@ -0,0 +1,260 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerreceiver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/proto-gen/api_v2"
+	"github.com/jaegertracing/jaeger/thrift-gen/jaeger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testutil"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
+)
+
+func startReceiver(t *testing.T, tt obsreporttest.TestTelemetry, next consumer.Traces) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.GRPC.NetAddr.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.ThriftHTTP.Endpoint = testutil.GetAvailableLocalAddress(t)
+	rcv, err := NewFactory().CreateTracesReceiver(context.Background(), tt.ToReceiverCreateSettings(), cfg, next)
+	require.NoError(t, err)
+	require.NoError(t, rcv.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, rcv.Shutdown(context.Background())) })
+	return cfg
+}
+
+func TestReceiveThriftHTTP(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	defer func() { require.NoError(t, tt.Shutdown(context.Background())) }()
+
+	sink := new(consumertest.TracesSink)
+	cfg := startReceiver(t, tt, sink)
+
+	resp, err := http.Post("http://"+cfg.ThriftHTTP.Endpoint+tracesPath, "application/x-thrift", bytes.NewReader(thriftBatch(t)))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	require.Len(t, sink.AllTraces(), 1)
+	td := sink.AllTraces()[0]
+	require.Equal(t, 1, td.SpanCount())
+	service, ok := td.ResourceSpans().At(0).Resource().Attributes().Get("service.name")
+	require.True(t, ok)
+	assert.Equal(t, "frontend", service.StringVal())
+	span := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	assert.Equal(t, "get", span.Name())
+	assert.Equal(t, pdata.SpanKindServer, span.Kind())
+
+	require.NoError(t, obsreporttest.CheckReceiverTraces(tt, cfg.ID(), thriftHTTPTransport, 1, 0))
+}
+
+func TestReceiveThriftHTTPErrors(t *testing.T) {
+	testCases := []struct {
+		name        string
+		method      string
+		contentType string
+		body        []byte
+		next        consumer.Traces
+		wantStatus  int
+		wantRetry   string
+	}{
+		{
+			name:       "method not allowed",
+			method:     http.MethodGet,
+			next:       consumertest.NewNop(),
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:        "unsupported content type",
+			method:      http.MethodPost,
+			contentType: "application/json",
+			body:        []byte(`{}`),
+			next:        consumertest.NewNop(),
+			wantStatus:  http.StatusUnsupportedMediaType,
+		},
+		{
+			name:        "invalid thrift",
+			method:      http.MethodPost,
+			contentType: "application/vnd.apache.thrift.binary",
+			body:        []byte{0xff, 0xff},
+			next:        consumertest.NewNop(),
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "consumer error",
+			method:      http.MethodPost,
+			contentType: "application/x-thrift",
+			body:        thriftBatch(t),
+			next:        consumertest.NewErr(errors.New("failed")),
+			wantStatus:  http.StatusInternalServerError,
+		},
+		{
+			name:        "throttled",
+			method:      http.MethodPost,
+			contentType: "application/x-thrift",
+			body:        thriftBatch(t),
+			next:        consumertest.NewErr(consumererror.NewThrottle(errors.New("memory limit"), 1500*time.Millisecond)),
+			wantStatus:  http.StatusTooManyRequests,
+			wantRetry:   "2",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tt, err := obsreporttest.SetupTelemetry()
+			require.NoError(t, err)
+			defer func() { require.NoError(t, tt.Shutdown(context.Background())) }()
+
+			cfg := startReceiver(t, tt, tc.next)
+
+			req, err := http.NewRequest(tc.method, "http://"+cfg.ThriftHTTP.Endpoint+tracesPath, bytes.NewReader(tc.body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", tc.contentType)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			assert.Equal(t, tc.wantStatus, resp.StatusCode)
+			assert.Equal(t, tc.wantRetry, resp.Header.Get("Retry-After"))
+		})
+	}
+}
+
+func TestReceiveGRPC(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	defer func() { require.NoError(t, tt.Shutdown(context.Background())) }()
+
+	sink := new(consumertest.TracesSink)
+	cfg := startReceiver(t, tt, sink)
+	client := newGRPCClient(t, cfg.GRPC.NetAddr.Endpoint)
+
+	traceID := model.NewTraceID(1, 2)
+	_, err = client.PostSpans(context.Background(), &api_v2.PostSpansRequest{
+		Batch: model.Batch{
+			Process: &model.Process{ServiceName: "frontend"},
+			Spans: []*model.Span{{
+				TraceID:       traceID,
+				SpanID:        model.NewSpanID(3),
+				OperationName: "get",
+				References:    []model.SpanRef{model.NewChildOfRef(traceID, model.NewSpanID(4))},
+				StartTime:     time.Unix(1600000000, 0),
+				Duration:      time.Second,
+				Tags:          []model.KeyValue{model.String("span.kind", "client")},
+			}},
+		},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, sink.AllTraces(), 1)
+	span := sink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	assert.Equal(t, pdata.NewTraceID([16]byte{0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 2}), span.TraceID())
+	assert.Equal(t, pdata.NewSpanID([8]byte{0, 0, 0, 0, 0, 0, 0, 4}), span.ParentSpanID())
+	assert.Equal(t, pdata.SpanKindClient, span.Kind())
+
+	require.NoError(t, obsreporttest.CheckReceiverTraces(tt, cfg.ID(), grpcTransport, 1, 0))
+}
+
+func TestReceiveGRPCThrottled(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	defer func() { require.NoError(t, tt.Shutdown(context.Background())) }()
+
+	next := consumertest.NewErr(consumererror.NewThrottle(errors.New("memory limit"), time.Second))
+	cfg := startReceiver(t, tt, next)
+	client := newGRPCClient(t, cfg.GRPC.NetAddr.Endpoint)
+
+	_, err = client.PostSpans(context.Background(), &api_v2.PostSpansRequest{
+		Batch: model.Batch{
+			Process: &model.Process{ServiceName: "frontend"},
+			Spans:   []*model.Span{{TraceID: model.NewTraceID(1, 2), SpanID: model.NewSpanID(3)}},
+		},
+	})
+	s, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.ResourceExhausted, s.Code())
+	require.Len(t, s.Details(), 1)
+	assert.Equal(t, time.Second, s.Details()[0].(*errdetails.RetryInfo).RetryDelay.AsDuration())
+
+	require.NoError(t, obsreporttest.CheckReceiverTraces(tt, cfg.ID(), grpcTransport, 0, 1))
+}
+
+func TestReceiveGRPCPermanentError(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	defer func() { require.NoError(t, tt.Shutdown(context.Background())) }()
+
+	next := consumertest.NewErr(consumererror.NewPermanent(errors.New("invalid span")))
+	cfg := startReceiver(t, tt, next)
+	client := newGRPCClient(t, cfg.GRPC.NetAddr.Endpoint)
+
+	_, err = client.PostSpans(context.Background(), &api_v2.PostSpansRequest{
+		Batch: model.Batch{
+			Process: &model.Process{ServiceName: "frontend"},
+			Spans:   []*model.Span{{TraceID: model.NewTraceID(1, 2), SpanID: model.NewSpanID(3)}},
+		},
+	})
+	// The clients must not retry the spans refused permanently.
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	require.NoError(t, obsreporttest.CheckReceiverTraces(tt, cfg.ID(), grpcTransport, 0, 1))
+}
+
+func newGRPCClient(t *testing.T, endpoint string) api_v2.CollectorServiceClient {
+	conn, err := grpc.Dial(endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, conn.Close()) })
+	return api_v2.NewCollectorServiceClient(conn)
+}
+
+func thriftBatch(t *testing.T) []byte {
+	buf := thrift.NewTMemoryBuffer()
+	batch := &jaeger.Batch{
+		Process: &jaeger.Process{ServiceName: "frontend"},
+		Spans: []*jaeger.Span{{
+			TraceIdLow:    1,
+			SpanId:        2,
+			OperationName: "get",
+			StartTime:     1600000000000000,
+			Duration:      1000,
+			Tags: []*jaeger.Tag{{
+				Key:   "span.kind",
+				VType: jaeger.TagType_STRING,
+				VStr:  thrift.StringPtr("server"),
+			}},
+		}},
+	}
+	require.NoError(t, batch.Write(context.Background(), thrift.NewTBinaryProtocolConf(buf, &thrift.TConfiguration{})))
+	return buf.Bytes()
+}
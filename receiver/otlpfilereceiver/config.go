@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpfilereceiver // import "go.opentelemetry.io/collector/receiver/otlpfilereceiver"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+const (
+	formatJSON  = "json"
+	formatProto = "proto"
+)
+
+// Config defines configuration for the OTLP file receiver.
+type Config struct {
+	config.ReceiverSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Path is the path of the replayed file. It must contain the data of the
+	// type of the pipelines of the receiver.
+	Path string `mapstructure:"path"`
+
+	// Format of the file, either "json" for OTLP JSON messages separated by newlines,
+	// or "proto" for OTLP protobuf messages each prefixed by its size as a
+	// 4-byte big-endian integer.
+	Format string `mapstructure:"format"`
+
+	// RewriteTimestamps shifts the timestamps of the data so that the earliest
+	// timestamp of the first message is the time the replay starts.
+	RewriteTimestamps bool `mapstructure:"rewrite_timestamps"`
+
+	// Rate is the maximum number of messages replayed per second. The messages
+	// are replayed as fast as they are consumed if 0.
+	Rate float64 `mapstructure:"rate"`
+
+	// Loop replays the file again once its end is reached, until the receiver is
+	// shut down. Otherwise, the receiver stops after replaying the file once.
+	Loop bool `mapstructure:"loop"`
+}
+
+var _ config.Receiver = (*Config)(nil)
+
+// Validate checks the receiver configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Path == "" {
+		return errors.New("path must be specified")
+	}
+	if cfg.Format != formatJSON && cfg.Format != formatProto {
+		return fmt.Errorf("unsupported format %q, must be %q or %q", cfg.Format, formatJSON, formatProto)
+	}
+	if cfg.Rate < 0 {
+		return errors.New("rate must not be negative")
+	}
+	return nil
+}
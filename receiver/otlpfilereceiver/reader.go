@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpfilereceiver // import "go.opentelemetry.io/collector/receiver/otlpfilereceiver"
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// maxMessageSize is the maximum size in bytes of a message of the file.
+const maxMessageSize = 64 * 1024 * 1024
+
+// messageReader reads the messages of a file in one of the supported formats.
+type messageReader interface {
+	// next returns the next message, or io.EOF at the end of the file.
+	next() ([]byte, error)
+}
+
+func newMessageReader(r io.Reader, format string) messageReader {
+	if format == formatProto {
+		return &protoReader{r: bufio.NewReader(r)}
+	}
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, maxMessageSize)
+	return &jsonLinesReader{scanner: s}
+}
+
+// jsonLinesReader reads the JSON messages separated by newlines, skipping the empty lines.
+type jsonLinesReader struct {
+	scanner *bufio.Scanner
+}
+
+func (r *jsonLinesReader) next() ([]byte, error) {
+	for r.scanner.Scan() {
+		if line := bytes.TrimSpace(r.scanner.Bytes()); len(line) > 0 {
+			return line, nil
+		}
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// protoReader reads the protobuf messages prefixed by their size as a 4-byte
+// big-endian integer.
+type protoReader struct {
+	r *bufio.Reader
+}
+
+func (r *protoReader) next() ([]byte, error) {
+	var prefix [4]byte
+	if _, err := io.ReadFull(r.r, prefix[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return nil, fmt.Errorf("truncated message size: %w", err)
+		}
+		return nil, err
+	}
+	size := binary.BigEndian.Uint32(prefix[:])
+	if size > maxMessageSize {
+		return nil, fmt.Errorf("message size %d exceeds the maximum of %d bytes", size, maxMessageSize)
+	}
+	buf := make([]byte, size)
+	if _, err := io.ReadFull(r.r, buf); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, fmt.Errorf("truncated message: %w", err)
+	}
+	return buf, nil
+}
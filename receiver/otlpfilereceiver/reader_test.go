@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpfilereceiver
+
+import (
+	"encoding/binary"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func protoFrame(msg string) string {
+	var prefix [4]byte
+	binary.BigEndian.PutUint32(prefix[:], uint32(len(msg)))
+	return string(prefix[:]) + msg
+}
+
+func readAll(t *testing.T, r messageReader) []string {
+	var msgs []string
+	for {
+		msg, err := r.next()
+		if err == io.EOF {
+			return msgs
+		}
+		require.NoError(t, err)
+		msgs = append(msgs, string(msg))
+	}
+}
+
+func TestJSONLinesReader(t *testing.T) {
+	r := newMessageReader(strings.NewReader("{\"a\":1}\n\n  \r\n{\"b\":2}\r\n{\"c\":3}"), formatJSON)
+	assert.Equal(t, []string{`{"a":1}`, `{"b":2}`, `{"c":3}`}, readAll(t, r))
+}
+
+func TestProtoReader(t *testing.T) {
+	r := newMessageReader(strings.NewReader(protoFrame("first")+protoFrame("")+protoFrame("third")), formatProto)
+	assert.Equal(t, []string{"first", "", "third"}, readAll(t, r))
+}
+
+func TestProtoReaderErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		data string
+	}{
+		{name: "truncated size", data: "\x00\x00"},
+		{name: "truncated message", data: protoFrame("message")[:6]},
+		{name: "missing message", data: protoFrame("message")[:4]},
+		{name: "message too large", data: "\xff\xff\xff\xff"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newMessageReader(strings.NewReader(tt.data), formatProto).next()
+			require.Error(t, err)
+			assert.NotEqual(t, io.EOF, err)
+		})
+	}
+}
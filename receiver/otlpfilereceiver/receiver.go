@@ -0,0 +1,260 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpfilereceiver // import "go.opentelemetry.io/collector/receiver/otlpfilereceiver"
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/model/otlp"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+const transport = "file"
+
+// payload is the data of a message of the file.
+type payload interface {
+	itemCount() int
+	updateTimestamps(f timestampFunc)
+}
+
+type tracesPayload struct{ td pdata.Traces }
+
+func (p tracesPayload) itemCount() int                   { return p.td.SpanCount() }
+func (p tracesPayload) updateTimestamps(f timestampFunc) { updateTracesTimestamps(p.td, f) }
+
+type metricsPayload struct{ md pdata.Metrics }
+
+func (p metricsPayload) itemCount() int                   { return p.md.DataPointCount() }
+func (p metricsPayload) updateTimestamps(f timestampFunc) { updateMetricsTimestamps(p.md, f) }
+
+type logsPayload struct{ ld pdata.Logs }
+
+func (p logsPayload) itemCount() int                   { return p.ld.LogRecordCount() }
+func (p logsPayload) updateTimestamps(f timestampFunc) { updateLogsTimestamps(p.ld, f) }
+
+// replayReceiver replays the messages of a file, for a single type of data.
+type replayReceiver struct {
+	cfg     *Config
+	logger  *zap.Logger
+	obsrecv *obsreport.Receiver
+
+	// unmarshal decodes a message of the file.
+	unmarshal func(buf []byte) (payload, error)
+	// consume passes the data of a message to the next consumer.
+	consume func(ctx context.Context, p payload) error
+
+	file   *os.File
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newReplayReceiver(cfg *Config, set component.ReceiverCreateSettings) *replayReceiver {
+	return &replayReceiver{
+		cfg:    cfg,
+		logger: set.Logger,
+		obsrecv: obsreport.NewReceiver(obsreport.ReceiverSettings{
+			ReceiverID:             cfg.ID(),
+			Transport:              transport,
+			ReceiverCreateSettings: set,
+		}),
+	}
+}
+
+func newTracesReceiver(cfg *Config, set component.ReceiverCreateSettings, nextConsumer consumer.Traces) *replayReceiver {
+	r := newReplayReceiver(cfg, set)
+	unmarshaler := otlp.NewJSONTracesUnmarshaler()
+	if cfg.Format == formatProto {
+		unmarshaler = otlp.NewProtobufTracesUnmarshaler()
+	}
+	r.unmarshal = func(buf []byte) (payload, error) {
+		td, err := unmarshaler.UnmarshalTraces(buf)
+		return tracesPayload{td: td}, err
+	}
+	r.consume = func(ctx context.Context, p payload) error {
+		ctx = r.obsrecv.StartTracesOp(ctx)
+		err := nextConsumer.ConsumeTraces(ctx, p.(tracesPayload).td)
+		r.obsrecv.EndTracesOp(ctx, cfg.Format, p.itemCount(), err)
+		return err
+	}
+	return r
+}
+
+func newMetricsReceiver(cfg *Config, set component.ReceiverCreateSettings, nextConsumer consumer.Metrics) *replayReceiver {
+	r := newReplayReceiver(cfg, set)
+	unmarshaler := otlp.NewJSONMetricsUnmarshaler()
+	if cfg.Format == formatProto {
+		unmarshaler = otlp.NewProtobufMetricsUnmarshaler()
+	}
+	r.unmarshal = func(buf []byte) (payload, error) {
+		md, err := unmarshaler.UnmarshalMetrics(buf)
+		return metricsPayload{md: md}, err
+	}
+	r.consume = func(ctx context.Context, p payload) error {
+		ctx = r.obsrecv.StartMetricsOp(ctx)
+		err := nextConsumer.ConsumeMetrics(ctx, p.(metricsPayload).md)
+		r.obsrecv.EndMetricsOp(ctx, cfg.Format, p.itemCount(), err)
+		return err
+	}
+	return r
+}
+
+func newLogsReceiver(cfg *Config, set component.ReceiverCreateSettings, nextConsumer consumer.Logs) *replayReceiver {
+	r := newReplayReceiver(cfg, set)
+	unmarshaler := otlp.NewJSONLogsUnmarshaler()
+	if cfg.Format == formatProto {
+		unmarshaler = otlp.NewProtobufLogsUnmarshaler()
+	}
+	r.unmarshal = func(buf []byte) (payload, error) {
+		ld, err := unmarshaler.UnmarshalLogs(buf)
+		return logsPayload{ld: ld}, err
+	}
+	r.consume = func(ctx context.Context, p payload) error {
+		ctx = r.obsrecv.StartLogsOp(ctx)
+		err := nextConsumer.ConsumeLogs(ctx, p.(logsPayload).ld)
+		r.obsrecv.EndLogsOp(ctx, cfg.Format, p.itemCount(), err)
+		return err
+	}
+	return r
+}
+
+// Start opens the file and starts replaying it.
+func (r *replayReceiver) Start(context.Context, component.Host) error {
+	f, err := os.Open(r.cfg.Path)
+	if err != nil {
+		return fmt.Errorf("failed to open the replayed file: %w", err)
+	}
+	r.file = f
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.wg.Add(1)
+	go r.run(ctx)
+	return nil
+}
+
+// Shutdown stops the replay if it is still running.
+func (r *replayReceiver) Shutdown(context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	return nil
+}
+
+func (r *replayReceiver) run(ctx context.Context) {
+	defer r.wg.Done()
+	defer r.file.Close()
+	for {
+		n, err := r.replay(ctx)
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err != nil:
+			r.logger.Error("Failed to replay the file", zap.String("path", r.cfg.Path), zap.Error(err))
+			return
+		case !r.cfg.Loop || n == 0:
+			// A file without valid messages is not replayed again.
+			r.logger.Info("Finished replaying the file", zap.String("path", r.cfg.Path))
+			return
+		}
+		if _, err = r.file.Seek(0, io.SeekStart); err != nil {
+			r.logger.Error("Failed to replay the file again", zap.String("path", r.cfg.Path), zap.Error(err))
+			return
+		}
+	}
+}
+
+// replay replays the file once, returning the number of valid messages.
+func (r *replayReceiver) replay(ctx context.Context) (int, error) {
+	var interval time.Duration
+	if r.cfg.Rate > 0 {
+		interval = time.Duration(float64(time.Second) / r.cfg.Rate)
+	}
+	start := time.Now()
+	next := start
+	// delta is the shift of the timestamps, known once a message with a timestamp is read.
+	var delta int64
+	rebased := false
+
+	reader := newMessageReader(r.file, r.cfg.Format)
+	count := 0
+	for ctx.Err() == nil {
+		buf, err := reader.next()
+		if err == io.EOF {
+			return count, nil
+		}
+		if err != nil {
+			return count, err
+		}
+		p, err := r.unmarshal(buf)
+		if err != nil {
+			r.logger.Warn("Skipping invalid message", zap.String("path", r.cfg.Path), zap.Error(err))
+			continue
+		}
+		count++
+
+		if r.cfg.RewriteTimestamps {
+			if !rebased {
+				if earliest := earliestTimestamp(p); earliest != 0 {
+					delta = start.UnixNano() - int64(earliest)
+					rebased = true
+				}
+			}
+			if rebased {
+				shiftTimestamps(p, delta)
+			}
+		}
+
+		if interval > 0 {
+			if !waitUntil(ctx, next) {
+				break
+			}
+			// The late messages are not sent faster to catch up.
+			if now := time.Now(); next.Before(now) {
+				next = now
+			}
+			next = next.Add(interval)
+		}
+		if err = r.consume(ctx, p); err != nil {
+			r.logger.Debug("Failed to consume replayed message", zap.String("path", r.cfg.Path), zap.Error(err))
+		}
+	}
+	return count, ctx.Err()
+}
+
+// waitUntil blocks until the given time, returning false if the context is done before.
+func waitUntil(ctx context.Context, t time.Time) bool {
+	d := time.Until(t)
+	if d <= 0 {
+		return true
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
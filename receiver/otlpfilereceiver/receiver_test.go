@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpfilereceiver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/model/otlp"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
+)
+
+func writeFile(t *testing.T, name string, data string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(data), 0600))
+	return path
+}
+
+func TestReplayTracesJSON(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	defer func() { require.NoError(t, tt.Shutdown(context.Background())) }()
+
+	marshaler := otlp.NewJSONTracesMarshaler()
+	first, err := marshaler.MarshalTraces(testdata.GenerateTracesOneSpan())
+	require.NoError(t, err)
+	second, err := marshaler.MarshalTraces(testdata.GenerateTracesTwoSpansSameResource())
+	require.NoError(t, err)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Path = writeFile(t, "traces.json", string(first)+"\n"+"invalid\n"+string(second)+"\n")
+	cfg.RewriteTimestamps = true
+	sink := new(consumertest.TracesSink)
+	rcv, err := NewFactory().CreateTracesReceiver(context.Background(), tt.ToReceiverCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+
+	start := pdata.NewTimestampFromTime(time.Now())
+	require.NoError(t, rcv.Start(context.Background(), componenttest.NewNopHost()))
+	require.Eventually(t, func() bool { return sink.SpanCount() == 3 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, rcv.Shutdown(context.Background()))
+
+	// The earliest timestamp of the first message is moved to the start of the replay,
+	// and the other timestamps keep their distance to it.
+	original := testdata.GenerateTracesOneSpan().ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	span := sink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	assert.GreaterOrEqual(t, span.StartTimestamp(), start)
+	assert.Less(t, span.StartTimestamp(), start+pdata.Timestamp(5*time.Second))
+	assert.Equal(t, original.EndTimestamp()-original.StartTimestamp(), span.EndTimestamp()-span.StartTimestamp())
+
+	require.NoError(t, obsreporttest.CheckReceiverTraces(tt, cfg.ID(), "file", 3, 0))
+}
+
+func TestReplayMetricsProtoLoop(t *testing.T) {
+	buf, err := otlp.NewProtobufMetricsMarshaler().MarshalMetrics(testdata.GenerateMetricsOneMetric())
+	require.NoError(t, err)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Path = writeFile(t, "metrics.pb", protoFrame(string(buf)))
+	cfg.Format = formatProto
+	cfg.Loop = true
+	sink := new(consumertest.MetricsSink)
+	rcv, err := NewFactory().CreateMetricsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+
+	require.NoError(t, rcv.Start(context.Background(), componenttest.NewNopHost()))
+	require.Eventually(t, func() bool { return len(sink.AllMetrics()) >= 3 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, rcv.Shutdown(context.Background()))
+
+	// No message is replayed after the shutdown.
+	n := len(sink.AllMetrics())
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, n, len(sink.AllMetrics()))
+	assert.Equal(t, testdata.GenerateMetricsOneMetric(), sink.AllMetrics()[0])
+}
+
+func TestReplayLogsRate(t *testing.T) {
+	buf, err := otlp.NewJSONLogsMarshaler().MarshalLogs(testdata.GenerateLogsOneLogRecord())
+	require.NoError(t, err)
+	line := string(buf) + "\n"
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Path = writeFile(t, "logs.json", line+line+line)
+	cfg.Rate = 20
+	sink := new(consumertest.LogsSink)
+	rcv, err := NewFactory().CreateLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+
+	start := time.Now()
+	require.NoError(t, rcv.Start(context.Background(), componenttest.NewNopHost()))
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 3 }, 5*time.Second, 5*time.Millisecond)
+	// The 3 messages are separated by 50ms.
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+	require.NoError(t, rcv.Shutdown(context.Background()))
+}
+
+func TestReplayShutdownDuringWait(t *testing.T) {
+	buf, err := otlp.NewJSONLogsMarshaler().MarshalLogs(testdata.GenerateLogsOneLogRecord())
+	require.NoError(t, err)
+	line := string(buf) + "\n"
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Path = writeFile(t, "logs.json", line+line)
+	cfg.Rate = 0.01
+	sink := new(consumertest.LogsSink)
+	rcv, err := NewFactory().CreateLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+
+	require.NoError(t, rcv.Start(context.Background(), componenttest.NewNopHost()))
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 1 }, 5*time.Second, 5*time.Millisecond)
+	done := make(chan struct{})
+	go func() {
+		assert.NoError(t, rcv.Shutdown(context.Background()))
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the shutdown did not interrupt the wait for the next message")
+	}
+	assert.Equal(t, 1, sink.LogRecordCount())
+}
+
+func TestReplayMissingFile(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Path = filepath.Join(t.TempDir(), "missing.json")
+	rcv, err := NewFactory().CreateTracesReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	assert.Error(t, rcv.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, rcv.Shutdown(context.Background()))
+}
@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpfilereceiver // import "go.opentelemetry.io/collector/receiver/otlpfilereceiver"
+
+import (
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// timestampFunc returns the new value of a timestamp.
+type timestampFunc func(pdata.Timestamp) pdata.Timestamp
+
+// earliestTimestamp returns the earliest non-zero timestamp of a payload, 0 if none.
+func earliestTimestamp(p payload) pdata.Timestamp {
+	var earliest pdata.Timestamp
+	p.updateTimestamps(func(ts pdata.Timestamp) pdata.Timestamp {
+		if ts != 0 && (earliest == 0 || ts < earliest) {
+			earliest = ts
+		}
+		return ts
+	})
+	return earliest
+}
+
+// shiftTimestamps adds a delta in nanoseconds to the non-zero timestamps of a payload.
+func shiftTimestamps(p payload, delta int64) {
+	p.updateTimestamps(func(ts pdata.Timestamp) pdata.Timestamp {
+		if ts == 0 {
+			return 0
+		}
+		return pdata.Timestamp(int64(ts) + delta)
+	})
+}
+
+func updateTracesTimestamps(td pdata.Traces, f timestampFunc) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		sss := rss.At(i).ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			spans := sss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				span.SetStartTimestamp(f(span.StartTimestamp()))
+				span.SetEndTimestamp(f(span.EndTimestamp()))
+				events := span.Events()
+				for l := 0; l < events.Len(); l++ {
+					events.At(l).SetTimestamp(f(events.At(l).Timestamp()))
+				}
+			}
+		}
+	}
+}
+
+func updateLogsTimestamps(ld pdata.Logs, f timestampFunc) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			logs := sls.At(j).LogRecords()
+			for k := 0; k < logs.Len(); k++ {
+				logs.At(k).SetTimestamp(f(logs.At(k).Timestamp()))
+			}
+		}
+	}
+}
+
+func updateMetricsTimestamps(md pdata.Metrics, f timestampFunc) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			metrics := sms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				updateMetricTimestamps(metrics.At(k), f)
+			}
+		}
+	}
+}
+
+func updateMetricTimestamps(m pdata.Metric, f timestampFunc) {
+	switch m.DataType() {
+	case pdata.MetricDataTypeGauge:
+		updateNumberDataPointsTimestamps(m.Gauge().DataPoints(), f)
+	case pdata.MetricDataTypeSum:
+		updateNumberDataPointsTimestamps(m.Sum().DataPoints(), f)
+	case pdata.MetricDataTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			dp.SetStartTimestamp(f(dp.StartTimestamp()))
+			dp.SetTimestamp(f(dp.Timestamp()))
+			updateExemplarsTimestamps(dp.Exemplars(), f)
+		}
+	case pdata.MetricDataTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			dp.SetStartTimestamp(f(dp.StartTimestamp()))
+			dp.SetTimestamp(f(dp.Timestamp()))
+			updateExemplarsTimestamps(dp.Exemplars(), f)
+		}
+	case pdata.MetricDataTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			dp.SetStartTimestamp(f(dp.StartTimestamp()))
+			dp.SetTimestamp(f(dp.Timestamp()))
+		}
+	}
+}
+
+func updateNumberDataPointsTimestamps(dps pdata.NumberDataPointSlice, f timestampFunc) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		dp.SetStartTimestamp(f(dp.StartTimestamp()))
+		dp.SetTimestamp(f(dp.Timestamp()))
+		updateExemplarsTimestamps(dp.Exemplars(), f)
+	}
+}
+
+func updateExemplarsTimestamps(exemplars pdata.ExemplarSlice, f timestampFunc) {
+	for i := 0; i < exemplars.Len(); i++ {
+		exemplars.At(i).SetTimestamp(f(exemplars.At(i).Timestamp()))
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpfilereceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestShiftTracesTimestamps(t *testing.T) {
+	td := testdata.GenerateTracesOneSpan()
+	span := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	start, end := span.StartTimestamp(), span.EndTimestamp()
+	event := span.Events().At(0).Timestamp()
+	p := tracesPayload{td: td}
+
+	assert.Equal(t, start, earliestTimestamp(p))
+	shiftTimestamps(p, 1000)
+	assert.Equal(t, start+1000, span.StartTimestamp())
+	assert.Equal(t, end+1000, span.EndTimestamp())
+	assert.Equal(t, event+1000, span.Events().At(0).Timestamp())
+}
+
+func TestShiftMetricsTimestamps(t *testing.T) {
+	md := pdata.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	gauge := metrics.AppendEmpty()
+	gauge.SetDataType(pdata.MetricDataTypeGauge)
+	gaugeDP := gauge.Gauge().DataPoints().AppendEmpty()
+	gaugeDP.SetTimestamp(300)
+	sum := metrics.AppendEmpty()
+	sum.SetDataType(pdata.MetricDataTypeSum)
+	sumDP := sum.Sum().DataPoints().AppendEmpty()
+	sumDP.SetStartTimestamp(100)
+	sumDP.SetTimestamp(300)
+	sumDP.Exemplars().AppendEmpty().SetTimestamp(250)
+	histogram := metrics.AppendEmpty()
+	histogram.SetDataType(pdata.MetricDataTypeHistogram)
+	histogramDP := histogram.Histogram().DataPoints().AppendEmpty()
+	histogramDP.SetStartTimestamp(200)
+	histogramDP.SetTimestamp(300)
+	expHistogram := metrics.AppendEmpty()
+	expHistogram.SetDataType(pdata.MetricDataTypeExponentialHistogram)
+	expHistogramDP := expHistogram.ExponentialHistogram().DataPoints().AppendEmpty()
+	expHistogramDP.SetTimestamp(300)
+	summary := metrics.AppendEmpty()
+	summary.SetDataType(pdata.MetricDataTypeSummary)
+	summaryDP := summary.Summary().DataPoints().AppendEmpty()
+	summaryDP.SetTimestamp(300)
+	p := metricsPayload{md: md}
+
+	assert.Equal(t, pdata.Timestamp(100), earliestTimestamp(p))
+	shiftTimestamps(p, -50)
+	// The unset start timestamp of the gauge is left unset.
+	assert.Equal(t, pdata.Timestamp(0), gaugeDP.StartTimestamp())
+	assert.Equal(t, pdata.Timestamp(250), gaugeDP.Timestamp())
+	assert.Equal(t, pdata.Timestamp(50), sumDP.StartTimestamp())
+	assert.Equal(t, pdata.Timestamp(200), sumDP.Exemplars().At(0).Timestamp())
+	assert.Equal(t, pdata.Timestamp(150), histogramDP.StartTimestamp())
+	assert.Equal(t, pdata.Timestamp(250), histogramDP.Timestamp())
+	assert.Equal(t, pdata.Timestamp(250), expHistogramDP.Timestamp())
+	assert.Equal(t, pdata.Timestamp(250), summaryDP.Timestamp())
+}
+
+func TestShiftLogsTimestamps(t *testing.T) {
+	ld := testdata.GenerateLogsTwoLogRecordsSameResource()
+	logs := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	logs.At(1).SetTimestamp(0)
+	p := logsPayload{ld: ld}
+
+	earliest := logs.At(0).Timestamp()
+	assert.Equal(t, earliest, earliestTimestamp(p))
+	shiftTimestamps(p, 10)
+	assert.Equal(t, earliest+10, logs.At(0).Timestamp())
+	assert.Equal(t, pdata.Timestamp(0), logs.At(1).Timestamp())
+
+	assert.Equal(t, pdata.Timestamp(0), earliestTimestamp(logsPayload{ld: pdata.NewLogs()}))
+}
@@ -15,7 +15,9 @@
 package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configgrpc"
@@ -27,12 +29,130 @@ const (
 	protoGRPC          = "grpc"
 	protoHTTP          = "http"
 	protocolsFieldName = "protocols"
+	webSocketFieldName = "websocket"
 )
 
+// WebSocketSettings defines the configuration of the OTLP messages received over
+// WebSocket connections, for the clients keeping a connection open.
+type WebSocketSettings struct {
+	// URLPath is the URL path, after URLPrefix, to open the connections on.
+	URLPath string `mapstructure:"url_path"`
+}
+
+// HTTPConfig defines the configuration of the OTLP/HTTP protocol.
+type HTTPConfig struct {
+	confighttp.HTTPServerSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// URLPrefix is prepended to the URL paths of all the signals, for example
+	// "/otel/ingest" when the collector is exposed under this path by an ingress.
+	URLPrefix string `mapstructure:"url_prefix"`
+
+	// TracesURLPath is the URL path, after URLPrefix, to receive traces on.
+	TracesURLPath string `mapstructure:"traces_url_path"`
+
+	// MetricsURLPath is the URL path, after URLPrefix, to receive metrics on.
+	MetricsURLPath string `mapstructure:"metrics_url_path"`
+
+	// LogsURLPath is the URL path, after URLPrefix, to receive logs on.
+	LogsURLPath string `mapstructure:"logs_url_path"`
+
+	// LegacyURLPrefix, if set, additionally accepts the requests sent to the URL paths
+	// of the signals under this prefix instead of URLPrefix, to keep the clients working
+	// while they are migrated. Use "/" to accept the URL paths without prefix.
+	LegacyURLPrefix string `mapstructure:"legacy_url_prefix"`
+
+	// WebSocket, if set, also receives the signals over WebSocket connections.
+	WebSocket *WebSocketSettings `mapstructure:"websocket"`
+}
+
+// Validate checks the HTTP protocol configuration is valid
+func (cfg *HTTPConfig) Validate() error {
+	if cfg.URLPrefix != "" && !strings.HasPrefix(cfg.URLPrefix, "/") {
+		return fmt.Errorf("url_prefix %q must start with a slash", cfg.URLPrefix)
+	}
+	if cfg.LegacyURLPrefix != "" {
+		if !strings.HasPrefix(cfg.LegacyURLPrefix, "/") {
+			return fmt.Errorf("legacy_url_prefix %q must start with a slash", cfg.LegacyURLPrefix)
+		}
+		if strings.TrimSuffix(cfg.LegacyURLPrefix, "/") == strings.TrimSuffix(cfg.URLPrefix, "/") {
+			return errors.New("legacy_url_prefix must be different from url_prefix")
+		}
+	}
+	if !strings.HasPrefix(cfg.TracesURLPath, "/") {
+		return fmt.Errorf("traces_url_path %q must start with a slash", cfg.TracesURLPath)
+	}
+	if !strings.HasPrefix(cfg.MetricsURLPath, "/") {
+		return fmt.Errorf("metrics_url_path %q must start with a slash", cfg.MetricsURLPath)
+	}
+	if !strings.HasPrefix(cfg.LogsURLPath, "/") {
+		return fmt.Errorf("logs_url_path %q must start with a slash", cfg.LogsURLPath)
+	}
+	if cfg.WebSocket != nil && !strings.HasPrefix(cfg.WebSocket.URLPath, "/") {
+		return fmt.Errorf("websocket url_path %q must start with a slash", cfg.WebSocket.URLPath)
+	}
+	return nil
+}
+
+// urlPaths returns the URL paths to receive a signal on, given its path after the prefix.
+func (cfg *HTTPConfig) urlPaths(signalPath string) []string {
+	urlPaths := []string{strings.TrimSuffix(cfg.URLPrefix, "/") + signalPath}
+	if cfg.LegacyURLPrefix != "" {
+		urlPaths = append(urlPaths, strings.TrimSuffix(cfg.LegacyURLPrefix, "/")+signalPath)
+	}
+	return urlPaths
+}
+
 // Protocols is the configuration for the supported protocols.
 type Protocols struct {
 	GRPC *configgrpc.GRPCServerSettings `mapstructure:"grpc"`
-	HTTP *confighttp.HTTPServerSettings `mapstructure:"http"`
+	HTTP *HTTPConfig                    `mapstructure:"http"`
+}
+
+const (
+	// Rate limit key values.
+	rateLimitKeyPeer     = "peer"
+	rateLimitKeyAuth     = "auth"
+	rateLimitKeyMetadata = "metadata"
+)
+
+// RateLimitSettings defines the limits applied to each client of the receiver.
+type RateLimitSettings struct {
+	// Key identifies the clients: "peer" for the IP address of their connection,
+	// "auth" for an attribute of the authentication data, or "metadata" for a
+	// header of the requests. Defaults to "peer".
+	Key string `mapstructure:"key"`
+
+	// KeyName is the name of the authentication attribute or the metadata header
+	// identifying the clients, required with the "auth" and "metadata" keys.
+	KeyName string `mapstructure:"key_name"`
+
+	// RequestsPerSecond is the number of export requests accepted per second from
+	// each client, zero meaning no limit.
+	RequestsPerSecond float64 `mapstructure:"requests_per_second"`
+
+	// ItemsPerSecond is the number of spans, data points and log records accepted
+	// per second from each client, zero meaning no limit.
+	ItemsPerSecond float64 `mapstructure:"items_per_second"`
+}
+
+// Validate checks the rate limit configuration is valid
+func (cfg *RateLimitSettings) Validate() error {
+	switch cfg.Key {
+	case "", rateLimitKeyPeer:
+	case rateLimitKeyAuth, rateLimitKeyMetadata:
+		if cfg.KeyName == "" {
+			return fmt.Errorf("key_name must be specified with the %q key", cfg.Key)
+		}
+	default:
+		return fmt.Errorf("unknown key %q, must be one of %q, %q or %q", cfg.Key, rateLimitKeyPeer, rateLimitKeyAuth, rateLimitKeyMetadata)
+	}
+	if cfg.RequestsPerSecond < 0 || cfg.ItemsPerSecond < 0 {
+		return errors.New("requests_per_second and items_per_second must not be negative")
+	}
+	if cfg.RequestsPerSecond == 0 && cfg.ItemsPerSecond == 0 {
+		return errors.New("at least one of requests_per_second or items_per_second must be specified")
+	}
+	return nil
 }
 
 // Config defines configuration for OTLP receiver.
@@ -40,6 +160,9 @@ type Config struct {
 	config.ReceiverSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
 	// Protocols is the configuration for the supported protocols, currently gRPC and HTTP (Proto and JSON).
 	Protocols `mapstructure:"protocols"`
+	// RateLimit, if set, limits the requests and items accepted from each client
+	// over all the protocols and signals.
+	RateLimit *RateLimitSettings `mapstructure:"rate_limit"`
 }
 
 var _ config.Receiver = (*Config)(nil)
@@ -51,6 +174,24 @@ func (cfg *Config) Validate() error {
 		cfg.HTTP == nil {
 		return fmt.Errorf("must specify at least one protocol when using the OTLP receiver")
 	}
+	if cfg.HTTP != nil {
+		if err := cfg.HTTP.Validate(); err != nil {
+			return fmt.Errorf("invalid http protocol configuration: %w", err)
+		}
+	}
+	if cfg.RateLimit != nil {
+		if err := cfg.RateLimit.Validate(); err != nil {
+			return fmt.Errorf("invalid rate_limit configuration: %w", err)
+		}
+		if cfg.RateLimit.Key == rateLimitKeyMetadata {
+			if cfg.GRPC != nil && !cfg.GRPC.IncludeMetadata {
+				return errors.New(`invalid rate_limit configuration: the "metadata" key requires include_metadata on the grpc protocol`)
+			}
+			if cfg.HTTP != nil && !cfg.HTTP.IncludeMetadata {
+				return errors.New(`invalid rate_limit configuration: the "metadata" key requires include_metadata on the http protocol`)
+			}
+		}
+	}
 	return nil
 }
 
@@ -77,6 +218,14 @@ func (cfg *Config) Unmarshal(componentParser *config.Map) error {
 
 	if !protocols.IsSet(protoHTTP) {
 		cfg.HTTP = nil
+	} else if protocols.IsSet(protoHTTP + config.KeyDelimiter + webSocketFieldName) {
+		// the websocket section may be empty to use the defaults.
+		if cfg.HTTP.WebSocket == nil {
+			cfg.HTTP.WebSocket = &WebSocketSettings{}
+		}
+		if cfg.HTTP.WebSocket.URLPath == "" {
+			cfg.HTTP.WebSocket.URLPath = defaultWebSocketURLPath
+		}
 	}
 
 	return nil
@@ -42,7 +42,7 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 
-	assert.Equal(t, len(cfg.Receivers), 10)
+	assert.Equal(t, len(cfg.Receivers), 13)
 
 	assert.Equal(t, cfg.Receivers[config.NewComponentID(typeStr)], factory.CreateDefaultConfig())
 
@@ -138,14 +138,19 @@ func TestLoadConfig(t *testing.T) {
 					},
 					ReadBufferSize: 512 * 1024,
 				},
-				HTTP: &confighttp.HTTPServerSettings{
-					Endpoint: "0.0.0.0:4318",
-					TLSSetting: &configtls.TLSServerSetting{
-						TLSSetting: configtls.TLSSetting{
-							CertFile: "test.crt",
-							KeyFile:  "test.key",
+				HTTP: &HTTPConfig{
+					HTTPServerSettings: confighttp.HTTPServerSettings{
+						Endpoint: "0.0.0.0:4318",
+						TLSSetting: &configtls.TLSServerSetting{
+							TLSSetting: configtls.TLSSetting{
+								CertFile: "test.crt",
+								KeyFile:  "test.key",
+							},
 						},
 					},
+					TracesURLPath:  defaultTracesURLPath,
+					MetricsURLPath: defaultMetricsURLPath,
+					LogsURLPath:    defaultLogsURLPath,
 				},
 			},
 		})
@@ -154,12 +159,17 @@ func TestLoadConfig(t *testing.T) {
 		&Config{
 			ReceiverSettings: config.NewReceiverSettings(config.NewComponentIDWithName(typeStr, "cors")),
 			Protocols: Protocols{
-				HTTP: &confighttp.HTTPServerSettings{
-					Endpoint: "0.0.0.0:4318",
-					CORS: &confighttp.CORSSettings{
-						AllowedOrigins: []string{"https://*.test.com", "https://test.com"},
-						MaxAge:         7200,
+				HTTP: &HTTPConfig{
+					HTTPServerSettings: confighttp.HTTPServerSettings{
+						Endpoint: "0.0.0.0:4318",
+						CORS: &confighttp.CORSSettings{
+							AllowedOrigins: []string{"https://*.test.com", "https://test.com"},
+							MaxAge:         7200,
+						},
 					},
+					TracesURLPath:  defaultTracesURLPath,
+					MetricsURLPath: defaultMetricsURLPath,
+					LogsURLPath:    defaultLogsURLPath,
 				},
 			},
 		})
@@ -168,12 +178,17 @@ func TestLoadConfig(t *testing.T) {
 		&Config{
 			ReceiverSettings: config.NewReceiverSettings(config.NewComponentIDWithName(typeStr, "corsheader")),
 			Protocols: Protocols{
-				HTTP: &confighttp.HTTPServerSettings{
-					Endpoint: "0.0.0.0:4318",
-					CORS: &confighttp.CORSSettings{
-						AllowedOrigins: []string{"https://*.test.com", "https://test.com"},
-						AllowedHeaders: []string{"ExampleHeader"},
+				HTTP: &HTTPConfig{
+					HTTPServerSettings: confighttp.HTTPServerSettings{
+						Endpoint: "0.0.0.0:4318",
+						CORS: &confighttp.CORSSettings{
+							AllowedOrigins: []string{"https://*.test.com", "https://test.com"},
+							AllowedHeaders: []string{"ExampleHeader"},
+						},
 					},
+					TracesURLPath:  defaultTracesURLPath,
+					MetricsURLPath: defaultMetricsURLPath,
+					LogsURLPath:    defaultLogsURLPath,
 				},
 			},
 		})
@@ -189,12 +204,241 @@ func TestLoadConfig(t *testing.T) {
 					},
 					ReadBufferSize: 512 * 1024,
 				},
-				HTTP: &confighttp.HTTPServerSettings{
-					Endpoint: "/tmp/http_otlp.sock",
-					// Transport: "unix",
+				HTTP: &HTTPConfig{
+					HTTPServerSettings: confighttp.HTTPServerSettings{
+						Endpoint: "/tmp/http_otlp.sock",
+						// Transport: "unix",
+					},
+					TracesURLPath:  defaultTracesURLPath,
+					MetricsURLPath: defaultMetricsURLPath,
+					LogsURLPath:    defaultLogsURLPath,
+				},
+			},
+		})
+
+	assert.Equal(t, cfg.Receivers[config.NewComponentIDWithName(typeStr, "urlpaths")],
+		&Config{
+			ReceiverSettings: config.NewReceiverSettings(config.NewComponentIDWithName(typeStr, "urlpaths")),
+			Protocols: Protocols{
+				HTTP: &HTTPConfig{
+					HTTPServerSettings: confighttp.HTTPServerSettings{
+						Endpoint: "0.0.0.0:4318",
+					},
+					URLPrefix:       "/otel/ingest",
+					TracesURLPath:   "/traces",
+					MetricsURLPath:  "/v1/metrics",
+					LogsURLPath:     "/logs",
+					LegacyURLPrefix: "/",
 				},
 			},
 		})
+
+	assert.Equal(t, cfg.Receivers[config.NewComponentIDWithName(typeStr, "ratelimit")],
+		&Config{
+			ReceiverSettings: config.NewReceiverSettings(config.NewComponentIDWithName(typeStr, "ratelimit")),
+			Protocols: Protocols{
+				HTTP: &HTTPConfig{
+					HTTPServerSettings: confighttp.HTTPServerSettings{
+						Endpoint:        "0.0.0.0:4318",
+						IncludeMetadata: true,
+					},
+					TracesURLPath:  defaultTracesURLPath,
+					MetricsURLPath: defaultMetricsURLPath,
+					LogsURLPath:    defaultLogsURLPath,
+				},
+			},
+			RateLimit: &RateLimitSettings{
+				Key:               "metadata",
+				KeyName:           "X-Tenant",
+				RequestsPerSecond: 100,
+				ItemsPerSecond:    10000,
+			},
+		})
+
+	assert.Equal(t, cfg.Receivers[config.NewComponentIDWithName(typeStr, "websocket")],
+		&Config{
+			ReceiverSettings: config.NewReceiverSettings(config.NewComponentIDWithName(typeStr, "websocket")),
+			Protocols: Protocols{
+				HTTP: &HTTPConfig{
+					HTTPServerSettings: confighttp.HTTPServerSettings{
+						Endpoint: "0.0.0.0:4318",
+					},
+					TracesURLPath:  defaultTracesURLPath,
+					MetricsURLPath: defaultMetricsURLPath,
+					LogsURLPath:    defaultLogsURLPath,
+					WebSocket: &WebSocketSettings{
+						URLPath: defaultWebSocketURLPath,
+					},
+				},
+			},
+		})
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:   "default",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name: "prefixes",
+			modify: func(cfg *Config) {
+				cfg.HTTP.URLPrefix = "/otel/ingest/"
+				cfg.HTTP.LegacyURLPrefix = "/"
+			},
+		},
+		{
+			name: "relative_url_prefix",
+			modify: func(cfg *Config) {
+				cfg.HTTP.URLPrefix = "otel"
+			},
+			wantErr: `invalid http protocol configuration: url_prefix "otel" must start with a slash`,
+		},
+		{
+			name: "relative_legacy_url_prefix",
+			modify: func(cfg *Config) {
+				cfg.HTTP.LegacyURLPrefix = "otel"
+			},
+			wantErr: `invalid http protocol configuration: legacy_url_prefix "otel" must start with a slash`,
+		},
+		{
+			name: "same_prefixes",
+			modify: func(cfg *Config) {
+				cfg.HTTP.URLPrefix = "/otel"
+				cfg.HTTP.LegacyURLPrefix = "/otel/"
+			},
+			wantErr: "invalid http protocol configuration: legacy_url_prefix must be different from url_prefix",
+		},
+		{
+			name: "root_legacy_url_prefix",
+			modify: func(cfg *Config) {
+				cfg.HTTP.LegacyURLPrefix = "/"
+			},
+			wantErr: "invalid http protocol configuration: legacy_url_prefix must be different from url_prefix",
+		},
+		{
+			name: "relative_traces_url_path",
+			modify: func(cfg *Config) {
+				cfg.HTTP.TracesURLPath = "traces"
+			},
+			wantErr: `invalid http protocol configuration: traces_url_path "traces" must start with a slash`,
+		},
+		{
+			name: "empty_metrics_url_path",
+			modify: func(cfg *Config) {
+				cfg.HTTP.MetricsURLPath = ""
+			},
+			wantErr: `invalid http protocol configuration: metrics_url_path "" must start with a slash`,
+		},
+		{
+			name: "relative_logs_url_path",
+			modify: func(cfg *Config) {
+				cfg.HTTP.LogsURLPath = "logs"
+			},
+			wantErr: `invalid http protocol configuration: logs_url_path "logs" must start with a slash`,
+		},
+		{
+			name: "websocket",
+			modify: func(cfg *Config) {
+				cfg.HTTP.WebSocket = &WebSocketSettings{URLPath: "/ws"}
+			},
+		},
+		{
+			name: "relative_websocket_url_path",
+			modify: func(cfg *Config) {
+				cfg.HTTP.WebSocket = &WebSocketSettings{URLPath: "ws"}
+			},
+			wantErr: `invalid http protocol configuration: websocket url_path "ws" must start with a slash`,
+		},
+		{
+			name: "rate_limit",
+			modify: func(cfg *Config) {
+				cfg.RateLimit = &RateLimitSettings{RequestsPerSecond: 10}
+			},
+		},
+		{
+			name: "rate_limit_auth",
+			modify: func(cfg *Config) {
+				cfg.RateLimit = &RateLimitSettings{Key: "auth", KeyName: "tenant", ItemsPerSecond: 10}
+			},
+		},
+		{
+			name: "rate_limit_unknown_key",
+			modify: func(cfg *Config) {
+				cfg.RateLimit = &RateLimitSettings{Key: "host", RequestsPerSecond: 10}
+			},
+			wantErr: `invalid rate_limit configuration: unknown key "host", must be one of "peer", "auth" or "metadata"`,
+		},
+		{
+			name: "rate_limit_missing_key_name",
+			modify: func(cfg *Config) {
+				cfg.RateLimit = &RateLimitSettings{Key: "metadata", RequestsPerSecond: 10}
+			},
+			wantErr: `invalid rate_limit configuration: key_name must be specified with the "metadata" key`,
+		},
+		{
+			name: "rate_limit_metadata",
+			modify: func(cfg *Config) {
+				cfg.GRPC.IncludeMetadata = true
+				cfg.HTTP.IncludeMetadata = true
+				cfg.RateLimit = &RateLimitSettings{Key: "metadata", KeyName: "X-Tenant", RequestsPerSecond: 10}
+			},
+		},
+		{
+			name: "rate_limit_metadata_without_grpc_metadata",
+			modify: func(cfg *Config) {
+				cfg.HTTP.IncludeMetadata = true
+				cfg.RateLimit = &RateLimitSettings{Key: "metadata", KeyName: "X-Tenant", RequestsPerSecond: 10}
+			},
+			wantErr: `invalid rate_limit configuration: the "metadata" key requires include_metadata on the grpc protocol`,
+		},
+		{
+			name: "rate_limit_metadata_without_http_metadata",
+			modify: func(cfg *Config) {
+				cfg.GRPC.IncludeMetadata = true
+				cfg.RateLimit = &RateLimitSettings{Key: "metadata", KeyName: "X-Tenant", RequestsPerSecond: 10}
+			},
+			wantErr: `invalid rate_limit configuration: the "metadata" key requires include_metadata on the http protocol`,
+		},
+		{
+			name: "rate_limit_metadata_http_only",
+			modify: func(cfg *Config) {
+				cfg.GRPC = nil
+				cfg.HTTP.IncludeMetadata = true
+				cfg.RateLimit = &RateLimitSettings{Key: "metadata", KeyName: "X-Tenant", RequestsPerSecond: 10}
+			},
+		},
+		{
+			name: "rate_limit_negative",
+			modify: func(cfg *Config) {
+				cfg.RateLimit = &RateLimitSettings{RequestsPerSecond: 10, ItemsPerSecond: -1}
+			},
+			wantErr: "invalid rate_limit configuration: requests_per_second and items_per_second must not be negative",
+		},
+		{
+			name: "rate_limit_no_limit",
+			modify: func(cfg *Config) {
+				cfg.RateLimit = &RateLimitSettings{}
+			},
+			wantErr: "invalid rate_limit configuration: at least one of requests_per_second or items_per_second must be specified",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
 }
 
 func TestFailedLoadConfig(t *testing.T) {
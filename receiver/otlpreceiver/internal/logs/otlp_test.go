@@ -28,6 +28,7 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/model/otlpgrpc"
@@ -91,6 +92,22 @@ func TestExport_ErrorConsumer(t *testing.T) {
 	assert.Equal(t, otlpgrpc.LogsResponse{}, resp)
 }
 
+func TestExport_PartialConsumer(t *testing.T) {
+	addr, doneFn := otlpReceiverOnGRPCServer(t, consumertest.NewErr(consumererror.NewPartial(errors.New("my error"), 1)))
+	defer doneFn()
+
+	logClient, logClientDoneFn, err := makeLogsServiceClient(addr)
+	require.NoError(t, err, "Failed to create the LogsServiceClient: %v", err)
+	defer logClientDoneFn()
+
+	req := otlpgrpc.NewLogsRequest()
+	req.SetLogs(testdata.GenerateLogsTwoLogRecordsSameResource())
+	resp, err := logClient.Export(context.Background(), req)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, resp.PartialSuccess().RejectedLogRecords())
+	assert.Equal(t, "Partial (1 rejected), error: my error", resp.PartialSuccess().ErrorMessage())
+}
+
 func makeLogsServiceClient(addr net.Addr) (otlpgrpc.LogsClient, func(), error) {
 	cc, err := grpc.Dial(addr.String(), grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
 	if err != nil {
@@ -114,7 +131,7 @@ func otlpReceiverOnGRPCServer(t *testing.T, tc consumer.Logs) (net.Addr, func())
 		}
 	}
 
-	r := New(config.NewComponentIDWithName("otlp", "log"), tc, componenttest.NewNopReceiverCreateSettings())
+	r := New(config.NewComponentIDWithName("otlp", "log"), tc, nil, componenttest.NewNopReceiverCreateSettings())
 	require.NoError(t, err)
 
 	// Now run it as a gRPC server
@@ -20,8 +20,11 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/internal/errorutil"
 	"go.opentelemetry.io/collector/model/otlpgrpc"
 	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/ratelimit"
 )
 
 const (
@@ -32,13 +35,15 @@ const (
 // Receiver is the type used to handle metrics from OpenTelemetry exporters.
 type Receiver struct {
 	nextConsumer consumer.Metrics
+	limiter      *ratelimit.Limiter
 	obsrecv      *obsreport.Receiver
 }
 
 // New creates a new Receiver reference.
-func New(id config.ComponentID, nextConsumer consumer.Metrics, set component.ReceiverCreateSettings) *Receiver {
+func New(id config.ComponentID, nextConsumer consumer.Metrics, limiter *ratelimit.Limiter, set component.ReceiverCreateSettings) *Receiver {
 	return &Receiver{
 		nextConsumer: nextConsumer,
+		limiter:      limiter,
 		obsrecv: obsreport.NewReceiver(obsreport.ReceiverSettings{
 			ReceiverID:             id,
 			Transport:              receiverTransport,
@@ -56,8 +61,21 @@ func (r *Receiver) Export(ctx context.Context, req otlpgrpc.MetricsRequest) (otl
 	}
 
 	ctx = r.obsrecv.StartMetricsOp(ctx)
-	err := r.nextConsumer.ConsumeMetrics(ctx, md)
+	err := r.limiter.AcquireItems(ctx, dataPointCount)
+	if err == nil {
+		err = r.nextConsumer.ConsumeMetrics(ctx, md)
+	}
 	r.obsrecv.EndMetricsOp(ctx, dataFormatProtobuf, dataPointCount, err)
 
-	return otlpgrpc.NewMetricsResponse(), err
+	resp := otlpgrpc.NewMetricsResponse()
+	if consumererror.IsPartial(err) {
+		// Only a part of the data was refused, report it in the response rather
+		// than failing the whole request.
+		ps := otlpgrpc.NewMetricsPartialSuccess()
+		ps.SetRejectedDataPoints(int64(consumererror.GetRejectedCount(err)))
+		ps.SetErrorMessage(err.Error())
+		resp.SetPartialSuccess(ps)
+		return resp, nil
+	}
+	return resp, errorutil.GetStatusFromError(err)
 }
@@ -28,6 +28,7 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/model/otlpgrpc"
@@ -93,6 +94,22 @@ func TestExport_ErrorConsumer(t *testing.T) {
 	assert.Equal(t, otlpgrpc.MetricsResponse{}, resp)
 }
 
+func TestExport_PartialConsumer(t *testing.T) {
+	addr, doneFn := otlpReceiverOnGRPCServer(t, consumertest.NewErr(consumererror.NewPartial(errors.New("my error"), 1)))
+	defer doneFn()
+
+	metricsClient, metricsClientDoneFn, err := makeMetricsServiceClient(addr)
+	require.NoError(t, err, "Failed to create the MetricsServiceClient: %v", err)
+	defer metricsClientDoneFn()
+
+	req := otlpgrpc.NewMetricsRequest()
+	req.SetMetrics(testdata.GenerateMetricsTwoMetrics())
+	resp, err := metricsClient.Export(context.Background(), req)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, resp.PartialSuccess().RejectedDataPoints())
+	assert.Equal(t, "Partial (1 rejected), error: my error", resp.PartialSuccess().ErrorMessage())
+}
+
 func makeMetricsServiceClient(addr net.Addr) (otlpgrpc.MetricsClient, func(), error) {
 	cc, err := grpc.Dial(addr.String(), grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
 	if err != nil {
@@ -116,7 +133,7 @@ func otlpReceiverOnGRPCServer(t *testing.T, mc consumer.Metrics) (net.Addr, func
 		}
 	}
 
-	r := New(config.NewComponentIDWithName("otlp", "metrics"), mc, componenttest.NewNopReceiverCreateSettings())
+	r := New(config.NewComponentIDWithName("otlp", "metrics"), mc, nil, componenttest.NewNopReceiverCreateSettings())
 	// Now run it as a gRPC server
 	srv := grpc.NewServer()
 	otlpgrpc.RegisterMetricsServer(srv, r)
@@ -0,0 +1,219 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ratelimit limits the rate of the requests and items received from
+// each client of the OTLP receiver.
+package ratelimit // import "go.opentelemetry.io/collector/receiver/otlpreceiver/internal/ratelimit"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+// idleTimeout is the duration after which the buckets of a client that sent
+// no requests are forgotten. It is greater than the time needed to fill the
+// buckets, so forgetting them does not change the limits applied.
+const idleTimeout = time.Minute
+
+var errRateLimited = errors.New("rate limit exceeded")
+
+// KeyFunc returns the key identifying the client of a request from its context.
+type KeyFunc func(ctx context.Context) string
+
+// PeerKey identifies the clients by the IP address of their connection.
+func PeerKey() KeyFunc {
+	return func(ctx context.Context) string {
+		addr := client.FromContext(ctx).Addr
+		if addr == nil {
+			return ""
+		}
+		host, _, err := net.SplitHostPort(addr.String())
+		if err != nil {
+			return addr.String()
+		}
+		return host
+	}
+}
+
+// AuthKey identifies the clients by the given attribute of the authentication
+// data set by the authenticator of the receiver.
+func AuthKey(attribute string) KeyFunc {
+	return func(ctx context.Context) string {
+		auth := client.FromContext(ctx).Auth
+		if auth == nil {
+			return ""
+		}
+		value := auth.GetAttribute(attribute)
+		if value == nil {
+			return ""
+		}
+		return fmt.Sprint(value)
+	}
+}
+
+// MetadataKey identifies the clients by the first value of the given metadata
+// header, which requires the protocols to include the metadata in the client
+// information. The header name is matched as given, lowercased as in gRPC, or
+// canonicalized as in HTTP.
+func MetadataKey(header string) KeyFunc {
+	names := []string{header, strings.ToLower(header), http.CanonicalHeaderKey(header)}
+	return func(ctx context.Context) string {
+		md := client.FromContext(ctx).Metadata
+		for _, name := range names {
+			if values := md.Get(name); len(values) > 0 {
+				return values[0]
+			}
+		}
+		return ""
+	}
+}
+
+// Limiter limits the number of requests and items per second accepted from
+// each client. The clients without key share the same limits.
+type Limiter struct {
+	key               KeyFunc
+	requestsPerSecond float64
+	itemsPerSecond    float64
+	now               func() time.Time
+
+	mu        sync.Mutex
+	clients   map[string]*clientLimits
+	lastSweep time.Time
+}
+
+type clientLimits struct {
+	requests   bucket
+	items      bucket
+	lastAccess time.Time
+}
+
+// NewLimiter returns a Limiter accepting the given number of requests and items
+// per second from each client identified by key. A zero rate means no limit.
+func NewLimiter(key KeyFunc, requestsPerSecond, itemsPerSecond float64) *Limiter {
+	return &Limiter{
+		key:               key,
+		requestsPerSecond: requestsPerSecond,
+		itemsPerSecond:    itemsPerSecond,
+		now:               time.Now,
+		clients:           make(map[string]*clientLimits),
+	}
+}
+
+// AcquireRequest accounts for a request, or returns a throttle error with the
+// delay after which the client may retry if its requests limit is exceeded. It
+// must be called before reading the request, so that the requests refused do
+// not cost their decoding. A nil Limiter accepts every request.
+func (l *Limiter) AcquireRequest(ctx context.Context) error {
+	if l == nil || l.requestsPerSecond <= 0 {
+		return nil
+	}
+	return l.acquire(ctx, func(cl *clientLimits) *bucket { return &cl.requests }, 1)
+}
+
+// AcquireItems accounts for the given number of items of a decoded request, or
+// returns a throttle error with the delay after which the client may retry if
+// its items limit is exceeded. A nil Limiter accepts every request.
+func (l *Limiter) AcquireItems(ctx context.Context, items int) error {
+	if l == nil || l.itemsPerSecond <= 0 {
+		return nil
+	}
+	return l.acquire(ctx, func(cl *clientLimits) *bucket { return &cl.items }, float64(items))
+}
+
+// acquire takes n tokens from the bucket of the client of the request.
+func (l *Limiter) acquire(ctx context.Context, getBucket func(*clientLimits) *bucket, n float64) error {
+	key := l.key(ctx)
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+	cl, ok := l.clients[key]
+	if !ok {
+		cl = &clientLimits{
+			requests: newBucket(l.requestsPerSecond, now),
+			items:    newBucket(l.itemsPerSecond, now),
+		}
+		l.clients[key] = cl
+	}
+	cl.lastAccess = now
+
+	b := getBucket(cl)
+	if delay := b.delay(n, now); delay > 0 {
+		return consumererror.NewThrottle(fmt.Errorf("%w for client %q", errRateLimited, key), delay)
+	}
+	b.take(n)
+	return nil
+}
+
+// sweep forgets the clients idle for longer than idleTimeout, at most once per idleTimeout.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < idleTimeout {
+		return
+	}
+	l.lastSweep = now
+	for key, cl := range l.clients {
+		if now.Sub(cl.lastAccess) >= idleTimeout {
+			delete(l.clients, key)
+		}
+	}
+}
+
+// bucket is a token bucket holding up to one second of tokens. A request taking
+// more tokens than the bucket can hold is accepted when the bucket is full, and
+// the bucket then goes into debt, so that the average rate is still enforced.
+type bucket struct {
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(rate float64, now time.Time) bucket {
+	return bucket{
+		rate:   rate,
+		tokens: rate,
+		last:   now,
+	}
+}
+
+// delay refills the bucket and returns how long to wait before n tokens can be taken.
+func (b *bucket) delay(n float64, now time.Time) time.Duration {
+	if b.rate <= 0 {
+		return 0
+	}
+	b.tokens = math.Min(b.rate, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	needed := math.Min(n, b.rate)
+	if b.tokens >= needed {
+		return 0
+	}
+	return time.Duration((needed - b.tokens) / b.rate * float64(time.Second))
+}
+
+func (b *bucket) take(n float64) {
+	if b.rate > 0 {
+		b.tokens -= n
+	}
+}
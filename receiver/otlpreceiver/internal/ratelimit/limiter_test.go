@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+type testAuthData map[string]interface{}
+
+func (a testAuthData) GetAttribute(name string) interface{} {
+	return a[name]
+}
+
+func (a testAuthData) GetAttributeNames() []string {
+	var names []string
+	for name := range a {
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestKeys(t *testing.T) {
+	ctx := client.NewContext(context.Background(), client.Info{
+		Addr:     &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 1234},
+		Auth:     testAuthData{"tenant": "acme", "id": 42},
+		Metadata: client.NewMetadata(map[string][]string{"x-tenant": {"a", "b"}, "X-Scope-Orgid": {"org"}}),
+	})
+
+	assert.Equal(t, "10.0.0.1", PeerKey()(ctx))
+	assert.Equal(t, "acme", AuthKey("tenant")(ctx))
+	assert.Equal(t, "42", AuthKey("id")(ctx))
+	assert.Equal(t, "", AuthKey("missing")(ctx))
+	assert.Equal(t, "a", MetadataKey("X-Tenant")(ctx))
+	assert.Equal(t, "org", MetadataKey("x-scope-orgid")(ctx))
+	assert.Equal(t, "", MetadataKey("missing")(ctx))
+
+	ipCtx := client.NewContext(context.Background(), client.Info{Addr: &net.IPAddr{IP: net.IPv4(10, 0, 0, 2)}})
+	assert.Equal(t, "10.0.0.2", PeerKey()(ipCtx))
+
+	assert.Equal(t, "", PeerKey()(context.Background()))
+	assert.Equal(t, "", AuthKey("tenant")(context.Background()))
+	assert.Equal(t, "", MetadataKey("x-tenant")(context.Background()))
+}
+
+func newTestLimiter(requestsPerSecond, itemsPerSecond float64) (*Limiter, *time.Time) {
+	now := time.Unix(1000, 0)
+	l := NewLimiter(AuthKey("tenant"), requestsPerSecond, itemsPerSecond)
+	l.now = func() time.Time { return now }
+	return l, &now
+}
+
+func tenantContext(tenant string) context.Context {
+	return client.NewContext(context.Background(), client.Info{Auth: testAuthData{"tenant": tenant}})
+}
+
+func TestLimiterRequests(t *testing.T) {
+	l, now := newTestLimiter(2, 0)
+	ctx := tenantContext("a")
+
+	require.NoError(t, l.AcquireRequest(ctx))
+	require.NoError(t, l.AcquireRequest(ctx))
+	err := l.AcquireRequest(ctx)
+	require.Error(t, err)
+	assert.True(t, consumererror.IsThrottle(err))
+	assert.Equal(t, 500*time.Millisecond, consumererror.GetThrottleDelay(err))
+	assert.ErrorIs(t, err, errRateLimited)
+	assert.Contains(t, err.Error(), `for client "a"`)
+
+	// Other clients have their own limits.
+	require.NoError(t, l.AcquireRequest(tenantContext("b")))
+
+	*now = now.Add(500 * time.Millisecond)
+	require.NoError(t, l.AcquireRequest(ctx))
+	assert.Error(t, l.AcquireRequest(ctx))
+}
+
+func TestLimiterItems(t *testing.T) {
+	l, now := newTestLimiter(0, 10)
+	ctx := tenantContext("a")
+
+	require.NoError(t, l.AcquireItems(ctx, 6))
+	err := l.AcquireItems(ctx, 6)
+	require.Error(t, err)
+	assert.Equal(t, 200*time.Millisecond, consumererror.GetThrottleDelay(err))
+
+	// A refused request does not take tokens.
+	require.NoError(t, l.AcquireItems(ctx, 4))
+
+	// A request larger than the limit is accepted when the bucket is full,
+	// then the client must wait until the debt is paid.
+	*now = now.Add(time.Second)
+	require.NoError(t, l.AcquireItems(ctx, 25))
+	err = l.AcquireItems(ctx, 1)
+	require.Error(t, err)
+	assert.Equal(t, 1600*time.Millisecond, consumererror.GetThrottleDelay(err))
+}
+
+func TestLimiterBoth(t *testing.T) {
+	l, _ := newTestLimiter(10, 10)
+	ctx := tenantContext("a")
+
+	// The requests and the items are limited separately.
+	require.NoError(t, l.AcquireRequest(ctx))
+	require.NoError(t, l.AcquireItems(ctx, 10))
+	require.NoError(t, l.AcquireRequest(ctx))
+	err := l.AcquireItems(ctx, 5)
+	require.Error(t, err)
+	assert.Equal(t, 500*time.Millisecond, consumererror.GetThrottleDelay(err))
+}
+
+func TestLimiterSweep(t *testing.T) {
+	l, now := newTestLimiter(1, 0)
+
+	require.NoError(t, l.AcquireRequest(tenantContext("a")))
+	*now = now.Add(30 * time.Second)
+	require.NoError(t, l.AcquireRequest(tenantContext("b")))
+	assert.Len(t, l.clients, 2)
+
+	*now = now.Add(idleTimeout)
+	require.NoError(t, l.AcquireRequest(tenantContext("b")))
+	assert.Len(t, l.clients, 1)
+	assert.Contains(t, l.clients, "b")
+}
+
+func TestNilLimiter(t *testing.T) {
+	var l *Limiter
+	assert.NoError(t, l.AcquireRequest(context.Background()))
+	assert.NoError(t, l.AcquireItems(context.Background(), 1))
+}
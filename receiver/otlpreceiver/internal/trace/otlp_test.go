@@ -19,18 +19,25 @@ import (
 	"errors"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/model/otlpgrpc"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/ratelimit"
 )
 
 func TestExport(t *testing.T) {
@@ -90,6 +97,64 @@ func TestExport_ErrorConsumer(t *testing.T) {
 	assert.Equal(t, otlpgrpc.TracesResponse{}, resp)
 }
 
+func TestExport_ThrottleConsumer(t *testing.T) {
+	addr, doneFn := otlpReceiverOnGRPCServer(t, consumertest.NewErr(consumererror.NewThrottle(errors.New("my error"), 2*time.Second)))
+	defer doneFn()
+
+	traceClient, traceClientDoneFn, err := makeTraceServiceClient(addr)
+	require.NoError(t, err, "Failed to create the TraceServiceClient: %v", err)
+	defer traceClientDoneFn()
+
+	req := otlpgrpc.NewTracesRequest()
+	req.SetTraces(testdata.GenerateTracesOneSpan())
+	_, err = traceClient.Export(context.Background(), req)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	require.Len(t, st.Details(), 1)
+	retryInfo, ok := st.Details()[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	assert.Equal(t, 2*time.Second, retryInfo.RetryDelay.AsDuration())
+}
+
+func TestExport_PartialConsumer(t *testing.T) {
+	addr, doneFn := otlpReceiverOnGRPCServer(t, consumertest.NewErr(consumererror.NewPartial(errors.New("my error"), 1)))
+	defer doneFn()
+
+	traceClient, traceClientDoneFn, err := makeTraceServiceClient(addr)
+	require.NoError(t, err, "Failed to create the TraceServiceClient: %v", err)
+	defer traceClientDoneFn()
+
+	req := otlpgrpc.NewTracesRequest()
+	req.SetTraces(testdata.GenerateTracesTwoSpansSameResource())
+	resp, err := traceClient.Export(context.Background(), req)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, resp.PartialSuccess().RejectedSpans())
+	assert.Equal(t, "Partial (1 rejected), error: my error", resp.PartialSuccess().ErrorMessage())
+}
+
+func TestExport_RateLimited(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	id := config.NewComponentIDWithName("otlp", "trace")
+	sink := new(consumertest.TracesSink)
+	r := New(id, sink, ratelimit.NewLimiter(ratelimit.PeerKey(), 0, 2), tt.ToReceiverCreateSettings())
+
+	req := otlpgrpc.NewTracesRequest()
+	req.SetTraces(testdata.GenerateTracesTwoSpansSameResource())
+	_, err = r.Export(context.Background(), req)
+	require.NoError(t, err)
+
+	_, err = r.Export(context.Background(), req)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	assert.Equal(t, 2, sink.SpanCount())
+	require.NoError(t, obsreporttest.CheckReceiverTraces(tt, id, "grpc", 2, 2))
+}
+
 func makeTraceServiceClient(addr net.Addr) (otlpgrpc.TracesClient, func(), error) {
 	cc, err := grpc.Dial(addr.String(), grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
 	if err != nil {
@@ -113,7 +178,7 @@ func otlpReceiverOnGRPCServer(t *testing.T, tc consumer.Traces) (net.Addr, func(
 		}
 	}
 
-	r := New(config.NewComponentIDWithName("otlp", "trace"), tc, componenttest.NewNopReceiverCreateSettings())
+	r := New(config.NewComponentIDWithName("otlp", "trace"), tc, nil, componenttest.NewNopReceiverCreateSettings())
 	require.NoError(t, err)
 
 	// Now run it as a gRPC server
@@ -22,6 +22,7 @@ import (
 	"sync"
 
 	"github.com/gorilla/mux"
+	"golang.org/x/net/websocket"
 	"google.golang.org/grpc"
 
 	"go.opentelemetry.io/collector/component"
@@ -29,9 +30,12 @@ import (
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/errorutil"
 	"go.opentelemetry.io/collector/model/otlpgrpc"
+	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/logs"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/metrics"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/ratelimit"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/trace"
 )
 
@@ -42,11 +46,19 @@ type otlpReceiver struct {
 	httpMux    *mux.Router
 	serverHTTP *http.Server
 
+	limiter         *ratelimit.Limiter
+	obsrepGRPC      *obsreport.Receiver
+	obsrepHTTP      *obsreport.Receiver
+	obsrepWebSocket *obsreport.Receiver
 	traceReceiver   *trace.Receiver
 	metricsReceiver *metrics.Receiver
 	logReceiver     *logs.Receiver
 	shutdownWG      sync.WaitGroup
 
+	wsMu     sync.Mutex
+	wsConns  map[*websocket.Conn]struct{}
+	wsClosed bool
+
 	settings component.ReceiverCreateSettings
 }
 
@@ -60,11 +72,46 @@ func newOtlpReceiver(cfg *Config, settings component.ReceiverCreateSettings) *ot
 	}
 	if cfg.HTTP != nil {
 		r.httpMux = mux.NewRouter()
+		if cfg.HTTP.WebSocket != nil {
+			r.wsConns = make(map[*websocket.Conn]struct{})
+			handler := r.newWebSocketHandler()
+			for _, urlPath := range cfg.HTTP.urlPaths(cfg.HTTP.WebSocket.URLPath) {
+				r.httpMux.Handle(urlPath, handler).Methods(http.MethodGet)
+			}
+		}
+	}
+	if cfg.RateLimit != nil {
+		r.limiter = newLimiter(cfg.RateLimit)
+		r.obsrepGRPC = newObsReceiver(cfg, "grpc", settings)
+		r.obsrepHTTP = newObsReceiver(cfg, "http", settings)
+		r.obsrepWebSocket = newObsReceiver(cfg, "websocket", settings)
 	}
 
 	return r
 }
 
+// newLimiter creates the limiter shared by all the protocols and signals of the receiver.
+func newLimiter(cfg *RateLimitSettings) *ratelimit.Limiter {
+	key := ratelimit.PeerKey()
+	switch cfg.Key {
+	case rateLimitKeyAuth:
+		key = ratelimit.AuthKey(cfg.KeyName)
+	case rateLimitKeyMetadata:
+		key = ratelimit.MetadataKey(cfg.KeyName)
+	}
+	return ratelimit.NewLimiter(key, cfg.RequestsPerSecond, cfg.ItemsPerSecond)
+}
+
+// newObsReceiver creates the observability of the requests refused by the limiter
+// on a transport.
+func newObsReceiver(cfg *Config, transport string, settings component.ReceiverCreateSettings) *obsreport.Receiver {
+	return obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID:             cfg.ID(),
+		Transport:              transport,
+		ReceiverCreateSettings: settings,
+	})
+}
+
 func (r *otlpReceiver) startGRPCServer(cfg *configgrpc.GRPCServerSettings, host component.Host) error {
 	r.settings.Logger.Info("Starting GRPC server on endpoint " + cfg.NetAddr.Endpoint)
 
@@ -83,7 +130,7 @@ func (r *otlpReceiver) startGRPCServer(cfg *configgrpc.GRPCServerSettings, host
 	return nil
 }
 
-func (r *otlpReceiver) startHTTPServer(cfg *confighttp.HTTPServerSettings, host component.Host) error {
+func (r *otlpReceiver) startHTTPServer(cfg *HTTPConfig, host component.Host) error {
 	r.settings.Logger.Info("Starting HTTP server on endpoint " + cfg.Endpoint)
 	var hln net.Listener
 	hln, err := cfg.ToListener()
@@ -109,6 +156,10 @@ func (r *otlpReceiver) startProtocolServers(host component.Host) error {
 		if err != nil {
 			return err
 		}
+		if r.limiter != nil {
+			// Chained after the interceptors of the settings, which set the client information.
+			opts = append(opts, grpc.ChainUnaryInterceptor(r.rateLimitUnaryInterceptor))
+		}
 		r.serverGRPC = grpc.NewServer(opts...)
 
 		if r.traceReceiver != nil {
@@ -148,6 +199,16 @@ func (r *otlpReceiver) startProtocolServers(host component.Host) error {
 	return err
 }
 
+// rateLimitUnaryInterceptor refuses the gRPC requests of the clients exceeding their
+// requests limit with a RESOURCE_EXHAUSTED status, before calling the handler.
+func (r *otlpReceiver) rateLimitUnaryInterceptor(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := r.limiter.AcquireRequest(ctx); err != nil {
+		r.obsrepGRPC.RequestRefused(ctx)
+		return nil, errorutil.GetStatusFromError(err)
+	}
+	return handler(ctx, req)
+}
+
 // Start runs the trace receiver on the gRPC server. Currently
 // it also enables the metrics receiver too.
 func (r *otlpReceiver) Start(_ context.Context, host component.Host) error {
@@ -162,6 +223,10 @@ func (r *otlpReceiver) Shutdown(ctx context.Context) error {
 		err = r.serverHTTP.Shutdown(ctx)
 	}
 
+	if r.wsConns != nil {
+		r.closeWebSockets()
+	}
+
 	if r.serverGRPC != nil {
 		r.serverGRPC.GracefulStop()
 	}
@@ -174,16 +239,10 @@ func (r *otlpReceiver) registerTraceConsumer(tc consumer.Traces) error {
 	if tc == nil {
 		return componenterror.ErrNilNextConsumer
 	}
-	r.traceReceiver = trace.New(r.cfg.ID(), tc, r.settings)
+	r.traceReceiver = trace.New(r.cfg.ID(), tc, r.limiter, r.settings)
 	if r.httpMux != nil {
-		r.httpMux.HandleFunc("/v1/traces", func(resp http.ResponseWriter, req *http.Request) {
-			handleTraces(resp, req, r.traceReceiver, pbEncoder)
-		}).Methods(http.MethodPost).Headers("Content-Type", pbContentType)
-		r.httpMux.HandleFunc("/v1/traces", func(resp http.ResponseWriter, req *http.Request) {
-			handleTraces(resp, req, r.traceReceiver, jsEncoder)
-		}).Methods(http.MethodPost).Headers("Content-Type", jsonContentType)
-		r.httpMux.HandleFunc("/v1/traces", func(resp http.ResponseWriter, req *http.Request) {
-			handleUnmatchedRequests(resp, req)
+		r.registerHTTPHandlers(r.cfg.HTTP.TracesURLPath, func(resp http.ResponseWriter, req *http.Request, encoder encoder) {
+			handleTraces(resp, req, r.traceReceiver, encoder)
 		})
 	}
 	return nil
@@ -193,16 +252,10 @@ func (r *otlpReceiver) registerMetricsConsumer(mc consumer.Metrics) error {
 	if mc == nil {
 		return componenterror.ErrNilNextConsumer
 	}
-	r.metricsReceiver = metrics.New(r.cfg.ID(), mc, r.settings)
+	r.metricsReceiver = metrics.New(r.cfg.ID(), mc, r.limiter, r.settings)
 	if r.httpMux != nil {
-		r.httpMux.HandleFunc("/v1/metrics", func(resp http.ResponseWriter, req *http.Request) {
-			handleMetrics(resp, req, r.metricsReceiver, pbEncoder)
-		}).Methods(http.MethodPost).Headers("Content-Type", pbContentType)
-		r.httpMux.HandleFunc("/v1/metrics", func(resp http.ResponseWriter, req *http.Request) {
-			handleMetrics(resp, req, r.metricsReceiver, jsEncoder)
-		}).Methods(http.MethodPost).Headers("Content-Type", jsonContentType)
-		r.httpMux.HandleFunc("/v1/metrics", func(resp http.ResponseWriter, req *http.Request) {
-			handleUnmatchedRequests(resp, req)
+		r.registerHTTPHandlers(r.cfg.HTTP.MetricsURLPath, func(resp http.ResponseWriter, req *http.Request, encoder encoder) {
+			handleMetrics(resp, req, r.metricsReceiver, encoder)
 		})
 	}
 	return nil
@@ -212,19 +265,40 @@ func (r *otlpReceiver) registerLogsConsumer(lc consumer.Logs) error {
 	if lc == nil {
 		return componenterror.ErrNilNextConsumer
 	}
-	r.logReceiver = logs.New(r.cfg.ID(), lc, r.settings)
+	r.logReceiver = logs.New(r.cfg.ID(), lc, r.limiter, r.settings)
 	if r.httpMux != nil {
-		r.httpMux.HandleFunc("/v1/logs", func(w http.ResponseWriter, req *http.Request) {
-			handleLogs(w, req, r.logReceiver, pbEncoder)
+		r.registerHTTPHandlers(r.cfg.HTTP.LogsURLPath, func(resp http.ResponseWriter, req *http.Request, encoder encoder) {
+			handleLogs(resp, req, r.logReceiver, encoder)
+		})
+	}
+	return nil
+}
+
+// registerHTTPHandlers routes the POST requests on the URL paths of a signal to the
+// given handler, with the encoder matching their content type.
+func (r *otlpReceiver) registerHTTPHandlers(signalPath string, handle func(http.ResponseWriter, *http.Request, encoder)) {
+	for _, urlPath := range r.cfg.HTTP.urlPaths(signalPath) {
+		r.httpMux.HandleFunc(urlPath, func(resp http.ResponseWriter, req *http.Request) {
+			r.handleRateLimited(resp, req, pbEncoder, handle)
 		}).Methods(http.MethodPost).Headers("Content-Type", pbContentType)
-		r.httpMux.HandleFunc("/v1/logs", func(w http.ResponseWriter, req *http.Request) {
-			handleLogs(w, req, r.logReceiver, jsEncoder)
+		r.httpMux.HandleFunc(urlPath, func(resp http.ResponseWriter, req *http.Request) {
+			r.handleRateLimited(resp, req, jsEncoder, handle)
 		}).Methods(http.MethodPost).Headers("Content-Type", jsonContentType)
-		r.httpMux.HandleFunc("/v1/logs", func(resp http.ResponseWriter, req *http.Request) {
+		r.httpMux.HandleFunc(urlPath, func(resp http.ResponseWriter, req *http.Request) {
 			handleUnmatchedRequests(resp, req)
 		})
 	}
-	return nil
+}
+
+// handleRateLimited refuses the HTTP requests of the clients exceeding their requests
+// limit before reading their body, and passes the others to handle.
+func (r *otlpReceiver) handleRateLimited(resp http.ResponseWriter, req *http.Request, encoder encoder, handle func(http.ResponseWriter, *http.Request, encoder)) {
+	if err := r.limiter.AcquireRequest(req.Context()); err != nil {
+		r.obsrepHTTP.RequestRefused(req.Context())
+		writeError(resp, encoder, errorutil.GetStatusFromError(err), http.StatusTooManyRequests)
+		return
+	}
+	handle(resp, req, encoder)
 }
 
 func handleUnmatchedRequests(resp http.ResponseWriter, req *http.Request) {
@@ -44,6 +44,7 @@ import (
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/internal/internalconsumertest"
 	"go.opentelemetry.io/collector/internal/testdata"
@@ -184,7 +185,12 @@ func TestHandleInvalidRequests(t *testing.T) {
 	endpoint := testutil.GetAvailableLocalAddress(t)
 	cfg := &Config{
 		ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
-		Protocols:        Protocols{HTTP: &confighttp.HTTPServerSettings{Endpoint: endpoint}},
+		Protocols: Protocols{HTTP: &HTTPConfig{
+			HTTPServerSettings: confighttp.HTTPServerSettings{Endpoint: endpoint},
+			TracesURLPath:      defaultTracesURLPath,
+			MetricsURLPath:     defaultMetricsURLPath,
+			LogsURLPath:        defaultLogsURLPath,
+		}},
 	}
 
 	// Traces
@@ -506,6 +512,309 @@ func testHTTPProtobufRequest(
 	}
 }
 
+func TestHTTPConsumerErrors(t *testing.T) {
+	tests := []struct {
+		name               string
+		err                error
+		expectedStatusCode int
+		expectedRetryAfter string
+	}{
+		{
+			name:               "ThrottleWithDelay",
+			err:                consumererror.NewThrottle(errors.New("my error"), 1500*time.Millisecond),
+			expectedStatusCode: http.StatusTooManyRequests,
+			expectedRetryAfter: "2",
+		},
+		{
+			name:               "ThrottleWithoutDelay",
+			err:                consumererror.NewThrottle(errors.New("my error"), 0),
+			expectedStatusCode: http.StatusTooManyRequests,
+		},
+		{
+			name:               "Unavailable",
+			err:                status.New(codes.Unavailable, "my error").Err(),
+			expectedStatusCode: http.StatusServiceUnavailable,
+		},
+		{
+			name:               "Permanent",
+			err:                consumererror.NewPermanent(errors.New("my error")),
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Other",
+			err:                errors.New("my error"),
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+	addr := testutil.GetAvailableLocalAddress(t)
+
+	tSink := &internalconsumertest.ErrOrSinkConsumer{TracesSink: new(consumertest.TracesSink)}
+	ocr := newHTTPReceiver(t, addr, tSink, consumertest.NewNop())
+
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()), "Failed to start trace receiver")
+	t.Cleanup(func() { require.NoError(t, ocr.Shutdown(context.Background())) })
+
+	traceBytes, err := otlp.NewProtobufTracesMarshaler().MarshalTraces(testdata.GenerateTracesOneSpan())
+	require.NoError(t, err)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tSink.SetConsumeError(test.err)
+
+			req := createHTTPProtobufRequest(t, fmt.Sprintf("http://%s/v1/traces", addr), "", traceBytes)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+
+			respBytes, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+
+			assert.Equal(t, test.expectedStatusCode, resp.StatusCode)
+			assert.Equal(t, test.expectedRetryAfter, resp.Header.Get("Retry-After"))
+
+			errStatus := &spb.Status{}
+			require.NoError(t, proto.Unmarshal(respBytes, errStatus))
+			assert.NotEqual(t, int32(codes.OK), errStatus.Code)
+		})
+	}
+}
+
+func TestGRPCPermanentError(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+
+	sink := &internalconsumertest.ErrOrSinkConsumer{TracesSink: new(consumertest.TracesSink)}
+	sink.SetConsumeError(consumererror.NewPermanent(errors.New("my error")))
+	ocr := newGRPCReceiver(t, otlpReceiverName, addr, sink, nil)
+
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()), "Failed to start trace receiver")
+	t.Cleanup(func() { require.NoError(t, ocr.Shutdown(context.Background())) })
+
+	cc, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	require.NoError(t, err)
+	defer cc.Close()
+
+	err = exportTraces(cc, testdata.GenerateTracesOneSpan())
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestHTTPPartialSuccess(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+
+	tSink := &internalconsumertest.ErrOrSinkConsumer{TracesSink: new(consumertest.TracesSink)}
+	tSink.SetConsumeError(consumererror.NewPartial(errors.New("my error"), 1))
+	ocr := newHTTPReceiver(t, addr, tSink, consumertest.NewNop())
+
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()), "Failed to start trace receiver")
+	t.Cleanup(func() { require.NoError(t, ocr.Shutdown(context.Background())) })
+
+	td := testdata.GenerateTracesTwoSpansSameResource()
+	protoBytes, err := otlp.NewProtobufTracesMarshaler().MarshalTraces(td)
+	require.NoError(t, err)
+	jsonBytes, err := otlp.NewJSONTracesMarshaler().MarshalTraces(td)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        []byte
+		unmarshal   func(otlpgrpc.TracesResponse, []byte) error
+	}{
+		{
+			name:        "Proto",
+			contentType: "application/x-protobuf",
+			body:        protoBytes,
+			unmarshal:   otlpgrpc.TracesResponse.UnmarshalProto,
+		},
+		{
+			name:        "JSON",
+			contentType: "application/json",
+			body:        jsonBytes,
+			unmarshal:   otlpgrpc.TracesResponse.UnmarshalJSON,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", fmt.Sprintf("http://%s/v1/traces", addr), bytes.NewReader(test.body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", test.contentType)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+
+			respBytes, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, test.contentType, resp.Header.Get("Content-Type"))
+
+			tr := otlpgrpc.NewTracesResponse()
+			require.NoError(t, test.unmarshal(tr, respBytes))
+			assert.EqualValues(t, 1, tr.PartialSuccess().RejectedSpans())
+			assert.Equal(t, "Partial (1 rejected), error: my error", tr.PartialSuccess().ErrorMessage())
+		})
+	}
+}
+
+func TestHTTPURLPaths(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.SetIDName(otlpReceiverName)
+	cfg.GRPC = nil
+	cfg.HTTP.Endpoint = addr
+	cfg.HTTP.URLPrefix = "/otel/ingest/"
+	cfg.HTTP.MetricsURLPath = "/metrics"
+	cfg.HTTP.LegacyURLPrefix = "/"
+	tSink := new(consumertest.TracesSink)
+	mSink := new(consumertest.MetricsSink)
+	ocr := newReceiver(t, factory, cfg, tSink, mSink)
+
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()), "Failed to start trace receiver")
+	t.Cleanup(func() { require.NoError(t, ocr.Shutdown(context.Background())) })
+
+	traceBytes, err := otlp.NewProtobufTracesMarshaler().MarshalTraces(testdata.GenerateTracesOneSpan())
+	require.NoError(t, err)
+	metricBytes, err := otlp.NewProtobufMetricsMarshaler().MarshalMetrics(testdata.GenerateMetricsOneMetric())
+	require.NoError(t, err)
+
+	tests := []struct {
+		urlPath            string
+		body               []byte
+		expectedStatusCode int
+	}{
+		{urlPath: "/otel/ingest/v1/traces", body: traceBytes, expectedStatusCode: http.StatusOK},
+		{urlPath: "/v1/traces", body: traceBytes, expectedStatusCode: http.StatusOK},
+		{urlPath: "/otel/ingest/metrics", body: metricBytes, expectedStatusCode: http.StatusOK},
+		{urlPath: "/metrics", body: metricBytes, expectedStatusCode: http.StatusOK},
+		{urlPath: "/otel/ingest/v1/metrics", body: metricBytes, expectedStatusCode: http.StatusNotFound},
+		{urlPath: "/v1/metrics", body: metricBytes, expectedStatusCode: http.StatusNotFound},
+		{urlPath: "/other/v1/traces", body: traceBytes, expectedStatusCode: http.StatusNotFound},
+	}
+	for _, test := range tests {
+		t.Run(test.urlPath, func(t *testing.T) {
+			req := createHTTPProtobufRequest(t, fmt.Sprintf("http://%s%s", addr, test.urlPath), "", test.body)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			assert.Equal(t, test.expectedStatusCode, resp.StatusCode)
+		})
+	}
+	assert.Len(t, tSink.AllTraces(), 2)
+	assert.Len(t, mSink.AllMetrics(), 2)
+}
+
+func TestHTTPRateLimit(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	addr := testutil.GetAvailableLocalAddress(t)
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.SetIDName(otlpReceiverName)
+	cfg.GRPC = nil
+	cfg.HTTP.Endpoint = addr
+	cfg.HTTP.IncludeMetadata = true
+	cfg.RateLimit = &RateLimitSettings{
+		Key:               "metadata",
+		KeyName:           "X-Tenant",
+		RequestsPerSecond: 1,
+	}
+	sink := new(consumertest.TracesSink)
+	ocr := newReceiver(t, factory, cfg, sink, nil)
+
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()), "Failed to start trace receiver")
+	t.Cleanup(func() { require.NoError(t, ocr.Shutdown(context.Background())) })
+
+	traceBytes, err := otlp.NewProtobufTracesMarshaler().MarshalTraces(testdata.GenerateTracesOneSpan())
+	require.NoError(t, err)
+
+	send := func(tenant string) *http.Response {
+		req := createHTTPProtobufRequest(t, fmt.Sprintf("http://%s/v1/traces", addr), "", traceBytes)
+		req.Header.Set("X-Tenant", tenant)
+		resp, errDo := http.DefaultClient.Do(req)
+		require.NoError(t, errDo)
+		require.NoError(t, resp.Body.Close())
+		return resp
+	}
+
+	assert.Equal(t, http.StatusOK, send("a").StatusCode)
+	resp := send("a")
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "1", resp.Header.Get("Retry-After"))
+	// The limits apply to each tenant separately.
+	assert.Equal(t, http.StatusOK, send("b").StatusCode)
+	assert.Len(t, sink.AllTraces(), 2)
+
+	// The requests are refused before their body is read.
+	req := createHTTPProtobufRequest(t, fmt.Sprintf("http://%s/v1/traces", addr), "", []byte("not protobuf"))
+	req.Header.Set("X-Tenant", "a")
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+	require.NoError(t, obsreporttest.CheckReceiverRefusedRequests(tt, cfg.ID(), "http", 2))
+}
+
+func TestGRPCRequestRateLimit(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	addr := testutil.GetAvailableLocalAddress(t)
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.SetIDName(otlpReceiverName)
+	cfg.HTTP = nil
+	cfg.GRPC.NetAddr.Endpoint = addr
+	cfg.RateLimit = &RateLimitSettings{RequestsPerSecond: 1}
+	sink := new(consumertest.TracesSink)
+	ocr := newReceiver(t, factory, cfg, sink, nil)
+
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()), "Failed to start trace receiver")
+	t.Cleanup(func() { require.NoError(t, ocr.Shutdown(context.Background())) })
+
+	cc, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	require.NoError(t, err)
+	defer cc.Close()
+
+	require.NoError(t, exportTraces(cc, testdata.GenerateTracesOneSpan()))
+	err = exportTraces(cc, testdata.GenerateTracesOneSpan())
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, 1, sink.SpanCount())
+
+	require.NoError(t, obsreporttest.CheckReceiverRefusedRequests(tt, cfg.ID(), "grpc", 1))
+}
+
+func TestGRPCRateLimit(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.SetIDName(otlpReceiverName)
+	cfg.HTTP = nil
+	cfg.GRPC.NetAddr.Endpoint = addr
+	cfg.RateLimit = &RateLimitSettings{ItemsPerSecond: 2}
+	sink := new(consumertest.TracesSink)
+	ocr := newReceiver(t, factory, cfg, sink, nil)
+
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()), "Failed to start trace receiver")
+	t.Cleanup(func() { require.NoError(t, ocr.Shutdown(context.Background())) })
+
+	cc, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	require.NoError(t, err)
+	defer cc.Close()
+
+	require.NoError(t, exportTraces(cc, testdata.GenerateTracesTwoSpansSameResource()))
+	err = exportTraces(cc, testdata.GenerateTracesOneSpan())
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, 2, sink.SpanCount())
+}
+
 func TestOTLPReceiverInvalidContentEncoding(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -771,13 +1080,18 @@ func TestHTTPInvalidTLSCredentials(t *testing.T) {
 	cfg := &Config{
 		ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
 		Protocols: Protocols{
-			HTTP: &confighttp.HTTPServerSettings{
-				Endpoint: testutil.GetAvailableLocalAddress(t),
-				TLSSetting: &configtls.TLSServerSetting{
-					TLSSetting: configtls.TLSSetting{
-						CertFile: "willfail",
+			HTTP: &HTTPConfig{
+				HTTPServerSettings: confighttp.HTTPServerSettings{
+					Endpoint: testutil.GetAvailableLocalAddress(t),
+					TLSSetting: &configtls.TLSServerSetting{
+						TLSSetting: configtls.TLSSetting{
+							CertFile: "willfail",
+						},
 					},
 				},
+				TracesURLPath:  defaultTracesURLPath,
+				MetricsURLPath: defaultMetricsURLPath,
+				LogsURLPath:    defaultLogsURLPath,
 			},
 		},
 	}
@@ -800,9 +1114,14 @@ func testHTTPMaxRequestBodySizeJSON(t *testing.T, payload []byte, size int, expe
 	cfg := &Config{
 		ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
 		Protocols: Protocols{
-			HTTP: &confighttp.HTTPServerSettings{
-				Endpoint:           endpoint,
-				MaxRequestBodySize: int64(size),
+			HTTP: &HTTPConfig{
+				HTTPServerSettings: confighttp.HTTPServerSettings{
+					Endpoint:           endpoint,
+					MaxRequestBodySize: int64(size),
+				},
+				TracesURLPath:  defaultTracesURLPath,
+				MetricsURLPath: defaultMetricsURLPath,
+				LogsURLPath:    defaultLogsURLPath,
 			},
 		},
 	}
@@ -22,6 +22,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"go.opentelemetry.io/collector/internal/errorutil"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/logs"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/metrics"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/trace"
@@ -128,6 +129,13 @@ func writeError(w http.ResponseWriter, encoder encoder, err error, statusCode in
 	s, ok := status.FromError(err)
 	if !ok {
 		s = errorMsgToStatus(err.Error(), statusCode)
+	} else if throttleStatusCode, isThrottle := errorutil.ThrottleStatusCode(s); isThrottle {
+		// See spec https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/protocol/otlp.md#throttling-1
+		statusCode = throttleStatusCode
+		errorutil.SetRetryAfter(w, s)
+	} else if s.Code() == codes.InvalidArgument {
+		// The data was refused permanently, the client must not retry it.
+		statusCode = http.StatusBadRequest
 	}
 	writeStatusResponse(w, encoder, statusCode, s.Proto())
 }
@@ -0,0 +1,284 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/rs/cors"
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/internal/errorutil"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/logs"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/metrics"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/trace"
+)
+
+// The signals of the WebSocket messages, set in the first byte of the binary
+// messages and in the "signal" field of the JSON messages.
+const (
+	wsSignalTraces  byte = 1
+	wsSignalMetrics byte = 2
+	wsSignalLogs    byte = 3
+)
+
+// The results of the binary acknowledgements, set after their header.
+const (
+	wsResultOK    byte = 0
+	wsResultError byte = 1
+)
+
+// wsHeaderSize is the size of the signal and the big-endian message ID prefixing
+// the binary messages and acknowledgements.
+const wsHeaderSize = 9
+
+var (
+	wsSignalNames = map[string]byte{
+		"traces":  wsSignalTraces,
+		"metrics": wsSignalMetrics,
+		"logs":    wsSignalLogs,
+	}
+
+	errWebSocketOrigin = errors.New("origin not allowed")
+	errShortMessage    = errors.New("binary message shorter than its header")
+)
+
+// wsFrame is a WebSocket message with its payload type, binary or text.
+type wsFrame struct {
+	payloadType byte
+	data        []byte
+}
+
+var wsCodec = websocket.Codec{
+	Marshal: func(v interface{}) ([]byte, byte, error) {
+		f := v.(wsFrame)
+		return f.data, f.payloadType, nil
+	},
+	Unmarshal: func(data []byte, payloadType byte, v interface{}) error {
+		f := v.(*wsFrame)
+		f.data = data
+		f.payloadType = payloadType
+		return nil
+	},
+}
+
+// wsJSONMessage is the envelope of the JSON messages and acknowledgements. The
+// messages carry a request, and the acknowledgements either a response or an error.
+type wsJSONMessage struct {
+	ID       uint64          `json:"id"`
+	Signal   string          `json:"signal"`
+	Request  json.RawMessage `json:"request,omitempty"`
+	Response json.RawMessage `json:"response,omitempty"`
+	Error    json.RawMessage `json:"error,omitempty"`
+}
+
+// newWebSocketHandler returns the handler upgrading the requests to WebSocket
+// connections. The connections are accepted without origin, from the origin of
+// the receiver itself, or from the origins allowed by the CORS settings.
+func (r *otlpReceiver) newWebSocketHandler() http.Handler {
+	originAllowed := func(*http.Request) bool { return false }
+	if r.cfg.HTTP.CORS != nil && len(r.cfg.HTTP.CORS.AllowedOrigins) > 0 {
+		originAllowed = cors.New(cors.Options{AllowedOrigins: r.cfg.HTTP.CORS.AllowedOrigins}).OriginAllowed
+	}
+	server := websocket.Server{
+		Handshake: func(wsCfg *websocket.Config, req *http.Request) error {
+			origin, err := websocket.Origin(wsCfg, req)
+			if err != nil {
+				return err
+			}
+			if origin != nil && !strings.EqualFold(origin.Host, req.Host) && !originAllowed(req) {
+				return errWebSocketOrigin
+			}
+			return nil
+		},
+		Handler: r.serveWebSocket,
+	}
+	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		if _, ok := resp.(http.Hijacker); !ok {
+			writeResponse(resp, "text/plain", http.StatusInternalServerError, []byte("WebSocket not supported by the connection"))
+			return
+		}
+		server.ServeHTTP(resp, req)
+	})
+}
+
+// serveWebSocket exports the messages received on a WebSocket connection and
+// acknowledges each of them, until the connection is closed or a message cannot
+// be acknowledged because its header is invalid.
+func (r *otlpReceiver) serveWebSocket(ws *websocket.Conn) {
+	if !r.trackWebSocket(ws) {
+		return
+	}
+	defer r.untrackWebSocket(ws)
+
+	if r.cfg.HTTP.MaxRequestBodySize > 0 {
+		ws.MaxPayloadBytes = int(r.cfg.HTTP.MaxRequestBodySize)
+	}
+	ctx := ws.Request().Context()
+	for {
+		var frame wsFrame
+		if err := wsCodec.Receive(ws, &frame); err != nil {
+			return
+		}
+		ack, err := r.handleWebSocketFrame(ctx, frame)
+		if err != nil {
+			r.settings.Logger.Debug("Closing the WebSocket connection after an invalid message", zap.Error(err))
+			return
+		}
+		if err = wsCodec.Send(ws, ack); err != nil {
+			return
+		}
+	}
+}
+
+// trackWebSocket records an open connection to close it on shutdown, returning
+// false if the receiver is already shut down.
+func (r *otlpReceiver) trackWebSocket(ws *websocket.Conn) bool {
+	r.wsMu.Lock()
+	defer r.wsMu.Unlock()
+	if r.wsClosed {
+		return false
+	}
+	r.wsConns[ws] = struct{}{}
+	r.shutdownWG.Add(1)
+	return true
+}
+
+func (r *otlpReceiver) untrackWebSocket(ws *websocket.Conn) {
+	r.wsMu.Lock()
+	delete(r.wsConns, ws)
+	r.wsMu.Unlock()
+	r.shutdownWG.Done()
+}
+
+// closeWebSockets closes the open connections, which are not closed by the
+// shutdown of the HTTP server since they are hijacked.
+func (r *otlpReceiver) closeWebSockets() {
+	r.wsMu.Lock()
+	defer r.wsMu.Unlock()
+	r.wsClosed = true
+	for ws := range r.wsConns {
+		_ = ws.Close()
+	}
+}
+
+// handleWebSocketFrame exports a message and returns its acknowledgement.
+func (r *otlpReceiver) handleWebSocketFrame(ctx context.Context, frame wsFrame) (wsFrame, error) {
+	if frame.payloadType == websocket.BinaryFrame {
+		if len(frame.data) < wsHeaderSize {
+			return wsFrame{}, errShortMessage
+		}
+		msg, isErr, err := r.exportWebSocketMessage(ctx, frame.data[0], frame.data[wsHeaderSize:], pbEncoder)
+		if err != nil {
+			return wsFrame{}, err
+		}
+		ack := make([]byte, 0, wsHeaderSize+1+len(msg))
+		ack = append(ack, frame.data[:wsHeaderSize]...)
+		if isErr {
+			ack = append(ack, wsResultError)
+		} else {
+			ack = append(ack, wsResultOK)
+		}
+		return wsFrame{payloadType: websocket.BinaryFrame, data: append(ack, msg...)}, nil
+	}
+
+	var req wsJSONMessage
+	if err := json.Unmarshal(frame.data, &req); err != nil {
+		return wsFrame{}, err
+	}
+	msg, isErr, err := r.exportWebSocketMessage(ctx, wsSignalNames[req.Signal], req.Request, jsEncoder)
+	if err != nil {
+		return wsFrame{}, err
+	}
+	ack := wsJSONMessage{ID: req.ID, Signal: req.Signal}
+	if isErr {
+		ack.Error = msg
+	} else {
+		ack.Response = msg
+	}
+	data, err := json.Marshal(ack)
+	if err != nil {
+		return wsFrame{}, err
+	}
+	return wsFrame{payloadType: websocket.TextFrame, data: data}, nil
+}
+
+// exportWebSocketMessage exports the request of a signal and returns the encoded
+// response, or the encoded status and true if the request failed.
+func (r *otlpReceiver) exportWebSocketMessage(ctx context.Context, signal byte, payload []byte, encoder encoder) ([]byte, bool, error) {
+	var msg []byte
+	err := r.limiter.AcquireRequest(ctx)
+	switch {
+	case err != nil:
+		r.obsrepWebSocket.RequestRefused(ctx)
+		err = errorutil.GetStatusFromError(err)
+	case signal == wsSignalTraces && r.traceReceiver != nil:
+		msg, err = exportWebSocketTraces(ctx, r.traceReceiver, payload, encoder)
+	case signal == wsSignalMetrics && r.metricsReceiver != nil:
+		msg, err = exportWebSocketMetrics(ctx, r.metricsReceiver, payload, encoder)
+	case signal == wsSignalLogs && r.logReceiver != nil:
+		msg, err = exportWebSocketLogs(ctx, r.logReceiver, payload, encoder)
+	default:
+		err = status.Error(codes.Unimplemented, "signal not supported by the receiver")
+	}
+	if err == nil {
+		return msg, false, nil
+	}
+	msg, err = encoder.marshalStatus(status.Convert(err).Proto())
+	return msg, true, err
+}
+
+func exportWebSocketTraces(ctx context.Context, tracesReceiver *trace.Receiver, payload []byte, encoder encoder) ([]byte, error) {
+	otlpReq, err := encoder.unmarshalTracesRequest(payload)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	otlpResp, err := tracesReceiver.Export(ctx, otlpReq)
+	if err != nil {
+		return nil, err
+	}
+	return encoder.marshalTracesResponse(otlpResp)
+}
+
+func exportWebSocketMetrics(ctx context.Context, metricsReceiver *metrics.Receiver, payload []byte, encoder encoder) ([]byte, error) {
+	otlpReq, err := encoder.unmarshalMetricsRequest(payload)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	otlpResp, err := metricsReceiver.Export(ctx, otlpReq)
+	if err != nil {
+		return nil, err
+	}
+	return encoder.marshalMetricsResponse(otlpResp)
+}
+
+func exportWebSocketLogs(ctx context.Context, logsReceiver *logs.Receiver, payload []byte, encoder encoder) ([]byte, error) {
+	otlpReq, err := encoder.unmarshalLogsRequest(payload)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	otlpResp, err := logsReceiver.Export(ctx, otlpReq)
+	if err != nil {
+		return nil, err
+	}
+	return encoder.marshalLogsResponse(otlpResp)
+}
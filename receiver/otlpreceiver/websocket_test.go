@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpreceiver
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/internal/testutil"
+	"go.opentelemetry.io/collector/model/otlpgrpc"
+)
+
+func newWebSocketReceiver(t *testing.T, modify func(cfg *Config)) (string, *consumertest.TracesSink, *consumertest.MetricsSink, component.Component) {
+	addr := testutil.GetAvailableLocalAddress(t)
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.SetIDName(otlpReceiverName)
+	cfg.GRPC = nil
+	cfg.HTTP.Endpoint = addr
+	cfg.HTTP.WebSocket = &WebSocketSettings{URLPath: defaultWebSocketURLPath}
+	if modify != nil {
+		modify(cfg)
+	}
+	tSink := new(consumertest.TracesSink)
+	mSink := new(consumertest.MetricsSink)
+	ocr := newReceiver(t, factory, cfg, tSink, mSink)
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()))
+	return addr, tSink, mSink, ocr
+}
+
+func newBinaryWebSocketMessage(signal byte, id uint64, payload []byte) []byte {
+	msg := make([]byte, wsHeaderSize, wsHeaderSize+len(payload))
+	msg[0] = signal
+	binary.BigEndian.PutUint64(msg[1:], id)
+	return append(msg, payload...)
+}
+
+func TestWebSocketBinary(t *testing.T) {
+	addr, tSink, _, ocr := newWebSocketReceiver(t, nil)
+	t.Cleanup(func() { require.NoError(t, ocr.Shutdown(context.Background())) })
+
+	ws, err := websocket.Dial(fmt.Sprintf("ws://%s/v1/websocket", addr), "", "http://"+addr)
+	require.NoError(t, err)
+	defer ws.Close()
+
+	req := otlpgrpc.NewTracesRequest()
+	req.SetTraces(testdata.GenerateTracesOneSpan())
+	payload, err := req.MarshalProto()
+	require.NoError(t, err)
+
+	for id := uint64(1); id <= 2; id++ {
+		require.NoError(t, websocket.Message.Send(ws, newBinaryWebSocketMessage(wsSignalTraces, id, payload)))
+		var ack []byte
+		require.NoError(t, websocket.Message.Receive(ws, &ack))
+		require.Greater(t, len(ack), wsHeaderSize)
+		assert.Equal(t, wsSignalTraces, ack[0])
+		assert.Equal(t, id, binary.BigEndian.Uint64(ack[1:wsHeaderSize]))
+		assert.Equal(t, wsResultOK, ack[wsHeaderSize])
+		assert.NoError(t, otlpgrpc.NewTracesResponse().UnmarshalProto(ack[wsHeaderSize+1:]))
+	}
+	assert.Len(t, tSink.AllTraces(), 2)
+
+	// The logs are not consumed by the receiver.
+	require.NoError(t, websocket.Message.Send(ws, newBinaryWebSocketMessage(wsSignalLogs, 3, nil)))
+	var ack []byte
+	require.NoError(t, websocket.Message.Receive(ws, &ack))
+	require.Greater(t, len(ack), wsHeaderSize)
+	assert.Equal(t, uint64(3), binary.BigEndian.Uint64(ack[1:wsHeaderSize]))
+	assert.Equal(t, wsResultError, ack[wsHeaderSize])
+	st := &spb.Status{}
+	require.NoError(t, proto.Unmarshal(ack[wsHeaderSize+1:], st))
+	assert.Equal(t, int32(codes.Unimplemented), st.Code)
+
+	// The connection is closed after a message without header.
+	require.NoError(t, websocket.Message.Send(ws, []byte{wsSignalTraces}))
+	assert.Error(t, websocket.Message.Receive(ws, &ack))
+}
+
+func TestWebSocketJSON(t *testing.T) {
+	addr, _, mSink, ocr := newWebSocketReceiver(t, func(cfg *Config) {
+		cfg.HTTP.URLPrefix = "/otel"
+	})
+	t.Cleanup(func() { require.NoError(t, ocr.Shutdown(context.Background())) })
+
+	ws, err := websocket.Dial(fmt.Sprintf("ws://%s/otel/v1/websocket", addr), "", "http://"+addr)
+	require.NoError(t, err)
+	defer ws.Close()
+
+	req := otlpgrpc.NewMetricsRequest()
+	req.SetMetrics(testdata.GenerateMetricsOneMetric())
+	payload, err := req.MarshalJSON()
+	require.NoError(t, err)
+
+	require.NoError(t, websocket.JSON.Send(ws, wsJSONMessage{ID: 7, Signal: "metrics", Request: payload}))
+	var ack wsJSONMessage
+	require.NoError(t, websocket.JSON.Receive(ws, &ack))
+	assert.Equal(t, uint64(7), ack.ID)
+	assert.Equal(t, "metrics", ack.Signal)
+	assert.Empty(t, ack.Error)
+	assert.NoError(t, otlpgrpc.NewMetricsResponse().UnmarshalJSON(ack.Response))
+	assert.Len(t, mSink.AllMetrics(), 1)
+
+	require.NoError(t, websocket.JSON.Send(ws, wsJSONMessage{ID: 8, Signal: "metrics", Request: json.RawMessage(`{"resourceMetrics":1}`)}))
+	ack = wsJSONMessage{}
+	require.NoError(t, websocket.JSON.Receive(ws, &ack))
+	assert.Equal(t, uint64(8), ack.ID)
+	assert.Empty(t, ack.Response)
+	var st map[string]interface{}
+	require.NoError(t, json.Unmarshal(ack.Error, &st))
+	assert.EqualValues(t, codes.InvalidArgument, st["code"])
+	assert.Len(t, mSink.AllMetrics(), 1)
+}
+
+func TestWebSocketOrigin(t *testing.T) {
+	addr, _, _, ocr := newWebSocketReceiver(t, func(cfg *Config) {
+		cfg.HTTP.CORS = &confighttp.CORSSettings{AllowedOrigins: []string{"https://*.example.com"}}
+	})
+	t.Cleanup(func() { require.NoError(t, ocr.Shutdown(context.Background())) })
+
+	url := fmt.Sprintf("ws://%s/v1/websocket", addr)
+	ws, err := websocket.Dial(url, "", "https://app.example.com")
+	require.NoError(t, err)
+	require.NoError(t, ws.Close())
+
+	_, err = websocket.Dial(url, "", "https://evil.test")
+	assert.Error(t, err)
+}
+
+func TestWebSocketShutdown(t *testing.T) {
+	addr, _, _, ocr := newWebSocketReceiver(t, nil)
+
+	ws, err := websocket.Dial(fmt.Sprintf("ws://%s/v1/websocket", addr), "", "http://"+addr)
+	require.NoError(t, err)
+	defer ws.Close()
+
+	// Wait for the connection to be served before shutting down.
+	req := otlpgrpc.NewTracesRequest()
+	req.SetTraces(testdata.GenerateTracesOneSpan())
+	payload, err := req.MarshalProto()
+	require.NoError(t, err)
+	require.NoError(t, websocket.Message.Send(ws, newBinaryWebSocketMessage(wsSignalTraces, 1, payload)))
+	var msg []byte
+	require.NoError(t, websocket.Message.Receive(ws, &msg))
+
+	require.NoError(t, ocr.Shutdown(context.Background()))
+	assert.Error(t, websocket.Message.Receive(ws, &msg))
+}
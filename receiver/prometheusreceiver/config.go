@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusreceiver // import "go.opentelemetry.io/collector/receiver/prometheusreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+)
+
+// Config defines configuration for the Prometheus receiver.
+type Config struct {
+	scraperhelper.ScraperControllerSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Jobs are the scrape jobs, each scraping a static list of targets.
+	Jobs []JobConfig `mapstructure:"jobs"`
+}
+
+// JobConfig defines a scrape job.
+type JobConfig struct {
+	// JobName is the name of the job, set as the service name of its targets.
+	JobName string `mapstructure:"job_name"`
+
+	// HTTPClientSettings configures the HTTP client scraping the targets. The endpoint
+	// is not supported, the scraped URLs are built from the targets.
+	confighttp.HTTPClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Scheme is the scheme of the scraped URLs, "http" or "https". Defaults to "http".
+	Scheme string `mapstructure:"scheme"`
+
+	// MetricsPath is the path of the scraped URLs. Defaults to "/metrics".
+	MetricsPath string `mapstructure:"metrics_path"`
+
+	// Targets are the host:port addresses of the targets.
+	Targets []string `mapstructure:"targets"`
+
+	// Labels are added as attributes to the data points of the targets.
+	Labels map[string]string `mapstructure:"labels"`
+}
+
+var _ config.Receiver = (*Config)(nil)
+
+// Validate checks the receiver configuration is valid
+func (cfg *Config) Validate() error {
+	if len(cfg.Jobs) == 0 {
+		return errors.New("at least one job must be specified")
+	}
+	names := map[string]bool{}
+	for _, job := range cfg.Jobs {
+		if job.JobName == "" {
+			return errors.New("job_name must be specified")
+		}
+		if names[job.JobName] {
+			return fmt.Errorf("duplicate job %q", job.JobName)
+		}
+		names[job.JobName] = true
+		if err := job.validate(cfg.CollectionInterval); err != nil {
+			return fmt.Errorf("job %q: %w", job.JobName, err)
+		}
+	}
+	return nil
+}
+
+func (job *JobConfig) validate(collectionInterval time.Duration) error {
+	if job.Endpoint != "" {
+		return errors.New("endpoint is not supported, use targets")
+	}
+	switch job.Scheme {
+	case "", "http", "https":
+	default:
+		return fmt.Errorf("unsupported scheme %q, must be http or https", job.Scheme)
+	}
+	if job.Timeout > collectionInterval {
+		return errors.New("timeout must not be greater than collection_interval")
+	}
+	if len(job.Targets) == 0 {
+		return errors.New("at least one target must be specified")
+	}
+	for _, target := range job.Targets {
+		if _, _, err := net.SplitHostPort(target); err != nil {
+			return fmt.Errorf("invalid target %q: %w", target, err)
+		}
+	}
+	for name := range job.Labels {
+		if name == labelJob || name == labelInstance {
+			return fmt.Errorf("label %q is reserved", name)
+		}
+	}
+	return nil
+}
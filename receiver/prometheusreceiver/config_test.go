@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+	"go.opentelemetry.io/collector/service/servicetest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := servicetest.LoadConfigAndValidate(filepath.Join("testdata", "config.yaml"), factories)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, len(cfg.Receivers), 2)
+
+	r0 := cfg.Receivers[config.NewComponentID(typeStr)]
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.Jobs = []JobConfig{{JobName: "node", Targets: []string{"localhost:9100"}}}
+	assert.Equal(t, defaultCfg, r0)
+
+	r1 := cfg.Receivers[config.NewComponentIDWithName(typeStr, "customname")]
+	assert.Equal(t,
+		&Config{
+			ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+				ReceiverSettings:   config.NewReceiverSettings(config.NewComponentIDWithName(typeStr, "customname")),
+				CollectionInterval: 30 * time.Second,
+			},
+			Jobs: []JobConfig{
+				{
+					JobName: "api",
+					HTTPClientSettings: confighttp.HTTPClientSettings{
+						Timeout: 5 * time.Second,
+						TLSSetting: configtls.TLSClientSetting{
+							InsecureSkipVerify: true,
+						},
+						Headers: map[string]string{"Authorization": "Bearer token"},
+					},
+					Scheme:      "https",
+					MetricsPath: "/custom/metrics",
+					Targets:     []string{"api-1:8443", "api-2:8443"},
+					Labels:      map[string]string{"env": "prod"},
+				},
+			},
+		}, r1)
+}
+
+func TestValidateConfig(t *testing.T) {
+	testCases := []struct {
+		name   string
+		modify func(cfg *Config)
+	}{
+		{
+			name:   "no jobs",
+			modify: func(cfg *Config) { cfg.Jobs = nil },
+		},
+		{
+			name:   "no job_name",
+			modify: func(cfg *Config) { cfg.Jobs[0].JobName = "" },
+		},
+		{
+			name:   "duplicate job_name",
+			modify: func(cfg *Config) { cfg.Jobs = append(cfg.Jobs, cfg.Jobs[0]) },
+		},
+		{
+			name:   "endpoint",
+			modify: func(cfg *Config) { cfg.Jobs[0].Endpoint = "http://localhost:9100/metrics" },
+		},
+		{
+			name:   "unsupported scheme",
+			modify: func(cfg *Config) { cfg.Jobs[0].Scheme = "ftp" },
+		},
+		{
+			name:   "timeout greater than collection_interval",
+			modify: func(cfg *Config) { cfg.Jobs[0].Timeout = 2 * time.Minute },
+		},
+		{
+			name:   "no targets",
+			modify: func(cfg *Config) { cfg.Jobs[0].Targets = nil },
+		},
+		{
+			name:   "invalid target",
+			modify: func(cfg *Config) { cfg.Jobs[0].Targets = []string{"localhost"} },
+		},
+		{
+			name:   "reserved label",
+			modify: func(cfg *Config) { cfg.Jobs[0].Labels = map[string]string{"instance": "a"} },
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Jobs = []JobConfig{{JobName: "node", Targets: []string{"localhost:9100"}}}
+			require.NoError(t, cfg.Validate())
+			tt.modify(cfg)
+			assert.Error(t, cfg.Validate())
+		})
+	}
+}
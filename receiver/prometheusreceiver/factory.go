@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusreceiver // import "go.opentelemetry.io/collector/receiver/prometheusreceiver"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "prometheus"
+)
+
+// NewFactory creates a factory for the Prometheus receiver.
+func NewFactory() component.ReceiverFactory {
+	return component.NewReceiverFactory(
+		typeStr,
+		createDefaultConfig,
+		component.WithMetricsReceiver(createMetricsReceiver))
+}
+
+func createDefaultConfig() config.Receiver {
+	return &Config{
+		ScraperControllerSettings: scraperhelper.NewDefaultScraperControllerSettings(typeStr),
+	}
+}
+
+func createMetricsReceiver(
+	_ context.Context,
+	set component.ReceiverCreateSettings,
+	cfg config.Receiver,
+	nextConsumer consumer.Metrics,
+) (component.MetricsReceiver, error) {
+	rCfg := cfg.(*Config)
+	options := make([]scraperhelper.ScraperControllerOption, 0, len(rCfg.Jobs))
+	for _, job := range rCfg.Jobs {
+		js := newJobScraper(job, set.TelemetrySettings)
+		scraper, err := scraperhelper.NewScraper(job.JobName, js.scrape, scraperhelper.WithStart(js.start))
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, scraperhelper.AddScraper(scraper))
+	}
+	return scraperhelper.NewScraperControllerReceiver(&rCfg.ScraperControllerSettings, set, nextConsumer, options...)
+}
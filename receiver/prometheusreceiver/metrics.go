@@ -0,0 +1,350 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusreceiver // import "go.opentelemetry.io/collector/receiver/prometheusreceiver"
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+const (
+	labelJob      = "job"
+	labelInstance = "instance"
+	labelLe       = "le"
+	labelQuantile = "quantile"
+
+	suffixBucket  = "_bucket"
+	suffixSum     = "_sum"
+	suffixCount   = "_count"
+	suffixCreated = "_created"
+
+	// exportedPrefix is added to the scraped labels conflicting with the target labels.
+	exportedPrefix = "exported_"
+)
+
+// appendMetrics converts the scraped metric families into metrics and returns the
+// number of samples. The counters become cumulative sums, the histograms and summaries
+// are combined from their series, and the other families become gauges.
+func (t *target) appendMetrics(ms pdata.MetricSlice, families []*metricFamily, now pdata.Timestamp) int {
+	samples := 0
+	for _, f := range families {
+		samples += len(f.samples)
+		switch f.typ {
+		case typeCounter:
+			t.appendSums(ms, f, now)
+		case typeHistogram:
+			t.appendHistogram(ms, f, now)
+		case typeSummary:
+			t.appendSummary(ms, f, now)
+		default:
+			t.appendGauges(ms, f, now)
+		}
+	}
+	return samples
+}
+
+func (t *target) appendGauges(ms pdata.MetricSlice, f *metricFamily, now pdata.Timestamp) {
+	metrics := map[string]pdata.Metric{}
+	for _, s := range f.samples {
+		m, ok := metrics[s.name]
+		if !ok {
+			m = newMetric(ms, s.name, f, pdata.MetricDataTypeGauge)
+			metrics[s.name] = m
+		}
+		dp := m.Gauge().DataPoints().AppendEmpty()
+		dp.SetTimestamp(sampleTimestamp(s, now))
+		dp.SetDoubleVal(s.value)
+		insertLabels(dp.Attributes(), t.attributes(s.labels))
+	}
+}
+
+func (t *target) appendSums(ms pdata.MetricSlice, f *metricFamily, now pdata.Timestamp) {
+	created := map[string]pdata.Timestamp{}
+	for _, s := range f.samples {
+		if s.name == f.name+suffixCreated {
+			created[labelsKey(s.labels)] = timestampFromSeconds(s.value)
+		}
+	}
+
+	metrics := map[string]pdata.Metric{}
+	for _, s := range f.samples {
+		if s.name == f.name+suffixCreated {
+			continue
+		}
+		m, ok := metrics[s.name]
+		if !ok {
+			m = newMetric(ms, s.name, f, pdata.MetricDataTypeSum)
+			m.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+			m.Sum().SetIsMonotonic(true)
+			metrics[s.name] = m
+		}
+		key := labelsKey(s.labels)
+		timestamp := sampleTimestamp(s, now)
+		start := t.starts.startTime(s.name+"\x00"+key, s.value, timestamp)
+		if c, ok := created[key]; ok {
+			start = c
+		}
+
+		dp := m.Sum().DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(start)
+		dp.SetTimestamp(timestamp)
+		dp.SetDoubleVal(s.value)
+		insertLabels(dp.Attributes(), t.attributes(s.labels))
+	}
+}
+
+type histogramPoint struct {
+	labels    []label
+	timestamp pdata.Timestamp
+	created   pdata.Timestamp
+	buckets   map[float64]float64
+	sum       float64
+	count     float64
+	hasCount  bool
+}
+
+func (t *target) appendHistogram(ms pdata.MetricSlice, f *metricFamily, now pdata.Timestamp) {
+	points := map[string]*histogramPoint{}
+	var keys []string
+	for _, s := range f.samples {
+		labels := withoutLabel(s.labels, labelLe)
+		key := labelsKey(labels)
+		p, ok := points[key]
+		if !ok {
+			p = &histogramPoint{labels: labels, timestamp: sampleTimestamp(s, now), buckets: map[float64]float64{}}
+			points[key] = p
+			keys = append(keys, key)
+		}
+		switch s.name[len(f.name):] {
+		case suffixBucket:
+			le, err := strconv.ParseFloat(labelValue(s.labels, labelLe), 64)
+			if err == nil {
+				p.buckets[le] = s.value
+			}
+		case suffixSum:
+			p.sum = s.value
+		case suffixCount:
+			p.count = s.value
+			p.hasCount = true
+		case suffixCreated:
+			p.created = timestampFromSeconds(s.value)
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+
+	m := newMetric(ms, f.name, f, pdata.MetricDataTypeHistogram)
+	m.Histogram().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+	for _, key := range keys {
+		p := points[key]
+		dp := m.Histogram().DataPoints().AppendEmpty()
+		p.toDataPoint(dp)
+		start := t.starts.startTime(f.name+"\x00"+key, float64(dp.Count()), p.timestamp)
+		if p.created != 0 {
+			start = p.created
+		}
+		dp.SetStartTimestamp(start)
+		insertLabels(dp.Attributes(), t.attributes(p.labels))
+	}
+}
+
+// toDataPoint converts the cumulative counts of the buckets into the counts of each bucket.
+func (p *histogramPoint) toDataPoint(dp pdata.HistogramDataPoint) {
+	dp.SetTimestamp(p.timestamp)
+
+	bounds := make([]float64, 0, len(p.buckets))
+	for le := range p.buckets {
+		if !math.IsInf(le, 1) {
+			bounds = append(bounds, le)
+		}
+	}
+	sort.Float64s(bounds)
+	total, hasInf := p.buckets[math.Inf(1)]
+	if !hasInf {
+		total = p.count
+	}
+	if !p.hasCount {
+		p.count = total
+	}
+
+	counts := make([]uint64, 0, len(bounds)+1)
+	previous := 0.0
+	for _, le := range bounds {
+		counts = append(counts, bucketCount(p.buckets[le]-previous))
+		previous = p.buckets[le]
+	}
+	counts = append(counts, bucketCount(total-previous))
+
+	dp.SetCount(uint64(p.count))
+	dp.SetSum(p.sum)
+	dp.SetExplicitBounds(bounds)
+	dp.SetBucketCounts(counts)
+}
+
+func bucketCount(v float64) uint64 {
+	if v < 0 || math.IsNaN(v) {
+		return 0
+	}
+	return uint64(v)
+}
+
+type summaryPoint struct {
+	labels    []label
+	timestamp pdata.Timestamp
+	created   pdata.Timestamp
+	quantiles map[float64]float64
+	sum       float64
+	count     float64
+}
+
+func (t *target) appendSummary(ms pdata.MetricSlice, f *metricFamily, now pdata.Timestamp) {
+	points := map[string]*summaryPoint{}
+	var keys []string
+	for _, s := range f.samples {
+		labels := withoutLabel(s.labels, labelQuantile)
+		key := labelsKey(labels)
+		p, ok := points[key]
+		if !ok {
+			p = &summaryPoint{labels: labels, timestamp: sampleTimestamp(s, now), quantiles: map[float64]float64{}}
+			points[key] = p
+			keys = append(keys, key)
+		}
+		switch s.name[len(f.name):] {
+		case "":
+			q, err := strconv.ParseFloat(labelValue(s.labels, labelQuantile), 64)
+			if err == nil {
+				p.quantiles[q] = s.value
+			}
+		case suffixSum:
+			p.sum = s.value
+		case suffixCount:
+			p.count = s.value
+		case suffixCreated:
+			p.created = timestampFromSeconds(s.value)
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+
+	m := newMetric(ms, f.name, f, pdata.MetricDataTypeSummary)
+	for _, key := range keys {
+		p := points[key]
+		dp := m.Summary().DataPoints().AppendEmpty()
+		start := t.starts.startTime(f.name+"\x00"+key, p.count, p.timestamp)
+		if p.created != 0 {
+			start = p.created
+		}
+		dp.SetStartTimestamp(start)
+		dp.SetTimestamp(p.timestamp)
+		dp.SetCount(uint64(p.count))
+		dp.SetSum(p.sum)
+		quantiles := make([]float64, 0, len(p.quantiles))
+		for q := range p.quantiles {
+			quantiles = append(quantiles, q)
+		}
+		sort.Float64s(quantiles)
+		for _, q := range quantiles {
+			qv := dp.QuantileValues().AppendEmpty()
+			qv.SetQuantile(q)
+			qv.SetValue(p.quantiles[q])
+		}
+		insertLabels(dp.Attributes(), t.attributes(p.labels))
+	}
+}
+
+// attributes returns the attributes of a data point from the labels of its samples
+// and the labels of the target. The scraped labels conflicting with the target labels
+// are prefixed with "exported_".
+func (t *target) attributes(labels []label) []label {
+	attrs := make([]label, 0, len(labels)+len(t.labels))
+	for _, l := range labels {
+		if l.name == labelJob || l.name == labelInstance || hasLabel(t.labels, l.name) {
+			l.name = exportedPrefix + l.name
+		}
+		attrs = append(attrs, l)
+	}
+	return append(attrs, t.labels...)
+}
+
+func newMetric(ms pdata.MetricSlice, name string, f *metricFamily, dataType pdata.MetricDataType) pdata.Metric {
+	m := ms.AppendEmpty()
+	m.SetName(name)
+	m.SetDescription(f.help)
+	m.SetUnit(f.unit)
+	m.SetDataType(dataType)
+	return m
+}
+
+func sampleTimestamp(s promSample, now pdata.Timestamp) pdata.Timestamp {
+	if !s.hasTimestamp {
+		return now
+	}
+	return pdata.Timestamp(s.timestamp * int64(1e6))
+}
+
+func timestampFromSeconds(seconds float64) pdata.Timestamp {
+	return pdata.Timestamp(math.Round(seconds * 1e9))
+}
+
+func insertLabels(attrs pdata.Map, labels []label) {
+	for _, l := range labels {
+		attrs.UpsertString(l.name, l.value)
+	}
+}
+
+func withoutLabel(labels []label, name string) []label {
+	result := make([]label, 0, len(labels))
+	for _, l := range labels {
+		if l.name != name {
+			result = append(result, l)
+		}
+	}
+	return result
+}
+
+func labelsKey(labels []label) string {
+	var b strings.Builder
+	for _, l := range labels {
+		b.WriteString(l.name)
+		b.WriteByte(0)
+		b.WriteString(l.value)
+		b.WriteByte(0)
+	}
+	return b.String()
+}
+
+func labelValue(labels []label, name string) string {
+	for _, l := range labels {
+		if l.name == name {
+			return l.value
+		}
+	}
+	return ""
+}
+
+func hasLabel(labels []label, name string) bool {
+	for _, l := range labels {
+		if l.name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,297 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusreceiver // import "go.opentelemetry.io/collector/receiver/prometheusreceiver"
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The types of the metric families. The text format uses "untyped" and the
+// OpenMetrics format "unknown" for the metrics without a type.
+const (
+	typeCounter        = "counter"
+	typeGauge          = "gauge"
+	typeHistogram      = "histogram"
+	typeSummary        = "summary"
+	typeGaugeHistogram = "gaugehistogram"
+	typeInfo           = "info"
+	typeStateSet       = "stateset"
+	typeUntyped        = "untyped"
+	typeUnknown        = "unknown"
+)
+
+// familySuffixes are the suffixes of the samples names of a family, by type.
+var familySuffixes = map[string][]string{
+	typeCounter:        {"_total", "_created"},
+	typeHistogram:      {"_bucket", "_sum", "_count", "_created"},
+	typeSummary:        {"_sum", "_count", "_created"},
+	typeGaugeHistogram: {"_bucket", "_gsum", "_gcount"},
+	typeInfo:           {"_info"},
+}
+
+const maxLineSize = 1024 * 1024
+
+type label struct {
+	name  string
+	value string
+}
+
+type promSample struct {
+	name string
+	// labels are sorted by name.
+	labels []label
+	value  float64
+	// timestamp is in milliseconds, only set if hasTimestamp is.
+	timestamp    int64
+	hasTimestamp bool
+}
+
+type metricFamily struct {
+	name    string
+	typ     string
+	help    string
+	unit    string
+	samples []promSample
+}
+
+// parseExposition parses the metric families exposed in the Prometheus text format,
+// or in the OpenMetrics format if openMetrics is set. The families are returned in
+// the order they first appear, and the samples without a family declared by a TYPE
+// line are returned in untyped families. The exemplars are ignored.
+func parseExposition(r io.Reader, openMetrics bool) ([]*metricFamily, error) {
+	var families []*metricFamily
+	byName := map[string]*metricFamily{}
+	family := func(name string) *metricFamily {
+		f, ok := byName[name]
+		if !ok {
+			f = &metricFamily{name: name, typ: typeUntyped}
+			byName[name] = f
+			families = append(families, f)
+		}
+		return f
+	}
+
+	var current *metricFamily
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "#") {
+			fields := strings.SplitN(strings.TrimSpace(line[1:]), " ", 3)
+			if fields[0] == "EOF" && openMetrics {
+				return families, nil
+			}
+			if len(fields) < 3 {
+				// Other comments are ignored.
+				continue
+			}
+			switch fields[0] {
+			case "HELP":
+				current = family(fields[1])
+				current.help = unescape(fields[2])
+			case "TYPE":
+				current = family(fields[1])
+				current.typ = strings.TrimSpace(fields[2])
+			case "UNIT":
+				current = family(fields[1])
+				current.unit = strings.TrimSpace(fields[2])
+			}
+			continue
+		}
+
+		s, err := parseSample(line, openMetrics)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		if current == nil || !belongsTo(current, s.name) {
+			current = nil
+			for name, f := range byName {
+				if belongsTo(f, s.name) && (current == nil || len(name) > len(current.name)) {
+					current = f
+				}
+			}
+			if current == nil {
+				current = family(s.name)
+			}
+		}
+		current.samples = append(current.samples, s)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if openMetrics {
+		return nil, errors.New("missing # EOF")
+	}
+	return families, nil
+}
+
+// belongsTo returns whether a sample of the given name belongs to the family.
+func belongsTo(f *metricFamily, name string) bool {
+	if name == f.name {
+		return true
+	}
+	if !strings.HasPrefix(name, f.name) {
+		return false
+	}
+	for _, suffix := range familySuffixes[f.typ] {
+		if name[len(f.name):] == suffix {
+			return true
+		}
+	}
+	return false
+}
+
+// parseSample parses a sample line, in the format
+// <name>[{<label>="<value>",...}] <value> [<timestamp>].
+func parseSample(line string, openMetrics bool) (promSample, error) {
+	i := strings.IndexAny(line, "{ \t")
+	if i <= 0 {
+		return promSample{}, fmt.Errorf("invalid sample %q", line)
+	}
+	s := promSample{name: line[:i]}
+	rest := line[i:]
+	if rest[0] == '{' {
+		labels, n, err := parseLabels(rest)
+		if err != nil {
+			return promSample{}, err
+		}
+		s.labels = labels
+		rest = rest[n:]
+	}
+	if openMetrics {
+		if j := strings.Index(rest, " # "); j >= 0 {
+			rest = rest[:j]
+		}
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) == 0 || len(fields) > 2 {
+		return promSample{}, fmt.Errorf("invalid sample %q", line)
+	}
+	var err error
+	if s.value, err = strconv.ParseFloat(fields[0], 64); err != nil {
+		return promSample{}, fmt.Errorf("invalid value %q", fields[0])
+	}
+	if len(fields) == 2 {
+		s.hasTimestamp = true
+		if openMetrics {
+			// The OpenMetrics timestamps are in seconds.
+			var seconds float64
+			if seconds, err = strconv.ParseFloat(fields[1], 64); err == nil {
+				s.timestamp = int64(math.Round(seconds * 1000))
+			}
+		} else {
+			s.timestamp, err = strconv.ParseInt(fields[1], 10, 64)
+		}
+		if err != nil {
+			return promSample{}, fmt.Errorf("invalid timestamp %q", fields[1])
+		}
+	}
+	return s, nil
+}
+
+// parseLabels parses the labels at the start of s, between braces, and returns them
+// sorted by name with the number of bytes read.
+func parseLabels(s string) ([]label, int, error) {
+	var labels []label
+	i := 1
+	for {
+		for i < len(s) && (s[i] == ' ' || s[i] == '\t') {
+			i++
+		}
+		if i >= len(s) {
+			return nil, 0, errors.New("unterminated label set")
+		}
+		if s[i] == '}' {
+			break
+		}
+
+		eq := strings.IndexByte(s[i:], '=')
+		if eq < 0 {
+			return nil, 0, errors.New("missing label value")
+		}
+		name := strings.TrimSpace(s[i : i+eq])
+		if name == "" {
+			return nil, 0, errors.New("empty label name")
+		}
+		i += eq + 1
+		for i < len(s) && s[i] == ' ' {
+			i++
+		}
+		if i >= len(s) || s[i] != '"' {
+			return nil, 0, fmt.Errorf("unquoted value of label %q", name)
+		}
+		i++
+
+		var value strings.Builder
+		for ; i < len(s) && s[i] != '"'; i++ {
+			if s[i] == '\\' && i+1 < len(s) {
+				i++
+				value.WriteByte(unescapeByte(s[i]))
+				continue
+			}
+			value.WriteByte(s[i])
+		}
+		if i >= len(s) {
+			return nil, 0, fmt.Errorf("unterminated value of label %q", name)
+		}
+		i++
+		labels = append(labels, label{name: name, value: value.String()})
+
+		for i < len(s) && s[i] == ' ' {
+			i++
+		}
+		if i < len(s) && s[i] == ',' {
+			i++
+		}
+	}
+	sort.SliceStable(labels, func(a, b int) bool { return labels[a].name < labels[b].name })
+	return labels, i + 1, nil
+}
+
+// unescape replaces the escape sequences \\, \" and \n of the HELP texts.
+func unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+			b.WriteByte(unescapeByte(s[i]))
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+func unescapeByte(c byte) byte {
+	if c == 'n' {
+		return '\n'
+	}
+	return c
+}
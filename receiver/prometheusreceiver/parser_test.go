@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusreceiver
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseText(t *testing.T) {
+	text := `# HELP http_requests_total The total number of HTTP requests.
+# TYPE http_requests_total counter
+http_requests_total{method="post",code="200"} 1027 1395066363000
+http_requests_total{method="post",code="400"}    3 1395066363000
+
+# A comment.
+msdos_file_access_time_seconds{path="C:\\DIR\\FILE.TXT",error="Cannot find file:\n\"FILE.TXT\""} 1.458255915e9
+
+# HELP http_request_duration_seconds A histogram of the request duration.
+# TYPE http_request_duration_seconds histogram
+http_request_duration_seconds_bucket{le="0.05"} 24054
+http_request_duration_seconds_bucket{le="+Inf"} 144320
+http_request_duration_seconds_sum 53423
+http_request_duration_seconds_count 144320
+metric_without_labels NaN
+`
+	families, err := parseExposition(strings.NewReader(text), false)
+	require.NoError(t, err)
+	require.Len(t, families, 4)
+
+	assert.Equal(t, &metricFamily{
+		name: "http_requests_total",
+		typ:  typeCounter,
+		help: "The total number of HTTP requests.",
+		samples: []promSample{
+			{
+				name:         "http_requests_total",
+				labels:       []label{{name: "code", value: "200"}, {name: "method", value: "post"}},
+				value:        1027,
+				timestamp:    1395066363000,
+				hasTimestamp: true,
+			},
+			{
+				name:         "http_requests_total",
+				labels:       []label{{name: "code", value: "400"}, {name: "method", value: "post"}},
+				value:        3,
+				timestamp:    1395066363000,
+				hasTimestamp: true,
+			},
+		},
+	}, families[0])
+
+	assert.Equal(t, &metricFamily{
+		name: "msdos_file_access_time_seconds",
+		typ:  typeUntyped,
+		samples: []promSample{{
+			name:   "msdos_file_access_time_seconds",
+			labels: []label{{name: "error", value: "Cannot find file:\n\"FILE.TXT\""}, {name: "path", value: `C:\DIR\FILE.TXT`}},
+			value:  1.458255915e9,
+		}},
+	}, families[1])
+
+	assert.Equal(t, "http_request_duration_seconds", families[2].name)
+	assert.Equal(t, typeHistogram, families[2].typ)
+	assert.Len(t, families[2].samples, 4)
+	assert.Equal(t, "+Inf", labelValue(families[2].samples[1].labels, labelLe))
+
+	assert.Equal(t, "metric_without_labels", families[3].name)
+	assert.True(t, math.IsNaN(families[3].samples[0].value))
+}
+
+func TestParseOpenMetrics(t *testing.T) {
+	text := `# TYPE acme_http_router_request_seconds summary
+# UNIT acme_http_router_request_seconds seconds
+# HELP acme_http_router_request_seconds Latency though all of ACME's HTTP request router.
+acme_http_router_request_seconds_sum{path="/api/v1",method="GET"} 9036.32
+acme_http_router_request_seconds_count{path="/api/v1",method="GET"} 807283.0
+acme_http_router_request_seconds_created{path="/api/v1",method="GET"} 1605281325.0
+# TYPE go_goroutines gauge
+go_goroutines 69 1605281325.5
+# TYPE process_cpu_seconds counter
+process_cpu_seconds_total 4.20072246e+06 # {trace_id="0123"} 1.0 1605281325.1
+# EOF
+`
+	families, err := parseExposition(strings.NewReader(text), true)
+	require.NoError(t, err)
+	require.Len(t, families, 3)
+
+	assert.Equal(t, typeSummary, families[0].typ)
+	assert.Equal(t, "seconds", families[0].unit)
+	assert.Equal(t, "Latency though all of ACME's HTTP request router.", families[0].help)
+	assert.Len(t, families[0].samples, 3)
+
+	assert.Equal(t, []promSample{{name: "go_goroutines", value: 69, timestamp: 1605281325500, hasTimestamp: true}}, families[1].samples)
+
+	assert.Equal(t, "process_cpu_seconds", families[2].name)
+	assert.Equal(t, []promSample{{name: "process_cpu_seconds_total", value: 4.20072246e+06}}, families[2].samples)
+}
+
+func TestParseErrors(t *testing.T) {
+	testCases := []struct {
+		name        string
+		text        string
+		openMetrics bool
+	}{
+		{name: "missing value", text: "metric\n"},
+		{name: "invalid value", text: "metric abc\n"},
+		{name: "invalid timestamp", text: "metric 1 1.5\n"},
+		{name: "too many fields", text: "metric 1 2 3\n"},
+		{name: "unterminated labels", text: "metric{a=\"b\" 1\n"},
+		{name: "unquoted label value", text: "metric{a=b} 1\n"},
+		{name: "missing label value", text: "metric{a} 1\n"},
+		{name: "missing eof", text: "metric 1\n", openMetrics: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parseExposition(strings.NewReader(tc.text), tc.openMetrics)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusreceiver // import "go.opentelemetry.io/collector/receiver/prometheusreceiver"
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.9.0"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+)
+
+const (
+	defaultScheme        = "http"
+	defaultMetricsPath   = "/metrics"
+	defaultScrapeTimeout = 10 * time.Second
+
+	acceptHeader           = "application/openmetrics-text;version=1.0.0,text/plain;version=0.0.4;q=0.5,*/*;q=0.1"
+	contentTypeOpenMetrics = "application/openmetrics-text"
+)
+
+// jobScraper scrapes the targets of a job.
+type jobScraper struct {
+	cfg      JobConfig
+	settings component.TelemetrySettings
+	timeout  time.Duration
+	client   *http.Client
+	targets  []*target
+}
+
+func newJobScraper(cfg JobConfig, settings component.TelemetrySettings) *jobScraper {
+	scheme := cfg.Scheme
+	if scheme == "" {
+		scheme = defaultScheme
+	}
+	path := cfg.MetricsPath
+	if path == "" {
+		path = defaultMetricsPath
+	}
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = defaultScrapeTimeout
+	}
+
+	labels := make([]label, 0, len(cfg.Labels))
+	for name, value := range cfg.Labels {
+		labels = append(labels, label{name: name, value: value})
+	}
+	sort.Slice(labels, func(i, j int) bool { return labels[i].name < labels[j].name })
+
+	js := &jobScraper{cfg: cfg, settings: settings, timeout: timeout}
+	for _, address := range cfg.Targets {
+		js.targets = append(js.targets, &target{
+			job:     cfg.JobName,
+			scheme:  scheme,
+			address: address,
+			url:     (&url.URL{Scheme: scheme, Host: address, Path: path}).String(),
+			labels:  labels,
+			starts:  newStartTimes(),
+		})
+	}
+	return js
+}
+
+func (s *jobScraper) start(_ context.Context, host component.Host) error {
+	var err error
+	s.client, err = s.cfg.ToClient(host.GetExtensions(), s.settings)
+	return err
+}
+
+// scrape scrapes the targets concurrently. A target that cannot be scraped is
+// reported as a partial scrape error, its up metric being set to 0.
+func (s *jobScraper) scrape(ctx context.Context) (pdata.Metrics, error) {
+	results := make([]pdata.Metrics, len(s.targets))
+	errs := make([]error, len(s.targets))
+	var wg sync.WaitGroup
+	for i, t := range s.targets {
+		wg.Add(1)
+		go func(i int, t *target) {
+			defer wg.Done()
+			tctx, cancel := context.WithTimeout(ctx, s.timeout)
+			defer cancel()
+			results[i], errs[i] = t.scrape(tctx, s.client)
+		}(i, t)
+	}
+	wg.Wait()
+
+	md := pdata.NewMetrics()
+	var scrapeErrs scrapererror.ScrapeErrors
+	for i, result := range results {
+		result.ResourceMetrics().MoveAndAppendTo(md.ResourceMetrics())
+		if errs[i] != nil {
+			scrapeErrs.AddPartial(1, fmt.Errorf("failed to scrape %s: %w", s.targets[i].url, errs[i]))
+		}
+	}
+	return md, scrapeErrs.Combine()
+}
+
+// target is a scraped target. Its start times are only accessed by its scrape,
+// which the scraper controller does not run concurrently.
+type target struct {
+	job     string
+	scheme  string
+	address string
+	url     string
+	labels  []label
+	starts  *startTimes
+}
+
+// scrape scrapes the target and returns its metrics, followed by the up,
+// scrape_duration_seconds and scrape_samples_scraped metrics.
+func (t *target) scrape(ctx context.Context, client *http.Client) (pdata.Metrics, error) {
+	md := pdata.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	attrs := rm.Resource().Attributes()
+	attrs.InsertString(conventions.AttributeServiceName, t.job)
+	attrs.InsertString(conventions.AttributeServiceInstanceID, t.address)
+	if host, port, err := net.SplitHostPort(t.address); err == nil {
+		attrs.InsertString(conventions.AttributeNetHostName, host)
+		attrs.InsertString(conventions.AttributeNetHostPort, port)
+	}
+	attrs.InsertString(conventions.AttributeHTTPScheme, t.scheme)
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+
+	start := time.Now()
+	now := pdata.NewTimestampFromTime(start)
+	families, err := t.fetch(ctx, client)
+	duration := time.Since(start)
+
+	up, samples := 0.0, 0
+	if err == nil {
+		up = 1
+		samples = t.appendMetrics(ms, families, now)
+		t.starts.commit()
+	} else {
+		// The target may have been restarted, so the start times are no longer known.
+		t.starts.reset()
+	}
+	t.appendGauge(ms, "up", now, up)
+	t.appendGauge(ms, "scrape_duration_seconds", now, duration.Seconds())
+	t.appendGauge(ms, "scrape_samples_scraped", now, float64(samples))
+	return md, err
+}
+
+func (t *target) fetch(ctx context.Context, client *http.Client) ([]*metricFamily, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, t.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", acceptHeader)
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server returned HTTP status %s", resp.Status)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	return parseExposition(resp.Body, mediaType == contentTypeOpenMetrics)
+}
+
+func (t *target) appendGauge(ms pdata.MetricSlice, name string, timestamp pdata.Timestamp, value float64) {
+	m := ms.AppendEmpty()
+	m.SetName(name)
+	m.SetDataType(pdata.MetricDataTypeGauge)
+	dp := m.Gauge().DataPoints().AppendEmpty()
+	dp.SetTimestamp(timestamp)
+	dp.SetDoubleVal(value)
+	insertLabels(dp.Attributes(), t.labels)
+}
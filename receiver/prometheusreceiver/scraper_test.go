@@ -0,0 +1,243 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusreceiver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+)
+
+// testTarget is a target exposing a body that can be changed between scrapes.
+type testTarget struct {
+	mu          sync.Mutex
+	contentType string
+	body        string
+	status      int
+}
+
+func (tt *testTarget) set(contentType, body string) {
+	tt.mu.Lock()
+	defer tt.mu.Unlock()
+	tt.contentType = contentType
+	tt.body = body
+	tt.status = http.StatusOK
+}
+
+func (tt *testTarget) fail() {
+	tt.mu.Lock()
+	defer tt.mu.Unlock()
+	tt.status = http.StatusInternalServerError
+}
+
+func (tt *testTarget) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	tt.mu.Lock()
+	defer tt.mu.Unlock()
+	if r.URL.Path != defaultMetricsPath {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", tt.contentType)
+	w.WriteHeader(tt.status)
+	_, _ = w.Write([]byte(tt.body))
+}
+
+func startTestTarget(t *testing.T) (*testTarget, string) {
+	tt := &testTarget{status: http.StatusOK}
+	server := httptest.NewServer(tt)
+	t.Cleanup(server.Close)
+	return tt, strings.TrimPrefix(server.URL, "http://")
+}
+
+func newTestJobScraper(t *testing.T, targets ...string) *jobScraper {
+	js := newJobScraper(JobConfig{
+		JobName: "test",
+		Targets: targets,
+		Labels:  map[string]string{"env": "test"},
+	}, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, js.start(context.Background(), componenttest.NewNopHost()))
+	return js
+}
+
+const textMetrics = `# HELP requests_total The number of requests.
+# TYPE requests_total counter
+requests_total{code="200",env="dev"} 10
+# TYPE temperature gauge
+temperature 21.5
+# TYPE latency histogram
+latency_bucket{le="0.1"} 2
+latency_bucket{le="1"} 5
+latency_bucket{le="+Inf"} 6
+latency_sum 3.5
+latency_count 6
+# TYPE rpc summary
+rpc{quantile="0.5"} 0.2
+rpc{quantile="0.9"} 0.8
+rpc_sum 12
+rpc_count 40
+`
+
+func TestScrapeText(t *testing.T) {
+	target, address := startTestTarget(t)
+	target.set("text/plain; version=0.0.4", textMetrics)
+	js := newTestJobScraper(t, address)
+
+	md, err := js.scrape(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+
+	rm := md.ResourceMetrics().At(0)
+	attrs := rm.Resource().Attributes().AsRaw()
+	assert.Equal(t, "test", attrs["service.name"])
+	assert.Equal(t, address, attrs["service.instance.id"])
+	assert.Equal(t, "http", attrs["http.scheme"])
+
+	metrics := metricsByName(rm.ScopeMetrics().At(0).Metrics())
+
+	requests := metrics["requests_total"]
+	assert.Equal(t, "The number of requests.", requests.Description())
+	require.Equal(t, pdata.MetricDataTypeSum, requests.DataType())
+	assert.True(t, requests.Sum().IsMonotonic())
+	assert.Equal(t, pdata.MetricAggregationTemporalityCumulative, requests.Sum().AggregationTemporality())
+	dp := requests.Sum().DataPoints().At(0)
+	assert.Equal(t, 10.0, dp.DoubleVal())
+	assert.Equal(t, dp.Timestamp(), dp.StartTimestamp())
+	assert.Equal(t, map[string]interface{}{"code": "200", "exported_env": "dev", "env": "test"}, dp.Attributes().AsRaw())
+
+	require.Equal(t, pdata.MetricDataTypeGauge, metrics["temperature"].DataType())
+	assert.Equal(t, 21.5, metrics["temperature"].Gauge().DataPoints().At(0).DoubleVal())
+
+	latency := metrics["latency"]
+	require.Equal(t, pdata.MetricDataTypeHistogram, latency.DataType())
+	hdp := latency.Histogram().DataPoints().At(0)
+	assert.Equal(t, uint64(6), hdp.Count())
+	assert.Equal(t, 3.5, hdp.Sum())
+	assert.Equal(t, []float64{0.1, 1}, hdp.ExplicitBounds())
+	assert.Equal(t, []uint64{2, 3, 1}, hdp.BucketCounts())
+
+	rpc := metrics["rpc"]
+	require.Equal(t, pdata.MetricDataTypeSummary, rpc.DataType())
+	sdp := rpc.Summary().DataPoints().At(0)
+	assert.Equal(t, uint64(40), sdp.Count())
+	assert.Equal(t, 12.0, sdp.Sum())
+	assert.Equal(t, 2, sdp.QuantileValues().Len())
+
+	assert.Equal(t, 1.0, metrics["up"].Gauge().DataPoints().At(0).DoubleVal())
+	assert.Equal(t, map[string]interface{}{"env": "test"}, metrics["up"].Gauge().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, 11.0, metrics["scrape_samples_scraped"].Gauge().DataPoints().At(0).DoubleVal())
+	assert.Contains(t, metrics, "scrape_duration_seconds")
+}
+
+func TestScrapeStartTimestamps(t *testing.T) {
+	target, address := startTestTarget(t)
+	js := newTestJobScraper(t, address)
+	scrapeCounter := func(value string) pdata.NumberDataPoint {
+		target.set("text/plain", "# TYPE requests_total counter\nrequests_total "+value+"\n")
+		md, err := js.scrape(context.Background())
+		require.NoError(t, err)
+		return metricsByName(md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics())["requests_total"].Sum().DataPoints().At(0)
+	}
+
+	first := scrapeCounter("10")
+	assert.Equal(t, first.Timestamp(), first.StartTimestamp())
+
+	second := scrapeCounter("15")
+	assert.Equal(t, first.Timestamp(), second.StartTimestamp())
+
+	// The counter was reset after the second scrape.
+	third := scrapeCounter("3")
+	assert.Equal(t, second.Timestamp(), third.StartTimestamp())
+
+	// The start timestamps are forgotten when the target is down.
+	target.fail()
+	_, err := js.scrape(context.Background())
+	require.Error(t, err)
+	fifth := scrapeCounter("4")
+	assert.Equal(t, fifth.Timestamp(), fifth.StartTimestamp())
+}
+
+func TestScrapeOpenMetricsCreated(t *testing.T) {
+	target, address := startTestTarget(t)
+	target.set("application/openmetrics-text; version=1.0.0; charset=utf-8", `# TYPE requests counter
+requests_total 10
+requests_created 1600000000.5
+# EOF
+`)
+	js := newTestJobScraper(t, address)
+
+	md, err := js.scrape(context.Background())
+	require.NoError(t, err)
+	metrics := metricsByName(md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics())
+	dp := metrics["requests_total"].Sum().DataPoints().At(0)
+	assert.Equal(t, pdata.Timestamp(1600000000500000000), dp.StartTimestamp())
+	assert.NotContains(t, metrics, "requests_created")
+}
+
+func TestScrapeFailure(t *testing.T) {
+	target, address := startTestTarget(t)
+	target.set("text/plain", textMetrics)
+	failing, failingAddress := startTestTarget(t)
+	failing.fail()
+	js := newTestJobScraper(t, address, failingAddress)
+
+	md, err := js.scrape(context.Background())
+	require.Error(t, err)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	require.Equal(t, 2, md.ResourceMetrics().Len())
+
+	metrics := metricsByName(md.ResourceMetrics().At(1).ScopeMetrics().At(0).Metrics())
+	assert.Len(t, metrics, 3)
+	assert.Equal(t, 0.0, metrics["up"].Gauge().DataPoints().At(0).DoubleVal())
+	assert.Equal(t, 0.0, metrics["scrape_samples_scraped"].Gauge().DataPoints().At(0).DoubleVal())
+}
+
+func TestReceiver(t *testing.T) {
+	target, address := startTestTarget(t)
+	target.set("text/plain", textMetrics)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.CollectionInterval = 10 * time.Millisecond
+	cfg.Jobs = []JobConfig{{JobName: "test", Targets: []string{address}}}
+	sink := new(consumertest.MetricsSink)
+	rcv, err := NewFactory().CreateMetricsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, rcv.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, rcv.Shutdown(context.Background())) }()
+
+	assert.Eventually(t, func() bool { return len(sink.AllMetrics()) > 0 }, 5*time.Second, 10*time.Millisecond)
+	md := sink.AllMetrics()[0]
+	metrics := metricsByName(md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics())
+	assert.Equal(t, 1.0, metrics["up"].Gauge().DataPoints().At(0).DoubleVal())
+}
+
+func metricsByName(ms pdata.MetricSlice) map[string]pdata.Metric {
+	metrics := map[string]pdata.Metric{}
+	for i := 0; i < ms.Len(); i++ {
+		metrics[ms.At(i).Name()] = ms.At(i)
+	}
+	return metrics
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusreceiver // import "go.opentelemetry.io/collector/receiver/prometheusreceiver"
+
+import (
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// startTimes tracks the start timestamps of the cumulative series of a target, which
+// the Prometheus formats do not expose, except for the _created series of OpenMetrics.
+// The start timestamp of a series is the timestamp it was first scraped at. When its
+// value decreases, the series was reset after the previous scrape, whose timestamp
+// becomes the start timestamp.
+type startTimes struct {
+	previous map[string]seriesState
+	current  map[string]seriesState
+}
+
+type seriesState struct {
+	start     pdata.Timestamp
+	timestamp pdata.Timestamp
+	value     float64
+}
+
+func newStartTimes() *startTimes {
+	return &startTimes{
+		previous: map[string]seriesState{},
+		current:  map[string]seriesState{},
+	}
+}
+
+// startTime returns the start timestamp of the series with the given key, scraped
+// with the given value and timestamp.
+func (s *startTimes) startTime(key string, value float64, timestamp pdata.Timestamp) pdata.Timestamp {
+	state, ok := s.previous[key]
+	switch {
+	case !ok:
+		state.start = timestamp
+	case value < state.value:
+		state.start = state.timestamp
+	}
+	state.value = value
+	state.timestamp = timestamp
+	s.current[key] = state
+	return state.start
+}
+
+// commit ends a scrape. The series that were not scraped are forgotten.
+func (s *startTimes) commit() {
+	s.previous = s.current
+	s.current = map[string]seriesState{}
+}
+
+// reset forgets all the series.
+func (s *startTimes) reset() {
+	s.previous = map[string]seriesState{}
+	s.current = map[string]seriesState{}
+}
@@ -0,0 +1,182 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver // import "go.opentelemetry.io/collector/receiver/prometheusremotewritereceiver"
+
+import (
+	"math"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The messages of the remote-write protocol, see
+// https://github.com/prometheus/prometheus/blob/main/prompb/remote.proto.
+type writeRequest struct {
+	timeseries []timeSeries
+	metadata   []metricMetadata
+}
+
+type timeSeries struct {
+	labels  []label
+	samples []sample
+}
+
+type label struct {
+	name  string
+	value string
+}
+
+type sample struct {
+	value     float64
+	timestamp int64
+}
+
+// metricType is the type of a metric family, as defined by the MetricMetadata message.
+type metricType uint64
+
+const (
+	metricTypeUnknown metricType = iota
+	metricTypeCounter
+	metricTypeGauge
+	metricTypeHistogram
+	metricTypeGaugeHistogram
+	metricTypeSummary
+)
+
+type metricMetadata struct {
+	typ        metricType
+	familyName string
+	help       string
+	unit       string
+}
+
+// field is a decoded protobuf field. Only the value of its wire type is set.
+type field struct {
+	num     protowire.Number
+	varint  uint64
+	fixed64 uint64
+	bytes   []byte
+}
+
+// unmarshalWriteRequest decodes a WriteRequest encoded in protobuf. The unknown
+// fields are ignored.
+func unmarshalWriteRequest(b []byte) (*writeRequest, error) {
+	req := &writeRequest{}
+	err := parseFields(b, func(f field) error {
+		switch f.num {
+		case 1:
+			ts, err := unmarshalTimeSeries(f.bytes)
+			if err != nil {
+				return err
+			}
+			req.timeseries = append(req.timeseries, ts)
+		case 3:
+			md, err := unmarshalMetricMetadata(f.bytes)
+			if err != nil {
+				return err
+			}
+			req.metadata = append(req.metadata, md)
+		}
+		return nil
+	})
+	return req, err
+}
+
+func unmarshalTimeSeries(b []byte) (timeSeries, error) {
+	ts := timeSeries{}
+	err := parseFields(b, func(f field) error {
+		switch f.num {
+		case 1:
+			l := label{}
+			if err := parseFields(f.bytes, func(f field) error {
+				switch f.num {
+				case 1:
+					l.name = string(f.bytes)
+				case 2:
+					l.value = string(f.bytes)
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+			ts.labels = append(ts.labels, l)
+		case 2:
+			s := sample{}
+			if err := parseFields(f.bytes, func(f field) error {
+				switch f.num {
+				case 1:
+					s.value = math.Float64frombits(f.fixed64)
+				case 2:
+					s.timestamp = int64(f.varint)
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+			ts.samples = append(ts.samples, s)
+		}
+		return nil
+	})
+	return ts, err
+}
+
+func unmarshalMetricMetadata(b []byte) (metricMetadata, error) {
+	md := metricMetadata{}
+	err := parseFields(b, func(f field) error {
+		switch f.num {
+		case 1:
+			md.typ = metricType(f.varint)
+		case 2:
+			md.familyName = string(f.bytes)
+		case 4:
+			md.help = string(f.bytes)
+		case 5:
+			md.unit = string(f.bytes)
+		}
+		return nil
+	})
+	return md, err
+}
+
+// parseFields calls fn for each field of the protobuf message b.
+func parseFields(b []byte, fn func(f field) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		f := field{num: num}
+		switch typ {
+		case protowire.VarintType:
+			f.varint, n = protowire.ConsumeVarint(b)
+		case protowire.Fixed64Type:
+			f.fixed64, n = protowire.ConsumeFixed64(b)
+		case protowire.BytesType:
+			f.bytes, n = protowire.ConsumeBytes(b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// marshalWriteRequest encodes a write request in protobuf, for the tests.
+func marshalWriteRequest(req *writeRequest) []byte {
+	var b []byte
+	for _, ts := range req.timeseries {
+		var tsb []byte
+		for _, l := range ts.labels {
+			var lb []byte
+			lb = protowire.AppendTag(lb, 1, protowire.BytesType)
+			lb = protowire.AppendString(lb, l.name)
+			lb = protowire.AppendTag(lb, 2, protowire.BytesType)
+			lb = protowire.AppendString(lb, l.value)
+			tsb = protowire.AppendTag(tsb, 1, protowire.BytesType)
+			tsb = protowire.AppendBytes(tsb, lb)
+		}
+		for _, s := range ts.samples {
+			var sb []byte
+			sb = protowire.AppendTag(sb, 1, protowire.Fixed64Type)
+			sb = protowire.AppendFixed64(sb, math.Float64bits(s.value))
+			sb = protowire.AppendTag(sb, 2, protowire.VarintType)
+			sb = protowire.AppendVarint(sb, uint64(s.timestamp))
+			tsb = protowire.AppendTag(tsb, 2, protowire.BytesType)
+			tsb = protowire.AppendBytes(tsb, sb)
+		}
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendBytes(b, tsb)
+	}
+	for _, md := range req.metadata {
+		var mb []byte
+		mb = protowire.AppendTag(mb, 1, protowire.VarintType)
+		mb = protowire.AppendVarint(mb, uint64(md.typ))
+		mb = protowire.AppendTag(mb, 2, protowire.BytesType)
+		mb = protowire.AppendString(mb, md.familyName)
+		mb = protowire.AppendTag(mb, 4, protowire.BytesType)
+		mb = protowire.AppendString(mb, md.help)
+		mb = protowire.AppendTag(mb, 5, protowire.BytesType)
+		mb = protowire.AppendString(mb, md.unit)
+		b = protowire.AppendTag(b, 3, protowire.BytesType)
+		b = protowire.AppendBytes(b, mb)
+	}
+	return b
+}
+
+func TestUnmarshalWriteRequest(t *testing.T) {
+	req := &writeRequest{
+		timeseries: []timeSeries{
+			{
+				labels:  []label{{name: "__name__", value: "up"}, {name: "job", value: "node"}},
+				samples: []sample{{value: 1, timestamp: 1000}, {value: -2.5, timestamp: -1}},
+			},
+		},
+		metadata: []metricMetadata{{typ: metricTypeGauge, familyName: "up", help: "Target is up.", unit: "1"}},
+	}
+	data := marshalWriteRequest(req)
+	// An unknown field is ignored.
+	data = protowire.AppendTag(data, 10, protowire.VarintType)
+	data = protowire.AppendVarint(data, 42)
+
+	got, err := unmarshalWriteRequest(data)
+	require.NoError(t, err)
+	assert.Equal(t, req, got)
+}
+
+func TestUnmarshalWriteRequestInvalid(t *testing.T) {
+	data := marshalWriteRequest(&writeRequest{
+		timeseries: []timeSeries{{labels: []label{{name: "__name__", value: "up"}}}},
+	})
+
+	_, err := unmarshalWriteRequest(data[:len(data)-1])
+	assert.Error(t, err)
+}
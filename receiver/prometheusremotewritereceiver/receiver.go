@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver // import "go.opentelemetry.io/collector/receiver/prometheusremotewritereceiver"
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/golang/snappy"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/errorutil"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+const (
+	receiverTransport = "http"
+	dataFormat        = "protobuf"
+
+	writePath = "/api/v1/write"
+)
+
+type remoteWriteReceiver struct {
+	cfg          *Config
+	settings     component.ReceiverCreateSettings
+	nextConsumer consumer.Metrics
+	obsrecv      *obsreport.Receiver
+
+	server     *http.Server
+	shutdownWG sync.WaitGroup
+}
+
+var _ http.Handler = (*remoteWriteReceiver)(nil)
+
+func newRemoteWriteReceiver(cfg *Config, set component.ReceiverCreateSettings, nextConsumer consumer.Metrics) *remoteWriteReceiver {
+	return &remoteWriteReceiver{
+		cfg:          cfg,
+		settings:     set,
+		nextConsumer: nextConsumer,
+		obsrecv: obsreport.NewReceiver(obsreport.ReceiverSettings{
+			ReceiverID:             cfg.ID(),
+			Transport:              receiverTransport,
+			ReceiverCreateSettings: set,
+		}),
+	}
+}
+
+// Start starts the HTTP server receiving the write requests.
+func (r *remoteWriteReceiver) Start(_ context.Context, host component.Host) error {
+	mux := http.NewServeMux()
+	mux.Handle(writePath, r)
+
+	var err error
+	r.server, err = r.cfg.HTTPServerSettings.ToServer(host, r.settings.TelemetrySettings, mux)
+	if err != nil {
+		return err
+	}
+	ln, err := r.cfg.HTTPServerSettings.ToListener()
+	if err != nil {
+		return err
+	}
+
+	r.settings.Logger.Info("Starting HTTP server on endpoint " + r.cfg.Endpoint)
+	r.shutdownWG.Add(1)
+	go func() {
+		defer r.shutdownWG.Done()
+		if errHTTP := r.server.Serve(ln); errHTTP != http.ErrServerClosed {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the HTTP server.
+func (r *remoteWriteReceiver) Shutdown(context.Context) error {
+	var err error
+	if r.server != nil {
+		err = r.server.Close()
+	}
+	r.shutdownWG.Wait()
+	return err
+}
+
+// ServeHTTP receives the snappy-compressed write requests sent to /api/v1/write.
+func (r *remoteWriteReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, fmt.Sprintf("%v method not allowed, supported: [POST]", req.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	ctx := r.obsrecv.StartMetricsOp(req.Context())
+	wr, err := decodeWriteRequest(req.Body, r.cfg.MaxDecompressedSize)
+	_ = req.Body.Close()
+	if err != nil {
+		r.obsrecv.EndMetricsOp(ctx, dataFormat, 0, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	md := toMetrics(wr)
+	if md.DataPointCount() == 0 {
+		r.obsrecv.EndMetricsOp(ctx, dataFormat, 0, nil)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	err = r.nextConsumer.ConsumeMetrics(ctx, md)
+	r.obsrecv.EndMetricsOp(ctx, dataFormat, md.DataPointCount(), err)
+	if err != nil {
+		errorutil.HTTPError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func decodeWriteRequest(body io.Reader, maxDecompressedSize int) (*writeRequest, error) {
+	compressed, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	size, err := snappy.DecodedLen(compressed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress the write request: %w", err)
+	}
+	if size > maxDecompressedSize {
+		return nil, fmt.Errorf("decompressed write request of %d bytes exceeds the maximum of %d bytes", size, maxDecompressedSize)
+	}
+	data, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress the write request: %w", err)
+	}
+	wr, err := unmarshalWriteRequest(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the write request: %w", err)
+	}
+	return wr, nil
+}
@@ -0,0 +1,162 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testutil"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
+)
+
+var testRequest = &writeRequest{
+	timeseries: []timeSeries{
+		newSeries("up", 1, 1000, "job", "node", "instance", "host:9100"),
+		newSeries("http_requests_total", 10, 1000, "job", "node", "instance", "host:9100"),
+	},
+}
+
+func startReceiver(t *testing.T, tt obsreporttest.TestTelemetry, next consumer.Metrics) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	rcv, err := NewFactory().CreateMetricsReceiver(context.Background(), tt.ToReceiverCreateSettings(), cfg, next)
+	require.NoError(t, err)
+	require.NoError(t, rcv.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, rcv.Shutdown(context.Background())) })
+	return cfg
+}
+
+func TestReceiveWriteRequest(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	defer func() { require.NoError(t, tt.Shutdown(context.Background())) }()
+
+	sink := new(consumertest.MetricsSink)
+	cfg := startReceiver(t, tt, sink)
+
+	body := snappy.Encode(nil, marshalWriteRequest(testRequest))
+	resp, err := http.Post("http://"+cfg.Endpoint+writePath, "application/x-protobuf", bytes.NewReader(body))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	require.Len(t, sink.AllMetrics(), 1)
+	md := sink.AllMetrics()[0]
+	assert.Equal(t, 2, md.DataPointCount())
+	service, ok := md.ResourceMetrics().At(0).Resource().Attributes().Get("service.name")
+	require.True(t, ok)
+	assert.Equal(t, "node", service.StringVal())
+
+	require.NoError(t, obsreporttest.CheckReceiverMetrics(tt, cfg.ID(), receiverTransport, 2, 0))
+}
+
+func TestReceiveErrors(t *testing.T) {
+	validBody := string(snappy.Encode(nil, marshalWriteRequest(testRequest)))
+	testCases := []struct {
+		name       string
+		method     string
+		body       string
+		next       consumer.Metrics
+		wantStatus int
+		wantRetry  string
+	}{
+		{
+			name:       "method not allowed",
+			method:     http.MethodGet,
+			next:       consumertest.NewNop(),
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:       "invalid snappy",
+			method:     http.MethodPost,
+			body:       "\xff\xff\xff",
+			next:       consumertest.NewNop(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid protobuf",
+			method:     http.MethodPost,
+			body:       string(snappy.Encode(nil, []byte("\x0a\xff"))),
+			next:       consumertest.NewNop(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "empty request",
+			method:     http.MethodPost,
+			body:       string(snappy.Encode(nil, nil)),
+			next:       consumertest.NewErr(errors.New("not called")),
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "consumer error",
+			method:     http.MethodPost,
+			body:       validBody,
+			next:       consumertest.NewErr(errors.New("failed")),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "throttled",
+			method:     http.MethodPost,
+			body:       validBody,
+			next:       consumertest.NewErr(consumererror.NewThrottle(errors.New("memory limit"), 1500*time.Millisecond)),
+			wantStatus: http.StatusTooManyRequests,
+			wantRetry:  "2",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tt, err := obsreporttest.SetupTelemetry()
+			require.NoError(t, err)
+			defer func() { require.NoError(t, tt.Shutdown(context.Background())) }()
+
+			cfg := startReceiver(t, tt, tc.next)
+
+			req, err := http.NewRequest(tc.method, "http://"+cfg.Endpoint+writePath, bytes.NewBufferString(tc.body))
+			require.NoError(t, err)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			assert.Equal(t, tc.wantStatus, resp.StatusCode)
+			assert.Equal(t, tc.wantRetry, resp.Header.Get("Retry-After"))
+		})
+	}
+}
+
+func TestDecodeWriteRequestMaxDecompressedSize(t *testing.T) {
+	data := marshalWriteRequest(testRequest)
+	body := snappy.Encode(nil, data)
+
+	_, err := decodeWriteRequest(bytes.NewReader(body), len(data)-1)
+	assert.EqualError(t, err, fmt.Sprintf("decompressed write request of %d bytes exceeds the maximum of %d bytes", len(data), len(data)-1))
+
+	wr, err := decodeWriteRequest(bytes.NewReader(body), len(data))
+	require.NoError(t, err)
+	assert.Len(t, wr.timeseries, 2)
+}
@@ -0,0 +1,483 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver // import "go.opentelemetry.io/collector/receiver/prometheusremotewritereceiver"
+
+import (
+	"math"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.9.0"
+)
+
+const (
+	labelMetricName = "__name__"
+	labelJob        = "job"
+	labelInstance   = "instance"
+	labelLe         = "le"
+	labelQuantile   = "quantile"
+
+	suffixBucket = "_bucket"
+	suffixSum    = "_sum"
+	suffixCount  = "_count"
+	suffixTotal  = "_total"
+)
+
+// staleNaN is the value of the samples marking the end of a series in Prometheus.
+const staleNaN uint64 = 0x7ff0000000000002
+
+// role is the role of a series in its metric family.
+type role int
+
+const (
+	roleValue role = iota
+	roleBucket
+	roleQuantile
+	roleSum
+	roleCount
+)
+
+// translator converts a write request into pdata.Metrics.
+type translator struct {
+	metadata   map[string]metricMetadata
+	histograms map[string]bool
+	summaries  map[string]bool
+
+	md        pdata.Metrics
+	resources map[string]*resourceMetrics
+}
+
+// resourceMetrics are the metrics of a job and instance.
+type resourceMetrics struct {
+	metrics    pdata.MetricSlice
+	byName     map[string]pdata.Metric
+	histograms map[pointKey]*histogramPoint
+	summaries  map[pointKey]*summaryPoint
+	// The keys of the histogram and summary points, in the order of their first series.
+	histogramKeys []pointKey
+	summaryKeys   []pointKey
+}
+
+// pointKey identifies a data point of a histogram or summary, made of several series.
+type pointKey struct {
+	family    string
+	attrs     string
+	timestamp int64
+}
+
+type histogramPoint struct {
+	attrs    []label
+	buckets  map[float64]float64
+	sum      float64
+	count    float64
+	hasCount bool
+	stale    bool
+}
+
+type summaryPoint struct {
+	attrs     []label
+	quantiles map[float64]float64
+	sum       float64
+	count     float64
+	stale     bool
+}
+
+// toMetrics converts the time series of a write request into metrics. The series of
+// a job and instance are grouped into a resource. The series of the histograms and
+// summaries are combined into their data points, the counters become cumulative sums
+// and the other series gauges.
+func toMetrics(req *writeRequest) pdata.Metrics {
+	t := &translator{
+		metadata:   map[string]metricMetadata{},
+		histograms: map[string]bool{},
+		summaries:  map[string]bool{},
+		md:         pdata.NewMetrics(),
+		resources:  map[string]*resourceMetrics{},
+	}
+	for _, md := range req.metadata {
+		t.metadata[md.familyName] = md
+		switch md.typ {
+		case metricTypeHistogram, metricTypeGaugeHistogram:
+			t.histograms[md.familyName] = true
+		case metricTypeSummary:
+			t.summaries[md.familyName] = true
+		}
+	}
+	// The families without metadata are identified by the labels of their series.
+	for _, ts := range req.timeseries {
+		name := labelValue(ts.labels, labelMetricName)
+		if _, ok := t.metadata[name]; ok {
+			continue
+		}
+		if strings.HasSuffix(name, suffixBucket) && hasLabel(ts.labels, labelLe) {
+			t.histograms[strings.TrimSuffix(name, suffixBucket)] = true
+		} else if hasLabel(ts.labels, labelQuantile) {
+			t.summaries[name] = true
+		}
+	}
+
+	for _, ts := range req.timeseries {
+		t.addSeries(ts)
+	}
+	for _, rm := range t.resources {
+		rm.flush()
+	}
+	return t.md
+}
+
+func (t *translator) addSeries(ts timeSeries) {
+	name := labelValue(ts.labels, labelMetricName)
+	if name == "" || len(ts.samples) == 0 {
+		return
+	}
+	family, r := t.classify(name, ts.labels)
+	rm := t.resource(labelValue(ts.labels, labelJob), labelValue(ts.labels, labelInstance))
+
+	switch {
+	case t.histograms[family]:
+		attrs := pointLabels(ts.labels, labelLe)
+		le := math.Inf(1)
+		if r == roleBucket {
+			var err error
+			if le, err = strconv.ParseFloat(labelValue(ts.labels, labelLe), 64); err != nil {
+				return
+			}
+		}
+		for _, s := range ts.samples {
+			p := rm.histogramPoint(pointKey{family: family, attrs: labelsKey(attrs), timestamp: s.timestamp}, attrs)
+			p.stale = p.stale || math.Float64bits(s.value) == staleNaN
+			switch r {
+			case roleBucket:
+				p.buckets[le] = s.value
+			case roleSum:
+				p.sum = s.value
+			case roleCount:
+				p.count = s.value
+				p.hasCount = true
+			}
+		}
+	case t.summaries[family]:
+		attrs := pointLabels(ts.labels, labelQuantile)
+		q := 0.0
+		if r == roleQuantile {
+			var err error
+			if q, err = strconv.ParseFloat(labelValue(ts.labels, labelQuantile), 64); err != nil {
+				return
+			}
+		}
+		for _, s := range ts.samples {
+			p := rm.summaryPoint(pointKey{family: family, attrs: labelsKey(attrs), timestamp: s.timestamp}, attrs)
+			p.stale = p.stale || math.Float64bits(s.value) == staleNaN
+			switch r {
+			case roleQuantile:
+				p.quantiles[q] = s.value
+			case roleSum:
+				p.sum = s.value
+			case roleCount:
+				p.count = s.value
+			}
+		}
+	default:
+		t.addNumberPoints(rm, name, ts)
+	}
+	t.describe(rm, family)
+}
+
+// classify returns the family of a series and its role in the family.
+func (t *translator) classify(name string, labels []label) (string, role) {
+	if t.histograms[name] || t.summaries[name] {
+		if hasLabel(labels, labelQuantile) {
+			return name, roleQuantile
+		}
+		return name, roleValue
+	}
+	for _, c := range []struct {
+		suffix string
+		role   role
+	}{{suffixBucket, roleBucket}, {suffixSum, roleSum}, {suffixCount, roleCount}} {
+		family := strings.TrimSuffix(name, c.suffix)
+		if family != name && (t.histograms[family] || t.summaries[family]) {
+			if c.role == roleBucket && !t.histograms[family] {
+				break
+			}
+			return family, c.role
+		}
+	}
+	return name, roleValue
+}
+
+// addNumberPoints adds the samples of a series as points of a cumulative sum if it is
+// a counter, or of a gauge otherwise.
+func (t *translator) addNumberPoints(rm *resourceMetrics, name string, ts timeSeries) {
+	isCounter := false
+	if md, ok := t.metadata[name]; ok {
+		isCounter = md.typ == metricTypeCounter
+	} else {
+		isCounter = strings.HasSuffix(name, suffixTotal)
+	}
+
+	dataType := pdata.MetricDataTypeGauge
+	if isCounter {
+		dataType = pdata.MetricDataTypeSum
+	}
+	m, exists := rm.metric(name, dataType)
+	if m.DataType() != dataType {
+		// The series of a metric with the same name and another type are dropped.
+		return
+	}
+	var dps pdata.NumberDataPointSlice
+	if isCounter {
+		if !exists {
+			m.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+			m.Sum().SetIsMonotonic(true)
+		}
+		dps = m.Sum().DataPoints()
+	} else {
+		dps = m.Gauge().DataPoints()
+	}
+
+	attrs := pointLabels(ts.labels)
+	for _, s := range ts.samples {
+		dp := dps.AppendEmpty()
+		dp.SetTimestamp(timestampFromMillis(s.timestamp))
+		if math.Float64bits(s.value) == staleNaN {
+			dp.SetFlags(pdata.NewMetricDataPointFlags(pdata.MetricDataPointFlagNoRecordedValue))
+		} else {
+			dp.SetDoubleVal(s.value)
+		}
+		insertLabels(dp.Attributes(), attrs)
+	}
+}
+
+// describe sets the description and unit of the metric of a family from its metadata.
+func (t *translator) describe(rm *resourceMetrics, family string) {
+	md, ok := t.metadata[family]
+	if !ok {
+		return
+	}
+	if m, ok := rm.byName[family]; ok {
+		m.SetDescription(md.help)
+		m.SetUnit(md.unit)
+	}
+}
+
+func (t *translator) resource(job, instance string) *resourceMetrics {
+	key := job + "\x00" + instance
+	if rm, ok := t.resources[key]; ok {
+		return rm
+	}
+	rms := t.md.ResourceMetrics().AppendEmpty()
+	attrs := rms.Resource().Attributes()
+	if job != "" {
+		attrs.InsertString(conventions.AttributeServiceName, job)
+	}
+	if instance != "" {
+		attrs.InsertString(conventions.AttributeServiceInstanceID, instance)
+		if host, port, err := net.SplitHostPort(instance); err == nil {
+			attrs.InsertString(conventions.AttributeNetHostName, host)
+			attrs.InsertString(conventions.AttributeNetHostPort, port)
+		}
+	}
+	rm := &resourceMetrics{
+		metrics:    rms.ScopeMetrics().AppendEmpty().Metrics(),
+		byName:     map[string]pdata.Metric{},
+		histograms: map[pointKey]*histogramPoint{},
+		summaries:  map[pointKey]*summaryPoint{},
+	}
+	t.resources[key] = rm
+	return rm
+}
+
+// metric returns the metric of the given name, creating it with the given type if it
+// does not exist yet.
+func (rm *resourceMetrics) metric(name string, dataType pdata.MetricDataType) (pdata.Metric, bool) {
+	if m, ok := rm.byName[name]; ok {
+		return m, true
+	}
+	m := rm.metrics.AppendEmpty()
+	m.SetName(name)
+	m.SetDataType(dataType)
+	rm.byName[name] = m
+	return m, false
+}
+
+func (rm *resourceMetrics) histogramPoint(key pointKey, attrs []label) *histogramPoint {
+	if p, ok := rm.histograms[key]; ok {
+		return p
+	}
+	p := &histogramPoint{attrs: attrs, buckets: map[float64]float64{}}
+	rm.histograms[key] = p
+	rm.histogramKeys = append(rm.histogramKeys, key)
+	if _, exists := rm.metric(key.family, pdata.MetricDataTypeHistogram); !exists {
+		rm.byName[key.family].Histogram().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+	}
+	return p
+}
+
+func (rm *resourceMetrics) summaryPoint(key pointKey, attrs []label) *summaryPoint {
+	if p, ok := rm.summaries[key]; ok {
+		return p
+	}
+	p := &summaryPoint{attrs: attrs, quantiles: map[float64]float64{}}
+	rm.summaries[key] = p
+	rm.summaryKeys = append(rm.summaryKeys, key)
+	rm.metric(key.family, pdata.MetricDataTypeSummary)
+	return p
+}
+
+// flush adds the histogram and summary points combined from their series to their metrics.
+func (rm *resourceMetrics) flush() {
+	for _, key := range rm.histogramKeys {
+		m := rm.byName[key.family]
+		if m.DataType() != pdata.MetricDataTypeHistogram {
+			continue
+		}
+		rm.histograms[key].toDataPoint(m.Histogram().DataPoints().AppendEmpty(), key.timestamp)
+	}
+	for _, key := range rm.summaryKeys {
+		m := rm.byName[key.family]
+		if m.DataType() != pdata.MetricDataTypeSummary {
+			continue
+		}
+		rm.summaries[key].toDataPoint(m.Summary().DataPoints().AppendEmpty(), key.timestamp)
+	}
+}
+
+// toDataPoint converts the cumulative counts of the buckets into the counts of each bucket.
+func (p *histogramPoint) toDataPoint(dp pdata.HistogramDataPoint, timestamp int64) {
+	dp.SetTimestamp(timestampFromMillis(timestamp))
+	insertLabels(dp.Attributes(), p.attrs)
+	if p.stale {
+		dp.SetFlags(pdata.NewMetricDataPointFlags(pdata.MetricDataPointFlagNoRecordedValue))
+		return
+	}
+
+	bounds := make([]float64, 0, len(p.buckets))
+	for le := range p.buckets {
+		if !math.IsInf(le, 1) {
+			bounds = append(bounds, le)
+		}
+	}
+	sort.Float64s(bounds)
+	total, hasInf := p.buckets[math.Inf(1)]
+	if !hasInf {
+		total = p.count
+	}
+	if !p.hasCount {
+		p.count = total
+	}
+
+	counts := make([]uint64, 0, len(bounds)+1)
+	previous := 0.0
+	for _, le := range bounds {
+		counts = append(counts, bucketCount(p.buckets[le]-previous))
+		previous = p.buckets[le]
+	}
+	counts = append(counts, bucketCount(total-previous))
+
+	dp.SetCount(uint64(p.count))
+	dp.SetSum(p.sum)
+	dp.SetExplicitBounds(bounds)
+	dp.SetBucketCounts(counts)
+}
+
+func bucketCount(v float64) uint64 {
+	if v < 0 {
+		return 0
+	}
+	return uint64(v)
+}
+
+func (p *summaryPoint) toDataPoint(dp pdata.SummaryDataPoint, timestamp int64) {
+	dp.SetTimestamp(timestampFromMillis(timestamp))
+	insertLabels(dp.Attributes(), p.attrs)
+	if p.stale {
+		dp.SetFlags(pdata.NewMetricDataPointFlags(pdata.MetricDataPointFlagNoRecordedValue))
+		return
+	}
+
+	dp.SetCount(uint64(p.count))
+	dp.SetSum(p.sum)
+	quantiles := make([]float64, 0, len(p.quantiles))
+	for q := range p.quantiles {
+		quantiles = append(quantiles, q)
+	}
+	sort.Float64s(quantiles)
+	for _, q := range quantiles {
+		qv := dp.QuantileValues().AppendEmpty()
+		qv.SetQuantile(q)
+		qv.SetValue(p.quantiles[q])
+	}
+}
+
+// pointLabels returns the labels of a series that become attributes of its data points,
+// without the name, job, instance and the excluded labels.
+func pointLabels(labels []label, excluded ...string) []label {
+	attrs := make([]label, 0, len(labels))
+	for _, l := range labels {
+		switch l.name {
+		case labelMetricName, labelJob, labelInstance:
+			continue
+		}
+		if len(excluded) > 0 && l.name == excluded[0] {
+			continue
+		}
+		attrs = append(attrs, l)
+	}
+	sort.Slice(attrs, func(i, j int) bool { return attrs[i].name < attrs[j].name })
+	return attrs
+}
+
+func insertLabels(attrs pdata.Map, labels []label) {
+	for _, l := range labels {
+		attrs.UpsertString(l.name, l.value)
+	}
+}
+
+func labelsKey(labels []label) string {
+	var b strings.Builder
+	for _, l := range labels {
+		b.WriteString(l.name)
+		b.WriteByte(0)
+		b.WriteString(l.value)
+		b.WriteByte(0)
+	}
+	return b.String()
+}
+
+func labelValue(labels []label, name string) string {
+	for _, l := range labels {
+		if l.name == name {
+			return l.value
+		}
+	}
+	return ""
+}
+
+func hasLabel(labels []label, name string) bool {
+	for _, l := range labels {
+		if l.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func timestampFromMillis(ms int64) pdata.Timestamp {
+	return pdata.Timestamp(ms * int64(1e6))
+}
@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.9.0"
+)
+
+// newSeries returns a series with a single sample and the given name and label pairs.
+func newSeries(name string, value float64, timestamp int64, labelPairs ...string) timeSeries {
+	labels := []label{{name: labelMetricName, value: name}}
+	for i := 0; i+1 < len(labelPairs); i += 2 {
+		labels = append(labels, label{name: labelPairs[i], value: labelPairs[i+1]})
+	}
+	return timeSeries{labels: labels, samples: []sample{{value: value, timestamp: timestamp}}}
+}
+
+func TestToMetricsGaugesAndCounters(t *testing.T) {
+	md := toMetrics(&writeRequest{
+		timeseries: []timeSeries{
+			newSeries("node_load1", 0.5, 1000, "job", "node", "instance", "host:9100"),
+			newSeries("http_requests_total", 42, 1000, "job", "node", "instance", "host:9100", "code", "200"),
+			newSeries("up", 1, 2000, "job", "api", "instance", "api"),
+		},
+	})
+
+	require.Equal(t, 2, md.ResourceMetrics().Len())
+
+	rm := md.ResourceMetrics().At(0)
+	assert.Equal(t, map[string]interface{}{
+		conventions.AttributeServiceName:       "node",
+		conventions.AttributeServiceInstanceID: "host:9100",
+		conventions.AttributeNetHostName:       "host",
+		conventions.AttributeNetHostPort:       "9100",
+	}, rm.Resource().Attributes().AsRaw())
+	metrics := rm.ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+
+	gauge := metrics.At(0)
+	assert.Equal(t, "node_load1", gauge.Name())
+	require.Equal(t, pdata.MetricDataTypeGauge, gauge.DataType())
+	dp := gauge.Gauge().DataPoints().At(0)
+	assert.Equal(t, 0.5, dp.DoubleVal())
+	assert.Equal(t, pdata.Timestamp(1e9), dp.Timestamp())
+	assert.Equal(t, 0, dp.Attributes().Len())
+
+	sum := metrics.At(1)
+	assert.Equal(t, "http_requests_total", sum.Name())
+	require.Equal(t, pdata.MetricDataTypeSum, sum.DataType())
+	assert.Equal(t, pdata.MetricAggregationTemporalityCumulative, sum.Sum().AggregationTemporality())
+	assert.True(t, sum.Sum().IsMonotonic())
+	dp = sum.Sum().DataPoints().At(0)
+	assert.Equal(t, 42.0, dp.DoubleVal())
+	assert.Equal(t, map[string]interface{}{"code": "200"}, dp.Attributes().AsRaw())
+
+	rm = md.ResourceMetrics().At(1)
+	assert.Equal(t, map[string]interface{}{
+		conventions.AttributeServiceName:       "api",
+		conventions.AttributeServiceInstanceID: "api",
+	}, rm.Resource().Attributes().AsRaw())
+}
+
+func TestToMetricsMetadata(t *testing.T) {
+	md := toMetrics(&writeRequest{
+		timeseries: []timeSeries{
+			newSeries("process_cpu_seconds", 3, 1000),
+			newSeries("queue_size_total", 7, 1000),
+		},
+		metadata: []metricMetadata{
+			{typ: metricTypeCounter, familyName: "process_cpu_seconds", help: "CPU time.", unit: "seconds"},
+			{typ: metricTypeGauge, familyName: "queue_size_total"},
+		},
+	})
+
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+	assert.Equal(t, pdata.MetricDataTypeSum, metrics.At(0).DataType())
+	assert.Equal(t, "CPU time.", metrics.At(0).Description())
+	assert.Equal(t, "seconds", metrics.At(0).Unit())
+	assert.Equal(t, pdata.MetricDataTypeGauge, metrics.At(1).DataType())
+}
+
+func TestToMetricsHistogram(t *testing.T) {
+	md := toMetrics(&writeRequest{
+		timeseries: []timeSeries{
+			newSeries("latency_bucket", 2, 1000, "job", "api", "path", "/", "le", "0.1"),
+			newSeries("latency_bucket", 7, 1000, "job", "api", "path", "/", "le", "0.5"),
+			newSeries("latency_bucket", 8, 1000, "job", "api", "path", "/", "le", "+Inf"),
+			newSeries("latency_sum", 1.25, 1000, "job", "api", "path", "/"),
+			newSeries("latency_count", 8, 1000, "job", "api", "path", "/"),
+			newSeries("latency_bucket", 1, 1000, "job", "api", "path", "/users", "le", "0.1"),
+			newSeries("latency_bucket", 1, 1000, "job", "api", "path", "/users", "le", "0.5"),
+			newSeries("latency_bucket", 3, 1000, "job", "api", "path", "/users", "le", "+Inf"),
+			newSeries("latency_sum", 4, 1000, "job", "api", "path", "/users"),
+		},
+	})
+
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 1, metrics.Len())
+	m := metrics.At(0)
+	assert.Equal(t, "latency", m.Name())
+	require.Equal(t, pdata.MetricDataTypeHistogram, m.DataType())
+	assert.Equal(t, pdata.MetricAggregationTemporalityCumulative, m.Histogram().AggregationTemporality())
+
+	dps := m.Histogram().DataPoints()
+	require.Equal(t, 2, dps.Len())
+	dp := dps.At(0)
+	assert.Equal(t, map[string]interface{}{"path": "/"}, dp.Attributes().AsRaw())
+	assert.Equal(t, pdata.Timestamp(1e9), dp.Timestamp())
+	assert.Equal(t, uint64(8), dp.Count())
+	assert.Equal(t, 1.25, dp.Sum())
+	assert.Equal(t, []float64{0.1, 0.5}, dp.ExplicitBounds())
+	assert.Equal(t, []uint64{2, 5, 1}, dp.BucketCounts())
+
+	// Without a _count series, the count is the one of the +Inf bucket.
+	dp = dps.At(1)
+	assert.Equal(t, map[string]interface{}{"path": "/users"}, dp.Attributes().AsRaw())
+	assert.Equal(t, uint64(3), dp.Count())
+	assert.Equal(t, []uint64{1, 0, 2}, dp.BucketCounts())
+}
+
+func TestToMetricsSummary(t *testing.T) {
+	md := toMetrics(&writeRequest{
+		timeseries: []timeSeries{
+			newSeries("rpc_duration", 0.9, 1000, "quantile", "0.99"),
+			newSeries("rpc_duration", 0.2, 1000, "quantile", "0.5"),
+			newSeries("rpc_duration_sum", 30, 1000),
+			newSeries("rpc_duration_count", 100, 1000),
+		},
+	})
+
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 1, metrics.Len())
+	m := metrics.At(0)
+	assert.Equal(t, "rpc_duration", m.Name())
+	require.Equal(t, pdata.MetricDataTypeSummary, m.DataType())
+
+	dp := m.Summary().DataPoints().At(0)
+	assert.Equal(t, uint64(100), dp.Count())
+	assert.Equal(t, 30.0, dp.Sum())
+	require.Equal(t, 2, dp.QuantileValues().Len())
+	assert.Equal(t, 0.5, dp.QuantileValues().At(0).Quantile())
+	assert.Equal(t, 0.2, dp.QuantileValues().At(0).Value())
+	assert.Equal(t, 0.99, dp.QuantileValues().At(1).Quantile())
+	assert.Equal(t, 0.9, dp.QuantileValues().At(1).Value())
+}
+
+func TestToMetricsStaleMarker(t *testing.T) {
+	md := toMetrics(&writeRequest{
+		timeseries: []timeSeries{
+			newSeries("up", math.Float64frombits(staleNaN), 1000),
+			newSeries("latency_bucket", math.Float64frombits(staleNaN), 1000, "le", "+Inf"),
+		},
+	})
+
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+	assert.True(t, metrics.At(0).Gauge().DataPoints().At(0).Flags().HasFlag(pdata.MetricDataPointFlagNoRecordedValue))
+	assert.True(t, metrics.At(1).Histogram().DataPoints().At(0).Flags().HasFlag(pdata.MetricDataPointFlagNoRecordedValue))
+}
+
+func TestToMetricsSkipsSeriesWithoutName(t *testing.T) {
+	md := toMetrics(&writeRequest{
+		timeseries: []timeSeries{
+			{labels: []label{{name: "job", value: "api"}}, samples: []sample{{value: 1, timestamp: 1000}}},
+		},
+	})
+	assert.Equal(t, 0, md.DataPointCount())
+}
@@ -32,6 +32,20 @@ func (sf ScrapeFunc) Scrape(ctx context.Context) (pdata.Metrics, error) {
 	return sf(ctx)
 }
 
+// ScrapeLogsFunc scrapes logs.
+type ScrapeLogsFunc func(context.Context) (pdata.Logs, error)
+
+func (sf ScrapeLogsFunc) Scrape(ctx context.Context) (pdata.Logs, error) {
+	return sf(ctx)
+}
+
+// ScrapeTracesFunc scrapes traces.
+type ScrapeTracesFunc func(context.Context) (pdata.Traces, error)
+
+func (sf ScrapeTracesFunc) Scrape(ctx context.Context) (pdata.Traces, error) {
+	return sf(ctx)
+}
+
 // Scraper is the base interface for scrapers.
 type Scraper interface {
 	component.Component
@@ -41,6 +55,24 @@ type Scraper interface {
 	Scrape(context.Context) (pdata.Metrics, error)
 }
 
+// LogsScraper is the interface for the scrapers of logs.
+type LogsScraper interface {
+	component.Component
+
+	// ID returns the scraper id.
+	ID() config.ComponentID
+	Scrape(context.Context) (pdata.Logs, error)
+}
+
+// TracesScraper is the interface for the scrapers of traces.
+type TracesScraper interface {
+	component.Component
+
+	// ID returns the scraper id.
+	ID() config.ComponentID
+	Scrape(context.Context) (pdata.Traces, error)
+}
+
 // ScraperOption apply changes to internal options.
 type ScraperOption func(*baseScraper)
 
@@ -58,32 +90,79 @@ func WithShutdown(shutdown component.ShutdownFunc) ScraperOption {
 	}
 }
 
-var _ Scraper = (*baseScraper)(nil)
-
 type baseScraper struct {
 	component.StartFunc
 	component.ShutdownFunc
-	ScrapeFunc
 	id config.ComponentID
 }
 
+func newBaseScraper(name string, options []ScraperOption) baseScraper {
+	bs := baseScraper{
+		id: config.NewComponentID(config.Type(name)),
+	}
+	for _, op := range options {
+		op(&bs)
+	}
+	return bs
+}
+
 func (b *baseScraper) ID() config.ComponentID {
 	return b.id
 }
 
+var _ Scraper = (*metricsScraper)(nil)
+
+type metricsScraper struct {
+	baseScraper
+	ScrapeFunc
+}
+
 // NewScraper creates a Scraper that calls Scrape at the specified collection interval,
 // reports observability information, and passes the scraped metrics to the next consumer.
 func NewScraper(name string, scrape ScrapeFunc, options ...ScraperOption) (Scraper, error) {
 	if scrape == nil {
 		return nil, errNilFunc
 	}
-	bs := &baseScraper{
-		ScrapeFunc: scrape,
-		id:         config.NewComponentID(config.Type(name)),
-	}
-	for _, op := range options {
-		op(bs)
+	return &metricsScraper{
+		baseScraper: newBaseScraper(name, options),
+		ScrapeFunc:  scrape,
+	}, nil
+}
+
+var _ LogsScraper = (*logsScraper)(nil)
+
+type logsScraper struct {
+	baseScraper
+	ScrapeLogsFunc
+}
+
+// NewLogsScraper creates a LogsScraper that calls Scrape at the specified collection interval,
+// reports observability information, and passes the scraped logs to the next consumer.
+func NewLogsScraper(name string, scrape ScrapeLogsFunc, options ...ScraperOption) (LogsScraper, error) {
+	if scrape == nil {
+		return nil, errNilFunc
 	}
+	return &logsScraper{
+		baseScraper:    newBaseScraper(name, options),
+		ScrapeLogsFunc: scrape,
+	}, nil
+}
+
+var _ TracesScraper = (*tracesScraper)(nil)
 
-	return bs, nil
+type tracesScraper struct {
+	baseScraper
+	ScrapeTracesFunc
+}
+
+// NewTracesScraper creates a TracesScraper that calls Scrape at the specified collection interval,
+// reports observability information, and passes the scraped traces to the next consumer.
+func NewTracesScraper(name string, scrape ScrapeTracesFunc, options ...ScraperOption) (TracesScraper, error) {
+	if scrape == nil {
+		return nil, errNilFunc
+	}
+	return &tracesScraper{
+		baseScraper:      newBaseScraper(name, options),
+		ScrapeTracesFunc: scrape,
+	}, nil
 }
@@ -17,6 +17,9 @@ package scraperhelper // import "go.opentelemetry.io/collector/receiver/scraperh
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
 	"time"
 
 	"go.uber.org/multierr"
@@ -37,6 +40,21 @@ import (
 type ScraperControllerSettings struct {
 	config.ReceiverSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
 	CollectionInterval      time.Duration            `mapstructure:"collection_interval"`
+
+	// Timeout is the duration after which a scrape is abandoned and reported as
+	// timed out. Defaults to the collection interval of the scraper if zero.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// InitialDelay is the delay before the first scrape.
+	InitialDelay time.Duration `mapstructure:"initial_delay"`
+
+	// Jitter is the maximum random duration added to the initial delay of each
+	// scraper, to spread the scrapes of the receivers started together.
+	Jitter time.Duration `mapstructure:"jitter"`
+
+	// MaxConcurrentScrapes is the maximum number of scrapes running at the same
+	// time, zero meaning no limit.
+	MaxConcurrentScrapes int `mapstructure:"max_concurrent_scrapes"`
 }
 
 // NewDefaultScraperControllerSettings returns default scraper controller
@@ -51,14 +69,67 @@ func NewDefaultScraperControllerSettings(cfgType config.Type) ScraperControllerS
 // ScraperControllerOption apply changes to internal options.
 type ScraperControllerOption func(*controller)
 
+// ScheduleOption overrides the settings of the controller for one scraper.
+type ScheduleOption func(*scheduledScraper)
+
+// WithCollectionInterval overrides the collection interval of the controller
+// for the scraper.
+func WithCollectionInterval(interval time.Duration) ScheduleOption {
+	return func(s *scheduledScraper) {
+		s.interval = interval
+	}
+}
+
+// WithTimeout overrides the timeout of the controller for the scraper.
+func WithTimeout(timeout time.Duration) ScheduleOption {
+	return func(s *scheduledScraper) {
+		s.timeout = timeout
+	}
+}
+
 // AddScraper configures the provided scrape function to be called
 // with the specified options, and at the specified collection interval.
 //
 // Observability information will be reported, and the scraped metrics
 // will be passed to the next consumer.
-func AddScraper(scraper Scraper) ScraperControllerOption {
+func AddScraper(scraper Scraper, options ...ScheduleOption) ScraperControllerOption {
+	return addScraper(scraper, scraper.ID(), config.MetricsDataType, func(ctx context.Context) scrapeResult {
+		md, err := scraper.Scrape(ctx)
+		return scrapeResult{metrics: md, err: err}
+	}, options)
+}
+
+// AddLogsScraper configures the provided logs scraper, like AddScraper, in a
+// controller created with NewLogsScraperControllerReceiver.
+func AddLogsScraper(scraper LogsScraper, options ...ScheduleOption) ScraperControllerOption {
+	return addScraper(scraper, scraper.ID(), config.LogsDataType, func(ctx context.Context) scrapeResult {
+		ld, err := scraper.Scrape(ctx)
+		return scrapeResult{logs: ld, err: err}
+	}, options)
+}
+
+// AddTracesScraper configures the provided traces scraper, like AddScraper, in a
+// controller created with NewTracesScraperControllerReceiver.
+func AddTracesScraper(scraper TracesScraper, options ...ScheduleOption) ScraperControllerOption {
+	return addScraper(scraper, scraper.ID(), config.TracesDataType, func(ctx context.Context) scrapeResult {
+		td, err := scraper.Scrape(ctx)
+		return scrapeResult{traces: td, err: err}
+	}, options)
+}
+
+func addScraper(
+	scraper component.Component,
+	id config.ComponentID,
+	dataType config.DataType,
+	scrape func(context.Context) scrapeResult,
+	options []ScheduleOption,
+) ScraperControllerOption {
 	return func(o *controller) {
-		o.scrapers = append(o.scrapers, scraper)
+		s := &scheduledScraper{Component: scraper, id: id, dataType: dataType, scrape: scrape}
+		for _, op := range options {
+			op(s)
+		}
+		o.scrapers = append(o.scrapers, s)
 	}
 }
 
@@ -71,22 +142,51 @@ func WithTickerChannel(tickerCh <-chan time.Time) ScraperControllerOption {
 	}
 }
 
-type controller struct {
-	id                 config.ComponentID
-	logger             *zap.Logger
-	collectionInterval time.Duration
-	nextConsumer       consumer.Metrics
+// scheduledScraper is a scraper with its own collection interval and timeout.
+type scheduledScraper struct {
+	component.Component
+	id       config.ComponentID
+	dataType config.DataType
+	scrape   func(context.Context) scrapeResult
+
+	interval time.Duration
+	timeout  time.Duration
+
+	obsrecv *obsreport.Scraper
+	// busy holds a token while a scrape is running, including a timed out scrape
+	// which has not returned yet.
+	busy chan struct{}
+	// tickerCh receives the ticks of WithTickerChannel.
+	tickerCh chan time.Time
+}
 
-	scrapers []Scraper
+type controller struct {
+	id           config.ComponentID
+	logger       *zap.Logger
+	initialDelay time.Duration
+	jitter       time.Duration
+
+	// dataType is the type of the scraped data, passed to the next consumer of
+	// this type.
+	dataType    config.DataType
+	nextMetrics consumer.Metrics
+	nextLogs    consumer.Logs
+	nextTraces  consumer.Traces
+
+	scrapers []*scheduledScraper
+	// workers holds a token for each running scrape, if their number is limited.
+	workers chan struct{}
 
 	tickerCh <-chan time.Time
 
 	initialized bool
 	done        chan struct{}
-	terminated  chan struct{}
+	terminated  sync.WaitGroup
+	// scrapes tracks the running scrapes, including the timed out scrapes which
+	// have not returned yet.
+	scrapes sync.WaitGroup
 
-	obsrecv      *obsreport.Receiver
-	recvSettings component.ReceiverCreateSettings
+	obsrecv *obsreport.Receiver
 }
 
 // NewScraperControllerReceiver creates a Receiver with the configured options, that can control multiple scrapers.
@@ -99,30 +199,98 @@ func NewScraperControllerReceiver(
 	if nextConsumer == nil {
 		return nil, componenterror.ErrNilNextConsumer
 	}
+	return newController(cfg, set, &controller{dataType: config.MetricsDataType, nextMetrics: nextConsumer}, options)
+}
 
+// NewLogsScraperControllerReceiver creates a Receiver with the configured options, that can control
+// multiple logs scrapers added with AddLogsScraper.
+func NewLogsScraperControllerReceiver(
+	cfg *ScraperControllerSettings,
+	set component.ReceiverCreateSettings,
+	nextConsumer consumer.Logs,
+	options ...ScraperControllerOption,
+) (component.Receiver, error) {
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+	return newController(cfg, set, &controller{dataType: config.LogsDataType, nextLogs: nextConsumer}, options)
+}
+
+// NewTracesScraperControllerReceiver creates a Receiver with the configured options, that can control
+// multiple traces scrapers added with AddTracesScraper.
+func NewTracesScraperControllerReceiver(
+	cfg *ScraperControllerSettings,
+	set component.ReceiverCreateSettings,
+	nextConsumer consumer.Traces,
+	options ...ScraperControllerOption,
+) (component.Receiver, error) {
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+	return newController(cfg, set, &controller{dataType: config.TracesDataType, nextTraces: nextConsumer}, options)
+}
+
+func newController(
+	cfg *ScraperControllerSettings,
+	set component.ReceiverCreateSettings,
+	sc *controller,
+	options []ScraperControllerOption,
+) (*controller, error) {
 	if cfg.CollectionInterval <= 0 {
 		return nil, errors.New("collection_interval must be a positive duration")
 	}
+	if cfg.Timeout < 0 || cfg.InitialDelay < 0 || cfg.Jitter < 0 {
+		return nil, errors.New("timeout, initial_delay and jitter must not be negative")
+	}
+	if cfg.MaxConcurrentScrapes < 0 {
+		return nil, errors.New("max_concurrent_scrapes must not be negative")
+	}
 
-	sc := &controller{
-		id:                 cfg.ID(),
-		logger:             set.Logger,
-		collectionInterval: cfg.CollectionInterval,
-		nextConsumer:       nextConsumer,
-		done:               make(chan struct{}),
-		terminated:         make(chan struct{}),
-		obsrecv: obsreport.NewReceiver(obsreport.ReceiverSettings{
-			ReceiverID:             cfg.ID(),
-			Transport:              "",
-			ReceiverCreateSettings: set,
-		}),
-		recvSettings: set,
+	sc.id = cfg.ID()
+	sc.logger = set.Logger
+	sc.initialDelay = cfg.InitialDelay
+	sc.jitter = cfg.Jitter
+	sc.done = make(chan struct{})
+	sc.obsrecv = obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID:             cfg.ID(),
+		Transport:              "",
+		ReceiverCreateSettings: set,
+	})
+	if cfg.MaxConcurrentScrapes > 0 {
+		sc.workers = make(chan struct{}, cfg.MaxConcurrentScrapes)
 	}
 
 	for _, op := range options {
 		op(sc)
 	}
 
+	for _, s := range sc.scrapers {
+		if s.dataType != sc.dataType {
+			return nil, fmt.Errorf("%s scraper %q cannot be added to a %s scraper controller", s.dataType, s.id, sc.dataType)
+		}
+		if s.interval == 0 {
+			s.interval = cfg.CollectionInterval
+		}
+		if s.interval < 0 {
+			return nil, fmt.Errorf("collection interval of scraper %q must be a positive duration", s.id)
+		}
+		if s.timeout == 0 {
+			s.timeout = cfg.Timeout
+		}
+		if s.timeout == 0 {
+			s.timeout = s.interval
+		}
+		if s.timeout < 0 {
+			return nil, fmt.Errorf("timeout of scraper %q must be a positive duration", s.id)
+		}
+		s.busy = make(chan struct{}, 1)
+		s.obsrecv = obsreport.NewScraper(obsreport.ScraperSettings{
+			ReceiverID:             sc.id,
+			Scraper:                s.id,
+			ReceiverCreateSettings: set,
+		})
+	}
+
 	return sc, nil
 }
 
@@ -143,9 +311,22 @@ func (sc *controller) Start(ctx context.Context, host component.Host) error {
 func (sc *controller) Shutdown(ctx context.Context) error {
 	sc.stopScraping()
 
-	// wait until scraping ticker has terminated
+	// wait until the scraping loops have terminated
 	if sc.initialized {
-		<-sc.terminated
+		sc.terminated.Wait()
+	}
+
+	// wait until the abandoned scrapes have returned, so that the scrapers are not
+	// shut down while they are scraping
+	scrapesDone := make(chan struct{})
+	go func() {
+		sc.scrapes.Wait()
+		close(scrapesDone)
+	}()
+	select {
+	case <-scrapesDone:
+	case <-ctx.Done():
+		return fmt.Errorf("failed to wait for the running scrapes: %w", ctx.Err())
 	}
 
 	var errs error
@@ -156,62 +337,194 @@ func (sc *controller) Shutdown(ctx context.Context) error {
 	return errs
 }
 
-// startScraping initiates a ticker that calls Scrape based on the configured
-// collection interval.
+// startScraping starts a loop for each scraper, calling Scrape after the initial
+// delay and then at the collection interval of the scraper.
 func (sc *controller) startScraping() {
-	go func() {
-		if sc.tickerCh == nil {
-			ticker := time.NewTicker(sc.collectionInterval)
-			defer ticker.Stop()
+	if sc.tickerCh != nil {
+		// Each tick is forwarded to all the scrapers, unless they are still
+		// handling the previous one.
+		for _, s := range sc.scrapers {
+			s.tickerCh = make(chan time.Time, 1)
+		}
+		go func() {
+			for {
+				select {
+				case t := <-sc.tickerCh:
+					for _, s := range sc.scrapers {
+						select {
+						case s.tickerCh <- t:
+						default:
+						}
+					}
+				case <-sc.done:
+					return
+				}
+			}
+		}()
+	}
+
+	for _, s := range sc.scrapers {
+		sc.terminated.Add(1)
+		go func(s *scheduledScraper) {
+			defer sc.terminated.Done()
+			sc.runScraper(s)
+		}(s)
+	}
+}
 
-			sc.tickerCh = ticker.C
+func (sc *controller) runScraper(s *scheduledScraper) {
+	tickerCh := (<-chan time.Time)(s.tickerCh)
+	if tickerCh == nil {
+		delay := sc.initialDelay
+		if sc.jitter > 0 {
+			// #nosec G404
+			delay += time.Duration(rand.Int63n(int64(sc.jitter) + 1))
 		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-sc.done:
+			timer.Stop()
+			return
+		}
+		sc.scrapeAndReport(context.Background(), s)
 
-		for {
-			select {
-			case <-sc.tickerCh:
-				sc.scrapeMetricsAndReport(context.Background())
-			case <-sc.done:
-				sc.terminated <- struct{}{}
-				return
-			}
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		tickerCh = ticker.C
+	}
+
+	for {
+		select {
+		case <-tickerCh:
+			sc.scrapeAndReport(context.Background(), s)
+		case <-sc.done:
+			return
 		}
-	}()
+	}
 }
 
-// scrapeMetricsAndReport calls the Scrape function for each of the configured
-// Scrapers, records observability information, and passes the scraped metrics
-// to the next component.
-func (sc *controller) scrapeMetricsAndReport(ctx context.Context) {
-	metrics := pdata.NewMetrics()
+// scrapeResult holds the data scraped by a scraper, of the type of the controller.
+type scrapeResult struct {
+	metrics pdata.Metrics
+	logs    pdata.Logs
+	traces  pdata.Traces
+	err     error
+}
 
-	for _, scraper := range sc.scrapers {
-		scrp := obsreport.NewScraper(obsreport.ScraperSettings{
-			ReceiverID:             sc.id,
-			Scraper:                scraper.ID(),
-			ReceiverCreateSettings: sc.recvSettings,
-		})
-		ctx = scrp.StartMetricsOp(ctx)
-		md, err := scraper.Scrape(ctx)
+// scrapeAndReport calls the Scrape function of a scraper, records
+// observability information, and passes the scraped data to the next
+// component. The scrape is skipped if the previous one has not returned yet, and
+// abandoned after the timeout of the scraper.
+func (sc *controller) scrapeAndReport(ctx context.Context, s *scheduledScraper) {
+	select {
+	case s.busy <- struct{}{}:
+	default:
+		sc.logger.Warn("Skipping scrape, the previous scrape is still running", zap.Stringer("scraper", s.id))
+		return
+	}
+	if sc.workers != nil {
+		select {
+		case sc.workers <- struct{}{}:
+		case <-sc.done:
+			<-s.busy
+			return
+		}
+	}
 
-		if err != nil {
-			sc.logger.Error("Error scraping metrics", zap.Error(err), zap.Stringer("scraper", scraper.ID()))
-			if !scrapererror.IsPartialScrapeError(err) {
-				scrp.EndMetricsOp(ctx, 0, err)
-				continue
-			}
+	ctx = sc.startScrapeOp(ctx, s)
+	scrapeCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	resultCh := make(chan scrapeResult, 1)
+	sc.scrapes.Add(1)
+	go func() {
+		defer sc.scrapes.Done()
+		result := s.scrape(scrapeCtx)
+		if sc.workers != nil {
+			<-sc.workers
+		}
+		<-s.busy
+		resultCh <- result
+	}()
+
+	var result scrapeResult
+	select {
+	case result = <-resultCh:
+	case <-scrapeCtx.Done():
+		select {
+		case result = <-resultCh:
+		default:
+			result = scrapeResult{err: fmt.Errorf("scrape timed out after %v: %w", s.timeout, scrapeCtx.Err())}
+		}
+	case <-sc.done:
+		sc.endScrapeOp(ctx, s, 0, context.Canceled)
+		return
+	}
+
+	if result.err != nil {
+		sc.logger.Error("Error scraping "+string(sc.dataType), zap.Error(result.err), zap.Stringer("scraper", s.id))
+	}
+	// The data of a failed scrape is replaced by empty data, unless the scrape
+	// partially failed.
+	failed := result.err != nil && !scrapererror.IsPartialScrapeError(result.err)
+
+	switch sc.dataType {
+	case config.MetricsDataType:
+		md := result.metrics
+		if failed {
+			md = pdata.NewMetrics()
+		}
+		sc.endScrapeOp(ctx, s, md.MetricCount(), result.err)
+		dataPointCount := md.DataPointCount()
+		ctx = sc.obsrecv.StartMetricsOp(ctx)
+		err := sc.nextMetrics.ConsumeMetrics(ctx, md)
+		sc.obsrecv.EndMetricsOp(ctx, "", dataPointCount, err)
+	case config.LogsDataType:
+		ld := result.logs
+		if failed {
+			ld = pdata.NewLogs()
 		}
-		scrp.EndMetricsOp(ctx, md.MetricCount(), err)
-		md.ResourceMetrics().MoveAndAppendTo(metrics.ResourceMetrics())
+		logRecordCount := ld.LogRecordCount()
+		sc.endScrapeOp(ctx, s, logRecordCount, result.err)
+		ctx = sc.obsrecv.StartLogsOp(ctx)
+		err := sc.nextLogs.ConsumeLogs(ctx, ld)
+		sc.obsrecv.EndLogsOp(ctx, "", logRecordCount, err)
+	case config.TracesDataType:
+		td := result.traces
+		if failed {
+			td = pdata.NewTraces()
+		}
+		spanCount := td.SpanCount()
+		sc.endScrapeOp(ctx, s, spanCount, result.err)
+		ctx = sc.obsrecv.StartTracesOp(ctx)
+		err := sc.nextTraces.ConsumeTraces(ctx, td)
+		sc.obsrecv.EndTracesOp(ctx, "", spanCount, err)
+	}
+}
+
+func (sc *controller) startScrapeOp(ctx context.Context, s *scheduledScraper) context.Context {
+	switch sc.dataType {
+	case config.LogsDataType:
+		return s.obsrecv.StartLogsOp(ctx)
+	case config.TracesDataType:
+		return s.obsrecv.StartTracesOp(ctx)
+	default:
+		return s.obsrecv.StartMetricsOp(ctx)
 	}
+}
 
-	dataPointCount := metrics.DataPointCount()
-	ctx = sc.obsrecv.StartMetricsOp(ctx)
-	err := sc.nextConsumer.ConsumeMetrics(ctx, metrics)
-	sc.obsrecv.EndMetricsOp(ctx, "", dataPointCount, err)
+func (sc *controller) endScrapeOp(ctx context.Context, s *scheduledScraper, numScrapedItems int, err error) {
+	switch sc.dataType {
+	case config.LogsDataType:
+		s.obsrecv.EndLogsOp(ctx, numScrapedItems, err)
+	case config.TracesDataType:
+		s.obsrecv.EndTracesOp(ctx, numScrapedItems, err)
+	default:
+		s.obsrecv.EndMetricsOp(ctx, numScrapedItems, err)
+	}
 }
 
-// stopScraping stops the ticker
+// stopScraping stops the scraping loops
 func (sc *controller) stopScraping() {
 	close(sc.done)
 }
@@ -17,6 +17,7 @@ package scraperhelper
 import (
 	"context"
 	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -115,6 +116,18 @@ func TestScrapeController(t *testing.T) {
 			scraperControllerSettings: &ScraperControllerSettings{CollectionInterval: -time.Millisecond},
 			expectedNewErr:            "collection_interval must be a positive duration",
 		},
+		{
+			name:                      "AddMetricsScrapers_NegativeTimeoutError",
+			scrapers:                  2,
+			scraperControllerSettings: &ScraperControllerSettings{CollectionInterval: time.Second, Timeout: -time.Second},
+			expectedNewErr:            "timeout, initial_delay and jitter must not be negative",
+		},
+		{
+			name:                      "AddMetricsScrapers_NegativeMaxConcurrentScrapesError",
+			scrapers:                  2,
+			scraperControllerSettings: &ScraperControllerSettings{CollectionInterval: time.Second, MaxConcurrentScrapes: -1},
+			expectedNewErr:            "max_concurrent_scrapes must not be negative",
+		},
 		{
 			name:      "AddMetricsScrapers_ScrapeError",
 			scrapers:  2,
@@ -362,3 +375,268 @@ func TestSingleScrapePerTick(t *testing.T) {
 		return
 	}
 }
+
+func TestScrapeTimeout(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	// The scraper ignores the cancellation of its context, and returns once released.
+	started := make(chan struct{}, 10)
+	release := make(chan struct{})
+	scp, err := NewScraper("scraper", func(context.Context) (pdata.Metrics, error) {
+		started <- struct{}{}
+		<-release
+		return pdata.NewMetrics(), nil
+	})
+	require.NoError(t, err)
+
+	tickerCh := make(chan time.Time)
+	cfg := NewDefaultScraperControllerSettings("receiver")
+	sink := new(consumertest.MetricsSink)
+	receiver, err := NewScraperControllerReceiver(&cfg, tt.ToReceiverCreateSettings(), sink,
+		AddScraper(scp, WithTimeout(10*time.Millisecond)), WithTickerChannel(tickerCh))
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+
+	tickerCh <- time.Now()
+	<-started
+	require.Eventually(t, func() bool { return len(sink.AllMetrics()) == 1 }, time.Second, time.Millisecond)
+	require.NoError(t, obsreporttest.CheckScraperTimedOutScrapes(tt, config.NewComponentID("receiver"), config.NewComponentID("scraper"), 1))
+
+	// The next tick is skipped while the timed out scrape has not returned.
+	tickerCh <- time.Now()
+	select {
+	case <-started:
+		assert.Fail(t, "Scrape was called while the previous one was running")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+	require.Eventually(t, func() bool {
+		tickerCh <- time.Now()
+		return len(sink.AllMetrics()) == 2
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, receiver.Shutdown(context.Background()))
+}
+
+func TestShutdownWaitsForScrapes(t *testing.T) {
+	// The scraper ignores the cancellation of its context, and returns once released.
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var scraping, shutdownWhileScraping int32
+	scp, err := NewScraper("scraper", func(context.Context) (pdata.Metrics, error) {
+		atomic.StoreInt32(&scraping, 1)
+		close(started)
+		<-release
+		atomic.StoreInt32(&scraping, 0)
+		return pdata.NewMetrics(), nil
+	}, WithShutdown(func(context.Context) error {
+		atomic.StoreInt32(&shutdownWhileScraping, atomic.LoadInt32(&scraping))
+		return nil
+	}))
+	require.NoError(t, err)
+
+	tickerCh := make(chan time.Time)
+	cfg := NewDefaultScraperControllerSettings("receiver")
+	receiver, err := NewScraperControllerReceiver(&cfg, componenttest.NewNopReceiverCreateSettings(), new(consumertest.MetricsSink),
+		AddScraper(scp, WithTimeout(10*time.Millisecond)), WithTickerChannel(tickerCh))
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+
+	tickerCh <- time.Now()
+	<-started
+
+	shutdownDone := make(chan error, 1)
+	go func() { shutdownDone <- receiver.Shutdown(context.Background()) }()
+	select {
+	case err = <-shutdownDone:
+		assert.Fail(t, "Shutdown returned while a scrape was running")
+	case <-time.After(20 * time.Millisecond):
+		close(release)
+		err = <-shutdownDone
+	}
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&shutdownWhileScraping))
+}
+
+func TestShutdownScrapesDeadline(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	scp, err := NewScraper("scraper", func(context.Context) (pdata.Metrics, error) {
+		close(started)
+		<-release
+		return pdata.NewMetrics(), nil
+	})
+	require.NoError(t, err)
+
+	tickerCh := make(chan time.Time)
+	cfg := NewDefaultScraperControllerSettings("receiver")
+	receiver, err := NewScraperControllerReceiver(&cfg, componenttest.NewNopReceiverCreateSettings(), new(consumertest.MetricsSink),
+		AddScraper(scp, WithTimeout(10*time.Millisecond)), WithTickerChannel(tickerCh))
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+
+	tickerCh <- time.Now()
+	<-started
+
+	// The shutdown fails if the timed out scrape does not return before its deadline.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, receiver.Shutdown(ctx), context.DeadlineExceeded)
+}
+
+func TestScraperCollectionIntervals(t *testing.T) {
+	var fastScrapes, slowScrapes int64
+	fast, err := NewScraper("fast", func(context.Context) (pdata.Metrics, error) {
+		atomic.AddInt64(&fastScrapes, 1)
+		return pdata.NewMetrics(), nil
+	})
+	require.NoError(t, err)
+	slow, err := NewScraper("slow", func(context.Context) (pdata.Metrics, error) {
+		atomic.AddInt64(&slowScrapes, 1)
+		return pdata.NewMetrics(), nil
+	})
+	require.NoError(t, err)
+
+	cfg := NewDefaultScraperControllerSettings("receiver")
+	cfg.CollectionInterval = time.Hour
+	cfg.Jitter = 10 * time.Millisecond
+	receiver, err := NewScraperControllerReceiver(&cfg, componenttest.NewNopReceiverCreateSettings(), new(consumertest.MetricsSink),
+		AddScraper(fast, WithCollectionInterval(5*time.Millisecond)), AddScraper(slow))
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+
+	// Both scrapers scrape after the initial delay, then only the fast one does.
+	require.Eventually(t, func() bool { return atomic.LoadInt64(&fastScrapes) >= 3 }, time.Second, time.Millisecond)
+	require.NoError(t, receiver.Shutdown(context.Background()))
+	assert.Equal(t, int64(1), atomic.LoadInt64(&slowScrapes))
+}
+
+func TestMaxConcurrentScrapes(t *testing.T) {
+	var running, maxRunning int64
+	scrape := func(context.Context) (pdata.Metrics, error) {
+		n := atomic.AddInt64(&running, 1)
+		defer atomic.AddInt64(&running, -1)
+		for {
+			m := atomic.LoadInt64(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt64(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		return pdata.NewMetrics(), nil
+	}
+
+	tickerCh := make(chan time.Time)
+	options := []ScraperControllerOption{WithTickerChannel(tickerCh)}
+	for i := 0; i < 4; i++ {
+		scp, err := NewScraper("scraper", scrape)
+		require.NoError(t, err)
+		options = append(options, AddScraper(scp))
+	}
+	cfg := NewDefaultScraperControllerSettings("receiver")
+	cfg.MaxConcurrentScrapes = 2
+	sink := new(consumertest.MetricsSink)
+	receiver, err := NewScraperControllerReceiver(&cfg, componenttest.NewNopReceiverCreateSettings(), sink, options...)
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+
+	tickerCh <- time.Now()
+	require.Eventually(t, func() bool { return len(sink.AllMetrics()) == 4 }, time.Second, time.Millisecond)
+	require.NoError(t, receiver.Shutdown(context.Background()))
+	assert.Equal(t, int64(2), atomic.LoadInt64(&maxRunning))
+}
+
+func TestLogsScrapeController(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	scrapeErr := scrapererror.NewPartialScrapeError(errors.New("one page failed"), 1)
+	scp, err := NewLogsScraper("scraper", func(context.Context) (pdata.Logs, error) {
+		ld := pdata.NewLogs()
+		lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+		lrs.AppendEmpty()
+		lrs.AppendEmpty()
+		return ld, scrapeErr
+	})
+	require.NoError(t, err)
+
+	tickerCh := make(chan time.Time)
+	cfg := NewDefaultScraperControllerSettings("receiver")
+	sink := new(consumertest.LogsSink)
+	receiver, err := NewLogsScraperControllerReceiver(&cfg, tt.ToReceiverCreateSettings(), sink,
+		AddLogsScraper(scp), WithTickerChannel(tickerCh))
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+
+	tickerCh <- time.Now()
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 2 }, time.Second, time.Millisecond)
+	require.NoError(t, receiver.Shutdown(context.Background()))
+
+	var scraperSpan bool
+	for _, span := range tt.SpanRecorder.Ended() {
+		if span.Name() == "scraper/receiver/scraper/LogsScraped" {
+			scraperSpan = true
+			assert.Equal(t, codes.Error, span.Status().Code)
+		}
+	}
+	assert.True(t, scraperSpan)
+	require.NoError(t, obsreporttest.CheckScraperLogs(tt, config.NewComponentID("receiver"), config.NewComponentID("scraper"), 2, 1))
+	require.NoError(t, obsreporttest.CheckReceiverLogs(tt, config.NewComponentID("receiver"), "", 2, 0))
+}
+
+func TestTracesScrapeController(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	scrapes := 0
+	scp, err := NewTracesScraper("scraper", func(context.Context) (pdata.Traces, error) {
+		scrapes++
+		if scrapes == 1 {
+			return pdata.Traces{}, errors.New("unavailable")
+		}
+		td := pdata.NewTraces()
+		td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+		return td, nil
+	})
+	require.NoError(t, err)
+
+	tickerCh := make(chan time.Time)
+	cfg := NewDefaultScraperControllerSettings("receiver")
+	sink := new(consumertest.TracesSink)
+	receiver, err := NewTracesScraperControllerReceiver(&cfg, tt.ToReceiverCreateSettings(), sink,
+		AddTracesScraper(scp), WithTickerChannel(tickerCh))
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+
+	tickerCh <- time.Now()
+	require.Eventually(t, func() bool { return len(sink.AllTraces()) == 1 }, time.Second, time.Millisecond)
+	tickerCh <- time.Now()
+	require.Eventually(t, func() bool { return len(sink.AllTraces()) == 2 }, time.Second, time.Millisecond)
+	require.NoError(t, receiver.Shutdown(context.Background()))
+
+	// The failed scrape passes empty traces to the next consumer.
+	assert.Equal(t, 1, sink.SpanCount())
+	require.NoError(t, obsreporttest.CheckScraperTraces(tt, config.NewComponentID("receiver"), config.NewComponentID("scraper"), 1, 0))
+	require.NoError(t, obsreporttest.CheckReceiverTraces(tt, config.NewComponentID("receiver"), "", 1, 0))
+}
+
+func TestScrapeControllerDataTypes(t *testing.T) {
+	cfg := NewDefaultScraperControllerSettings("receiver")
+	set := componenttest.NewNopReceiverCreateSettings()
+
+	_, err := NewLogsScraper("scraper", nil)
+	assert.Error(t, err)
+	_, err = NewTracesScraper("scraper", nil)
+	assert.Error(t, err)
+
+	scp, err := NewLogsScraper("scraper", func(context.Context) (pdata.Logs, error) { return pdata.NewLogs(), nil })
+	require.NoError(t, err)
+	_, err = NewScraperControllerReceiver(&cfg, set, consumertest.NewNop(), AddLogsScraper(scp))
+	assert.EqualError(t, err, `logs scraper "scraper" cannot be added to a metrics scraper controller`)
+	_, err = NewTracesScraperControllerReceiver(&cfg, set, nil, AddLogsScraper(scp))
+	assert.EqualError(t, err, "nil nextConsumer")
+}
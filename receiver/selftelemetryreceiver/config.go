@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selftelemetryreceiver // import "go.opentelemetry.io/collector/receiver/selftelemetryreceiver"
+
+import (
+	"fmt"
+	"regexp"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+)
+
+// Config defines configuration for the self telemetry receiver.
+type Config struct {
+	scraperhelper.ScraperControllerSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// IncludeMetrics are regular expressions matching the names of the emitted
+	// metrics, all the metrics being emitted if empty.
+	IncludeMetrics []string `mapstructure:"include_metrics"`
+}
+
+var _ config.Receiver = (*Config)(nil)
+
+// Validate checks the receiver configuration is valid
+func (cfg *Config) Validate() error {
+	if _, err := compileRegexps(cfg.IncludeMetrics); err != nil {
+		return fmt.Errorf("invalid include_metrics: %w", err)
+	}
+	return nil
+}
+
+func compileRegexps(exprs []string) ([]*regexp.Regexp, error) {
+	regexps := make([]*regexp.Regexp, 0, len(exprs))
+	for _, expr := range exprs {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, err
+		}
+		regexps = append(regexps, re)
+	}
+	return regexps, nil
+}
@@ -0,0 +1,224 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selftelemetryreceiver // import "go.opentelemetry.io/collector/receiver/selftelemetryreceiver"
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"go.opencensus.io/metric/metricdata"
+	"go.opencensus.io/metric/metricproducer"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
+	"go.opentelemetry.io/collector/model/pdata"
+	semconv "go.opentelemetry.io/collector/model/semconv/v1.9.0"
+	"go.opentelemetry.io/collector/service/featuregate"
+)
+
+// metricNamespace prefixes the names of the metrics, as on the Prometheus endpoint
+// of the collector.
+const metricNamespace = "otelcol"
+
+// selfScraper reads the metrics of the producers registered in the global
+// OpenCensus manager, where the views and the registries of the collector are.
+type selfScraper struct {
+	include   []*regexp.Regexp
+	buildInfo component.BuildInfo
+
+	// producers returns the producers to read, overridden by the tests.
+	producers func() []metricproducer.Producer
+}
+
+func newSelfScraper(cfg *Config, buildInfo component.BuildInfo) (*selfScraper, error) {
+	include, err := compileRegexps(cfg.IncludeMetrics)
+	if err != nil {
+		return nil, err
+	}
+	return &selfScraper{
+		include:   include,
+		buildInfo: buildInfo,
+		producers: metricproducer.GlobalManager().GetAll,
+	}, nil
+}
+
+// start refuses to run when the internal metrics are recorded with OpenTelemetry,
+// since they cannot be read from the meter provider of the collector.
+func (s *selfScraper) start(context.Context, component.Host) error {
+	if featuregate.IsEnabled(obsreportconfig.UseOtelForInternalMetricsfeatureGateID) {
+		return errors.New("the internal metrics cannot be read when the " +
+			obsreportconfig.UseOtelForInternalMetricsfeatureGateID + " feature gate is enabled")
+	}
+	return nil
+}
+
+func (s *selfScraper) scrape(context.Context) (pdata.Metrics, error) {
+	md := pdata.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	if s.buildInfo.Command != "" {
+		rm.Resource().Attributes().UpsertString(semconv.AttributeServiceName, s.buildInfo.Command)
+	}
+	if s.buildInfo.Version != "" {
+		rm.Resource().Attributes().UpsertString(semconv.AttributeServiceVersion, s.buildInfo.Version)
+	}
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+	for _, producer := range s.producers() {
+		for _, metric := range producer.Read() {
+			name := metricName(metric.Descriptor.Name)
+			if s.included(name) {
+				appendMetric(ms, name, metric)
+			}
+		}
+	}
+	return md, nil
+}
+
+func (s *selfScraper) included(name string) bool {
+	if len(s.include) == 0 {
+		return true
+	}
+	for _, re := range s.include {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// metricName returns the name of a metric on the Prometheus endpoint of the
+// collector, for example "otelcol_receiver_accepted_spans" for the
+// "receiver/accepted_spans" view.
+func metricName(name string) string {
+	return metricNamespace + "_" + strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) || unicode.IsLetter(r) || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// appendMetric converts an OpenCensus metric and appends it to the metric slice.
+// The gauge distributions, which have no equivalent, are skipped.
+func appendMetric(ms pdata.MetricSlice, name string, metric *metricdata.Metric) {
+	desc := metric.Descriptor
+	if desc.Type == metricdata.TypeGaugeDistribution {
+		return
+	}
+	m := ms.AppendEmpty()
+	m.SetName(name)
+	m.SetDescription(desc.Description)
+	m.SetUnit(string(desc.Unit))
+
+	switch desc.Type {
+	case metricdata.TypeGaugeInt64, metricdata.TypeGaugeFloat64:
+		m.SetDataType(pdata.MetricDataTypeGauge)
+		for _, ts := range metric.TimeSeries {
+			for _, p := range ts.Points {
+				setNumberDataPoint(m.Gauge().DataPoints().AppendEmpty(), desc.LabelKeys, ts, p, false)
+			}
+		}
+	case metricdata.TypeCumulativeInt64, metricdata.TypeCumulativeFloat64:
+		m.SetDataType(pdata.MetricDataTypeSum)
+		m.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		m.Sum().SetIsMonotonic(true)
+		for _, ts := range metric.TimeSeries {
+			for _, p := range ts.Points {
+				setNumberDataPoint(m.Sum().DataPoints().AppendEmpty(), desc.LabelKeys, ts, p, true)
+			}
+		}
+	case metricdata.TypeCumulativeDistribution:
+		m.SetDataType(pdata.MetricDataTypeHistogram)
+		m.Histogram().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		for _, ts := range metric.TimeSeries {
+			for _, p := range ts.Points {
+				if dist, ok := p.Value.(*metricdata.Distribution); ok {
+					setHistogramDataPoint(m.Histogram().DataPoints().AppendEmpty(), desc.LabelKeys, ts, p, dist)
+				}
+			}
+		}
+	case metricdata.TypeSummary:
+		m.SetDataType(pdata.MetricDataTypeSummary)
+		for _, ts := range metric.TimeSeries {
+			for _, p := range ts.Points {
+				if summary, ok := p.Value.(*metricdata.Summary); ok {
+					setSummaryDataPoint(m.Summary().DataPoints().AppendEmpty(), desc.LabelKeys, ts, p, summary)
+				}
+			}
+		}
+	}
+}
+
+// setAttributes sets the labels of a time series with a value as attributes.
+func setAttributes(attrs pdata.Map, keys []metricdata.LabelKey, values []metricdata.LabelValue) {
+	for i, key := range keys {
+		if i < len(values) && values[i].Present {
+			attrs.UpsertString(key.Key, values[i].Value)
+		}
+	}
+}
+
+func setNumberDataPoint(dp pdata.NumberDataPoint, keys []metricdata.LabelKey, ts *metricdata.TimeSeries, p metricdata.Point, cumulative bool) {
+	setAttributes(dp.Attributes(), keys, ts.LabelValues)
+	if cumulative {
+		dp.SetStartTimestamp(pdata.NewTimestampFromTime(ts.StartTime))
+	}
+	dp.SetTimestamp(pdata.NewTimestampFromTime(p.Time))
+	switch v := p.Value.(type) {
+	case int64:
+		dp.SetIntVal(v)
+	case float64:
+		dp.SetDoubleVal(v)
+	}
+}
+
+func setHistogramDataPoint(dp pdata.HistogramDataPoint, keys []metricdata.LabelKey, ts *metricdata.TimeSeries, p metricdata.Point, dist *metricdata.Distribution) {
+	setAttributes(dp.Attributes(), keys, ts.LabelValues)
+	dp.SetStartTimestamp(pdata.NewTimestampFromTime(ts.StartTime))
+	dp.SetTimestamp(pdata.NewTimestampFromTime(p.Time))
+	dp.SetCount(uint64(dist.Count))
+	dp.SetSum(dist.Sum)
+	if dist.BucketOptions != nil {
+		dp.SetExplicitBounds(dist.BucketOptions.Bounds)
+	}
+	counts := make([]uint64, len(dist.Buckets))
+	for i, bucket := range dist.Buckets {
+		counts[i] = uint64(bucket.Count)
+	}
+	dp.SetBucketCounts(counts)
+}
+
+func setSummaryDataPoint(dp pdata.SummaryDataPoint, keys []metricdata.LabelKey, ts *metricdata.TimeSeries, p metricdata.Point, summary *metricdata.Summary) {
+	setAttributes(dp.Attributes(), keys, ts.LabelValues)
+	dp.SetStartTimestamp(pdata.NewTimestampFromTime(ts.StartTime))
+	dp.SetTimestamp(pdata.NewTimestampFromTime(p.Time))
+	if summary.HasCountAndSum {
+		dp.SetCount(uint64(summary.Count))
+		dp.SetSum(summary.Sum)
+	}
+	percentiles := make([]float64, 0, len(summary.Snapshot.Percentiles))
+	for percentile := range summary.Snapshot.Percentiles {
+		percentiles = append(percentiles, percentile)
+	}
+	sort.Float64s(percentiles)
+	for _, percentile := range percentiles {
+		q := dp.QuantileValues().AppendEmpty()
+		q.SetQuantile(percentile / 100)
+		q.SetValue(summary.Snapshot.Percentiles[percentile])
+	}
+}
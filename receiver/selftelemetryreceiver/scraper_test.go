@@ -0,0 +1,192 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selftelemetryreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/metric/metricdata"
+	"go.opencensus.io/metric/metricproducer"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/service/featuregate"
+)
+
+type fakeProducer []*metricdata.Metric
+
+func (p fakeProducer) Read() []*metricdata.Metric {
+	return p
+}
+
+// findMetric returns the metric with the given name of the first resource.
+func findMetric(t *testing.T, md pdata.Metrics, name string) pdata.Metric {
+	ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		if ms.At(i).Name() == name {
+			return ms.At(i)
+		}
+	}
+	require.Failf(t, "metric not found", "no metric named %q", name)
+	return pdata.Metric{}
+}
+
+func TestScrapeGlobalViews(t *testing.T) {
+	measure := stats.Int64("selftelemetry_test/requests", "Number of requests", stats.UnitDimensionless)
+	key := tag.MustNewKey("receiver")
+	v := &view.View{
+		Name:        measure.Name(),
+		Description: measure.Description(),
+		Measure:     measure,
+		TagKeys:     []tag.Key{key},
+		Aggregation: view.Sum(),
+	}
+	require.NoError(t, view.Register(v))
+	defer view.Unregister(v)
+
+	ctx, err := tag.New(context.Background(), tag.Insert(key, "otlp"))
+	require.NoError(t, err)
+	stats.Record(ctx, measure.M(3))
+	stats.Record(ctx, measure.M(4))
+
+	s, err := newSelfScraper(&Config{IncludeMetrics: []string{"^otelcol_selftelemetry_test_"}}, component.BuildInfo{Command: "otelcol", Version: "1.2.3"})
+	require.NoError(t, err)
+
+	// The measurements are recorded asynchronously.
+	var md pdata.Metrics
+	require.Eventually(t, func() bool {
+		md, err = s.scrape(context.Background())
+		require.NoError(t, err)
+		ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+		return ms.Len() == 1 && ms.At(0).Sum().DataPoints().Len() == 1 && ms.At(0).Sum().DataPoints().At(0).IntVal() == 7
+	}, 5*time.Second, 10*time.Millisecond)
+
+	attrs := md.ResourceMetrics().At(0).Resource().Attributes()
+	assert.Equal(t, map[string]interface{}{"service.name": "otelcol", "service.version": "1.2.3"}, attrs.AsRaw())
+	m := findMetric(t, md, "otelcol_selftelemetry_test_requests")
+	assert.Equal(t, "Number of requests", m.Description())
+	assert.Equal(t, "1", m.Unit())
+	assert.Equal(t, pdata.MetricDataTypeSum, m.DataType())
+	assert.True(t, m.Sum().IsMonotonic())
+	dp := m.Sum().DataPoints().At(0)
+	assert.Equal(t, map[string]interface{}{"receiver": "otlp"}, dp.Attributes().AsRaw())
+	assert.NotZero(t, dp.StartTimestamp())
+}
+
+func TestScrapeMetricTypes(t *testing.T) {
+	start := time.Unix(100, 0)
+	now := time.Unix(200, 0)
+	keys := []metricdata.LabelKey{{Key: "exporter"}, {Key: "missing"}}
+	values := []metricdata.LabelValue{metricdata.NewLabelValue("otlp"), {}}
+	series := func(p metricdata.Point) []*metricdata.TimeSeries {
+		return []*metricdata.TimeSeries{{LabelValues: values, Points: []metricdata.Point{p}, StartTime: start}}
+	}
+	producer := fakeProducer{
+		{
+			Descriptor: metricdata.Descriptor{Name: "exporter/queue_size", Type: metricdata.TypeGaugeInt64, LabelKeys: keys},
+			TimeSeries: series(metricdata.NewInt64Point(now, 5)),
+		},
+		{
+			Descriptor: metricdata.Descriptor{Name: "process/cpu_seconds", Type: metricdata.TypeCumulativeFloat64, Unit: metricdata.UnitDimensionless},
+			TimeSeries: []*metricdata.TimeSeries{{Points: []metricdata.Point{metricdata.NewFloat64Point(now, 1.5)}, StartTime: start}},
+		},
+		{
+			Descriptor: metricdata.Descriptor{Name: "exporter/latency", Type: metricdata.TypeCumulativeDistribution, LabelKeys: keys, Unit: metricdata.UnitMilliseconds},
+			TimeSeries: series(metricdata.NewDistributionPoint(now, &metricdata.Distribution{
+				Count:         3,
+				Sum:           30,
+				BucketOptions: &metricdata.BucketOptions{Bounds: []float64{10, 20}},
+				Buckets:       []metricdata.Bucket{{Count: 1}, {Count: 1}, {Count: 1}},
+			})),
+		},
+		{
+			Descriptor: metricdata.Descriptor{Name: "exporter/summary", Type: metricdata.TypeSummary, LabelKeys: keys},
+			TimeSeries: series(metricdata.NewSummaryPoint(now, &metricdata.Summary{
+				Count:          4,
+				Sum:            40,
+				HasCountAndSum: true,
+				Snapshot:       metricdata.Snapshot{Percentiles: map[float64]float64{99: 20, 50: 10}},
+			})),
+		},
+		{
+			Descriptor: metricdata.Descriptor{Name: "exporter/gauge_distribution", Type: metricdata.TypeGaugeDistribution},
+			TimeSeries: series(metricdata.NewDistributionPoint(now, &metricdata.Distribution{})),
+		},
+	}
+
+	s, err := newSelfScraper(&Config{}, component.BuildInfo{})
+	require.NoError(t, err)
+	s.producers = func() []metricproducer.Producer { return []metricproducer.Producer{producer} }
+	md, err := s.scrape(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, md.ResourceMetrics().At(0).Resource().Attributes().Len())
+	assert.Equal(t, 4, md.MetricCount())
+
+	gauge := findMetric(t, md, "otelcol_exporter_queue_size")
+	require.Equal(t, pdata.MetricDataTypeGauge, gauge.DataType())
+	gdp := gauge.Gauge().DataPoints().At(0)
+	assert.Equal(t, int64(5), gdp.IntVal())
+	assert.Equal(t, pdata.NewTimestampFromTime(now), gdp.Timestamp())
+	assert.Zero(t, gdp.StartTimestamp())
+	assert.Equal(t, map[string]interface{}{"exporter": "otlp"}, gdp.Attributes().AsRaw())
+
+	sum := findMetric(t, md, "otelcol_process_cpu_seconds")
+	require.Equal(t, pdata.MetricDataTypeSum, sum.DataType())
+	assert.Equal(t, pdata.MetricAggregationTemporalityCumulative, sum.Sum().AggregationTemporality())
+	assert.Equal(t, 1.5, sum.Sum().DataPoints().At(0).DoubleVal())
+	assert.Equal(t, pdata.NewTimestampFromTime(start), sum.Sum().DataPoints().At(0).StartTimestamp())
+
+	histogram := findMetric(t, md, "otelcol_exporter_latency")
+	require.Equal(t, pdata.MetricDataTypeHistogram, histogram.DataType())
+	assert.Equal(t, "ms", histogram.Unit())
+	hdp := histogram.Histogram().DataPoints().At(0)
+	assert.Equal(t, uint64(3), hdp.Count())
+	assert.Equal(t, 30.0, hdp.Sum())
+	assert.Equal(t, []float64{10, 20}, hdp.ExplicitBounds())
+	assert.Equal(t, []uint64{1, 1, 1}, hdp.BucketCounts())
+
+	summary := findMetric(t, md, "otelcol_exporter_summary")
+	require.Equal(t, pdata.MetricDataTypeSummary, summary.DataType())
+	sdp := summary.Summary().DataPoints().At(0)
+	assert.Equal(t, uint64(4), sdp.Count())
+	assert.Equal(t, 40.0, sdp.Sum())
+	require.Equal(t, 2, sdp.QuantileValues().Len())
+	assert.Equal(t, 0.5, sdp.QuantileValues().At(0).Quantile())
+	assert.Equal(t, 10.0, sdp.QuantileValues().At(0).Value())
+	assert.Equal(t, 0.99, sdp.QuantileValues().At(1).Quantile())
+	assert.Equal(t, 20.0, sdp.QuantileValues().At(1).Value())
+}
+
+func TestStartWithOtelInternalMetrics(t *testing.T) {
+	featuregate.Apply(map[string]bool{obsreportconfig.UseOtelForInternalMetricsfeatureGateID: true})
+	defer featuregate.Apply(map[string]bool{obsreportconfig.UseOtelForInternalMetricsfeatureGateID: false})
+
+	factory := NewFactory()
+	rcv, err := factory.CreateMetricsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), factory.CreateDefaultConfig(), consumertest.NewNop())
+	require.NoError(t, err)
+	assert.EqualError(t, rcv.Start(context.Background(), componenttest.NewNopHost()),
+		"the internal metrics cannot be read when the telemetry.useOtelForInternalMetrics feature gate is enabled")
+}
@@ -0,0 +1,374 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statsdreceiver // import "go.opentelemetry.io/collector/receiver/statsdreceiver"
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// defaultExplicitBounds are the bucket bounds of the histograms without configured
+// bounds, suited for durations in milliseconds.
+var defaultExplicitBounds = []float64{5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000}
+
+// summaryQuantiles are the quantiles reported by the summaries.
+var summaryQuantiles = []float64{0, 0.5, 0.9, 0.95, 0.99, 1}
+
+// seriesKey identifies a series by the name, type and tags of its metrics.
+type seriesKey struct {
+	name string
+	typ  metricType
+	tags string
+}
+
+type series struct {
+	name string
+	tags []tag
+}
+
+type counter struct {
+	series
+	value float64
+}
+
+type gauge struct {
+	series
+	value float64
+	// updated is set when the gauge was updated during the current interval. The
+	// value of the gauges is kept across intervals for the relative updates.
+	updated bool
+	// lastUpdated is the end of the last interval the gauge was updated during.
+	lastUpdated time.Time
+}
+
+type set struct {
+	series
+	values map[string]struct{}
+}
+
+type observations struct {
+	series
+	typ     metricType
+	samples []sample
+	count   float64
+	sum     float64
+}
+
+// sample is an observed value, weighted by the inverse of its sample rate.
+type sample struct {
+	value  float64
+	weight float64
+}
+
+// aggregator aggregates the StatsD metrics received during an interval.
+type aggregator struct {
+	isMonotonicCounter bool
+	gaugeExpiry        time.Duration
+	mappings           map[metricType]TimerHistogramMapping
+
+	start        time.Time
+	counters     map[seriesKey]*counter
+	gauges       map[seriesKey]*gauge
+	sets         map[seriesKey]*set
+	observations map[seriesKey]*observations
+}
+
+func newAggregator(cfg *Config, start time.Time) *aggregator {
+	a := &aggregator{
+		isMonotonicCounter: cfg.IsMonotonicCounter,
+		gaugeExpiry:        cfg.GaugeExpiry,
+		mappings:           map[metricType]TimerHistogramMapping{},
+		gauges:             map[seriesKey]*gauge{},
+	}
+	for _, m := range cfg.TimerHistogramMapping {
+		if m.ObserverType == observerTypeHistogram && len(m.ExplicitBounds) == 0 {
+			m.ExplicitBounds = defaultExplicitBounds
+		}
+		switch m.StatsdType {
+		case statsdTypeTimer:
+			a.mappings[timerType] = m
+		case statsdTypeHistogram:
+			a.mappings[histogramType] = m
+		case statsdTypeDistribution:
+			a.mappings[distributionType] = m
+		}
+	}
+	a.reset(start)
+	return a
+}
+
+func (a *aggregator) reset(start time.Time) {
+	a.start = start
+	a.counters = map[seriesKey]*counter{}
+	a.sets = map[seriesKey]*set{}
+	a.observations = map[seriesKey]*observations{}
+	for key, g := range a.gauges {
+		if g.updated {
+			g.lastUpdated = start
+		} else if a.gaugeExpiry > 0 && start.Sub(g.lastUpdated) >= a.gaugeExpiry {
+			// Drop the gauges which are not updated anymore, so that the series of
+			// short-lived tags do not accumulate.
+			delete(a.gauges, key)
+		}
+		g.updated = false
+	}
+}
+
+// add aggregates a metric into its series.
+func (a *aggregator) add(m statsdMetric) {
+	key := seriesKey{name: m.name, typ: m.typ, tags: tagsKey(m.tags)}
+	s := series{name: m.name, tags: m.tags}
+
+	switch m.typ {
+	case counterType:
+		c, ok := a.counters[key]
+		if !ok {
+			c = &counter{series: s}
+			a.counters[key] = c
+		}
+		for _, v := range m.values {
+			c.value += v / m.sampleRate
+		}
+	case gaugeType:
+		g, ok := a.gauges[key]
+		if !ok {
+			g = &gauge{series: s}
+			a.gauges[key] = g
+		}
+		for _, v := range m.values {
+			if m.relative {
+				g.value += v
+			} else {
+				g.value = v
+			}
+		}
+		g.updated = true
+	case setType:
+		st, ok := a.sets[key]
+		if !ok {
+			st = &set{series: s, values: map[string]struct{}{}}
+			a.sets[key] = st
+		}
+		st.values[m.setValue] = struct{}{}
+	case timerType, histogramType, distributionType:
+		o, ok := a.observations[key]
+		if !ok {
+			o = &observations{series: s, typ: m.typ}
+			a.observations[key] = o
+		}
+		for _, v := range m.values {
+			o.samples = append(o.samples, sample{value: v, weight: 1 / m.sampleRate})
+			o.count += 1 / m.sampleRate
+			o.sum += v / m.sampleRate
+		}
+	}
+}
+
+// flush returns the metrics aggregated since the start of the interval, and starts
+// a new interval.
+func (a *aggregator) flush(now time.Time) pdata.Metrics {
+	md := pdata.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	start := pdata.NewTimestampFromTime(a.start)
+	ts := pdata.NewTimestampFromTime(now)
+	metrics := map[seriesKey]pdata.Metric{}
+
+	// getMetric returns the metric of the series, with the data points of all the
+	// series of the same name and type.
+	getMetric := func(name string, typ metricType, dataType pdata.MetricDataType) (pdata.Metric, bool) {
+		key := seriesKey{name: name, typ: typ}
+		if m, ok := metrics[key]; ok {
+			return m, true
+		}
+		m := ms.AppendEmpty()
+		m.SetName(name)
+		m.SetDataType(dataType)
+		metrics[key] = m
+		return m, false
+	}
+
+	counterKeys := make([]seriesKey, 0, len(a.counters))
+	for key := range a.counters {
+		counterKeys = append(counterKeys, key)
+	}
+	for _, key := range sortSeriesKeys(counterKeys) {
+		c := a.counters[key]
+		m, exists := getMetric(c.name, counterType, pdata.MetricDataTypeSum)
+		if !exists {
+			m.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityDelta)
+			m.Sum().SetIsMonotonic(a.isMonotonicCounter)
+		}
+		dp := m.Sum().DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(start)
+		dp.SetTimestamp(ts)
+		dp.SetIntVal(int64(math.Round(c.value)))
+		insertTags(dp.Attributes(), c.tags)
+	}
+
+	gaugeKeys := make([]seriesKey, 0, len(a.gauges))
+	for key := range a.gauges {
+		gaugeKeys = append(gaugeKeys, key)
+	}
+	for _, key := range sortSeriesKeys(gaugeKeys) {
+		g := a.gauges[key]
+		if !g.updated {
+			continue
+		}
+		m, _ := getMetric(g.name, gaugeType, pdata.MetricDataTypeGauge)
+		dp := m.Gauge().DataPoints().AppendEmpty()
+		dp.SetTimestamp(ts)
+		dp.SetDoubleVal(g.value)
+		insertTags(dp.Attributes(), g.tags)
+	}
+
+	setKeys := make([]seriesKey, 0, len(a.sets))
+	for key := range a.sets {
+		setKeys = append(setKeys, key)
+	}
+	for _, key := range sortSeriesKeys(setKeys) {
+		st := a.sets[key]
+		m, _ := getMetric(st.name, setType, pdata.MetricDataTypeGauge)
+		dp := m.Gauge().DataPoints().AppendEmpty()
+		dp.SetTimestamp(ts)
+		dp.SetIntVal(int64(len(st.values)))
+		insertTags(dp.Attributes(), st.tags)
+	}
+
+	observationKeys := make([]seriesKey, 0, len(a.observations))
+	for key := range a.observations {
+		observationKeys = append(observationKeys, key)
+	}
+	for _, key := range sortSeriesKeys(observationKeys) {
+		o := a.observations[key]
+		mapping := a.mappings[o.typ]
+		if mapping.ObserverType == observerTypeHistogram {
+			m, exists := getMetric(o.name, o.typ, pdata.MetricDataTypeHistogram)
+			if !exists {
+				m.Histogram().SetAggregationTemporality(pdata.MetricAggregationTemporalityDelta)
+			}
+			dp := m.Histogram().DataPoints().AppendEmpty()
+			dp.SetStartTimestamp(start)
+			dp.SetTimestamp(ts)
+			o.toHistogramDataPoint(dp, mapping.ExplicitBounds)
+			insertTags(dp.Attributes(), o.tags)
+			continue
+		}
+		m, _ := getMetric(o.name, o.typ, pdata.MetricDataTypeSummary)
+		dp := m.Summary().DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(start)
+		dp.SetTimestamp(ts)
+		o.toSummaryDataPoint(dp)
+		insertTags(dp.Attributes(), o.tags)
+	}
+
+	a.reset(now)
+	return md
+}
+
+func (o *observations) toHistogramDataPoint(dp pdata.HistogramDataPoint, bounds []float64) {
+	buckets := make([]float64, len(bounds)+1)
+	for _, s := range o.samples {
+		buckets[sort.SearchFloat64s(bounds, s.value)] += s.weight
+	}
+	count := uint64(math.Round(o.count))
+	dp.SetCount(count)
+	dp.SetSum(o.sum)
+	dp.SetExplicitBounds(bounds)
+	dp.SetBucketCounts(roundBuckets(buckets, count))
+}
+
+// roundBuckets rounds the weighted bucket counts so that they add up to count:
+// the counts are rounded down, and the remainder is spread across the buckets
+// with the largest fractional parts.
+func roundBuckets(buckets []float64, count uint64) []uint64 {
+	counts := make([]uint64, len(buckets))
+	indexes := make([]int, len(buckets))
+	var total uint64
+	for i, b := range buckets {
+		counts[i] = uint64(math.Floor(b))
+		total += counts[i]
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return buckets[indexes[i]]-math.Floor(buckets[indexes[i]]) > buckets[indexes[j]]-math.Floor(buckets[indexes[j]])
+	})
+	for i := 0; total < count && i < len(indexes); i++ {
+		counts[indexes[i]]++
+		total++
+	}
+	return counts
+}
+
+func (o *observations) toSummaryDataPoint(dp pdata.SummaryDataPoint) {
+	sort.Slice(o.samples, func(i, j int) bool { return o.samples[i].value < o.samples[j].value })
+	dp.SetCount(uint64(math.Round(o.count)))
+	dp.SetSum(o.sum)
+	quantiles := dp.QuantileValues()
+	quantiles.EnsureCapacity(len(summaryQuantiles))
+	for _, q := range summaryQuantiles {
+		qv := quantiles.AppendEmpty()
+		qv.SetQuantile(q)
+		qv.SetValue(quantile(o.samples, o.count, q))
+	}
+}
+
+// quantile returns the quantile q of the sorted samples with the nearest-rank method,
+// the rank of each sample being its cumulated weight.
+func quantile(sorted []sample, count float64, q float64) float64 {
+	rank := q * count
+	cumulated := 0.0
+	for _, s := range sorted {
+		cumulated += s.weight
+		if cumulated >= rank {
+			return s.value
+		}
+	}
+	return sorted[len(sorted)-1].value
+}
+
+func insertTags(attrs pdata.Map, tags []tag) {
+	for _, t := range tags {
+		attrs.UpsertString(t.key, t.value)
+	}
+}
+
+func tagsKey(tags []tag) string {
+	var b strings.Builder
+	for _, t := range tags {
+		b.WriteString(t.key)
+		b.WriteByte(':')
+		b.WriteString(t.value)
+		b.WriteByte(',')
+	}
+	return b.String()
+}
+
+// sortSeriesKeys sorts the keys so that the data points are emitted in a stable order.
+func sortSeriesKeys(keys []seriesKey) []seriesKey {
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].name != keys[j].name {
+			return keys[i].name < keys[j].name
+		}
+		if keys[i].typ != keys[j].typ {
+			return keys[i].typ < keys[j].typ
+		}
+		return keys[i].tags < keys[j].tags
+	})
+	return keys
+}
@@ -0,0 +1,192 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statsdreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func addLines(t *testing.T, a *aggregator, lines ...string) {
+	for _, line := range lines {
+		m, err := parseLine(line)
+		require.NoError(t, err)
+		a.add(m)
+	}
+}
+
+func TestAggregatorCounters(t *testing.T) {
+	start := time.Unix(1600000000, 0)
+	cfg := createDefaultConfig().(*Config)
+	cfg.IsMonotonicCounter = true
+	a := newAggregator(cfg, start)
+	addLines(t, a,
+		"requests:1|c|#env:prod",
+		"requests:2|c|@0.5|#env:prod",
+		"requests:1|c|#env:dev",
+	)
+
+	md := a.flush(start.Add(time.Minute))
+	ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 1, ms.Len())
+	m := ms.At(0)
+	assert.Equal(t, "requests", m.Name())
+	assert.Equal(t, pdata.MetricDataTypeSum, m.DataType())
+	assert.Equal(t, pdata.MetricAggregationTemporalityDelta, m.Sum().AggregationTemporality())
+	assert.True(t, m.Sum().IsMonotonic())
+	require.Equal(t, 2, m.Sum().DataPoints().Len())
+
+	dp := m.Sum().DataPoints().At(0)
+	assert.Equal(t, map[string]interface{}{"env": "dev"}, dp.Attributes().AsRaw())
+	assert.Equal(t, int64(1), dp.IntVal())
+	dp = m.Sum().DataPoints().At(1)
+	assert.Equal(t, map[string]interface{}{"env": "prod"}, dp.Attributes().AsRaw())
+	assert.Equal(t, int64(5), dp.IntVal())
+	assert.Equal(t, pdata.NewTimestampFromTime(start), dp.StartTimestamp())
+	assert.Equal(t, pdata.NewTimestampFromTime(start.Add(time.Minute)), dp.Timestamp())
+
+	// The counters are reset at every interval.
+	assert.Equal(t, 0, a.flush(start.Add(2*time.Minute)).DataPointCount())
+}
+
+func TestAggregatorGauges(t *testing.T) {
+	start := time.Unix(1600000000, 0)
+	a := newAggregator(createDefaultConfig().(*Config), start)
+	addLines(t, a, "temperature:20|g", "temperature:+5|g", "temperature:-2|g")
+
+	md := a.flush(start.Add(time.Minute))
+	require.Equal(t, 1, md.DataPointCount())
+	m := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, pdata.MetricDataTypeGauge, m.DataType())
+	assert.Equal(t, 23.0, m.Gauge().DataPoints().At(0).DoubleVal())
+
+	// The gauges are only emitted when updated, relatively to their last value.
+	assert.Equal(t, 0, a.flush(start.Add(2*time.Minute)).DataPointCount())
+	addLines(t, a, "temperature:+1|g")
+	md = a.flush(start.Add(3 * time.Minute))
+	require.Equal(t, 1, md.DataPointCount())
+	assert.Equal(t, 24.0, md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0).DoubleVal())
+}
+
+func TestAggregatorGaugesExpiry(t *testing.T) {
+	start := time.Unix(1600000000, 0)
+	cfg := createDefaultConfig().(*Config)
+	cfg.GaugeExpiry = 2 * time.Minute
+	a := newAggregator(cfg, start)
+	addLines(t, a, "temperature:20|g", "queue:5|g")
+
+	require.Equal(t, 2, a.flush(start.Add(time.Minute)).DataPointCount())
+	addLines(t, a, "queue:+1|g")
+	require.Equal(t, 1, a.flush(start.Add(2*time.Minute)).DataPointCount())
+	assert.Len(t, a.gauges, 2)
+
+	// The temperature gauge was not updated for 2 minutes.
+	require.Equal(t, 0, a.flush(start.Add(3*time.Minute)).DataPointCount())
+	assert.Len(t, a.gauges, 1)
+
+	// The relative updates of an expired gauge start from zero.
+	addLines(t, a, "temperature:+1|g")
+	md := a.flush(start.Add(4 * time.Minute))
+	require.Equal(t, 1, md.DataPointCount())
+	assert.Equal(t, 1.0, md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0).DoubleVal())
+}
+
+func TestAggregatorSets(t *testing.T) {
+	start := time.Unix(1600000000, 0)
+	a := newAggregator(createDefaultConfig().(*Config), start)
+	addLines(t, a, "users:alice|s", "users:bob|s", "users:alice|s")
+
+	md := a.flush(start.Add(time.Minute))
+	require.Equal(t, 1, md.DataPointCount())
+	m := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, pdata.MetricDataTypeGauge, m.DataType())
+	assert.Equal(t, int64(2), m.Gauge().DataPoints().At(0).IntVal())
+}
+
+func TestAggregatorSummaries(t *testing.T) {
+	start := time.Unix(1600000000, 0)
+	a := newAggregator(createDefaultConfig().(*Config), start)
+	addLines(t, a, "latency:10:20:30:40|ms", "latency:50|ms|@0.5")
+
+	md := a.flush(start.Add(time.Minute))
+	m := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	require.Equal(t, pdata.MetricDataTypeSummary, m.DataType())
+	dp := m.Summary().DataPoints().At(0)
+	assert.Equal(t, uint64(6), dp.Count())
+	assert.Equal(t, 200.0, dp.Sum())
+	assert.Equal(t, pdata.NewTimestampFromTime(start), dp.StartTimestamp())
+
+	quantiles := map[float64]float64{}
+	for i := 0; i < dp.QuantileValues().Len(); i++ {
+		qv := dp.QuantileValues().At(i)
+		quantiles[qv.Quantile()] = qv.Value()
+	}
+	assert.Equal(t, map[float64]float64{0: 10, 0.5: 30, 0.9: 50, 0.95: 50, 0.99: 50, 1: 50}, quantiles)
+}
+
+func TestAggregatorHistograms(t *testing.T) {
+	start := time.Unix(1600000000, 0)
+	cfg := createDefaultConfig().(*Config)
+	cfg.TimerHistogramMapping = []TimerHistogramMapping{
+		{StatsdType: statsdTypeHistogram, ObserverType: observerTypeHistogram, ExplicitBounds: []float64{10, 100}},
+		{StatsdType: statsdTypeDistribution, ObserverType: observerTypeHistogram},
+	}
+	a := newAggregator(cfg, start)
+	addLines(t, a, "size:5:10:50:500|h", "size:20|h|@0.25", "duration:7|d")
+
+	md := a.flush(start.Add(time.Minute))
+	ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, ms.Len())
+
+	m := ms.At(0)
+	assert.Equal(t, "duration", m.Name())
+	require.Equal(t, pdata.MetricDataTypeHistogram, m.DataType())
+	assert.Equal(t, defaultExplicitBounds, m.Histogram().DataPoints().At(0).ExplicitBounds())
+
+	m = ms.At(1)
+	assert.Equal(t, "size", m.Name())
+	require.Equal(t, pdata.MetricDataTypeHistogram, m.DataType())
+	assert.Equal(t, pdata.MetricAggregationTemporalityDelta, m.Histogram().AggregationTemporality())
+	dp := m.Histogram().DataPoints().At(0)
+	assert.Equal(t, uint64(8), dp.Count())
+	assert.Equal(t, 645.0, dp.Sum())
+	assert.Equal(t, []float64{10, 100}, dp.ExplicitBounds())
+	assert.Equal(t, []uint64{2, 5, 1}, dp.BucketCounts())
+}
+
+func TestAggregatorHistogramsSampleRates(t *testing.T) {
+	start := time.Unix(1600000000, 0)
+	cfg := createDefaultConfig().(*Config)
+	cfg.TimerHistogramMapping = []TimerHistogramMapping{
+		{StatsdType: statsdTypeHistogram, ObserverType: observerTypeHistogram, ExplicitBounds: []float64{10, 100}},
+	}
+	a := newAggregator(cfg, start)
+	addLines(t, a, "size:5|h|@0.3", "size:50|h|@0.3", "size:500|h|@0.3")
+
+	md := a.flush(start.Add(time.Minute))
+	dp := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Histogram().DataPoints().At(0)
+	assert.Equal(t, uint64(10), dp.Count())
+	var sum uint64
+	for _, c := range dp.BucketCounts() {
+		sum += c
+	}
+	assert.Equal(t, dp.Count(), sum)
+	assert.Equal(t, []uint64{4, 3, 3}, dp.BucketCounts())
+}
@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statsdreceiver // import "go.opentelemetry.io/collector/receiver/statsdreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confignet"
+)
+
+const (
+	statsdTypeTimer        = "timer"
+	statsdTypeHistogram    = "histogram"
+	statsdTypeDistribution = "distribution"
+
+	observerTypeSummary   = "summary"
+	observerTypeHistogram = "histogram"
+)
+
+// Config defines configuration for the StatsD receiver.
+type Config struct {
+	config.ReceiverSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// NetAddr is the address to listen on. The transport must be one of "udp", "udp4"
+	// or "udp6".
+	confignet.NetAddr `mapstructure:",squash"`
+
+	// AggregationInterval is the interval at which the aggregated metrics are emitted.
+	AggregationInterval time.Duration `mapstructure:"aggregation_interval"`
+
+	// GaugeExpiry is the duration after which the gauges which were not updated
+	// are dropped, their next relative update then starts from zero. Zero keeps
+	// the gauges forever.
+	GaugeExpiry time.Duration `mapstructure:"gauge_expiry"`
+
+	// IsMonotonicCounter marks the sums of the counters as monotonic.
+	IsMonotonicCounter bool `mapstructure:"is_monotonic_counter"`
+
+	// TimerHistogramMapping configures how the timers, histograms and distributions
+	// are aggregated. The types without mapping are aggregated into summaries.
+	TimerHistogramMapping []TimerHistogramMapping `mapstructure:"timer_histogram_mapping"`
+}
+
+// TimerHistogramMapping configures the aggregation of a StatsD type.
+type TimerHistogramMapping struct {
+	// StatsdType is the StatsD type, one of "timer", "histogram" or "distribution".
+	StatsdType string `mapstructure:"statsd_type"`
+
+	// ObserverType is the type of the aggregated metric, "summary" or "histogram".
+	ObserverType string `mapstructure:"observer_type"`
+
+	// ExplicitBounds are the bucket bounds of the histograms, in increasing order.
+	// Only used with the "histogram" observer type. Defaults to bounds suited for
+	// durations in milliseconds.
+	ExplicitBounds []float64 `mapstructure:"explicit_bounds"`
+}
+
+var _ config.Receiver = (*Config)(nil)
+
+// Validate checks the receiver configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("endpoint must be specified")
+	}
+	switch cfg.Transport {
+	case "udp", "udp4", "udp6":
+	default:
+		return fmt.Errorf("unsupported transport %q, must be udp", cfg.Transport)
+	}
+	if cfg.AggregationInterval <= 0 {
+		return errors.New("aggregation_interval must be positive")
+	}
+	if cfg.GaugeExpiry < 0 {
+		return errors.New("gauge_expiry must not be negative")
+	}
+
+	seen := map[string]bool{}
+	for _, m := range cfg.TimerHistogramMapping {
+		switch m.StatsdType {
+		case statsdTypeTimer, statsdTypeHistogram, statsdTypeDistribution:
+		default:
+			return fmt.Errorf("unsupported statsd_type %q, must be %q, %q or %q", m.StatsdType, statsdTypeTimer, statsdTypeHistogram, statsdTypeDistribution)
+		}
+		if seen[m.StatsdType] {
+			return fmt.Errorf("duplicate timer_histogram_mapping for statsd_type %q", m.StatsdType)
+		}
+		seen[m.StatsdType] = true
+
+		switch m.ObserverType {
+		case observerTypeSummary, observerTypeHistogram:
+		default:
+			return fmt.Errorf("unsupported observer_type %q, must be %q or %q", m.ObserverType, observerTypeSummary, observerTypeHistogram)
+		}
+		if !sort.SliceIsSorted(m.ExplicitBounds, func(i, j int) bool { return m.ExplicitBounds[i] <= m.ExplicitBounds[j] }) {
+			return fmt.Errorf("explicit_bounds of statsd_type %q must be in increasing order", m.StatsdType)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statsdreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/service/servicetest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := servicetest.LoadConfigAndValidate(filepath.Join("testdata", "config.yaml"), factories)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, len(cfg.Receivers), 2)
+
+	r0 := cfg.Receivers[config.NewComponentID(typeStr)]
+	assert.Equal(t, factory.CreateDefaultConfig(), r0)
+
+	r1 := cfg.Receivers[config.NewComponentIDWithName(typeStr, "customname")]
+	assert.Equal(t,
+		&Config{
+			ReceiverSettings: config.NewReceiverSettings(config.NewComponentIDWithName(typeStr, "customname")),
+			NetAddr: confignet.NetAddr{
+				Endpoint:  "0.0.0.0:8126",
+				Transport: "udp",
+			},
+			AggregationInterval: 10 * time.Second,
+			GaugeExpiry:         time.Minute,
+			IsMonotonicCounter:  true,
+			TimerHistogramMapping: []TimerHistogramMapping{
+				{
+					StatsdType:     statsdTypeTimer,
+					ObserverType:   observerTypeHistogram,
+					ExplicitBounds: []float64{10, 100, 1000},
+				},
+				{
+					StatsdType:   statsdTypeDistribution,
+					ObserverType: observerTypeSummary,
+				},
+			},
+		}, r1)
+}
+
+func TestValidateConfig(t *testing.T) {
+	testCases := []struct {
+		name   string
+		modify func(cfg *Config)
+	}{
+		{
+			name:   "no endpoint",
+			modify: func(cfg *Config) { cfg.Endpoint = "" },
+		},
+		{
+			name:   "unsupported transport",
+			modify: func(cfg *Config) { cfg.Transport = "tcp" },
+		},
+		{
+			name:   "zero aggregation_interval",
+			modify: func(cfg *Config) { cfg.AggregationInterval = 0 },
+		},
+		{
+			name:   "negative gauge_expiry",
+			modify: func(cfg *Config) { cfg.GaugeExpiry = -time.Second },
+		},
+		{
+			name: "unsupported statsd_type",
+			modify: func(cfg *Config) {
+				cfg.TimerHistogramMapping = []TimerHistogramMapping{{StatsdType: "counter", ObserverType: observerTypeSummary}}
+			},
+		},
+		{
+			name: "duplicate statsd_type",
+			modify: func(cfg *Config) {
+				cfg.TimerHistogramMapping = []TimerHistogramMapping{
+					{StatsdType: statsdTypeTimer, ObserverType: observerTypeSummary},
+					{StatsdType: statsdTypeTimer, ObserverType: observerTypeHistogram},
+				}
+			},
+		},
+		{
+			name: "unsupported observer_type",
+			modify: func(cfg *Config) {
+				cfg.TimerHistogramMapping = []TimerHistogramMapping{{StatsdType: statsdTypeTimer, ObserverType: "gauge"}}
+			},
+		},
+		{
+			name: "unsorted explicit_bounds",
+			modify: func(cfg *Config) {
+				cfg.TimerHistogramMapping = []TimerHistogramMapping{{
+					StatsdType:     statsdTypeTimer,
+					ObserverType:   observerTypeHistogram,
+					ExplicitBounds: []float64{10, 5},
+				}}
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			require.NoError(t, cfg.Validate())
+			tt.modify(cfg)
+			assert.Error(t, cfg.Validate())
+		})
+	}
+}
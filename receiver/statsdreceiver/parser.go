@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statsdreceiver // import "go.opentelemetry.io/collector/receiver/statsdreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// metricType is the type of a StatsD metric.
+type metricType string
+
+const (
+	counterType      metricType = "c"
+	gaugeType        metricType = "g"
+	timerType        metricType = "ms"
+	histogramType    metricType = "h"
+	distributionType metricType = "d"
+	setType          metricType = "s"
+)
+
+// tag is a DogStatsD tag. A tag without value has an empty value.
+type tag struct {
+	key   string
+	value string
+}
+
+// statsdMetric is a parsed StatsD line, in the format
+// <name>:<value>[:<value>...]|<type>[|@<sample rate>][|#<tag>[:<value>],...].
+type statsdMetric struct {
+	name   string
+	typ    metricType
+	values []float64
+	// relative is set for the gauges whose values are signed, which change the
+	// current value of the gauge instead of replacing it.
+	relative bool
+	// setValue is the raw value of a set, which can be any string.
+	setValue   string
+	sampleRate float64
+	tags       []tag
+}
+
+// parseLine parses a line in the StatsD format, extended with the DogStatsD tags
+// and multiple values. Unknown sections of the line are ignored.
+func parseLine(line string) (statsdMetric, error) {
+	sections := strings.Split(line, "|")
+	if len(sections) < 2 {
+		return statsdMetric{}, errors.New("missing metric type")
+	}
+
+	nameValue := sections[0]
+	sep := strings.IndexByte(nameValue, ':')
+	if sep <= 0 {
+		return statsdMetric{}, errors.New("missing metric name or value")
+	}
+	m := statsdMetric{
+		name:       nameValue[:sep],
+		typ:        metricType(sections[1]),
+		sampleRate: 1,
+	}
+	rawValue := nameValue[sep+1:]
+	if rawValue == "" {
+		return statsdMetric{}, errors.New("missing metric value")
+	}
+
+	switch m.typ {
+	case setType:
+		m.setValue = rawValue
+	case counterType, gaugeType, timerType, histogramType, distributionType:
+		for _, v := range strings.Split(rawValue, ":") {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return statsdMetric{}, fmt.Errorf("invalid metric value %q", v)
+			}
+			if m.typ == gaugeType && (v[0] == '+' || v[0] == '-') {
+				m.relative = true
+			}
+			m.values = append(m.values, f)
+		}
+	default:
+		return statsdMetric{}, fmt.Errorf("unsupported metric type %q", m.typ)
+	}
+
+	for _, section := range sections[2:] {
+		switch {
+		case strings.HasPrefix(section, "@"):
+			rate, err := strconv.ParseFloat(section[1:], 64)
+			if err != nil || rate <= 0 || rate > 1 {
+				return statsdMetric{}, fmt.Errorf("invalid sample rate %q", section[1:])
+			}
+			m.sampleRate = rate
+		case strings.HasPrefix(section, "#"):
+			m.tags = parseTags(section[1:])
+		}
+	}
+	return m, nil
+}
+
+// parseTags parses comma-separated tags, sorted by key so that the same tags
+// in a different order identify the same series.
+func parseTags(s string) []tag {
+	var tags []tag
+	for _, t := range strings.Split(s, ",") {
+		if t == "" {
+			continue
+		}
+		k, v := t, ""
+		if i := strings.IndexByte(t, ':'); i >= 0 {
+			k, v = t[:i], t[i+1:]
+		}
+		tags = append(tags, tag{key: k, value: v})
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].key < tags[j].key })
+	return tags
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statsdreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLine(t *testing.T) {
+	testCases := []struct {
+		line string
+		want statsdMetric
+	}{
+		{
+			line: "requests:1|c",
+			want: statsdMetric{name: "requests", typ: counterType, values: []float64{1}, sampleRate: 1},
+		},
+		{
+			line: "requests:2|c|@0.5|#region:eu,env:prod,canary",
+			want: statsdMetric{
+				name:       "requests",
+				typ:        counterType,
+				values:     []float64{2},
+				sampleRate: 0.5,
+				tags:       []tag{{key: "canary"}, {key: "env", value: "prod"}, {key: "region", value: "eu"}},
+			},
+		},
+		{
+			line: "temperature:21.5|g",
+			want: statsdMetric{name: "temperature", typ: gaugeType, values: []float64{21.5}, sampleRate: 1},
+		},
+		{
+			line: "temperature:-3|g",
+			want: statsdMetric{name: "temperature", typ: gaugeType, values: []float64{-3}, relative: true, sampleRate: 1},
+		},
+		{
+			line: "latency:10:20:30|ms|c:container",
+			want: statsdMetric{name: "latency", typ: timerType, values: []float64{10, 20, 30}, sampleRate: 1},
+		},
+		{
+			line: "size:512|h",
+			want: statsdMetric{name: "size", typ: histogramType, values: []float64{512}, sampleRate: 1},
+		},
+		{
+			line: "size:512|d",
+			want: statsdMetric{name: "size", typ: distributionType, values: []float64{512}, sampleRate: 1},
+		},
+		{
+			line: "users:user:42|s",
+			want: statsdMetric{name: "users", typ: setType, setValue: "user:42", sampleRate: 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.line, func(t *testing.T) {
+			m, err := parseLine(tc.line)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, m)
+		})
+	}
+}
+
+func TestParseLineErrors(t *testing.T) {
+	for _, line := range []string{
+		"requests:1",
+		":1|c",
+		"requests|c",
+		"requests:|c",
+		"requests:one|c",
+		"requests:1|x",
+		"requests:1|c|@0",
+		"requests:1|c|@2",
+		"requests:1|c|@half",
+	} {
+		t.Run(line, func(t *testing.T) {
+			_, err := parseLine(line)
+			assert.Error(t, err)
+		})
+	}
+}
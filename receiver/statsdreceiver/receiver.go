@@ -0,0 +1,168 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statsdreceiver // import "go.opentelemetry.io/collector/receiver/statsdreceiver"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+const (
+	dataFormat = "statsd"
+
+	// maxPacketSize is the maximum size of a UDP datagram.
+	maxPacketSize = 65535
+)
+
+type statsdReceiver struct {
+	cfg          *Config
+	logger       *zap.Logger
+	nextConsumer consumer.Metrics
+	obsrecv      *obsreport.Receiver
+
+	packetConn net.PacketConn
+	done       chan struct{}
+	wg         sync.WaitGroup
+
+	mu         sync.Mutex
+	aggregator *aggregator
+}
+
+func newStatsdReceiver(cfg *Config, set component.ReceiverCreateSettings, nextConsumer consumer.Metrics) *statsdReceiver {
+	return &statsdReceiver{
+		cfg:          cfg,
+		logger:       set.Logger,
+		nextConsumer: nextConsumer,
+		obsrecv: obsreport.NewReceiver(obsreport.ReceiverSettings{
+			ReceiverID:             cfg.ID(),
+			Transport:              cfg.Transport,
+			ReceiverCreateSettings: set,
+		}),
+		done: make(chan struct{}),
+	}
+}
+
+// Start listens on the configured address, and starts receiving and aggregating
+// the metrics.
+func (r *statsdReceiver) Start(_ context.Context, host component.Host) error {
+	pc, err := net.ListenPacket(r.cfg.Transport, r.cfg.Endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", r.cfg.Endpoint, err)
+	}
+	r.packetConn = pc
+	r.aggregator = newAggregator(r.cfg, time.Now())
+
+	r.wg.Add(2)
+	go r.readPackets(host)
+	go r.flushPeriodically()
+	return nil
+}
+
+// Shutdown stops listening, and emits the metrics aggregated since the last interval.
+func (r *statsdReceiver) Shutdown(ctx context.Context) error {
+	if r.packetConn == nil {
+		return nil
+	}
+	err := r.packetConn.Close()
+	close(r.done)
+	r.wg.Wait()
+	r.flush(ctx)
+	return err
+}
+
+func (r *statsdReceiver) readPackets(host component.Host) {
+	defer r.wg.Done()
+	buf := make([]byte, maxPacketSize)
+	for {
+		n, _, err := r.packetConn.ReadFrom(buf)
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				host.ReportFatalError(err)
+			}
+			return
+		}
+		r.handlePacket(buf[:n])
+	}
+}
+
+// handlePacket aggregates the metrics of a packet, one per line, in the same interval.
+func (r *statsdReceiver) handlePacket(data []byte) {
+	var metrics []statsdMetric
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		m, err := parseLine(string(line))
+		if err != nil {
+			r.reportMalformed(err)
+			continue
+		}
+		metrics = append(metrics, m)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, m := range metrics {
+		r.aggregator.add(m)
+	}
+}
+
+func (r *statsdReceiver) flushPeriodically() {
+	defer r.wg.Done()
+	ticker := time.NewTicker(r.cfg.AggregationInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			r.flush(context.Background())
+		case <-r.done:
+			return
+		}
+	}
+}
+
+// flush passes the metrics aggregated during the interval to the next consumer.
+func (r *statsdReceiver) flush(ctx context.Context) {
+	r.mu.Lock()
+	md := r.aggregator.flush(time.Now())
+	r.mu.Unlock()
+
+	numPoints := md.DataPointCount()
+	if numPoints == 0 {
+		return
+	}
+	ctx = r.obsrecv.StartMetricsOp(ctx)
+	err := r.nextConsumer.ConsumeMetrics(ctx, md)
+	r.obsrecv.EndMetricsOp(ctx, dataFormat, numPoints, err)
+}
+
+// reportMalformed records a line that cannot be parsed as a refused metric point.
+func (r *statsdReceiver) reportMalformed(err error) {
+	ctx := r.obsrecv.StartMetricsOp(context.Background())
+	r.obsrecv.EndMetricsOp(ctx, dataFormat, 1, fmt.Errorf("malformed statsd line: %w", err))
+	r.logger.Debug("Malformed statsd line", zap.Error(err))
+}
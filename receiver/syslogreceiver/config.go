@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogreceiver // import "go.opentelemetry.io/collector/receiver/syslogreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+const (
+	protocolRFC5424 = "rfc5424"
+	protocolRFC3164 = "rfc3164"
+)
+
+// Config defines configuration for the syslog receiver.
+type Config struct {
+	config.ReceiverSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// NetAddr is the address to listen on. The transport must be one of "tcp", "tcp4",
+	// "tcp6", "udp", "udp4" or "udp6".
+	confignet.NetAddr `mapstructure:",squash"`
+
+	// TLSSetting configures TLS for the TCP connections. Not supported with UDP.
+	TLSSetting *configtls.TLSServerSetting `mapstructure:"tls"`
+
+	// Protocol is the format of the syslog messages, either "rfc5424" or "rfc3164".
+	Protocol string `mapstructure:"protocol"`
+
+	// Location is the IANA time zone name of the RFC 3164 timestamps, which do not
+	// include a time zone. Defaults to UTC.
+	Location string `mapstructure:"location"`
+
+	// MaxMessageSize is the maximum size in bytes of a syslog message.
+	MaxMessageSize int `mapstructure:"max_message_size"`
+}
+
+var _ config.Receiver = (*Config)(nil)
+
+// Validate checks the receiver configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("endpoint must be specified")
+	}
+	switch {
+	case isTCP(cfg.Transport):
+	case isUDP(cfg.Transport):
+		if cfg.TLSSetting != nil {
+			return errors.New("tls is not supported with the udp transport")
+		}
+	default:
+		return fmt.Errorf("unsupported transport %q, must be tcp or udp", cfg.Transport)
+	}
+
+	switch cfg.Protocol {
+	case protocolRFC5424, protocolRFC3164:
+	default:
+		return fmt.Errorf("unsupported protocol %q, must be %q or %q", cfg.Protocol, protocolRFC5424, protocolRFC3164)
+	}
+
+	if _, err := time.LoadLocation(cfg.Location); err != nil {
+		return fmt.Errorf("invalid location: %w", err)
+	}
+
+	if cfg.MaxMessageSize <= 0 {
+		return errors.New("max_message_size must be positive")
+	}
+	return nil
+}
+
+func isTCP(transport string) bool {
+	return transport == "tcp" || transport == "tcp4" || transport == "tcp6"
+}
+
+func isUDP(transport string) bool {
+	return transport == "udp" || transport == "udp4" || transport == "udp6"
+}
@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogreceiver // import "go.opentelemetry.io/collector/receiver/syslogreceiver"
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// maxOctetCountDigits is the maximum number of digits of the octet counting frames.
+const maxOctetCountDigits = 10
+
+var errMessageTooLarge = errors.New("message too large")
+
+// frameReader reads the syslog messages of a TCP stream, framed either with octet
+// counting or with a trailing newline, as defined by RFC 6587. The framing is
+// detected for each message: the octet counting frames start with a digit, while
+// the syslog messages start with '<'.
+type frameReader struct {
+	reader         *bufio.Reader
+	maxMessageSize int
+}
+
+func newFrameReader(r io.Reader, maxMessageSize int) *frameReader {
+	return &frameReader{
+		// The buffer must hold a complete message for the newline framing.
+		reader:         bufio.NewReaderSize(r, maxMessageSize+1),
+		maxMessageSize: maxMessageSize,
+	}
+}
+
+// next returns the next message of the stream. It returns errMessageTooLarge if the
+// message exceeds the maximum size, in which case the message is skipped and the next
+// one can be read. Any other error is not recoverable.
+func (fr *frameReader) next() ([]byte, error) {
+	for {
+		first, err := fr.reader.Peek(1)
+		if err != nil {
+			return nil, err
+		}
+		if first[0] >= '1' && first[0] <= '9' {
+			return fr.nextOctetCounted()
+		}
+		msg, err := fr.nextNewlineTerminated()
+		if err != nil || len(msg) > 0 {
+			return msg, err
+		}
+		// Skip the empty lines.
+	}
+}
+
+func (fr *frameReader) nextOctetCounted() ([]byte, error) {
+	header, err := fr.reader.ReadSlice(' ')
+	if err != nil {
+		if err == bufio.ErrBufferFull || err == io.EOF {
+			return nil, fmt.Errorf("invalid octet counting frame: %w", err)
+		}
+		return nil, err
+	}
+	if len(header) > maxOctetCountDigits+1 {
+		return nil, errors.New("invalid octet counting frame length")
+	}
+	length, err := strconv.Atoi(string(header[:len(header)-1]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid octet counting frame length: %w", err)
+	}
+
+	if length > fr.maxMessageSize {
+		if _, err = fr.reader.Discard(length); err != nil {
+			return nil, err
+		}
+		return nil, errMessageTooLarge
+	}
+	msg := make([]byte, length)
+	if _, err = io.ReadFull(fr.reader, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+func (fr *frameReader) nextNewlineTerminated() ([]byte, error) {
+	line, err := fr.reader.ReadSlice('\n')
+	switch {
+	case err == bufio.ErrBufferFull:
+		// Skip the rest of the message.
+		for err == bufio.ErrBufferFull {
+			_, err = fr.reader.ReadSlice('\n')
+		}
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		return nil, errMessageTooLarge
+	case err == io.EOF && len(line) > 0:
+		// The last message of the stream may not be terminated.
+	case err != nil:
+		return nil, err
+	}
+	msg := bytes.TrimRight(line, "\r\n")
+	if len(msg) > fr.maxMessageSize {
+		return nil, errMessageTooLarge
+	}
+	// The slice is only valid until the next read.
+	return append([]byte(nil), msg...), nil
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogreceiver
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrameReader(t *testing.T) {
+	input := "<34>1 - - - - - - newline\r\n" +
+		octetCounted("<34>1 - - - - - - octet\ncounted") +
+		"\n\n" +
+		"<34>1 - - - - - - " + strings.Repeat("x", 64) + "\n" +
+		octetCounted("<34>1 - - - - - - "+strings.Repeat("y", 64)) +
+		"<34>1 - - - - - - last"
+
+	fr := newFrameReader(strings.NewReader(input), 64)
+
+	msg, err := fr.next()
+	require.NoError(t, err)
+	assert.Equal(t, "<34>1 - - - - - - newline", string(msg))
+
+	msg, err = fr.next()
+	require.NoError(t, err)
+	assert.Equal(t, "<34>1 - - - - - - octet\ncounted", string(msg))
+
+	// The messages larger than the maximum size are skipped.
+	_, err = fr.next()
+	assert.ErrorIs(t, err, errMessageTooLarge)
+	_, err = fr.next()
+	assert.ErrorIs(t, err, errMessageTooLarge)
+
+	msg, err = fr.next()
+	require.NoError(t, err)
+	assert.Equal(t, "<34>1 - - - - - - last", string(msg))
+
+	_, err = fr.next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func octetCounted(msg string) string {
+	return fmt.Sprintf("%d %s", len(msg), msg)
+}
+
+func TestFrameReaderInvalidOctetCount(t *testing.T) {
+	for _, input := range []string{"12345678901 <34>", "12x <34>", "10 <34>"} {
+		fr := newFrameReader(strings.NewReader(input), 64)
+		_, err := fr.next()
+		assert.Error(t, err, input)
+		assert.NotErrorIs(t, err, errMessageTooLarge, input)
+	}
+}
@@ -0,0 +1,330 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogreceiver // import "go.opentelemetry.io/collector/receiver/syslogreceiver"
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+const (
+	// Attribute names of the syslog fields.
+	attributeHostname       = "hostname"
+	attributeAppName        = "appname"
+	attributeProcID         = "proc_id"
+	attributeMsgID          = "msg_id"
+	attributePriority       = "priority"
+	attributeFacility       = "facility"
+	attributeVersion        = "version"
+	attributeStructuredData = "structured_data"
+
+	// nilValue is the value of the RFC 5424 fields that are not set.
+	nilValue = "-"
+)
+
+var (
+	errMissingPriority = errors.New("missing priority")
+	errInvalidPriority = errors.New("invalid priority")
+	errEmptyMessage    = errors.New("empty message")
+
+	utf8BOM = []byte("\xef\xbb\xbf")
+)
+
+// severities maps the syslog severities to the severity texts and numbers of the log records.
+var severities = [8]struct {
+	text   string
+	number pdata.SeverityNumber
+}{
+	{"emerg", pdata.SeverityNumberFATAL4},
+	{"alert", pdata.SeverityNumberFATAL3},
+	{"crit", pdata.SeverityNumberFATAL},
+	{"err", pdata.SeverityNumberERROR},
+	{"warning", pdata.SeverityNumberWARN},
+	{"notice", pdata.SeverityNumberINFO2},
+	{"info", pdata.SeverityNumberINFO},
+	{"debug", pdata.SeverityNumberDEBUG},
+}
+
+// message is a parsed syslog message.
+type message struct {
+	priority       int
+	version        int
+	timestamp      time.Time
+	hostname       string
+	appName        string
+	procID         string
+	msgID          string
+	structuredData map[string]interface{}
+	msg            string
+}
+
+// parser parses syslog messages.
+type parser interface {
+	parse(data []byte) (*message, error)
+}
+
+func newParser(cfg *Config) (parser, error) {
+	switch cfg.Protocol {
+	case protocolRFC5424:
+		return &rfc5424Parser{}, nil
+	case protocolRFC3164:
+		loc, err := time.LoadLocation(cfg.Location)
+		if err != nil {
+			return nil, err
+		}
+		return &rfc3164Parser{location: loc, now: time.Now}, nil
+	}
+	return nil, fmt.Errorf("unsupported protocol %q", cfg.Protocol)
+}
+
+// toLogRecord fills the log record with the fields of the message.
+func (m *message) toLogRecord(lr pdata.LogRecord) {
+	if !m.timestamp.IsZero() {
+		lr.SetTimestamp(pdata.NewTimestampFromTime(m.timestamp))
+	}
+	severity := severities[m.priority%8]
+	lr.SetSeverityText(severity.text)
+	lr.SetSeverityNumber(severity.number)
+	lr.Body().SetStringVal(m.msg)
+
+	attrs := lr.Attributes()
+	attrs.InsertInt(attributePriority, int64(m.priority))
+	attrs.InsertInt(attributeFacility, int64(m.priority/8))
+	if m.version != 0 {
+		attrs.InsertInt(attributeVersion, int64(m.version))
+	}
+	insertStringIfSet(attrs, attributeHostname, m.hostname)
+	insertStringIfSet(attrs, attributeAppName, m.appName)
+	insertStringIfSet(attrs, attributeProcID, m.procID)
+	insertStringIfSet(attrs, attributeMsgID, m.msgID)
+	if len(m.structuredData) > 0 {
+		sd := pdata.NewValueMap()
+		pdata.NewMapFromRaw(m.structuredData).Sort().CopyTo(sd.MapVal())
+		attrs.Insert(attributeStructuredData, sd)
+	}
+}
+
+func insertStringIfSet(attrs pdata.Map, key, value string) {
+	if value != "" {
+		attrs.InsertString(key, value)
+	}
+}
+
+// parsePriority parses the "<PRI>" header at the beginning of the data.
+func parsePriority(data []byte) (int, []byte, error) {
+	if len(data) == 0 || data[0] != '<' {
+		return 0, nil, errMissingPriority
+	}
+	end := bytes.IndexByte(data, '>')
+	if end < 2 || end > 4 {
+		return 0, nil, errInvalidPriority
+	}
+	priority, err := strconv.Atoi(string(data[1:end]))
+	if err != nil || priority < 0 || priority > 191 {
+		return 0, nil, errInvalidPriority
+	}
+	return priority, data[end+1:], nil
+}
+
+// rfc5424Parser parses the messages defined by RFC 5424:
+//   <PRI>VERSION TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA [MSG]
+type rfc5424Parser struct{}
+
+func (p *rfc5424Parser) parse(data []byte) (*message, error) {
+	priority, rest, err := parsePriority(data)
+	if err != nil {
+		return nil, err
+	}
+	m := &message{priority: priority}
+
+	var field string
+	if field, rest = nextField(rest); field == "" {
+		return nil, errors.New("missing version")
+	}
+	if m.version, err = strconv.Atoi(field); err != nil || m.version <= 0 {
+		return nil, fmt.Errorf("invalid version %q", field)
+	}
+
+	if field, rest = nextField(rest); field == "" {
+		return nil, errors.New("missing timestamp")
+	}
+	if field != nilValue {
+		if m.timestamp, err = time.Parse(time.RFC3339Nano, field); err != nil {
+			return nil, fmt.Errorf("invalid timestamp %q", field)
+		}
+	}
+
+	for _, dest := range []*string{&m.hostname, &m.appName, &m.procID, &m.msgID} {
+		if field, rest = nextField(rest); field == "" {
+			return nil, errors.New("missing header field")
+		}
+		if field != nilValue {
+			*dest = field
+		}
+	}
+
+	if m.structuredData, rest, err = parseStructuredData(rest); err != nil {
+		return nil, err
+	}
+
+	if len(rest) > 0 {
+		if rest[0] != ' ' {
+			return nil, errors.New("missing space before the message")
+		}
+		m.msg = string(bytes.TrimPrefix(rest[1:], utf8BOM))
+	}
+	return m, nil
+}
+
+// nextField returns the data until the next space, and the data after the space.
+func nextField(data []byte) (string, []byte) {
+	end := bytes.IndexByte(data, ' ')
+	if end < 0 {
+		return string(data), nil
+	}
+	return string(data[:end]), data[end+1:]
+}
+
+// parseStructuredData parses the RFC 5424 structured data elements:
+//   [SD-ID PARAM-NAME="PARAM-VALUE" ...][...]
+// into a map of the element IDs to the maps of their parameters.
+func parseStructuredData(data []byte) (map[string]interface{}, []byte, error) {
+	if bytes.HasPrefix(data, []byte(nilValue)) {
+		return nil, data[len(nilValue):], nil
+	}
+	if len(data) == 0 || data[0] != '[' {
+		return nil, nil, errors.New("missing structured data")
+	}
+
+	elements := map[string]interface{}{}
+	for len(data) > 0 && data[0] == '[' {
+		end := bytes.IndexAny(data, " ]")
+		if end < 2 {
+			return nil, nil, errors.New("invalid structured data element id")
+		}
+		params := map[string]interface{}{}
+		elements[string(data[1:end])] = params
+		data = data[end:]
+
+		for len(data) > 0 && data[0] == ' ' {
+			eq := bytes.IndexByte(data, '=')
+			if eq < 2 || len(data) < eq+2 || data[eq+1] != '"' {
+				return nil, nil, errors.New("invalid structured data parameter")
+			}
+			name := string(data[1:eq])
+			value, n, err := parseParamValue(data[eq+2:])
+			if err != nil {
+				return nil, nil, err
+			}
+			params[name] = value
+			data = data[eq+2+n:]
+		}
+
+		if len(data) == 0 || data[0] != ']' {
+			return nil, nil, errors.New("unterminated structured data element")
+		}
+		data = data[1:]
+	}
+	return elements, data, nil
+}
+
+// parseParamValue parses a structured data parameter value after its opening quote,
+// and returns the unescaped value and the number of bytes consumed, including the closing quote.
+func parseParamValue(data []byte) (string, int, error) {
+	var value strings.Builder
+	for i := 0; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			// Only '"', '\' and ']' are escaped, the backslash is kept before the other characters.
+			if i+1 < len(data) && (data[i+1] == '"' || data[i+1] == '\\' || data[i+1] == ']') {
+				i++
+			}
+			value.WriteByte(data[i])
+		case '"':
+			return value.String(), i + 1, nil
+		default:
+			value.WriteByte(data[i])
+		}
+	}
+	return "", 0, errors.New("unterminated structured data parameter value")
+}
+
+// rfc3164Parser parses the messages defined by RFC 3164:
+//   <PRI>Mmm dd hh:mm:ss HOSTNAME TAG[PID]: MSG
+// The timestamp and hostname are optional, as well as the tag and the pid.
+type rfc3164Parser struct {
+	location *time.Location
+	now      func() time.Time
+}
+
+func (p *rfc3164Parser) parse(data []byte) (*message, error) {
+	priority, rest, err := parsePriority(data)
+	if err != nil {
+		return nil, err
+	}
+	m := &message{priority: priority}
+
+	if len(rest) > len(time.Stamp) && rest[len(time.Stamp)] == ' ' {
+		if ts, err := time.ParseInLocation(time.Stamp, string(rest[:len(time.Stamp)]), p.location); err == nil {
+			m.timestamp = p.withYear(ts)
+			m.hostname, rest = nextField(rest[len(time.Stamp)+1:])
+		}
+	}
+
+	m.appName, m.procID, rest = parseTag(rest)
+	m.msg = string(rest)
+	if m.msg == "" && m.appName == "" {
+		return nil, errEmptyMessage
+	}
+	return m, nil
+}
+
+// withYear sets the year of a timestamp without year to the current year, or to the
+// previous year if the timestamp would be in the future, e.g. around new year.
+func (p *rfc3164Parser) withYear(ts time.Time) time.Time {
+	now := p.now().In(p.location)
+	ts = ts.AddDate(now.Year(), 0, 0)
+	if ts.After(now.AddDate(0, 0, 1)) {
+		ts = ts.AddDate(-1, 0, 0)
+	}
+	return ts
+}
+
+// parseTag parses the "TAG[PID]: " or "TAG: " prefix of the RFC 3164 messages.
+// If the message does not start with a tag, it is returned unchanged.
+func parseTag(data []byte) (tag string, pid string, rest []byte) {
+	end := bytes.IndexAny(data, "[: ")
+	if end <= 0 || data[end] == ' ' {
+		return "", "", data
+	}
+	tag, rest = string(data[:end]), data[end:]
+	if rest[0] == '[' {
+		closing := bytes.IndexByte(rest, ']')
+		if closing < 0 {
+			return "", "", data
+		}
+		pid, rest = string(rest[1:closing]), rest[closing+1:]
+	}
+	if len(rest) == 0 || rest[0] != ':' {
+		return "", "", data
+	}
+	return tag, pid, bytes.TrimPrefix(rest[1:], []byte(" "))
+}
@@ -0,0 +1,214 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestRFC5424Parser(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected *message
+	}{
+		{
+			name:  "full",
+			input: `<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog 1234 ID47 [exampleSDID@32473 iut="3" eventSource="Application" eventID="1011"][examplePriority@32473 class="high"] ` + "\xef\xbb\xbf" + `An application event log entry`,
+			expected: &message{
+				priority:  165,
+				version:   1,
+				timestamp: time.Date(2003, 10, 11, 22, 14, 15, 3_000_000, time.UTC),
+				hostname:  "mymachine.example.com",
+				appName:   "evntslog",
+				procID:    "1234",
+				msgID:     "ID47",
+				structuredData: map[string]interface{}{
+					"exampleSDID@32473": map[string]interface{}{
+						"iut":         "3",
+						"eventSource": "Application",
+						"eventID":     "1011",
+					},
+					"examplePriority@32473": map[string]interface{}{"class": "high"},
+				},
+				msg: "An application event log entry",
+			},
+		},
+		{
+			name:  "nil values",
+			input: `<34>1 - - - - - -`,
+			expected: &message{
+				priority: 34,
+				version:  1,
+			},
+		},
+		{
+			name:  "escaped structured data",
+			input: `<34>1 - host app - - [id@1 a="x\"y\]z\\" b="c\d"][empty@1] message`,
+			expected: &message{
+				priority: 34,
+				version:  1,
+				hostname: "host",
+				appName:  "app",
+				structuredData: map[string]interface{}{
+					"id@1":    map[string]interface{}{"a": `x"y]z\`, "b": `c\d`},
+					"empty@1": map[string]interface{}{},
+				},
+				msg: "message",
+			},
+		},
+	}
+
+	p := &rfc5424Parser{}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := p.parse([]byte(tt.input))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, m)
+		})
+	}
+}
+
+func TestRFC5424ParserErrors(t *testing.T) {
+	inputs := []string{
+		``,
+		`1 - - - - - -`,
+		`<192>1 - - - - - -`,
+		`<34x1 - - - - - -`,
+		`<34>0 - - - - - -`,
+		`<34>1 yesterday - - - - -`,
+		`<34>1 - - - -`,
+		`<34>1 - - - - - [id@1 a="b"`,
+		`<34>1 - - - - - [id@1 a=b]`,
+		`<34>1 - - - - - [id@1 a="b]`,
+		`<34>1 - - - - - message`,
+		`<34>1 - - - - - -message`,
+	}
+
+	p := &rfc5424Parser{}
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			_, err := p.parse([]byte(input))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRFC3164Parser(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+	now := time.Date(2022, 1, 1, 10, 0, 0, 0, paris)
+
+	testCases := []struct {
+		name     string
+		input    string
+		expected *message
+	}{
+		{
+			name:  "full",
+			input: `<34>Oct  1 22:14:15 mymachine su[123]: 'su root' failed for lonvick on /dev/pts/8`,
+			expected: &message{
+				priority: 34,
+				// The timestamp is in the future, it is from the previous year.
+				timestamp: time.Date(2021, 10, 1, 22, 14, 15, 0, paris),
+				hostname:  "mymachine",
+				appName:   "su",
+				procID:    "123",
+				msg:       "'su root' failed for lonvick on /dev/pts/8",
+			},
+		},
+		{
+			name:  "no pid",
+			input: `<13>Jan  1 09:00:00 host app: started`,
+			expected: &message{
+				priority:  13,
+				timestamp: time.Date(2022, 1, 1, 9, 0, 0, 0, paris),
+				hostname:  "host",
+				appName:   "app",
+				msg:       "started",
+			},
+		},
+		{
+			name:  "no tag",
+			input: `<13>Jan  1 09:00:00 host free text: with colon`,
+			expected: &message{
+				priority:  13,
+				timestamp: time.Date(2022, 1, 1, 9, 0, 0, 0, paris),
+				hostname:  "host",
+				msg:       "free text: with colon",
+			},
+		},
+		{
+			name:  "no header",
+			input: `<13>just a message`,
+			expected: &message{
+				priority: 13,
+				msg:      "just a message",
+			},
+		},
+	}
+
+	p := &rfc3164Parser{location: paris, now: func() time.Time { return now }}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := p.parse([]byte(tt.input))
+			require.NoError(t, err)
+			assert.True(t, tt.expected.timestamp.Equal(m.timestamp), "expected %v, got %v", tt.expected.timestamp, m.timestamp)
+			tt.expected.timestamp = m.timestamp
+			assert.Equal(t, tt.expected, m)
+		})
+	}
+
+	for _, input := range []string{``, `no priority`, `<13>`} {
+		_, err := p.parse([]byte(input))
+		assert.Error(t, err, input)
+	}
+}
+
+func TestToLogRecord(t *testing.T) {
+	m := &message{
+		priority:       165,
+		version:        1,
+		timestamp:      time.Date(2003, 10, 11, 22, 14, 15, 3_000_000, time.UTC),
+		hostname:       "mymachine.example.com",
+		appName:        "evntslog",
+		msgID:          "ID47",
+		structuredData: map[string]interface{}{"id@1": map[string]interface{}{"a": "b"}},
+		msg:            "An application event log entry",
+	}
+
+	lr := pdata.NewLogRecord()
+	m.toLogRecord(lr)
+
+	assert.Equal(t, pdata.NewTimestampFromTime(m.timestamp), lr.Timestamp())
+	assert.Equal(t, "notice", lr.SeverityText())
+	assert.Equal(t, pdata.SeverityNumberINFO2, lr.SeverityNumber())
+	assert.Equal(t, "An application event log entry", lr.Body().StringVal())
+	assert.Equal(t, pdata.NewMapFromRaw(map[string]interface{}{
+		attributePriority:       int64(165),
+		attributeFacility:       int64(20),
+		attributeVersion:        int64(1),
+		attributeHostname:       "mymachine.example.com",
+		attributeAppName:        "evntslog",
+		attributeMsgID:          "ID47",
+		attributeStructuredData: map[string]interface{}{"id@1": map[string]interface{}{"a": "b"}},
+	}).Sort(), lr.Attributes().Sort())
+}
@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogreceiver // import "go.opentelemetry.io/collector/receiver/syslogreceiver"
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/conntracker"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+type syslogReceiver struct {
+	cfg          *Config
+	logger       *zap.Logger
+	nextConsumer consumer.Logs
+	obsrecv      *obsreport.Receiver
+	parser       parser
+
+	listener   net.Listener
+	packetConn net.PacketConn
+
+	conns conntracker.Tracker
+	wg    sync.WaitGroup
+}
+
+func newSyslogReceiver(cfg *Config, set component.ReceiverCreateSettings, nextConsumer consumer.Logs) (*syslogReceiver, error) {
+	p, err := newParser(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &syslogReceiver{
+		cfg:          cfg,
+		logger:       set.Logger,
+		nextConsumer: nextConsumer,
+		obsrecv: obsreport.NewReceiver(obsreport.ReceiverSettings{
+			ReceiverID:             cfg.ID(),
+			Transport:              cfg.Transport,
+			ReceiverCreateSettings: set,
+		}),
+		parser: p,
+	}, nil
+}
+
+// Start listens on the configured address and starts receiving the messages.
+func (r *syslogReceiver) Start(_ context.Context, host component.Host) error {
+	if isUDP(r.cfg.Transport) {
+		pc, err := net.ListenPacket(r.cfg.Transport, r.cfg.Endpoint)
+		if err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", r.cfg.Endpoint, err)
+		}
+		r.packetConn = pc
+		r.wg.Add(1)
+		go r.readPackets(host)
+		return nil
+	}
+
+	ln, err := r.cfg.NetAddr.Listen()
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", r.cfg.Endpoint, err)
+	}
+	if r.cfg.TLSSetting != nil {
+		tlsCfg, err := r.cfg.TLSSetting.LoadTLSConfig()
+		if err != nil {
+			_ = ln.Close()
+			return err
+		}
+		ln = tls.NewListener(ln, tlsCfg)
+	}
+	r.listener = ln
+	r.wg.Add(1)
+	go r.acceptConnections(host)
+	return nil
+}
+
+// Shutdown stops listening and closes the open connections.
+func (r *syslogReceiver) Shutdown(context.Context) error {
+	var err error
+	if r.listener != nil {
+		err = r.listener.Close()
+	}
+	if r.packetConn != nil {
+		err = r.packetConn.Close()
+	}
+	r.conns.Close()
+	r.wg.Wait()
+	return err
+}
+
+func (r *syslogReceiver) acceptConnections(host component.Host) {
+	defer r.wg.Done()
+	for {
+		conn, err := r.listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				host.ReportFatalError(err)
+			}
+			return
+		}
+		if !r.conns.Add(conn) {
+			continue
+		}
+
+		r.wg.Add(1)
+		go r.handleConnection(conn)
+	}
+}
+
+func (r *syslogReceiver) handleConnection(conn net.Conn) {
+	defer r.wg.Done()
+	defer r.conns.Remove(conn)
+
+	frames := newFrameReader(conn, r.cfg.MaxMessageSize)
+	for {
+		msg, err := frames.next()
+		switch {
+		case err == nil:
+			r.handleMessage(msg)
+		case errors.Is(err, errMessageTooLarge):
+			r.reportMalformed(err)
+		default:
+			if err != io.EOF && !errors.Is(err, net.ErrClosed) {
+				r.reportMalformed(err)
+				r.logger.Debug("Closing syslog connection", zap.Stringer("remote_addr", conn.RemoteAddr()), zap.Error(err))
+			}
+			return
+		}
+	}
+}
+
+func (r *syslogReceiver) readPackets(host component.Host) {
+	defer r.wg.Done()
+	buf := make([]byte, r.cfg.MaxMessageSize)
+	for {
+		n, _, err := r.packetConn.ReadFrom(buf)
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				host.ReportFatalError(err)
+			}
+			return
+		}
+		r.handleMessage(bytes.TrimRight(buf[:n], "\r\n"))
+	}
+}
+
+// handleMessage parses a message and passes it to the next consumer.
+func (r *syslogReceiver) handleMessage(data []byte) {
+	m, err := r.parser.parse(data)
+	if err != nil {
+		r.reportMalformed(err)
+		return
+	}
+
+	ld := pdata.NewLogs()
+	m.toLogRecord(ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty())
+
+	ctx := r.obsrecv.StartLogsOp(context.Background())
+	err = r.nextConsumer.ConsumeLogs(ctx, ld)
+	r.obsrecv.EndLogsOp(ctx, r.cfg.Protocol, 1, err)
+}
+
+// reportMalformed records a message that cannot be parsed as refused.
+func (r *syslogReceiver) reportMalformed(err error) {
+	ctx := r.obsrecv.StartLogsOp(context.Background())
+	r.obsrecv.EndLogsOp(ctx, r.cfg.Protocol, 1, fmt.Errorf("malformed syslog message: %w", err))
+	r.logger.Debug("Malformed syslog message", zap.Error(err))
+}
@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zipkinreceiver // import "go.opentelemetry.io/collector/receiver/zipkinreceiver"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sync"
+
+	zipkinmodel "github.com/openzipkin/zipkin-go/model"
+	"github.com/openzipkin/zipkin-go/proto/zipkin_proto3"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/errorutil"
+	"go.opentelemetry.io/collector/internal/zipkin"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+const (
+	receiverTransport = "http"
+
+	spansPath = "/api/v2/spans"
+
+	contentTypeJSON     = "application/json"
+	contentTypeProtobuf = "application/x-protobuf"
+
+	dataFormatJSON     = "json"
+	dataFormatProtobuf = "protobuf"
+)
+
+type zipkinReceiver struct {
+	cfg          *Config
+	settings     component.ReceiverCreateSettings
+	nextConsumer consumer.Traces
+	obsrecv      *obsreport.Receiver
+
+	server     *http.Server
+	shutdownWG sync.WaitGroup
+}
+
+var _ http.Handler = (*zipkinReceiver)(nil)
+
+func newZipkinReceiver(cfg *Config, set component.ReceiverCreateSettings, nextConsumer consumer.Traces) *zipkinReceiver {
+	return &zipkinReceiver{
+		cfg:          cfg,
+		settings:     set,
+		nextConsumer: nextConsumer,
+		obsrecv: obsreport.NewReceiver(obsreport.ReceiverSettings{
+			ReceiverID:             cfg.ID(),
+			Transport:              receiverTransport,
+			ReceiverCreateSettings: set,
+		}),
+	}
+}
+
+// Start starts the HTTP server receiving the spans.
+func (r *zipkinReceiver) Start(_ context.Context, host component.Host) error {
+	mux := http.NewServeMux()
+	mux.Handle(spansPath, r)
+
+	var err error
+	r.server, err = r.cfg.HTTPServerSettings.ToServer(host, r.settings.TelemetrySettings, mux)
+	if err != nil {
+		return err
+	}
+	ln, err := r.cfg.HTTPServerSettings.ToListener()
+	if err != nil {
+		return err
+	}
+
+	r.settings.Logger.Info("Starting HTTP server on endpoint " + r.cfg.Endpoint)
+	r.shutdownWG.Add(1)
+	go func() {
+		defer r.shutdownWG.Done()
+		if errHTTP := r.server.Serve(ln); errHTTP != http.ErrServerClosed {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the HTTP server.
+func (r *zipkinReceiver) Shutdown(context.Context) error {
+	var err error
+	if r.server != nil {
+		err = r.server.Close()
+	}
+	r.shutdownWG.Wait()
+	return err
+}
+
+// ServeHTTP receives the spans sent to /api/v2/spans, in JSON or Protobuf
+// depending on the Content-Type of the request.
+func (r *zipkinReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, fmt.Sprintf("%v method not allowed, supported: [POST]", req.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	dataFormat := dataFormatJSON
+	if mediaType, _, err := mime.ParseMediaType(req.Header.Get("Content-Type")); err == nil && mediaType == contentTypeProtobuf {
+		dataFormat = dataFormatProtobuf
+	}
+
+	ctx := r.obsrecv.StartTracesOp(req.Context())
+	zspans, err := decodeSpans(req.Body, dataFormat)
+	_ = req.Body.Close()
+	if err != nil {
+		r.obsrecv.EndTracesOp(ctx, dataFormat, 0, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	td := zipkin.ToTraces(zspans)
+	err = r.nextConsumer.ConsumeTraces(ctx, td)
+	r.obsrecv.EndTracesOp(ctx, dataFormat, td.SpanCount(), err)
+	if err != nil {
+		errorutil.HTTPError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func decodeSpans(body io.Reader, dataFormat string) ([]*zipkinmodel.SpanModel, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	var zspans []*zipkinmodel.SpanModel
+	switch dataFormat {
+	case dataFormatProtobuf:
+		zspans, err = zipkin_proto3.ParseSpans(data, false)
+	default:
+		err = json.Unmarshal(data, &zspans)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the spans: %w", err)
+	}
+	for _, zspan := range zspans {
+		if zspan == nil {
+			return nil, errors.New("failed to decode the spans: null span")
+		}
+	}
+	return zspans, nil
+}
@@ -0,0 +1,209 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zipkinreceiver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	zipkinmodel "github.com/openzipkin/zipkin-go/model"
+	"github.com/openzipkin/zipkin-go/proto/zipkin_proto3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testutil"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
+)
+
+const jsonSpans = `[{
+	"traceId": "5982fe77008310cc80f1da5e10147517",
+	"id": "bd7a977555f6b982",
+	"name": "get",
+	"kind": "SERVER",
+	"timestamp": 1472470996199000,
+	"duration": 207000,
+	"localEndpoint": {"serviceName": "frontend", "ipv4": "192.168.99.101", "port": 9000},
+	"tags": {"http.path": "/api"}
+}, {
+	"traceId": "5982fe77008310cc80f1da5e10147517",
+	"parentId": "bd7a977555f6b982",
+	"id": "be2d01e33cc78d97",
+	"name": "get",
+	"kind": "CLIENT",
+	"timestamp": 1472470996238000,
+	"duration": 91000,
+	"localEndpoint": {"serviceName": "frontend"},
+	"remoteEndpoint": {"serviceName": "backend"}
+}]`
+
+func startReceiver(t *testing.T, tt obsreporttest.TestTelemetry, next consumer.Traces) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	rcv, err := NewFactory().CreateTracesReceiver(context.Background(), tt.ToReceiverCreateSettings(), cfg, next)
+	require.NoError(t, err)
+	require.NoError(t, rcv.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, rcv.Shutdown(context.Background())) })
+	return cfg
+}
+
+func TestReceiveJSON(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	defer func() { require.NoError(t, tt.Shutdown(context.Background())) }()
+
+	sink := new(consumertest.TracesSink)
+	cfg := startReceiver(t, tt, sink)
+
+	resp, err := http.Post("http://"+cfg.Endpoint+spansPath, contentTypeJSON, bytes.NewBufferString(jsonSpans))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	require.Len(t, sink.AllTraces(), 1)
+	td := sink.AllTraces()[0]
+	assert.Equal(t, 2, td.SpanCount())
+	service, ok := td.ResourceSpans().At(0).Resource().Attributes().Get("service.name")
+	require.True(t, ok)
+	assert.Equal(t, "frontend", service.StringVal())
+
+	require.NoError(t, obsreporttest.CheckReceiverTraces(tt, cfg.ID(), receiverTransport, 2, 0))
+}
+
+func TestReceiveProtobuf(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	defer func() { require.NoError(t, tt.Shutdown(context.Background())) }()
+
+	sink := new(consumertest.TracesSink)
+	cfg := startReceiver(t, tt, sink)
+
+	parentID := zipkinmodel.ID(0xbd7a977555f6b982)
+	body, err := zipkin_proto3.SpanSerializer{}.Serialize([]*zipkinmodel.SpanModel{{
+		SpanContext: zipkinmodel.SpanContext{
+			TraceID:  zipkinmodel.TraceID{High: 0x5982fe77008310cc, Low: 0x80f1da5e10147517},
+			ID:       0xbe2d01e33cc78d97,
+			ParentID: &parentID,
+		},
+		Name:          "get",
+		Kind:          zipkinmodel.Client,
+		Timestamp:     time.Unix(1472470996, 238000000),
+		Duration:      91 * time.Millisecond,
+		LocalEndpoint: &zipkinmodel.Endpoint{ServiceName: "frontend"},
+		Annotations:   []zipkinmodel.Annotation{{Timestamp: time.Unix(1472470996, 240000000), Value: "retry"}},
+	}})
+	require.NoError(t, err)
+
+	resp, err := http.Post("http://"+cfg.Endpoint+spansPath, contentTypeProtobuf, bytes.NewReader(body))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	require.Len(t, sink.AllTraces(), 1)
+	span := sink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	assert.Equal(t, pdata.SpanKindClient, span.Kind())
+	assert.Equal(t, pdata.NewSpanID([8]byte{0xbd, 0x7a, 0x97, 0x75, 0x55, 0xf6, 0xb9, 0x82}), span.ParentSpanID())
+	require.Equal(t, 1, span.Events().Len())
+	assert.Equal(t, "retry", span.Events().At(0).Name())
+
+	require.NoError(t, obsreporttest.CheckReceiverTraces(tt, cfg.ID(), receiverTransport, 1, 0))
+}
+
+func TestReceiveErrors(t *testing.T) {
+	testCases := []struct {
+		name        string
+		method      string
+		contentType string
+		body        string
+		next        consumer.Traces
+		wantStatus  int
+		wantRetry   string
+	}{
+		{
+			name:       "method not allowed",
+			method:     http.MethodGet,
+			next:       consumertest.NewNop(),
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:        "invalid json",
+			method:      http.MethodPost,
+			contentType: contentTypeJSON,
+			body:        `{"traceId":`,
+			next:        consumertest.NewNop(),
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "null span",
+			method:      http.MethodPost,
+			contentType: contentTypeJSON,
+			body:        `[null]`,
+			next:        consumertest.NewNop(),
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "invalid protobuf",
+			method:      http.MethodPost,
+			contentType: contentTypeProtobuf,
+			body:        "\xff\xff\xff",
+			next:        consumertest.NewNop(),
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "consumer error",
+			method:      http.MethodPost,
+			contentType: contentTypeJSON,
+			body:        jsonSpans,
+			next:        consumertest.NewErr(errors.New("failed")),
+			wantStatus:  http.StatusInternalServerError,
+		},
+		{
+			name:        "throttled",
+			method:      http.MethodPost,
+			contentType: contentTypeJSON,
+			body:        jsonSpans,
+			next:        consumertest.NewErr(consumererror.NewThrottle(errors.New("memory limit"), 1500*time.Millisecond)),
+			wantStatus:  http.StatusTooManyRequests,
+			wantRetry:   "2",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tt, err := obsreporttest.SetupTelemetry()
+			require.NoError(t, err)
+			defer func() { require.NoError(t, tt.Shutdown(context.Background())) }()
+
+			cfg := startReceiver(t, tt, tc.next)
+
+			req, err := http.NewRequest(tc.method, "http://"+cfg.Endpoint+spansPath, bytes.NewBufferString(tc.body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", tc.contentType)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			assert.Equal(t, tc.wantStatus, resp.StatusCode)
+			assert.Equal(t, tc.wantRetry, resp.Header.Get("Retry-After"))
+		})
+	}
+}
@@ -32,10 +32,12 @@ import (
 	"github.com/prometheus/common/expfmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
 	"go.opentelemetry.io/collector/internal/testcomponents"
 	"go.opentelemetry.io/collector/internal/testutil"
 	"go.opentelemetry.io/collector/service/featuregate"
@@ -151,18 +153,51 @@ func TestCollector_Start(t *testing.T) {
 
 func TestCollector_StartWithOtelInternalMetrics(t *testing.T) {
 	resetCollectorTelemetry()
-	originalFlag := featuregate.IsEnabled(useOtelForInternalMetricsfeatureGateID)
+	originalFlag := featuregate.IsEnabled(obsreportconfig.UseOtelForInternalMetricsfeatureGateID)
 	defer func() {
 		featuregate.Apply(map[string]bool{
-			useOtelForInternalMetricsfeatureGateID: originalFlag,
+			obsreportconfig.UseOtelForInternalMetricsfeatureGateID: originalFlag,
 		})
 	}()
 	featuregate.Apply(map[string]bool{
-		useOtelForInternalMetricsfeatureGateID: true,
+		obsreportconfig.UseOtelForInternalMetricsfeatureGateID: true,
 	})
 	testCollectorStartHelper(t)
 }
 
+func TestCollector_StartWithoutMetricsAddress(t *testing.T) {
+	resetCollectorTelemetry()
+	factories, err := testcomponents.NewDefaultFactories()
+	require.NoError(t, err)
+
+	col, err := New(CollectorSettings{
+		BuildInfo: component.NewDefaultBuildInfo(),
+		Factories: factories,
+		ConfigProvider: MustNewDefaultConfigProvider(
+			[]string{filepath.Join("testdata", "otelcol-config.yaml")},
+			[]string{"service.telemetry.metrics.address="}),
+	})
+	require.NoError(t, err)
+
+	colDone := make(chan struct{})
+	go func() {
+		defer close(colDone)
+		require.NoError(t, col.Run(context.Background()))
+	}()
+
+	assert.Eventually(t, func() bool {
+		return Running == col.GetState()
+	}, 2*time.Second, 200*time.Millisecond)
+
+	// The views are recorded to be read in process, without the Prometheus endpoint.
+	assert.NotNil(t, view.Find("receiver/accepted_spans"))
+
+	col.signalsChannel <- syscall.SIGTERM
+	<-colDone
+	assert.Equal(t, Closed, col.GetState())
+	assert.Nil(t, view.Find("receiver/accepted_spans"))
+}
+
 // TestCollector_ShutdownNoop verifies that shutdown can be called even if a collector
 // has yet to be started and it will execute without error.
 func TestCollector_ShutdownNoop(t *testing.T) {
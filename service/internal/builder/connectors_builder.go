@@ -0,0 +1,263 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder // import "go.opentelemetry.io/collector/service/internal/builder"
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/service/internal/components"
+)
+
+// connectorDataTypes is a pair of data types connected by a connector.
+type connectorDataTypes struct {
+	// exporter is the data type of the pipelines using the connector as an exporter.
+	exporter config.DataType
+	// receiver is the data type of the pipelines using the connector as a receiver.
+	receiver config.DataType
+}
+
+// builtConnector is a connector that is built based on a config. It has a
+// component for each pair of data types it connects.
+type builtConnector struct {
+	logger          *zap.Logger
+	connByDataTypes map[connectorDataTypes]component.Connector
+}
+
+// Start the connector.
+func (bconn *builtConnector) Start(ctx context.Context, host component.Host) error {
+	var errs error
+	bconn.logger.Info("Connector is starting...")
+	for _, conn := range bconn.connByDataTypes {
+		errs = multierr.Append(errs, conn.Start(ctx, components.NewHostWrapper(host, bconn.logger)))
+	}
+
+	if errs != nil {
+		return errs
+	}
+	bconn.logger.Info("Connector started.")
+	return nil
+}
+
+// Shutdown the components of a connector.
+func (bconn *builtConnector) Shutdown(ctx context.Context) error {
+	var errs error
+	for _, conn := range bconn.connByDataTypes {
+		errs = multierr.Append(errs, conn.Shutdown(ctx))
+	}
+
+	return errs
+}
+
+// Connectors is a map of connectors created from connector configs.
+type Connectors map[config.ComponentID]*builtConnector
+
+// dataTypes are the data types in the order connectors are created for them.
+var dataTypes = []config.DataType{config.TracesDataType, config.MetricsDataType, config.LogsDataType}
+
+// pipelinesBuildOrder returns the IDs of the pipelines in the order they must
+// be built: a pipeline using connectors as exporters is built after the pipelines
+// using these connectors as receivers. It returns an error if the connectors
+// form a cycle of pipelines.
+func pipelinesBuildOrder(cfg *config.Config) ([]config.ComponentID, error) {
+	ids := make([]config.ComponentID, 0, len(cfg.Service.Pipelines))
+	for id := range cfg.Service.Pipelines {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := make(map[config.ComponentID]int, len(ids))
+	order := make([]config.ComponentID, 0, len(ids))
+	// path is the chain of pipelines and connectors being visited.
+	var path []string
+
+	var visit func(id config.ComponentID) error
+	visit = func(id config.ComponentID) error {
+		node := fmt.Sprintf("pipeline %q", id)
+		switch state[id] {
+		case visited:
+			return nil
+		case visiting:
+			start := 0
+			for path[start] != node {
+				start++
+			}
+			return fmt.Errorf("cycle detected: %s", strings.Join(append(path[start:], node), " -> "))
+		}
+
+		state[id] = visiting
+		path = append(path, node)
+		for _, connID := range cfg.Service.Pipelines[id].Exporters {
+			if _, ok := cfg.Connectors[connID]; !ok {
+				continue
+			}
+			path = append(path, fmt.Sprintf("connector %q", connID))
+			for _, nextID := range ids {
+				if !hasReceiver(cfg.Service.Pipelines[nextID], connID) {
+					continue
+				}
+				if err := visit(nextID); err != nil {
+					return err
+				}
+			}
+			path = path[:len(path)-1]
+		}
+		path = path[:len(path)-1]
+		state[id] = visited
+		order = append(order, id)
+		return nil
+	}
+
+	for _, id := range ids {
+		if err := visit(id); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// buildConnector returns the components of the connector consuming the data of
+// the pipelines of dataType, one for each data type of the pipelines it feeds.
+// The components are created the first time they are requested, so the
+// pipelines using the connector as a receiver must already be built.
+func (pb *pipelinesBuilder) buildConnector(ctx context.Context, id config.ComponentID, dataType config.DataType) ([]component.Connector, error) {
+	cfg, existsCfg := pb.config.Connectors[id]
+	if !existsCfg {
+		return nil, fmt.Errorf("connector %q is not configured", id)
+	}
+
+	factory, existsFactory := pb.connectorFactories[id.Type()]
+	if !existsFactory || factory == nil {
+		return nil, fmt.Errorf("connector factory not found for type: %s", id.Type())
+	}
+
+	bconn := pb.connectors[id]
+	if bconn == nil {
+		bconn = &builtConnector{
+			logger: pb.settings.Logger.With(
+				zap.String(components.ZapKindKey, components.ZapKindConnector),
+				zap.String(components.ZapNameKey, id.String())),
+			connByDataTypes: make(map[connectorDataTypes]component.Connector),
+		}
+		pb.connectors[id] = bconn
+	}
+
+	// Find the pipelines fed by the connector, by data type.
+	attached := make(attachedPipelines)
+	for pipelineID, pipelineCfg := range pb.config.Service.Pipelines {
+		if hasReceiver(pipelineCfg, id) {
+			attached[pipelineID.Type()] = append(attached[pipelineID.Type()], pb.builtPipelines[pipelineID])
+		}
+	}
+
+	set := component.ConnectorCreateSettings{
+		TelemetrySettings: component.TelemetrySettings{
+			Logger:         bconn.logger,
+			TracerProvider: pb.settings.TracerProvider,
+			MeterProvider:  pb.settings.MeterProvider,
+			MetricsLevel:   pb.config.Telemetry.Metrics.Level,
+		},
+		BuildInfo: pb.buildInfo,
+	}
+
+	var conns []component.Connector
+	for _, receiverType := range dataTypes {
+		pipelines := attached[receiverType]
+		if len(pipelines) == 0 {
+			continue
+		}
+
+		pair := connectorDataTypes{exporter: dataType, receiver: receiverType}
+		if conn, ok := bconn.connByDataTypes[pair]; ok {
+			conns = append(conns, conn)
+			continue
+		}
+
+		conn, err := createConnector(ctx, set, factory, cfg, pair, pipelines)
+		if err != nil {
+			if err == componenterror.ErrDataTypeIsNotSupported {
+				return nil, fmt.Errorf(
+					"connector %v does not support %s to %s but it was used to connect a %s pipeline to a %s pipeline",
+					id, dataType, receiverType, dataType, receiverType)
+			}
+			return nil, fmt.Errorf("error creating %v connector: %w", id, err)
+		}
+
+		// Check if the factory really created the connector.
+		if conn == nil {
+			return nil, fmt.Errorf("factory for %v produced a nil connector", id)
+		}
+
+		bconn.connByDataTypes[pair] = conn
+		conns = append(conns, conn)
+		set.Logger.Info("Connector was built.", zap.String("exporter_datatype", string(dataType)), zap.String("receiver_datatype", string(receiverType)))
+	}
+
+	return conns, nil
+}
+
+// createConnector creates the component of a connector for a pair of data types,
+// feeding the given pipelines.
+func createConnector(
+	ctx context.Context,
+	set component.ConnectorCreateSettings,
+	factory component.ConnectorFactory,
+	cfg config.Connector,
+	pair connectorDataTypes,
+	pipelines []*builtPipeline,
+) (component.Connector, error) {
+	switch pair.exporter {
+	case config.TracesDataType:
+		switch pair.receiver {
+		case config.TracesDataType:
+			return factory.CreateTracesToTracesConnector(ctx, set, cfg, buildFanoutTraceConsumer(pipelines))
+		case config.MetricsDataType:
+			return factory.CreateTracesToMetricsConnector(ctx, set, cfg, buildFanoutMetricConsumer(pipelines))
+		case config.LogsDataType:
+			return factory.CreateTracesToLogsConnector(ctx, set, cfg, buildFanoutLogConsumer(pipelines))
+		}
+	case config.MetricsDataType:
+		switch pair.receiver {
+		case config.TracesDataType:
+			return factory.CreateMetricsToTracesConnector(ctx, set, cfg, buildFanoutTraceConsumer(pipelines))
+		case config.MetricsDataType:
+			return factory.CreateMetricsToMetricsConnector(ctx, set, cfg, buildFanoutMetricConsumer(pipelines))
+		case config.LogsDataType:
+			return factory.CreateMetricsToLogsConnector(ctx, set, cfg, buildFanoutLogConsumer(pipelines))
+		}
+	case config.LogsDataType:
+		switch pair.receiver {
+		case config.TracesDataType:
+			return factory.CreateLogsToTracesConnector(ctx, set, cfg, buildFanoutTraceConsumer(pipelines))
+		case config.MetricsDataType:
+			return factory.CreateLogsToMetricsConnector(ctx, set, cfg, buildFanoutMetricConsumer(pipelines))
+		case config.LogsDataType:
+			return factory.CreateLogsToLogsConnector(ctx, set, cfg, buildFanoutLogConsumer(pipelines))
+		}
+	}
+	return nil, componenterror.ErrDataTypeIsNotSupported
+}
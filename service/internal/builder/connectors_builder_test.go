@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/connector/forwardconnector"
+	"go.opentelemetry.io/collector/internal/testcomponents"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/processor/batchprocessor"
+	"go.opentelemetry.io/collector/service/servicetest"
+)
+
+func TestBuildPipelines_Connectors(t *testing.T) {
+	factories, err := testcomponents.ExampleComponents()
+	require.NoError(t, err)
+	cfg, err := servicetest.LoadConfigAndValidate(filepath.Join("testdata", "connectors_builder.yaml"), factories)
+	require.NoError(t, err)
+
+	allExporters, err := BuildExporters(componenttest.NewNopTelemetrySettings(), component.NewDefaultBuildInfo(), cfg, factories.Exporters)
+	require.NoError(t, err)
+	pipelineProcessors, connectors, err := BuildPipelines(componenttest.NewNopTelemetrySettings(), component.NewDefaultBuildInfo(), cfg, allExporters, factories.Processors, factories.Connectors)
+	require.NoError(t, err)
+	require.Len(t, pipelineProcessors, 4)
+
+	// The traces pipeline feeds the traces/2 and metrics pipelines, the metrics pipeline feeds the logs pipeline.
+	require.Len(t, connectors, 2)
+	assert.Len(t, connectors[config.NewComponentID("exampleconnector")].connByDataTypes, 2)
+	assert.Len(t, connectors[config.NewComponentIDWithName("exampleconnector", "2")].connByDataTypes, 1)
+
+	assert.NoError(t, pipelineProcessors.StartAll(context.Background(), componenttest.NewNopHost(), connectors))
+
+	td := testdata.GenerateTracesOneSpan()
+	require.NoError(t, pipelineProcessors[config.NewComponentID("traces")].firstTC.ConsumeTraces(context.Background(), td))
+
+	exporter := allExporters[config.NewComponentID("exampleexporter")]
+	tracesConsumer := exporter.getTracesExporter().(*testcomponents.ExampleExporterConsumer)
+	require.Len(t, tracesConsumer.Traces, 1)
+	assert.EqualValues(t, td, tracesConsumer.Traces[0])
+	assert.Len(t, exporter.getMetricsExporter().(*testcomponents.ExampleExporterConsumer).Metrics, 1)
+	assert.Len(t, exporter.getLogsExporter().(*testcomponents.ExampleExporterConsumer).Logs, 1)
+
+	assert.NoError(t, pipelineProcessors.ShutdownAll(context.Background(), connectors))
+	for _, bconn := range connectors {
+		for _, conn := range bconn.connByDataTypes {
+			assert.True(t, conn.(*testcomponents.ExampleConnectorConsumer).ConnectorStarted)
+			assert.True(t, conn.(*testcomponents.ExampleConnectorConsumer).ConnectorShutdown)
+		}
+	}
+}
+
+func TestBuildPipelines_ConnectorsShutdownFlushes(t *testing.T) {
+	factories, err := testcomponents.ExampleComponents()
+	require.NoError(t, err)
+	factories.Processors[batchprocessor.NewFactory().Type()] = batchprocessor.NewFactory()
+	factories.Connectors[forwardconnector.NewFactory().Type()] = forwardconnector.NewFactory()
+	cfg, err := servicetest.LoadConfigAndValidate(filepath.Join("testdata", "connectors_batch.yaml"), factories)
+	require.NoError(t, err)
+
+	allExporters, err := BuildExporters(componenttest.NewNopTelemetrySettings(), component.NewDefaultBuildInfo(), cfg, factories.Exporters)
+	require.NoError(t, err)
+	pipelineProcessors, connectors, err := BuildPipelines(componenttest.NewNopTelemetrySettings(), component.NewDefaultBuildInfo(), cfg, allExporters, factories.Processors, factories.Connectors)
+	require.NoError(t, err)
+
+	// The pipeline fed by the connector is built, started and shut down after the one exporting to it.
+	assert.Equal(t, []config.ComponentID{config.NewComponentIDWithName("traces", "2"), config.NewComponentID("traces")}, pipelineProcessors.buildOrder())
+
+	require.NoError(t, allExporters.StartAll(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, pipelineProcessors.StartAll(context.Background(), componenttest.NewNopHost(), connectors))
+
+	// Both batch processors hold the data until they are shut down.
+	td := testdata.GenerateTracesOneSpan()
+	require.NoError(t, pipelineProcessors[config.NewComponentID("traces")].firstTC.ConsumeTraces(context.Background(), td))
+
+	require.NoError(t, pipelineProcessors.ShutdownAll(context.Background(), connectors))
+	tracesConsumer := allExporters[config.NewComponentID("exampleexporter")].getTracesExporter().(*testcomponents.ExampleExporterConsumer)
+	require.Len(t, tracesConsumer.Traces, 1)
+	assert.Equal(t, 1, tracesConsumer.Traces[0].SpanCount())
+	require.NoError(t, allExporters.ShutdownAll(context.Background()))
+}
+
+func TestBuildPipelines_ConnectorErrors(t *testing.T) {
+	factories := createTestFactories()
+
+	tests := []struct {
+		configFile string
+		expected   string
+	}{
+		{
+			configFile: "connectors_cycle.yaml",
+			expected:   `cycle detected: pipeline "metrics" -> connector "exampleconnector/2" -> pipeline "traces/2" -> connector "exampleconnector" -> pipeline "metrics"`,
+		},
+		{
+			configFile: "connectors_self_cycle.yaml",
+			expected:   `cycle detected: pipeline "logs" -> connector "exampleconnector" -> pipeline "logs"`,
+		},
+		{
+			configFile: "not_supported_connector.yaml",
+			expected:   "connector bf does not support traces to metrics but it was used to connect a traces pipeline to a metrics pipeline",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.configFile, func(t *testing.T) {
+			cfg, err := servicetest.LoadConfigAndValidate(filepath.Join("testdata", test.configFile), factories)
+			require.NoError(t, err)
+
+			allExporters, err := BuildExporters(componenttest.NewNopTelemetrySettings(), component.NewDefaultBuildInfo(), cfg, factories.Exporters)
+			require.NoError(t, err)
+
+			pipelineProcessors, connectors, err := BuildPipelines(componenttest.NewNopTelemetrySettings(), component.NewDefaultBuildInfo(), cfg, allExporters, factories.Processors, factories.Connectors)
+			assert.EqualError(t, err, test.expected)
+			assert.Zero(t, len(pipelineProcessors))
+			assert.Zero(t, len(connectors))
+		})
+	}
+}
@@ -24,9 +24,11 @@ func createTestFactories() component.Factories {
 	exampleReceiverFactory := testcomponents.ExampleReceiverFactory
 	exampleProcessorFactory := testcomponents.ExampleProcessorFactory
 	exampleExporterFactory := testcomponents.ExampleExporterFactory
+	exampleConnectorFactory := testcomponents.ExampleConnectorFactory
 	badReceiverFactory := newBadReceiverFactory()
 	badProcessorFactory := newBadProcessorFactory()
 	badExporterFactory := newBadExporterFactory()
+	badConnectorFactory := newBadConnectorFactory()
 
 	factories := component.Factories{
 		Receivers: map[config.Type]component.ReceiverFactory{
@@ -41,6 +43,10 @@ func createTestFactories() component.Factories {
 			exampleExporterFactory.Type(): exampleExporterFactory,
 			badExporterFactory.Type():     badExporterFactory,
 		},
+		Connectors: map[config.Type]component.ConnectorFactory{
+			exampleConnectorFactory.Type(): exampleConnectorFactory,
+			badConnectorFactory.Type():     badConnectorFactory,
+		},
 	}
 
 	return factories
@@ -75,3 +81,13 @@ func newBadExporterFactory() component.ExporterFactory {
 		}
 	})
 }
+
+func newBadConnectorFactory() component.ConnectorFactory {
+	return component.NewConnectorFactory("bf", func() config.Connector {
+		return &struct {
+			config.ConnectorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+		}{
+			ConnectorSettings: config.NewConnectorSettings(config.NewComponentID("bf")),
+		}
+	})
+}
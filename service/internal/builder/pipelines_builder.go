@@ -17,6 +17,7 @@ package builder // import "go.opentelemetry.io/collector/service/internal/builde
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
@@ -24,6 +25,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/service/internal/components"
 	"go.opentelemetry.io/collector/service/internal/fanoutconsumer"
 )
@@ -44,13 +46,32 @@ type builtPipeline struct {
 	MutatesData bool
 
 	processors []component.Processor
+	// buildIndex is the position of the pipeline in the build order.
+	buildIndex int
 }
 
 // BuiltPipelines is a map of build pipelines created from pipeline configs.
 type BuiltPipelines map[config.ComponentID]*builtPipeline
 
-func (bps BuiltPipelines) StartProcessors(ctx context.Context, host component.Host) error {
-	for _, bp := range bps {
+// StartAll starts the processors of the pipelines and the connectors between them.
+// A connector is started after the processors of the pipelines it feeds, and
+// before the processors of the pipelines exporting to it, so that no data is sent
+// to a component which is not started yet.
+func (bps BuiltPipelines) StartAll(ctx context.Context, host component.Host, conns Connectors) error {
+	started := make(map[config.ComponentID]bool)
+	for _, pipelineID := range bps.buildOrder() {
+		bp := bps[pipelineID]
+		for _, expID := range bp.Config.Exporters {
+			conn, isConnector := conns[expID]
+			if !isConnector || started[expID] {
+				continue
+			}
+			if err := conn.Start(ctx, host); err != nil {
+				return err
+			}
+			started[expID] = true
+		}
+
 		bp.logger.Info("Pipeline is starting...")
 		hostWrapper := components.NewHostWrapper(host, bp.logger)
 		// Start in reverse order, starting from the back of processors pipeline.
@@ -67,49 +88,112 @@ func (bps BuiltPipelines) StartProcessors(ctx context.Context, host component.Ho
 	return nil
 }
 
-func (bps BuiltPipelines) ShutdownProcessors(ctx context.Context) error {
-	var errs error
+// ShutdownAll stops the processors of the pipelines and the connectors between them,
+// in the reverse order of StartAll. A connector is shut down once all the pipelines
+// exporting to it are shut down, so they can flush their data through it into the
+// pipelines it feeds, which are still running.
+func (bps BuiltPipelines) ShutdownAll(ctx context.Context, conns Connectors) error {
+	// Count the pipelines exporting to each connector which are still running.
+	running := make(map[config.ComponentID]int)
 	for _, bp := range bps {
+		for _, expID := range bp.Config.Exporters {
+			if _, isConnector := conns[expID]; isConnector {
+				running[expID]++
+			}
+		}
+	}
+
+	var errs error
+	order := bps.buildOrder()
+	for i := len(order) - 1; i >= 0; i-- {
+		bp := bps[order[i]]
 		bp.logger.Info("Pipeline is shutting down...")
 		for _, p := range bp.processors {
 			errs = multierr.Append(errs, p.Shutdown(ctx))
 		}
 		bp.logger.Info("Pipeline is shutdown.")
+
+		for _, expID := range bp.Config.Exporters {
+			conn, isConnector := conns[expID]
+			if !isConnector {
+				continue
+			}
+			running[expID]--
+			if running[expID] == 0 {
+				errs = multierr.Append(errs, conn.Shutdown(ctx))
+			}
+		}
 	}
 
 	return errs
 }
 
+// buildOrder returns the IDs of the pipelines in the order they were built: the
+// pipelines fed by a connector come before the pipelines exporting to it.
+func (bps BuiltPipelines) buildOrder() []config.ComponentID {
+	ids := make([]config.ComponentID, 0, len(bps))
+	for id := range bps {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if bps[ids[i]].buildIndex != bps[ids[j]].buildIndex {
+			return bps[ids[i]].buildIndex < bps[ids[j]].buildIndex
+		}
+		return ids[i].String() < ids[j].String()
+	})
+	return ids
+}
+
 // pipelinesBuilder builds Pipelines from config.
 type pipelinesBuilder struct {
-	settings  component.TelemetrySettings
-	buildInfo component.BuildInfo
-	config    *config.Config
-	exporters Exporters
-	factories map[config.Type]component.ProcessorFactory
+	settings           component.TelemetrySettings
+	buildInfo          component.BuildInfo
+	config             *config.Config
+	exporters          Exporters
+	factories          map[config.Type]component.ProcessorFactory
+	connectorFactories map[config.Type]component.ConnectorFactory
+
+	builtPipelines BuiltPipelines
+	connectors     Connectors
 }
 
-// BuildPipelines builds pipeline processors from config. Requires exporters to be already
-// built via BuildExporters.
+// BuildPipelines builds pipeline processors and the connectors between pipelines from
+// config. Requires exporters to be already built via BuildExporters.
 func BuildPipelines(
 	settings component.TelemetrySettings,
 	buildInfo component.BuildInfo,
 	config *config.Config,
 	exporters Exporters,
 	factories map[config.Type]component.ProcessorFactory,
-) (BuiltPipelines, error) {
-	pb := &pipelinesBuilder{settings, buildInfo, config, exporters, factories}
+	connectorFactories map[config.Type]component.ConnectorFactory,
+) (BuiltPipelines, Connectors, error) {
+	pb := &pipelinesBuilder{
+		settings:           settings,
+		buildInfo:          buildInfo,
+		config:             config,
+		exporters:          exporters,
+		factories:          factories,
+		connectorFactories: connectorFactories,
+		builtPipelines:     make(BuiltPipelines),
+		connectors:         make(Connectors),
+	}
 
-	pipelineProcessors := make(BuiltPipelines)
-	for pipelineID, pipeline := range pb.config.Service.Pipelines {
-		bp, err := pb.buildPipeline(context.Background(), pipelineID, pipeline)
+	// The pipelines fed by connectors must be built before the connectors.
+	order, err := pipelinesBuildOrder(config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i, pipelineID := range order {
+		bp, err := pb.buildPipeline(context.Background(), pipelineID, pb.config.Service.Pipelines[pipelineID])
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-		pipelineProcessors[pipelineID] = bp
+		bp.buildIndex = i
+		pb.builtPipelines[pipelineID] = bp
 	}
 
-	return pipelineProcessors, nil
+	return pb.builtPipelines, pb.connectors, nil
 }
 
 // Builds a pipeline of processors. Returns the first processor in the pipeline.
@@ -119,7 +203,26 @@ func (pb *pipelinesBuilder) buildPipeline(ctx context.Context, pipelineID config
 
 	// BuildProcessors the pipeline backwards.
 
-	// First create a consumer junction point that fans out the data to all exporters.
+	// First create a consumer junction point that fans out the data to all exporters
+	// and connectors.
+	exporterIDs, exporters, err := pb.getExportersByIDs(ctx, pipelineID.Type(), pipelineCfg.Exporters)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send the data to the exporters concurrently if the pipeline is configured so.
+	var parallel *fanoutconsumer.ParallelSettings
+	if pipelineCfg.Fanout.Parallel {
+		parallel = &fanoutconsumer.ParallelSettings{
+			Timeout:    pipelineCfg.Fanout.Timeout,
+			BufferSize: pipelineCfg.Fanout.BufferSize,
+			Obsreport: obsreport.NewFanout(obsreport.FanoutSettings{
+				Level:      pb.config.Telemetry.Metrics.Level,
+				PipelineID: pipelineID,
+			}),
+		}
+	}
+
 	var tc consumer.Traces
 	var mc consumer.Metrics
 	var lc consumer.Logs
@@ -128,13 +231,13 @@ func (pb *pipelinesBuilder) buildPipeline(ctx context.Context, pipelineID config
 	mutatesConsumedData := false
 	switch pipelineID.Type() {
 	case config.TracesDataType:
-		tc = pb.buildFanoutExportersTracesConsumer(pipelineCfg.Exporters)
+		tc = buildFanoutExportersTracesConsumer(parallel, exporterIDs, exporters)
 		mutatesConsumedData = tc.Capabilities().MutatesData
 	case config.MetricsDataType:
-		mc = pb.buildFanoutExportersMetricsConsumer(pipelineCfg.Exporters)
+		mc = buildFanoutExportersMetricsConsumer(parallel, exporterIDs, exporters)
 		mutatesConsumedData = mc.Capabilities().MutatesData
 	case config.LogsDataType:
-		lc = pb.buildFanoutExportersLogsConsumer(pipelineCfg.Exporters)
+		lc = buildFanoutExportersLogsConsumer(parallel, exporterIDs, exporters)
 		mutatesConsumedData = lc.Capabilities().MutatesData
 	}
 
@@ -160,7 +263,6 @@ func (pb *pipelinesBuilder) buildPipeline(ctx context.Context, pipelineID config
 		// This processor must point to the next consumer and then
 		// it becomes the next for the previous one (previous in the pipeline,
 		// which we will build in the next loop iteration).
-		var err error
 		set := component.ProcessorCreateSettings{
 			TelemetrySettings: component.TelemetrySettings{
 				Logger: pb.settings.Logger.With(
@@ -249,51 +351,68 @@ func (pb *pipelinesBuilder) buildPipeline(ctx context.Context, pipelineID config
 	return bp, nil
 }
 
-// Converts the list of exporter names to a list of corresponding builtExporters.
-func (pb *pipelinesBuilder) getBuiltExportersByIDs(exporterIDs []config.ComponentID) []*builtExporter {
-	var result []*builtExporter
+// Converts the list of exporter and connector names to a list of the corresponding
+// components consuming the data type, building the connectors if needed. The ID of
+// each component is returned at the same index.
+func (pb *pipelinesBuilder) getExportersByIDs(ctx context.Context, dataType config.DataType, exporterIDs []config.ComponentID) ([]config.ComponentID, []component.Component, error) {
+	var ids []config.ComponentID
+	var result []component.Component
 	for _, expID := range exporterIDs {
-		exporter := pb.exporters[expID]
-		result = append(result, exporter)
+		if _, isConnector := pb.config.Connectors[expID]; isConnector {
+			conns, err := pb.buildConnector(ctx, expID, dataType)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, conn := range conns {
+				ids = append(ids, expID)
+				result = append(result, conn)
+			}
+			continue
+		}
+		ids = append(ids, expID)
+		result = append(result, pb.exporters[expID].expByDataType[dataType])
 	}
 
-	return result
+	return ids, result, nil
 }
 
-func (pb *pipelinesBuilder) buildFanoutExportersTracesConsumer(exporterIDs []config.ComponentID) consumer.Traces {
-	builtExporters := pb.getBuiltExportersByIDs(exporterIDs)
-
-	var exporters []consumer.Traces
-	for _, builtExp := range builtExporters {
-		exporters = append(exporters, builtExp.getTracesExporter())
+func buildFanoutExportersTracesConsumer(parallel *fanoutconsumer.ParallelSettings, exporterIDs []config.ComponentID, exporters []component.Component) consumer.Traces {
+	consumers := make([]consumer.Traces, len(exporters))
+	for i, exp := range exporters {
+		consumers[i] = exp.(consumer.Traces)
 	}
 
 	// Create a junction point that fans out to all exporters.
-	return fanoutconsumer.NewTraces(exporters)
+	if parallel != nil {
+		return fanoutconsumer.NewParallelTraces(*parallel, exporterIDs, consumers)
+	}
+	return fanoutconsumer.NewTraces(consumers)
 }
 
-func (pb *pipelinesBuilder) buildFanoutExportersMetricsConsumer(exporterIDs []config.ComponentID) consumer.Metrics {
-	builtExporters := pb.getBuiltExportersByIDs(exporterIDs)
-
-	var exporters []consumer.Metrics
-	for _, builtExp := range builtExporters {
-		exporters = append(exporters, builtExp.getMetricsExporter())
+func buildFanoutExportersMetricsConsumer(parallel *fanoutconsumer.ParallelSettings, exporterIDs []config.ComponentID, exporters []component.Component) consumer.Metrics {
+	consumers := make([]consumer.Metrics, len(exporters))
+	for i, exp := range exporters {
+		consumers[i] = exp.(consumer.Metrics)
 	}
 
 	// Create a junction point that fans out to all exporters.
-	return fanoutconsumer.NewMetrics(exporters)
+	if parallel != nil {
+		return fanoutconsumer.NewParallelMetrics(*parallel, exporterIDs, consumers)
+	}
+	return fanoutconsumer.NewMetrics(consumers)
 }
 
-func (pb *pipelinesBuilder) buildFanoutExportersLogsConsumer(exporterIDs []config.ComponentID) consumer.Logs {
-	builtExporters := pb.getBuiltExportersByIDs(exporterIDs)
-
-	exporters := make([]consumer.Logs, len(builtExporters))
-	for i, builtExp := range builtExporters {
-		exporters[i] = builtExp.getLogsExporter()
+func buildFanoutExportersLogsConsumer(parallel *fanoutconsumer.ParallelSettings, exporterIDs []config.ComponentID, exporters []component.Component) consumer.Logs {
+	consumers := make([]consumer.Logs, len(exporters))
+	for i, exp := range exporters {
+		consumers[i] = exp.(consumer.Logs)
 	}
 
 	// Create a junction point that fans out to all exporters.
-	return fanoutconsumer.NewLogs(exporters)
+	if parallel != nil {
+		return fanoutconsumer.NewParallelLogs(*parallel, exporterIDs, consumers)
+	}
+	return fanoutconsumer.NewLogs(consumers)
 }
 
 type capabilitiesLogs struct {
@@ -47,6 +47,11 @@ func TestBuildPipelines(t *testing.T) {
 			pipelineID:    config.NewComponentIDWithName("traces", "2"),
 			exporterNames: []config.ComponentID{config.NewComponentID("exampleexporter"), config.NewComponentIDWithName("exampleexporter", "2")},
 		},
+		{
+			name:          "multi-exporter-parallel",
+			pipelineID:    config.NewComponentIDWithName("traces", "parallel"),
+			exporterNames: []config.ComponentID{config.NewComponentID("exampleexporter"), config.NewComponentIDWithName("exampleexporter", "2")},
+		},
 	}
 
 	for _, test := range tests {
@@ -117,12 +122,12 @@ func TestBuildPipelines_BuildVarious(t *testing.T) {
 
 			require.NoError(t, err)
 			require.EqualValues(t, 1, len(allExporters))
-			pipelineProcessors, err := BuildPipelines(componenttest.NewNopTelemetrySettings(), component.NewDefaultBuildInfo(), cfg, allExporters, factories.Processors)
+			pipelineProcessors, _, err := BuildPipelines(componenttest.NewNopTelemetrySettings(), component.NewDefaultBuildInfo(), cfg, allExporters, factories.Processors, factories.Connectors)
 
 			assert.NoError(t, err)
 			require.NotNil(t, pipelineProcessors)
 
-			err = pipelineProcessors.StartProcessors(context.Background(), componenttest.NewNopHost())
+			err = pipelineProcessors.StartAll(context.Background(), componenttest.NewNopHost(), nil)
 			assert.NoError(t, err)
 
 			processor := pipelineProcessors[config.NewComponentID(config.Type(dataType))]
@@ -166,7 +171,7 @@ func TestBuildPipelines_BuildVarious(t *testing.T) {
 				assert.EqualValues(t, log, expConsumer.Logs[0])
 			}
 
-			err = pipelineProcessors.ShutdownProcessors(context.Background())
+			err = pipelineProcessors.ShutdownAll(context.Background(), nil)
 			assert.NoError(t, err)
 		})
 	}
@@ -182,12 +187,12 @@ func testPipeline(t *testing.T, pipelineID config.ComponentID, exporterIDs []con
 	// BuildProcessors the pipeline
 	allExporters, err := BuildExporters(componenttest.NewNopTelemetrySettings(), component.NewDefaultBuildInfo(), cfg, factories.Exporters)
 	assert.NoError(t, err)
-	pipelineProcessors, err := BuildPipelines(componenttest.NewNopTelemetrySettings(), component.NewDefaultBuildInfo(), cfg, allExporters, factories.Processors)
+	pipelineProcessors, _, err := BuildPipelines(componenttest.NewNopTelemetrySettings(), component.NewDefaultBuildInfo(), cfg, allExporters, factories.Processors, factories.Connectors)
 
 	assert.NoError(t, err)
 	require.NotNil(t, pipelineProcessors)
 
-	assert.NoError(t, pipelineProcessors.StartProcessors(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, pipelineProcessors.StartAll(context.Background(), componenttest.NewNopHost(), nil))
 
 	processor := pipelineProcessors[pipelineID]
 
@@ -227,7 +232,7 @@ func testPipeline(t *testing.T, pipelineID config.ComponentID, exporterIDs []con
 		assert.EqualValues(t, td, expConsumer.Traces[0])
 	}
 
-	err = pipelineProcessors.ShutdownProcessors(context.Background())
+	err = pipelineProcessors.ShutdownAll(context.Background(), nil)
 	assert.NoError(t, err)
 }
 
@@ -257,7 +262,7 @@ func TestBuildPipelines_NotSupportedDataType(t *testing.T) {
 			allExporters, err := BuildExporters(componenttest.NewNopTelemetrySettings(), component.NewDefaultBuildInfo(), cfg, factories.Exporters)
 			assert.NoError(t, err)
 
-			pipelineProcessors, err := BuildPipelines(componenttest.NewNopTelemetrySettings(), component.NewDefaultBuildInfo(), cfg, allExporters, factories.Processors)
+			pipelineProcessors, _, err := BuildPipelines(componenttest.NewNopTelemetrySettings(), component.NewDefaultBuildInfo(), cfg, allExporters, factories.Processors, factories.Connectors)
 			assert.Error(t, err)
 			assert.Zero(t, len(pipelineProcessors))
 		})
@@ -20,6 +20,7 @@ const (
 	ZapKindProcessor   = "processor"
 	ZapKindLogExporter = "exporter"
 	ZapKindExtension   = "extension"
+	ZapKindConnector   = "connector"
 	ZapKindPipeline    = "pipeline"
 	ZapNameKey         = "name"
 )
@@ -0,0 +1,254 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fanoutconsumer // import "go.opentelemetry.io/collector/service/internal/fanoutconsumer"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+const defaultBufferSize = 10
+
+// ParallelSettings configures the fan-out sending the data to all the consumers concurrently.
+type ParallelSettings struct {
+	// Timeout is the maximum time to wait for each consumer, zero means no timeout.
+	Timeout time.Duration
+	// BufferSize is the maximum number of batches each consumer may be processing
+	// at once, defaults to 10 if zero.
+	BufferSize int
+	// Obsreport reports the consumers which timed out or rejected data.
+	Obsreport *obsreport.Fanout
+}
+
+// NewParallelTraces wraps multiple trace consumers, identified by ids, in a single one.
+// Unlike NewTraces, it calls all the consumers concurrently and stops waiting for a consumer
+// after the timeout, so a slow consumer does not delay the others. A consumer which timed out
+// is only reported, its result being unknown. A consumer which is already processing
+// BufferSize batches is not sent the data and an error is returned for it.
+// Every consumer that needs to mutate the data gets a clone, unless all of them do.
+func NewParallelTraces(set ParallelSettings, ids []config.ComponentID, tcs []consumer.Traces) consumer.Traces {
+	mutates := make([]bool, len(tcs))
+	for i, tc := range tcs {
+		mutates[i] = tc.Capabilities().MutatesData
+	}
+	return &parallelTracesConsumer{parallelFanout: newParallelFanout(set, ids, mutates), tcs: tcs}
+}
+
+type parallelTracesConsumer struct {
+	*parallelFanout
+	tcs []consumer.Traces
+}
+
+// ConsumeTraces exports the pdata.Traces to all consumers wrapped by the current one.
+func (ptc *parallelTracesConsumer) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	calls := make([]func(context.Context) error, len(ptc.tcs))
+	for i := range ptc.tcs {
+		tc, data := ptc.tcs[i], td
+		if ptc.consumers[i].clone {
+			data = td.Clone()
+		}
+		calls[i] = func(ctx context.Context) error { return tc.ConsumeTraces(ctx, data) }
+	}
+	return ptc.consume(ctx, calls)
+}
+
+// NewParallelMetrics wraps multiple metrics consumers, identified by ids, in a single one
+// sending the data to all of them concurrently. See NewParallelTraces.
+func NewParallelMetrics(set ParallelSettings, ids []config.ComponentID, mcs []consumer.Metrics) consumer.Metrics {
+	mutates := make([]bool, len(mcs))
+	for i, mc := range mcs {
+		mutates[i] = mc.Capabilities().MutatesData
+	}
+	return &parallelMetricsConsumer{parallelFanout: newParallelFanout(set, ids, mutates), mcs: mcs}
+}
+
+type parallelMetricsConsumer struct {
+	*parallelFanout
+	mcs []consumer.Metrics
+}
+
+// ConsumeMetrics exports the pdata.Metrics to all consumers wrapped by the current one.
+func (pmc *parallelMetricsConsumer) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	calls := make([]func(context.Context) error, len(pmc.mcs))
+	for i := range pmc.mcs {
+		mc, data := pmc.mcs[i], md
+		if pmc.consumers[i].clone {
+			data = md.Clone()
+		}
+		calls[i] = func(ctx context.Context) error { return mc.ConsumeMetrics(ctx, data) }
+	}
+	return pmc.consume(ctx, calls)
+}
+
+// NewParallelLogs wraps multiple log consumers, identified by ids, in a single one
+// sending the data to all of them concurrently. See NewParallelTraces.
+func NewParallelLogs(set ParallelSettings, ids []config.ComponentID, lcs []consumer.Logs) consumer.Logs {
+	mutates := make([]bool, len(lcs))
+	for i, lc := range lcs {
+		mutates[i] = lc.Capabilities().MutatesData
+	}
+	return &parallelLogsConsumer{parallelFanout: newParallelFanout(set, ids, mutates), lcs: lcs}
+}
+
+type parallelLogsConsumer struct {
+	*parallelFanout
+	lcs []consumer.Logs
+}
+
+// ConsumeLogs exports the pdata.Logs to all consumers wrapped by the current one.
+func (plc *parallelLogsConsumer) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	calls := make([]func(context.Context) error, len(plc.lcs))
+	for i := range plc.lcs {
+		lc, data := plc.lcs[i], ld
+		if plc.consumers[i].clone {
+			data = ld.Clone()
+		}
+		calls[i] = func(ctx context.Context) error { return lc.ConsumeLogs(ctx, data) }
+	}
+	return plc.consume(ctx, calls)
+}
+
+type parallelConsumer struct {
+	id config.ComponentID
+	// clone is true if the consumer must be given a copy of the data.
+	clone bool
+	// inFlight holds a token for each batch the consumer is processing.
+	inFlight chan struct{}
+}
+
+type parallelFanout struct {
+	timeout   time.Duration
+	obsrep    *obsreport.Fanout
+	consumers []*parallelConsumer
+}
+
+func newParallelFanout(set ParallelSettings, ids []config.ComponentID, mutates []bool) *parallelFanout {
+	bufferSize := set.BufferSize
+	if bufferSize == 0 {
+		bufferSize = defaultBufferSize
+	}
+	// The consumers run concurrently, so the original data can be shared only by
+	// the consumers which do not mutate it, or given to a single mutating consumer
+	// if all of them mutate it.
+	allMutate := true
+	for _, m := range mutates {
+		allMutate = allMutate && m
+	}
+	consumers := make([]*parallelConsumer, len(ids))
+	for i, id := range ids {
+		consumers[i] = &parallelConsumer{
+			id:       id,
+			clone:    mutates[i] && !(allMutate && i == len(ids)-1),
+			inFlight: make(chan struct{}, bufferSize),
+		}
+	}
+	return &parallelFanout{
+		timeout:   set.Timeout,
+		obsrep:    set.Obsreport,
+		consumers: consumers,
+	}
+}
+
+func (pf *parallelFanout) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// consume runs calls[i] for the i-th consumer concurrently, and waits for all of
+// them to return or for the timeout to expire.
+func (pf *parallelFanout) consume(ctx context.Context, calls []func(context.Context) error) error {
+	var errs error
+	results := make([]chan error, len(calls))
+	for i, call := range calls {
+		pc := pf.consumers[i]
+		select {
+		case pc.inFlight <- struct{}{}:
+		default:
+			pf.obsrep.ExporterRejected(ctx, pc.id)
+			errs = multierr.Append(errs, fmt.Errorf("%v is already processing %d batches", pc.id, cap(pc.inFlight)))
+			continue
+		}
+		results[i] = make(chan error, 1)
+		go pf.run(ctx, pc, call, results[i])
+	}
+
+	var expired <-chan time.Time
+	if pf.timeout > 0 {
+		timer := time.NewTimer(pf.timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+	timedOut := false
+	for i, result := range results {
+		if result == nil {
+			continue
+		}
+		if !timedOut {
+			select {
+			case err := <-result:
+				errs = multierr.Append(errs, err)
+				continue
+			case <-expired:
+				timedOut = true
+			}
+		}
+		// The timeout already expired, only collect the consumers which are done. The
+		// consumers which are not done are not failed, so that the caller does not retry
+		// the data already consumed by the others.
+		select {
+		case err := <-result:
+			errs = multierr.Append(errs, err)
+		default:
+			pf.obsrep.ExporterTimedOut(ctx, pf.consumers[i].id)
+		}
+	}
+	return errs
+}
+
+func (pf *parallelFanout) run(ctx context.Context, pc *parallelConsumer, call func(context.Context) error, result chan<- error) {
+	defer func() { <-pc.inFlight }()
+	if pf.timeout > 0 {
+		// The consumer may outlive the call of the caller, which may cancel its context
+		// once returned.
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(detachedContext{ctx}, pf.timeout)
+		defer cancel()
+	}
+	result <- call(ctx)
+}
+
+// detachedContext keeps the values of a context, without its deadline and cancellation.
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
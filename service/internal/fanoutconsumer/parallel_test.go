@@ -0,0 +1,233 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fanoutconsumer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
+)
+
+var (
+	pipelineID = config.NewComponentID("traces")
+	ids        = []config.ComponentID{
+		config.NewComponentIDWithName("exp", "1"),
+		config.NewComponentIDWithName("exp", "2"),
+		config.NewComponentIDWithName("exp", "3"),
+	}
+)
+
+func newParallelSettings(timeout time.Duration, bufferSize int) ParallelSettings {
+	return ParallelSettings{
+		Timeout:    timeout,
+		BufferSize: bufferSize,
+		Obsreport:  obsreport.NewFanout(obsreport.FanoutSettings{Level: configtelemetry.LevelNormal, PipelineID: pipelineID}),
+	}
+}
+
+func TestParallelTracesNonMutating(t *testing.T) {
+	p1 := new(consumertest.TracesSink)
+	p2 := new(consumertest.TracesSink)
+	p3 := new(consumertest.TracesSink)
+
+	tfc := NewParallelTraces(newParallelSettings(time.Second, 0), ids, []consumer.Traces{p1, p2, p3})
+	assert.False(t, tfc.Capabilities().MutatesData)
+	td := testdata.GenerateTracesOneSpan()
+	require.NoError(t, tfc.ConsumeTraces(context.Background(), td))
+
+	assert.True(t, td == p1.AllTraces()[0])
+	assert.True(t, td == p2.AllTraces()[0])
+	assert.True(t, td == p3.AllTraces()[0])
+}
+
+func TestParallelTracesMixMutating(t *testing.T) {
+	p1 := &mutatingTracesSink{TracesSink: new(consumertest.TracesSink)}
+	p2 := new(consumertest.TracesSink)
+	p3 := &mutatingTracesSink{TracesSink: new(consumertest.TracesSink)}
+
+	tfc := NewParallelTraces(newParallelSettings(time.Second, 0), ids, []consumer.Traces{p1, p2, p3})
+	td := testdata.GenerateTracesOneSpan()
+	require.NoError(t, tfc.ConsumeTraces(context.Background(), td))
+
+	assert.True(t, td != p1.AllTraces()[0])
+	assert.EqualValues(t, td, p1.AllTraces()[0])
+	assert.True(t, td == p2.AllTraces()[0])
+	assert.True(t, td != p3.AllTraces()[0])
+	assert.EqualValues(t, td, p3.AllTraces()[0])
+}
+
+func TestParallelTracesAllMutating(t *testing.T) {
+	p1 := &mutatingTracesSink{TracesSink: new(consumertest.TracesSink)}
+	p2 := &mutatingTracesSink{TracesSink: new(consumertest.TracesSink)}
+	p3 := &mutatingTracesSink{TracesSink: new(consumertest.TracesSink)}
+
+	tfc := NewParallelTraces(newParallelSettings(time.Second, 0), ids, []consumer.Traces{p1, p2, p3})
+	td := testdata.GenerateTracesOneSpan()
+	require.NoError(t, tfc.ConsumeTraces(context.Background(), td))
+
+	assert.True(t, td != p1.AllTraces()[0])
+	assert.True(t, td != p2.AllTraces()[0])
+	// The last consumer gets the original data.
+	assert.True(t, td == p3.AllTraces()[0])
+}
+
+func TestParallelTracesSlowConsumer(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	p1 := new(consumertest.TracesSink)
+	p2 := newBlockingConsumer()
+	defer close(p2.release)
+	p3 := new(consumertest.TracesSink)
+
+	tfc := NewParallelTraces(newParallelSettings(50*time.Millisecond, 1), ids, []consumer.Traces{p1, p2, p3})
+	td := testdata.GenerateTracesOneSpan()
+
+	// The slow consumer is only reported, not to have the data sent again to the others.
+	require.NoError(t, tfc.ConsumeTraces(context.Background(), td))
+	assert.Equal(t, 1, len(p1.AllTraces()))
+	assert.Equal(t, 1, len(p3.AllTraces()))
+
+	// The buffer of the slow consumer is full, the other consumers are not delayed.
+	start := time.Now()
+	err = tfc.ConsumeTraces(context.Background(), td)
+	require.Error(t, err)
+	assert.Less(t, int64(time.Since(start)), int64(50*time.Millisecond))
+	assert.EqualError(t, err, `exp/2 is already processing 1 batches`)
+	assert.Equal(t, 2, len(p1.AllTraces()))
+	assert.Equal(t, 2, len(p3.AllTraces()))
+
+	require.NoError(t, obsreporttest.CheckFanoutMetrics(tt, pipelineID, ids[1], 1, 1))
+}
+
+func TestParallelTracesContext(t *testing.T) {
+	type ctxKey struct{}
+	var consumerErr error
+	var consumerValue interface{}
+	var hasDeadline bool
+	p1 := consumerFunc(func(ctx context.Context) error {
+		consumerErr = ctx.Err()
+		consumerValue = ctx.Value(ctxKey{})
+		_, hasDeadline = ctx.Deadline()
+		return nil
+	})
+
+	tfc := NewParallelTraces(newParallelSettings(time.Second, 0), ids[:1], []consumer.Traces{p1})
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKey{}, "value"))
+	cancel()
+	require.NoError(t, tfc.ConsumeTraces(ctx, testdata.GenerateTracesOneSpan()))
+
+	// The consumer may outlive the call, so it is not canceled with the context of the
+	// caller, but its context still carries the values of the caller and the timeout.
+	assert.NoError(t, consumerErr)
+	assert.Equal(t, "value", consumerValue)
+	assert.True(t, hasDeadline)
+}
+
+func TestParallelTracesWhenErrors(t *testing.T) {
+	p1 := mutatingErr{Consumer: consumertest.NewErr(errors.New("my error"))}
+	p2 := consumertest.NewErr(errors.New("my error"))
+	p3 := new(consumertest.TracesSink)
+
+	tfc := NewParallelTraces(newParallelSettings(0, 0), ids, []consumer.Traces{p1, p2, p3})
+	td := testdata.GenerateTracesOneSpan()
+	assert.EqualError(t, tfc.ConsumeTraces(context.Background(), td), "my error; my error")
+	assert.True(t, td == p3.AllTraces()[0])
+}
+
+func TestParallelMetrics(t *testing.T) {
+	p1 := new(consumertest.MetricsSink)
+	p2 := &mutatingMetricsSink{MetricsSink: new(consumertest.MetricsSink)}
+	p3 := newBlockingConsumer()
+	defer close(p3.release)
+
+	mfc := NewParallelMetrics(newParallelSettings(50*time.Millisecond, 0), ids, []consumer.Metrics{p1, p2, p3})
+	assert.False(t, mfc.Capabilities().MutatesData)
+	md := testdata.GenerateMetricsOneMetric()
+
+	require.NoError(t, mfc.ConsumeMetrics(context.Background(), md))
+	assert.True(t, md == p1.AllMetrics()[0])
+	assert.True(t, md != p2.AllMetrics()[0])
+	assert.EqualValues(t, md, p2.AllMetrics()[0])
+}
+
+func TestParallelLogs(t *testing.T) {
+	p1 := new(consumertest.LogsSink)
+	p2 := &mutatingLogsSink{LogsSink: new(consumertest.LogsSink)}
+	p3 := newBlockingConsumer()
+	defer close(p3.release)
+
+	lfc := NewParallelLogs(newParallelSettings(50*time.Millisecond, 0), ids, []consumer.Logs{p1, p2, p3})
+	assert.False(t, lfc.Capabilities().MutatesData)
+	ld := testdata.GenerateLogsOneLogRecord()
+
+	require.NoError(t, lfc.ConsumeLogs(context.Background(), ld))
+	assert.True(t, ld == p1.AllLogs()[0])
+	assert.True(t, ld != p2.AllLogs()[0])
+	assert.EqualValues(t, ld, p2.AllLogs()[0])
+}
+
+// blockingConsumer consumes all the data types, blocking until released.
+type blockingConsumer struct {
+	release chan struct{}
+}
+
+func newBlockingConsumer() *blockingConsumer {
+	return &blockingConsumer{release: make(chan struct{})}
+}
+
+func (bc *blockingConsumer) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (bc *blockingConsumer) ConsumeTraces(context.Context, pdata.Traces) error {
+	<-bc.release
+	return nil
+}
+
+func (bc *blockingConsumer) ConsumeMetrics(context.Context, pdata.Metrics) error {
+	<-bc.release
+	return nil
+}
+
+func (bc *blockingConsumer) ConsumeLogs(context.Context, pdata.Logs) error {
+	<-bc.release
+	return nil
+}
+
+// consumerFunc consumes the traces with a function of the context.
+type consumerFunc func(context.Context) error
+
+func (cf consumerFunc) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (cf consumerFunc) ConsumeTraces(ctx context.Context, _ pdata.Traces) error {
+	return cf(ctx)
+}
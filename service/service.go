@@ -39,6 +39,7 @@ type service struct {
 	builtExporters  builder.Exporters
 	builtReceivers  builder.Receivers
 	builtPipelines  builder.BuiltPipelines
+	builtConnectors builder.Connectors
 	builtExtensions extensions.Extensions
 }
 
@@ -65,8 +66,8 @@ func newService(set *svcSettings) (*service, error) {
 		return nil, fmt.Errorf("cannot build exporters: %w", err)
 	}
 
-	// Create pipelines and their processors and plug exporters to the end of the pipelines.
-	if srv.builtPipelines, err = builder.BuildPipelines(srv.telemetry, srv.buildInfo, srv.config, srv.builtExporters, srv.factories.Processors); err != nil {
+	// Create pipelines and their processors and plug exporters and connectors to the end of the pipelines.
+	if srv.builtPipelines, srv.builtConnectors, err = builder.BuildPipelines(srv.telemetry, srv.buildInfo, srv.config, srv.builtExporters, srv.factories.Processors, srv.factories.Connectors); err != nil {
 		return nil, fmt.Errorf("cannot build pipelines: %w", err)
 	}
 
@@ -89,9 +90,9 @@ func (srv *service) Start(ctx context.Context) error {
 		return fmt.Errorf("cannot start exporters: %w", err)
 	}
 
-	srv.telemetry.Logger.Info("Starting processors...")
-	if err := srv.builtPipelines.StartProcessors(ctx, srv); err != nil {
-		return fmt.Errorf("cannot start processors: %w", err)
+	srv.telemetry.Logger.Info("Starting processors and connectors...")
+	if err := srv.builtPipelines.StartAll(ctx, srv, srv.builtConnectors); err != nil {
+		return fmt.Errorf("cannot start processors and connectors: %w", err)
 	}
 
 	srv.telemetry.Logger.Info("Starting receivers...")
@@ -119,9 +120,11 @@ func (srv *service) Shutdown(ctx context.Context) error {
 		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown receivers: %w", err))
 	}
 
-	srv.telemetry.Logger.Info("Stopping processors...")
-	if err := srv.builtPipelines.ShutdownProcessors(ctx); err != nil {
-		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown processors: %w", err))
+	// The pipelines exporting to connectors are shut down before the pipelines the
+	// connectors feed, so that the data they flush on shutdown is still processed.
+	srv.telemetry.Logger.Info("Stopping processors and connectors...")
+	if err := srv.builtPipelines.ShutdownAll(ctx, srv.builtConnectors); err != nil {
+		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown processors and connectors: %w", err))
 	}
 
 	srv.telemetry.Logger.Info("Stopping exporters...")
@@ -154,6 +157,8 @@ func (srv *service) GetFactory(kind component.Kind, componentType config.Type) c
 		return srv.factories.Exporters[componentType]
 	case component.KindExtension:
 		return srv.factories.Extensions[componentType]
+	case component.KindConnector:
+		return srv.factories.Connectors[componentType]
 	}
 	return nil
 }
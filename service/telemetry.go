@@ -37,6 +37,7 @@ import (
 	"go.opentelemetry.io/collector/internal/version"
 	semconv "go.opentelemetry.io/collector/model/semconv/v1.5.0"
 	"go.opentelemetry.io/collector/processor/batchprocessor"
+	"go.opentelemetry.io/collector/processor/logparserprocessor"
 	"go.opentelemetry.io/collector/service/featuregate"
 	telemetry2 "go.opentelemetry.io/collector/service/internal/telemetry"
 )
@@ -50,21 +51,8 @@ const AddCollectorVersionTag = true
 const (
 	zapKeyTelemetryAddress = "address"
 	zapKeyTelemetryLevel   = "level"
-
-	// useOtelForInternalMetricsfeatureGateID is the feature gate ID that controls whether the collector uses open
-	// telemetry for internal metrics.
-	useOtelForInternalMetricsfeatureGateID = "telemetry.useOtelForInternalMetrics"
 )
 
-func init() {
-	//nolint:staticcheck
-	featuregate.Register(featuregate.Gate{
-		ID:          useOtelForInternalMetricsfeatureGateID,
-		Description: "controls whether the collector to uses open telemetry for internal metrics",
-		Enabled:     false,
-	})
-}
-
 type collectorTelemetryExporter interface {
 	init(col *Collector) error
 	shutdown() error
@@ -96,7 +84,8 @@ func (tel *colTelemetry) initOnce(col *Collector) error {
 	level := cfg.Metrics.Level
 	metricsAddr := cfg.Metrics.Address
 
-	if level == configtelemetry.LevelNone || metricsAddr == "" {
+	useOtel := featuregate.IsEnabled(obsreportconfig.UseOtelForInternalMetricsfeatureGateID)
+	if level == configtelemetry.LevelNone || (metricsAddr == "" && useOtel) {
 		logger.Info(
 			"Skipping telemetry setup.",
 			zap.String(zapKeyTelemetryAddress, metricsAddr),
@@ -105,13 +94,23 @@ func (tel *colTelemetry) initOnce(col *Collector) error {
 		return nil
 	}
 
+	if metricsAddr == "" {
+		// The views are recorded without serving them, so that they can still be
+		// read in process, for instance by the selftelemetry receiver.
+		logger.Info(
+			"Recording own telemetry without serving Prometheus metrics",
+			zap.String(zapKeyTelemetryLevel, level.String()),
+		)
+		return tel.initOpenCensusViews(col)
+	}
+
 	logger.Info("Setting up own telemetry...")
 
 	instanceUUID, _ := uuid.NewRandom()
 	instanceID := instanceUUID.String()
 
 	var pe http.Handler
-	if featuregate.IsEnabled(useOtelForInternalMetricsfeatureGateID) {
+	if useOtel {
 		otelHandler, err := tel.initOpenTelemetry(col)
 		if err != nil {
 			return err
@@ -151,24 +150,34 @@ func (tel *colTelemetry) initOnce(col *Collector) error {
 	return nil
 }
 
-func (tel *colTelemetry) initOpenCensus(col *Collector, instanceID string) (http.Handler, error) {
+// initOpenCensusViews registers the OpenCensus views of the collector and starts
+// collecting the process metrics.
+func (tel *colTelemetry) initOpenCensusViews(col *Collector) error {
 	processMetricsViews, err := telemetry2.NewProcessMetricsViews(getBallastSize(col.service))
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	var views []*view.View
 	obsMetrics := obsreportconfig.Configure(col.service.config.Telemetry.Metrics.Level)
 	views = append(views, batchprocessor.MetricViews()...)
+	views = append(views, logparserprocessor.MetricViews()...)
 	views = append(views, obsMetrics.Views...)
 	views = append(views, processMetricsViews.Views()...)
 
 	tel.views = views
 	if err = view.Register(views...); err != nil {
-		return nil, err
+		return err
 	}
 
 	processMetricsViews.StartCollection()
+	return nil
+}
+
+func (tel *colTelemetry) initOpenCensus(col *Collector, instanceID string) (http.Handler, error) {
+	if err := tel.initOpenCensusViews(col); err != nil {
+		return nil, err
+	}
 
 	// Until we can use a generic metrics exporter, default to Prometheus.
 	opts := prometheus.Options{